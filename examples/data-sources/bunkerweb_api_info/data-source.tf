@@ -0,0 +1,21 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+data "bunkerweb_api_info" "target" {}
+
+output "bunkerweb_version" {
+  value = data.bunkerweb_api_info.target.version
+}
+
+# Only manage web UI users when the deployment supports them.
+resource "bunkerweb_user" "ops" {
+  count    = data.bunkerweb_api_info.target.features["users"] ? 1 : 0
+  username = "ops"
+  password = var.ops_password
+}
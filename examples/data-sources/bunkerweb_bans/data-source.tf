@@ -0,0 +1,19 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Automated bans issued during the last day for a single service.
+data "bunkerweb_bans" "recent" {
+  service = "app.example.com"
+  reason  = "bad behavior"
+  since   = timeadd(plantimestamp(), "-24h")
+}
+
+output "recently_banned_ips" {
+  value = [for ban in data.bunkerweb_bans.recent.bans : ban.ip]
+}
@@ -0,0 +1,31 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+data "bunkerweb_configs" "app_http" {
+  service = "app.example.com"
+  type    = "http"
+}
+
+# Enumerate names only, without pulling snippet contents into state.
+data "bunkerweb_configs" "modsec_names" {
+  type       = "modsec"
+  name_regex = "^crs-"
+  names_only = true
+}
+
+output "modsec_config_names" {
+  value = data.bunkerweb_configs.modsec_names.names
+}
+
+# Pick configs by service and type without filtering the flat list.
+data "bunkerweb_configs" "all" {}
+
+output "app_modsec_configs" {
+  value = [for cfg in data.bunkerweb_configs.all.by_service["app.example.com"].by_type["modsec"].configs : cfg.name]
+}
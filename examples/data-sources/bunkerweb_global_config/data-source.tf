@@ -14,3 +14,17 @@ data "bunkerweb_global_config" "current" {
 output "global_settings" {
   value = data.bunkerweb_global_config.current.settings
 }
+
+# Adopt every non-default global setting at once (Terraform 1.7+).
+import {
+  for_each = toset(data.bunkerweb_global_config.current.import_ids)
+  to       = bunkerweb_global_config_setting.adopted[each.value]
+  id       = each.value
+}
+
+resource "bunkerweb_global_config_setting" "adopted" {
+  for_each = toset(data.bunkerweb_global_config.current.import_ids)
+
+  key   = each.value
+  value = data.bunkerweb_global_config.current.settings[each.value]
+}
@@ -0,0 +1,24 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+locals {
+  global_settings = {
+    USE_GZIP               = "yes"
+    LIMIT_REQ_RATE         = "5r/s"
+    DISABLE_DEFAULT_SERVER = "yes"
+  }
+}
+
+data "bunkerweb_global_config_diff" "pending" {
+  settings = local.global_settings
+}
+
+output "global_settings_delta" {
+  value = data.bunkerweb_global_config_diff.pending.changes
+}
@@ -0,0 +1,24 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+data "bunkerweb_job_run_history" "certbot" {
+  plugin = "letsencrypt"
+  name   = "certbot-renew"
+  since  = timeadd(plantimestamp(), "-24h")
+}
+
+# Fail the plan when certificates were not renewed successfully in the last 24h.
+resource "terraform_data" "certbot_check" {
+  lifecycle {
+    precondition {
+      condition     = data.bunkerweb_job_run_history.certbot.last_success != null
+      error_message = "certbot-renew has not run successfully within the last 24 hours."
+    }
+  }
+}
@@ -0,0 +1,25 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+data "bunkerweb_job_status" "certbot" {
+  plugin = "letsencrypt"
+  name   = "certbot-renew"
+}
+
+check "certbot_renewal" {
+  assert {
+    condition     = data.bunkerweb_job_status.certbot.last_run_success == true
+    error_message = "The last certbot-renew run failed."
+  }
+
+  assert {
+    condition     = timecmp(data.bunkerweb_job_status.certbot.next_run, plantimestamp()) >= 0
+    error_message = "certbot-renew is overdue."
+  }
+}
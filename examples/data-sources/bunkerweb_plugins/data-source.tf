@@ -14,3 +14,18 @@ data "bunkerweb_plugins" "ui" {
 output "plugin_ids" {
   value = [for plugin in data.bunkerweb_plugins.ui.plugins : plugin.id]
 }
+
+data "bunkerweb_plugins" "external" {
+  type      = "external"
+  with_data = true
+}
+
+# Default value of every setting declared by external plugins, ready to be
+# merged into a service's variables.
+output "external_plugin_defaults" {
+  value = merge([
+    for plugin in data.bunkerweb_plugins.external.plugins : {
+      for name, setting in plugin.settings : name => setting.default
+    }
+  ]...)
+}
@@ -0,0 +1,32 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Verify the platform after every apply.
+check "bunkerweb_ready" {
+  data "bunkerweb_readiness" "platform" {
+    min_instances = 2
+  }
+
+  assert {
+    condition     = data.bunkerweb_readiness.platform.ready
+    error_message = join("; ", data.bunkerweb_readiness.platform.problems)
+  }
+}
+
+# Refuse to start an apply while the previous one is still being applied.
+data "bunkerweb_readiness" "converged" {}
+
+resource "terraform_data" "converged" {
+  lifecycle {
+    precondition {
+      condition     = data.bunkerweb_readiness.converged.pending_changes != true
+      error_message = "The scheduler is still applying: ${join(", ", data.bunkerweb_readiness.converged.pending_change_kinds)}."
+    }
+  }
+}
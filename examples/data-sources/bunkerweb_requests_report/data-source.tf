@@ -0,0 +1,25 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+data "bunkerweb_requests_report" "daily" {
+  window_seconds = 86400
+  top            = 5
+}
+
+output "blocked_last_24h" {
+  value = data.bunkerweb_requests_report.daily.total_blocked
+}
+
+output "blocked_per_service" {
+  value = data.bunkerweb_requests_report.daily.blocked_per_service
+}
+
+output "top_attacking_countries" {
+  value = data.bunkerweb_requests_report.daily.top_countries
+}
@@ -0,0 +1,26 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Call an endpoint the provider does not model yet.
+ephemeral "bunkerweb_api_call" "job_history" {
+  method = "GET"
+  path   = "jobs/history?plugin=letsencrypt"
+}
+
+# Send a write request, accepting a conflict when it was already applied.
+ephemeral "bunkerweb_api_call" "run_backup" {
+  method          = "POST"
+  path            = "jobs/run"
+  body            = jsonencode({ jobs = [{ plugin = "backup", name = "backup-data" }] })
+  expected_status = [200, 202, 409]
+}
+
+locals {
+  last_renewals = jsondecode(ephemeral.bunkerweb_api_call.job_history.response).runs
+}
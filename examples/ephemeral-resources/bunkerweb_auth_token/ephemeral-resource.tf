@@ -0,0 +1,21 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+ephemeral "bunkerweb_auth_token" "ci" {
+  username = var.api_username
+  password = var.api_password
+}
+
+# Hand the short-lived token to another provider talking to the API directly.
+provider "restapi" {
+  uri = ephemeral.bunkerweb_auth_token.ci.api_endpoint
+  headers = {
+    Authorization = "Bearer ${ephemeral.bunkerweb_auth_token.ci.token}"
+  }
+}
@@ -0,0 +1,43 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Only ban/unban what changed since the last run.
+ephemeral "bunkerweb_ban_bulk" "threat_feed" {
+  diff_only = true
+
+  bans = [
+    for ip in var.blocked_ips : {
+      ip         = ip
+      reason     = "threat-feed"
+      expires_in = 86400
+    }
+  ]
+
+  unbans = [
+    for ip in var.unblocked_ips : { ip = ip }
+  ]
+}
+
+# Plain-text threat feed, one IP per line with optional # comments.
+ephemeral "bunkerweb_ban_bulk" "blocklist" {
+  diff_only      = true
+  reason         = "blocklist"
+  expires_in     = 86400
+  bans_from_text = file("${path.module}/blocklist.txt")
+}
+
+# Copy the bans of the EU region to this one and drop the bans it no longer
+# has, so both regions block the same addresses.
+ephemeral "bunkerweb_ban_bulk" "sync_from_eu" {
+  mirror_from = {
+    api_endpoint = "https://bunkerweb-eu.example.com:8888"
+    api_token    = var.eu_api_token
+    prune        = true
+  }
+}
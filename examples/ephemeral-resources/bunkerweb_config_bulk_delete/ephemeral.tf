@@ -35,3 +35,14 @@ ephemeral "bunkerweb_config_bulk_delete" "cleanup" {
 
   depends_on = [bunkerweb_config.foo, bunkerweb_config.bar]
 }
+
+# Audit a large cleanup first: nothing is deleted, and configs that no longer
+# exist are reported in `missing` instead of failing the run.
+ephemeral "bunkerweb_config_bulk_delete" "audit" {
+  dry_run    = true
+  on_missing = "skip"
+
+  configs = [
+    for name in var.stale_configs : { type = "http", name = name }
+  ]
+}
@@ -11,6 +11,10 @@ ephemeral "bunkerweb_config_upload" "batch" {
   service = "web"
   type    = "http"
 
+  # Keep the accepted files when one is rejected; rejected files are listed
+  # in `errors`, created ones in `configs`.
+  continue_on_error = true
+
   files = [
     {
       name    = "http.conf"
@@ -22,3 +26,18 @@ ephemeral "bunkerweb_config_upload" "batch" {
     }
   ]
 }
+
+# Inject a config for the duration of the apply only: it is deleted again
+# when Terraform closes the ephemeral resource.
+ephemeral "bunkerweb_config_upload" "maintenance" {
+  service                  = "web"
+  type                     = "server_http"
+  delete_uploaded_on_close = true
+
+  files = [
+    {
+      name    = "maintenance.conf"
+      content = "location /maintenance { return 503; }"
+    }
+  ]
+}
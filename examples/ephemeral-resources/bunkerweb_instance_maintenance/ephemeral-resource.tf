@@ -0,0 +1,15 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Take the edge nodes down while the apply runs; they are reloaded when
+# Terraform closes the resource at the end of the run.
+ephemeral "bunkerweb_instance_maintenance" "edge" {
+  hostnames    = ["edge-1.example.internal", "edge-2.example.internal"]
+  drain_period = "45s"
+}
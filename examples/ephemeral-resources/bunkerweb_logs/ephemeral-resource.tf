@@ -0,0 +1,19 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Scheduler logs related to a single service.
+ephemeral "bunkerweb_logs" "scheduler" {
+  service = "app.example.com"
+  lines   = 50
+}
+
+# Logs of a single instance, e.g. after a failed reload.
+ephemeral "bunkerweb_logs" "edge" {
+  instance = "edge-1.example.internal"
+}
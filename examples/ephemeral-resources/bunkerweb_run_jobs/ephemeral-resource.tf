@@ -13,3 +13,13 @@ ephemeral "bunkerweb_run_jobs" "trigger" {
     name   = "daily"
   }]
 }
+
+# Renew certificates and fail the run unless the job succeeds within 10 minutes.
+ephemeral "bunkerweb_run_jobs" "renew" {
+  jobs = [{
+    plugin = "letsencrypt"
+    name   = "certbot-renew"
+  }]
+  wait_for_completion = true
+  timeout             = "10m"
+}
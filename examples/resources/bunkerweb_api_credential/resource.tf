@@ -0,0 +1,32 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+resource "time_rotating" "monthly" {
+  rotation_days = 30
+}
+
+# Token for CI pipelines, rotated every 30 days. The new token is issued
+# before the old one is revoked.
+resource "bunkerweb_api_credential" "ci" {
+  name = "ci-pipeline"
+
+  rotate_when_changed = {
+    rotation = time_rotating.monthly.id
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+# Token restricted to a single instance.
+resource "bunkerweb_api_credential" "edge" {
+  name     = "edge-1"
+  instance = "edge-1.example.com"
+}
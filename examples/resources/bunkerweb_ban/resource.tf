@@ -12,3 +12,10 @@ resource "bunkerweb_ban" "blocked_host" {
   reason             = "manual"
   expiration_seconds = 86400
 }
+
+# Bans until the resource is destroyed.
+resource "bunkerweb_ban" "attacker" {
+  ip        = "198.51.100.11"
+  reason    = "incident-42"
+  permanent = true
+}
@@ -0,0 +1,5 @@
+# Global exemption
+terraform import bunkerweb_ban_exemption.office "203.0.113.0/24"
+
+# Service-specific exemption
+terraform import bunkerweb_ban_exemption.vpn "2001:db8:1234::/48/app.example.com"
@@ -0,0 +1,18 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Office and VPN ranges must never be banned, whatever the detection plugins say.
+resource "bunkerweb_ban_exemption" "office" {
+  cidr = "203.0.113.0/24"
+}
+
+resource "bunkerweb_ban_exemption" "vpn" {
+  cidr    = "2001:db8:1234::/48"
+  service = "app.example.com"
+}
@@ -0,0 +1 @@
+terraform import bunkerweb_certificate.app "app.example.com"
@@ -0,0 +1,21 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+}
+
+resource "bunkerweb_certificate" "app" {
+  service     = bunkerweb_service.app.id
+  certificate = file("${path.module}/certs/app.example.com.crt")
+  private_key = file("${path.module}/certs/app.example.com.key")
+
+  # Plan a replacement two weeks before the certificate expires.
+  renew_before_days = 14
+}
@@ -0,0 +1,8 @@
+# Service config
+terraform import bunkerweb_config.headers "app.example.com/server_http/headers"
+
+# Global config (type/name)
+terraform import bunkerweb_config.access_log "http/access_log"
+
+# Keep only the content hash in state, for configs managed with data_wo
+terraform import bunkerweb_config.secret "http/secret?with_data=false"
@@ -12,3 +12,27 @@ resource "bunkerweb_config" "http_snippet" {
   name = "log_settings"
   data = "log_format combined '$remote_addr - $remote_user [$time_local] \"$request\" $status $body_bytes_sent';"
 }
+
+# Keep only a SHA-256 of the snippet in state (requires Terraform 1.11+).
+resource "bunkerweb_config" "upstream_auth" {
+  type                = "server_http"
+  name                = "upstream_auth"
+  data_wo             = "proxy_set_header Authorization \"Bearer ${var.upstream_token}\";"
+  store_data_in_state = false
+}
+
+# Stored as "10-rate_limit_zone", so it is included before higher priorities.
+resource "bunkerweb_config" "rate_limit_zone" {
+  type     = "http"
+  name     = "rate_limit_zone"
+  data     = "limit_req_zone $binary_remote_addr zone=api:10m rate=10r/s;"
+  priority = 10
+}
+
+# Content read from a file kept next to the configuration; only its hash is
+# stored in state.
+resource "bunkerweb_config" "security_headers" {
+  type   = "server_http"
+  name   = "security_headers"
+  source = "${path.module}/confs/security_headers.conf"
+}
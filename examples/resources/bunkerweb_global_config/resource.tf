@@ -0,0 +1,24 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  api_token    = var.api_token
+}
+
+# Global settings kept as a reviewed YAML file next to the configuration:
+#
+#   USE_GZIP: true
+#   LIMIT_REQ_RATE: 10r/s
+#   REVERSE_PROXY_URL_1: /api
+#
+# Keys are checked against the settings declared by the installed plugins
+# before anything is applied; keys removed from the file are reset.
+resource "bunkerweb_global_config" "main" {
+  settings_yaml = file("${path.module}/global.yaml")
+}
+
+# Inline alternative.
+# resource "bunkerweb_global_config" "main" {
+#   settings = {
+#     USE_GZIP       = "yes"
+#     LIMIT_REQ_RATE = "10r/s"
+#   }
+# }
@@ -11,3 +11,18 @@ resource "bunkerweb_global_config_setting" "retry" {
   key   = "retry_limit"
   value = "10"
 }
+
+# Multiple settings: writes REVERSE_PROXY_URL, REVERSE_PROXY_URL_1 and
+# REVERSE_PROXY_URL_2, and resets higher numbered keys.
+resource "bunkerweb_global_config_setting" "reverse_proxy_urls" {
+  key    = "REVERSE_PROXY_URL"
+  values = ["/", "/api", "/static"]
+}
+
+# Pro-only setting: skipped with a warning on installations without a
+# BunkerWeb Pro license instead of failing the apply.
+resource "bunkerweb_global_config_setting" "reporting" {
+  key                = "USE_REPORTING"
+  value              = "yes"
+  ignore_unsupported = true
+}
@@ -7,6 +7,11 @@ provider "bunkerweb" {
   api_password = var.api_password # required with api_username to work.
 }
 
+variable "instance_api_token" {
+  type      = string
+  sensitive = true
+}
+
 resource "bunkerweb_instance" "example" {
   hostname     = "worker-1.example.internal"
   name         = "Worker 1"
@@ -15,4 +20,18 @@ resource "bunkerweb_instance" "example" {
   https_port   = 8443
   server_name  = "worker-1.example.internal"
   method       = "api"
+  type         = "docker"
+
+  # Credentials the control plane uses to reach this instance's API.
+  api_token = var.instance_api_token
+
+  # Keep labels aligned with what autoconf publishes for the same instance.
+  labels = {
+    "bunkerweb.INSTANCE" = "yes"
+  }
+}
+
+# Ready-made endpoint for monitoring or load balancer pools.
+output "worker_api_url" {
+  value = bunkerweb_instance.example.api_url # https://worker-1.example.internal:8443
 }
@@ -0,0 +1,34 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+
+  variables = {
+    USE_REVERSE_PROXY  = "yes"
+    REVERSE_PROXY_HOST = "http://10.0.0.12:8080"
+  }
+}
+
+# Reload every instance whenever the service variables change.
+resource "bunkerweb_instance_reload" "fleet" {
+  triggers = {
+    app = sha256(jsonencode(bunkerweb_service.app.variables))
+  }
+
+  # Reloads are safe to repeat: retry them harder than the provider default.
+  retries = {
+    count   = 5
+    backoff = "2s"
+  }
+}
+
+output "last_reload" {
+  value = bunkerweb_instance_reload.fleet.last_reloaded_at
+}
@@ -0,0 +1 @@
+terraform import bunkerweb_letsencrypt_settings.app "app.example.com"
@@ -0,0 +1,29 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+variable "cloudflare_api_token" {
+  type      = string
+  sensitive = true
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+}
+
+resource "bunkerweb_letsencrypt_settings" "app" {
+  service      = bunkerweb_service.app.id
+  email        = "admin@example.com"
+  challenge    = "dns"
+  dns_provider = "cloudflare"
+  wildcard     = true
+
+  dns_credentials = {
+    dns_cloudflare_api_token = var.cloudflare_api_token
+  }
+}
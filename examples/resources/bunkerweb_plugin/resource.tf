@@ -0,0 +1,22 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Single-file plugin.
+resource "bunkerweb_plugin" "custom" {
+  name    = "custom.lua"
+  content = file("${path.module}/plugins/custom.lua")
+}
+
+# Plugin directory packaged into a .tar.gz archive. Only the matched files are
+# uploaded, and the plan is the same on Windows, macOS and Linux checkouts.
+resource "bunkerweb_plugin" "geo" {
+  name            = "geo.tar.gz"
+  source_dir      = "${path.module}/plugins/geo"
+  source_patterns = ["plugin.json", "**/*.lua", "ui/**"]
+}
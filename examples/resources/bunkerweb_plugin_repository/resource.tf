@@ -0,0 +1,16 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+resource "bunkerweb_plugin_repository" "official" {
+  url = "https://github.com/bunkerity/bunkerweb-plugins/archive/refs/tags/v1.9.zip"
+}
+
+resource "bunkerweb_plugin_repository" "internal" {
+  url = "https://artifacts.example.com/bunkerweb/plugins.tar.gz"
+}
@@ -15,3 +15,84 @@ resource "bunkerweb_service" "example" {
     mode     = "production"
   }
 }
+
+# Standardize hardening with a preset; variables still override its settings.
+resource "bunkerweb_service" "hardened" {
+  server_name     = "admin.example.com"
+  security_preset = "high"
+
+  variables = {
+    LIMIT_REQ_RATE = "5r/s"
+  }
+}
+
+# Take over a service previously created from the web UI.
+resource "bunkerweb_service" "legacy" {
+  server_name    = "legacy.example.com"
+  adopt_existing = true
+
+  variables = {
+    USE_GZIP = "yes"
+  }
+}
+
+# Ship service-scoped custom configs together with the service.
+resource "bunkerweb_service" "with_configs" {
+  server_name = "shop.example.com"
+
+  custom_configs = [
+    {
+      type = "server_http"
+      name = "security-headers"
+      data = "add_header X-Frame-Options DENY;"
+    },
+    {
+      type = "modsec"
+      name = "allow-checkout"
+      data = file("${path.module}/modsec/allow-checkout.conf")
+    },
+  ]
+}
+
+# Refuse `terraform destroy` while the production vhost is online.
+resource "bunkerweb_service" "production" {
+  server_name         = "www.example.com"
+  deletion_protection = true
+}
+
+# Stop routing to the vhost and let connections finish before it is deleted.
+resource "bunkerweb_service" "legacy_api" {
+  server_name        = "api-v1.example.com"
+  drain_on_destroy   = true
+  drain_grace_period = "2m"
+}
+
+# Proxy plain TCP traffic, for example to a database.
+resource "bunkerweb_service" "postgres" {
+  server_name     = "db.example.com"
+  listen_stream   = true
+  stream_port     = 5432
+  stream_protocol = "tcp"
+
+  variables = {
+    USE_REVERSE_PROXY  = "yes"
+    REVERSE_PROXY_HOST = "10.0.0.20:5432"
+  }
+}
+
+# Fail the apply unless the vhost serves its health endpoint once deployed.
+resource "bunkerweb_service" "shop" {
+  server_name = "shop.example.com"
+
+  variables = {
+    USE_REVERSE_PROXY  = "yes"
+    REVERSE_PROXY_HOST = "http://shop-backend:8080"
+  }
+
+  health_check = {
+    instance        = "bunkerweb-1"
+    url             = "https://shop.example.com/healthz"
+    expected_status = 200
+    timeout         = "3m"
+  }
+}
@@ -0,0 +1,30 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+resource "bunkerweb_service_template" "hardened" {
+  name        = "hardened"
+  description = "Baseline security settings shared by public services."
+
+  variables = {
+    USE_ANTIBOT      = "captcha"
+    USE_LIMIT_REQ    = "yes"
+    USE_BAD_BEHAVIOR = "yes"
+    USE_MODSECURITY  = "yes"
+  }
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+  template    = bunkerweb_service_template.hardened.variables
+
+  # Service variables override the template.
+  variables = {
+    USE_LIMIT_REQ = "no"
+  }
+}
@@ -0,0 +1,30 @@
+provider "bunkerweb" {
+  api_endpoint = "https://127.0.0.1:8888"
+  # Bearer token Auth
+  api_token = var.api_token # If you choose to use Bearer Token configured in your API deployment
+  # OR Basic Auth
+  api_username = var.api_username # Basic Auth configured in your API deployment.
+  api_password = var.api_password # required with api_username to work.
+}
+
+# Every service on the control plane that is not defined here (or excluded)
+# is deleted.
+resource "bunkerweb_services_sync" "fleet" {
+  services = {
+    "app.example.com" = {
+      server_name = "app.example.com www.app.example.com"
+      variables = {
+        USE_REVERSE_PROXY  = "yes"
+        REVERSE_PROXY_HOST = "http://app:8080"
+      }
+    }
+    "api.example.com" = {
+      variables = {
+        USE_REVERSE_PROXY  = "yes"
+        REVERSE_PROXY_HOST = "http://api:8080"
+      }
+    }
+  }
+
+  exclude = ["legacy.example.com"]
+}
@@ -0,0 +1,211 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package acctest runs tests against a real BunkerWeb all-in-one container
+// instead of the in-memory fake API, so that envelope and endpoint
+// differences between the fake and actual releases surface in tests.
+//
+// The mode is opt-in: set BUNKERWEB_ACC_DOCKER=1 and make the docker CLI
+// available. BUNKERWEB_ACC_IMAGE selects the image to validate, e.g.
+// bunkerity/bunkerweb-all-in-one:1.6.5.
+package acctest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// EnvDocker enables the real container mode when set to a true value.
+	EnvDocker = "BUNKERWEB_ACC_DOCKER"
+	// EnvImage overrides DefaultImage.
+	EnvImage = "BUNKERWEB_ACC_IMAGE"
+
+	// DefaultImage is the all-in-one image started when EnvImage is unset.
+	DefaultImage = "bunkerity/bunkerweb-all-in-one:latest"
+
+	apiPort             = "8888/tcp"
+	defaultStartTimeout = 5 * time.Minute
+)
+
+// Options customizes the container started by Start.
+type Options struct {
+	// Image defaults to EnvImage, then DefaultImage.
+	Image string
+	// Token is the API bearer token; a random one is generated when empty.
+	Token string
+	// Env holds extra container settings, applied after the ones enabling
+	// the API.
+	Env map[string]string
+	// StartTimeout bounds how long to wait for the API. Defaults to 5m.
+	StartTimeout time.Duration
+}
+
+// BunkerWeb is a running BunkerWeb container.
+type BunkerWeb struct {
+	// Endpoint is the base URL of the API, as set in api_endpoint.
+	Endpoint string
+	// Token is the API bearer token, as set in api_token.
+	Token string
+	// Image is the image the container runs.
+	Image string
+	// Container is the docker container ID.
+	Container string
+}
+
+// Enabled reports whether tests should target a real container.
+func Enabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EnvDocker))) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// Start starts a BunkerWeb container with its API enabled and waits for the
+// API to answer. The container is removed when t finishes. t is skipped when
+// the mode is not enabled, and fails when docker cannot start the container.
+func Start(t testing.TB, opts Options) *BunkerWeb {
+	t.Helper()
+	if !Enabled() {
+		t.Skipf("%s is not set; skipping test against a real BunkerWeb container", EnvDocker)
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Fatalf("%s is set but the docker CLI is not available: %s", EnvDocker, err)
+	}
+
+	bw := &BunkerWeb{Image: opts.Image, Token: opts.Token}
+	if bw.Image == "" {
+		bw.Image = os.Getenv(EnvImage)
+	}
+	if bw.Image == "" {
+		bw.Image = DefaultImage
+	}
+	if bw.Token == "" {
+		bw.Token = randomToken(t)
+	}
+	timeout := opts.StartTimeout
+	if timeout <= 0 {
+		timeout = defaultStartTimeout
+	}
+
+	env := map[string]string{
+		"SERVICE_API":       "yes",
+		"API_TOKEN":         bw.Token,
+		"API_WHITELIST_IPS": "0.0.0.0/0",
+		"MULTISITE":         "yes",
+	}
+	for key, value := range opts.Env {
+		env[key] = value
+	}
+
+	args := []string{"run", "--detach", "--rm", "--publish", "127.0.0.1::" + apiPort}
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		args = append(args, "--env", key+"="+env[key])
+	}
+	args = append(args, bw.Image)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	out, err := docker(ctx, args...)
+	if err != nil {
+		t.Fatalf("start %s: %s", bw.Image, err)
+	}
+	bw.Container = out
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := docker(ctx, "rm", "--force", bw.Container); err != nil {
+			t.Logf("remove container %s: %s", bw.Container, err)
+		}
+	})
+
+	address, err := docker(ctx, "port", bw.Container, apiPort)
+	if err != nil {
+		t.Fatalf("find the API port of %s: %s", bw.Container, err)
+	}
+	// docker port prints one line per address family.
+	address, _, _ = strings.Cut(address, "\n")
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		t.Fatalf("unexpected API address %q for %s: %s", address, bw.Container, err)
+	}
+	bw.Endpoint = "http://" + address
+
+	if err := bw.waitReady(ctx); err != nil {
+		logs, _ := docker(context.Background(), "logs", "--tail", "50", bw.Container)
+		t.Fatalf("BunkerWeb %s did not become ready: %s\n%s", bw.Image, err, logs)
+	}
+	t.Logf("BunkerWeb %s listening on %s (container %.12s)", bw.Image, bw.Endpoint, bw.Container)
+	return bw
+}
+
+// ProviderConfig returns a provider block targeting the container.
+func (bw *BunkerWeb) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = %q
+  api_token    = %q
+}
+`, bw.Endpoint, bw.Token)
+}
+
+// waitReady polls /ping until the API answers with a success status.
+func (bw *BunkerWeb) waitReady(ctx context.Context) error {
+	client := &http.Client{Timeout: 5 * time.Second}
+	lastErr := fmt.Errorf("no answer yet")
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, bw.Endpoint+"/ping", nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+bw.Token)
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			err = fmt.Errorf("/ping answered %s", resp.Status)
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %s)", ctx.Err(), lastErr)
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func randomToken(t testing.TB) string {
+	t.Helper()
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatalf("generate API token: %s", err)
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,213 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiCallMethods are the HTTP methods bunkerweb_api_call accepts.
+var apiCallMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+var _ ephemeral.EphemeralResource = &BunkerWebAPICallEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &BunkerWebAPICallEphemeralResource{}
+
+// BunkerWebAPICallEphemeralResource sends an arbitrary request to the API.
+type BunkerWebAPICallEphemeralResource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebAPICallModel captures Terraform configuration.
+type BunkerWebAPICallModel struct {
+	Method         types.String `tfsdk:"method"`
+	Path           types.String `tfsdk:"path"`
+	Body           types.String `tfsdk:"body"`
+	ExpectedStatus types.List   `tfsdk:"expected_status"`
+	StatusCode     types.Int64  `tfsdk:"status_code"`
+	Response       types.String `tfsdk:"response"`
+}
+
+func NewBunkerWebAPICallEphemeralResource() ephemeral.EphemeralResource {
+	return &BunkerWebAPICallEphemeralResource{}
+}
+
+func (r *BunkerWebAPICallEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_call"
+}
+
+func (r *BunkerWebAPICallEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a request to any BunkerWeb API endpoint and returns the raw JSON response, for endpoints the provider does not model yet. " +
+			"The request uses the provider's endpoint, authentication, tenant and headers. Prefer the dedicated resources where they exist: " +
+			"nothing is tracked in state, and the request is sent every time the ephemeral resource is opened, during plan as well as apply.",
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "HTTP method: `GET`, `POST`, `PUT`, `PATCH` or `DELETE`.",
+			},
+			"path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Endpoint relative to `api_endpoint`, optionally with a query string (for example `jobs/history?plugin=letsencrypt`).",
+			},
+			"body": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON request body, usually built with `jsonencode`.",
+			},
+			"expected_status": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "HTTP status codes that count as success. Any other status fails the operation. Defaults to any `2xx` status.",
+			},
+			"status_code": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "HTTP status code of the response.",
+			},
+			"response": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Raw response body, usually JSON to read with `jsondecode`.",
+			},
+		},
+	}
+}
+
+func (r *BunkerWebAPICallEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *BunkerWebAPICallEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data BunkerWebAPICallModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Method.IsUnknown() && !slices.Contains(apiCallMethods, strings.ToUpper(data.Method.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("method"),
+			"Invalid Method",
+			fmt.Sprintf("Expected one of %s, got %q.", strings.Join(apiCallMethods, ", "), data.Method.ValueString()),
+		)
+	}
+
+	if !data.Path.IsUnknown() {
+		if err := validateAPICallPath(data.Path.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid Path", err.Error())
+		}
+	}
+
+	if !data.Body.IsNull() && !data.Body.IsUnknown() && !json.Valid([]byte(data.Body.ValueString())) {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid Body", "`body` must be valid JSON; build it with `jsonencode`.")
+	}
+
+	if !data.ExpectedStatus.IsNull() && !data.ExpectedStatus.IsUnknown() {
+		var statuses []types.Int64
+		resp.Diagnostics.Append(data.ExpectedStatus.ElementsAs(ctx, &statuses, false)...)
+		for idx, status := range statuses {
+			if !status.IsUnknown() && (status.ValueInt64() < 100 || status.ValueInt64() > 599) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("expected_status").AtListIndex(idx),
+					"Invalid Status Code",
+					fmt.Sprintf("Expected an HTTP status code between 100 and 599, got %d.", status.ValueInt64()),
+				)
+			}
+		}
+	}
+
+	if resp.Diagnostics.HasError() || data.Method.IsUnknown() || strings.EqualFold(data.Method.ValueString(), http.MethodGet) {
+		return
+	}
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+	addPlanSummary(&resp.Diagnostics, "bunkerweb_api_call", []string{fmt.Sprintf("send %s /%s", strings.ToUpper(data.Method.ValueString()), strings.TrimPrefix(data.Path.ValueString(), "/"))})
+}
+
+func (r *BunkerWebAPICallEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebAPICallModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expected []int64
+	if !data.ExpectedStatus.IsNull() {
+		resp.Diagnostics.Append(data.ExpectedStatus.ElementsAs(ctx, &expected, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	method := strings.ToUpper(data.Method.ValueString())
+	endpoint := strings.TrimPrefix(data.Path.ValueString(), "/")
+	status, body, err := r.client.Call(ctx, method, endpoint, []byte(data.Body.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Call BunkerWeb API", err.Error())
+		return
+	}
+
+	success := status >= 200 && status < 300
+	if len(expected) > 0 {
+		success = slices.Contains(expected, int64(status))
+	}
+	if !success {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response Status",
+			fmt.Sprintf("%s /%s answered HTTP %d: %s", method, endpoint, status, firstNonEmpty(strings.TrimSpace(string(body)), http.StatusText(status))),
+		)
+		return
+	}
+
+	data.StatusCode = types.Int64Value(int64(status))
+	data.Response = types.StringValue(string(body))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// validateAPICallPath rejects paths that would leave api_endpoint: absolute
+// URLs and parent directory segments.
+func validateAPICallPath(raw string) error {
+	if strings.TrimSpace(raw) == "" {
+		return fmt.Errorf("`path` cannot be empty")
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("`path` is not a valid URL path: %s", err)
+	}
+	if parsed.Scheme != "" || parsed.Host != "" || strings.HasPrefix(raw, "//") {
+		return fmt.Errorf("`path` must be relative to `api_endpoint`, got %q", raw)
+	}
+	if slices.Contains(strings.Split(parsed.Path, "/"), "..") {
+		return fmt.Errorf("`path` cannot contain `..` segments, got %q", raw)
+	}
+	return nil
+}
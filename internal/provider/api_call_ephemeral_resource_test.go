@@ -0,0 +1,105 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestBunkerWebClientCall(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	status, body, err := client.Call(ctx, http.MethodGet, "ping", nil)
+	if err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if status != http.StatusOK || !strings.Contains(string(body), `"status"`) {
+		t.Fatalf("unexpected response: %d %s", status, body)
+	}
+
+	status, _, err = client.Call(ctx, http.MethodGet, "services/missing.example.com", nil)
+	if err != nil {
+		t.Fatalf("expected error statuses to be returned as is, got %v", err)
+	}
+	if status != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestValidateAPICallPath(t *testing.T) {
+	for raw, valid := range map[string]bool{
+		"ping":                             true,
+		"/jobs/history?plugin=letsencrypt": true,
+		"":                                 false,
+		"https://example.com/ping":         false,
+		"//example.com/ping":               false,
+		"services/../../admin":             false,
+	} {
+		if err := validateAPICallPath(raw); (err == nil) != valid {
+			t.Errorf("validateAPICallPath(%q) = %v, want valid %t", raw, err, valid)
+		}
+	}
+}
+
+func TestAccBunkerWebAPICallEphemeralResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	providerConfig := fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+`, fakeAPI.URL())
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+ephemeral "bunkerweb_api_call" "ping" {
+  method = "GET"
+  path   = "ping"
+}
+`,
+			},
+			{
+				Config: providerConfig + `
+ephemeral "bunkerweb_api_call" "missing" {
+  method          = "GET"
+  path            = "services/missing.example.com"
+  expected_status = [200]
+}
+`,
+				ExpectError: regexp.MustCompile(`answered HTTP 404`),
+			},
+			{
+				Config: providerConfig + `
+ephemeral "bunkerweb_api_call" "escape" {
+  method = "GET"
+  path   = "https://example.com/ping"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Path`),
+			},
+		},
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &BunkerWebAPICredentialResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebAPICredentialResource{}
+
+// BunkerWebAPICredentialResource manages an API token used by CI or a single
+// instance. Any argument change, including rotate_when_changed, issues a new
+// token.
+type BunkerWebAPICredentialResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebAPICredentialResourceModel is the Terraform state.
+type BunkerWebAPICredentialResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Instance          types.String `tfsdk:"instance"`
+	RotateWhenChanged types.Map    `tfsdk:"rotate_when_changed"`
+	Token             types.String `tfsdk:"token"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	Retries           types.Object `tfsdk:"retries"`
+}
+
+func NewBunkerWebAPICredentialResource() resource.Resource {
+	return &BunkerWebAPICredentialResource{}
+}
+
+func (r *BunkerWebAPICredentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_credential"
+}
+
+func (r *BunkerWebAPICredentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Issues an API credential (bearer token) for CI jobs or a single instance. Changing any argument, " +
+			"including `rotate_when_changed`, rotates the token; use `create_before_destroy` so the old token stays valid " +
+			"until its consumers have been updated. The token is stored in state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the credential inside BunkerWeb.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Human-readable name of the credential.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Hostname of the instance the credential is restricted to. When omitted, the credential is valid for the whole API.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_when_changed": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that rotate the credential when they change, for example `{ rotated = time_rotating.monthly.id }`.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Bearer token of the credential.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp at which the credential was issued.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebAPICredentialResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+// ModifyPlan fails the plan when the BunkerWeb version predates the endpoint.
+func (r *BunkerWebAPICredentialResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	addFeatureCheck(&resp.Diagnostics, r.client, featureAPICredentials)
+}
+
+func (r *BunkerWebAPICredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebAPICredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential, err := r.client.CreateAPICredential(withIdempotencyKey(ctx), APICredentialCreateRequest{
+		Name:     plan.Name.ValueString(),
+		Instance: optionalString(plan.Instance),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create API Credential", err.Error())
+		return
+	}
+	if credential.Token == "" {
+		resp.Diagnostics.AddError("Missing API Credential Token", "The API did not return a token for the new credential.")
+		return
+	}
+
+	plan.ID = types.StringValue(credential.ID)
+	plan.Token = types.StringValue(credential.Token)
+	plan.CreatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	if credential.CreatedAt != 0 {
+		plan.CreatedAt = types.StringValue(time.Unix(credential.CreatedAt, 0).UTC().Format(time.RFC3339))
+	}
+
+	tflog.Info(ctx, "created bunkerweb api credential", map[string]any{"id": credential.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebAPICredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebAPICredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential, err := r.client.GetAPICredential(ctx, state.ID.ValueString())
+	if err != nil {
+		var apiErr *bunkerWebAPIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			// Revoked out-of-band: recreate to issue a fresh token.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read API Credential", err.Error())
+		return
+	}
+
+	// The token is never returned again; keep the one from state.
+	state.Name = types.StringValue(credential.Name)
+	if credential.Instance != nil {
+		state.Instance = types.StringValue(*credential.Instance)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BunkerWebAPICredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every argument forces replacement; nothing to update in place.
+	var plan BunkerWebAPICredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebAPICredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebAPICredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteAPICredential(ctx, state.ID.ValueString()); err != nil {
+		var apiErr *bunkerWebAPIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return
+		}
+		resp.Diagnostics.AddError("Unable to Delete API Credential", err.Error())
+	}
+}
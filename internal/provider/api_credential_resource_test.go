@@ -0,0 +1,75 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccBunkerWebAPICredentialResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	expectCredentials := func(ids ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := fakeAPI.APICredentialIDs(); !slices.Equal(got, ids) {
+				return fmt.Errorf("expected credentials %v, got %v", ids, got)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             expectCredentials(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebAPICredentialResourceConfig(fakeAPI.URL(), "2026-01"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_api_credential.ci", "id", "1"),
+					resource.TestCheckResourceAttr("bunkerweb_api_credential.ci", "token", "credential-token-1"),
+					resource.TestCheckResourceAttrSet("bunkerweb_api_credential.ci", "created_at"),
+					expectCredentials("1"),
+				),
+			},
+			{
+				Config:   testAccBunkerWebAPICredentialResourceConfig(fakeAPI.URL(), "2026-01"),
+				PlanOnly: true,
+			},
+			{
+				// Changing the trigger rotates the token; the old one is revoked.
+				Config: testAccBunkerWebAPICredentialResourceConfig(fakeAPI.URL(), "2026-02"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_api_credential.ci", "token", "credential-token-2"),
+					expectCredentials("2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebAPICredentialResourceConfig(endpoint, rotation string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_api_credential" "ci" {
+  name = "ci"
+  rotate_when_changed = {
+    rotation = %q
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, endpoint, rotation)
+}
@@ -0,0 +1,128 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// apiErrorKind groups API error messages that callers scope to the same
+// attribute.
+type apiErrorKind int
+
+const (
+	apiErrorSetting apiErrorKind = iota + 1
+	apiErrorConfigName
+	apiErrorDraftOnly
+	apiErrorReadOnly
+	apiErrorProOnly
+)
+
+// apiErrorTranslation turns a known API error message into an actionable
+// diagnostic.
+type apiErrorTranslation struct {
+	kind    apiErrorKind
+	pattern *regexp.Regexp
+	summary string
+	detail  string
+	docs    string
+}
+
+// settingNamePattern finds a setting name such as USE_ANTIBOT in a message.
+var settingNamePattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]*(?:_[A-Z0-9]+)+\b`)
+
+// apiErrorTranslations lists the messages BunkerWeb returns most often for
+// configuration mistakes, matched in order.
+var apiErrorTranslations = []apiErrorTranslation{
+	{
+		kind:    apiErrorProOnly,
+		pattern: regexp.MustCompile(`(?i)\brequires?\b.*\bpro\b|\bpro\b.*\b(?:licen[cs]e|only|plan|subscription)\b|\blicen[cs]e\b`),
+		summary: "Setting Requires BunkerWeb Pro",
+		detail:  "BunkerWeb rejected a setting that is only available with a BunkerWeb Pro license, which this installation does not have. Remove the setting or upgrade to BunkerWeb Pro.",
+		docs:    "https://docs.bunkerweb.io/latest/pro/",
+	},
+	{
+		kind:    apiErrorSetting,
+		pattern: regexp.MustCompile(`(?i)\b(?:invalid|doesn't match|does not match)\b.*\b(?:setting|value|regex)\b|\b(?:setting|value)\b.*\b(?:invalid|doesn't match|does not match)\b`),
+		summary: "Invalid Setting Value",
+		detail:  "BunkerWeb rejected a setting value because it does not match the setting's validation regex. Check the value against the setting's documented format.",
+		docs:    "https://docs.bunkerweb.io/latest/features/",
+	},
+	{
+		kind:    apiErrorConfigName,
+		pattern: regexp.MustCompile(`(?i)\breserved\b|\binvalid (?:config )?name\b|\bname\b.*\b(?:invalid|not allowed)\b`),
+		summary: "Invalid Config Name",
+		detail:  "BunkerWeb rejected the config name. Names must match ^[\\w_-]{1,64}$ and must not reuse a name reserved by BunkerWeb or its plugins.",
+		docs:    "https://docs.bunkerweb.io/latest/advanced/#custom-configurations",
+	},
+	{
+		kind:    apiErrorDraftOnly,
+		pattern: regexp.MustCompile(`(?i)\bdraft\b`),
+		summary: "Service Must Be a Draft",
+		detail:  "BunkerWeb only allows this operation on draft services. Set is_draft = true and apply before retrying.",
+		docs:    "https://docs.bunkerweb.io/latest/api/",
+	},
+	{
+		kind:    apiErrorReadOnly,
+		pattern: regexp.MustCompile(`(?i)(?:not (?:editable|deletable)|read[- ]only|can't be (?:edited|deleted|modified)|cannot be (?:edited|deleted|modified)|created by (?:the )?(?:scheduler|autoconf|ui|core))`),
+		summary: "Object Not Managed by the API",
+		detail:  "The object was created by another method (for example the web UI, autoconf or a plugin) and BunkerWeb refuses to change it through the API. Change it where it was created, or remove it there and let Terraform create it.",
+		docs:    "https://docs.bunkerweb.io/latest/api/",
+	},
+}
+
+// translateAPIError returns the translation matching a 4xx API error, and the
+// setting it names, if any.
+func translateAPIError(err error) (translation apiErrorTranslation, setting string, ok bool) {
+	var apiErr *bunkerWebAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode < 400 || apiErr.StatusCode >= 500 || apiErr.Message == "" {
+		return apiErrorTranslation{}, "", false
+	}
+
+	for _, t := range apiErrorTranslations {
+		if !t.pattern.MatchString(apiErr.Message) {
+			continue
+		}
+		if t.kind == apiErrorSetting || t.kind == apiErrorProOnly {
+			setting = settingNamePattern.FindString(apiErr.Message)
+		}
+		return t, setting, true
+	}
+	return apiErrorTranslation{}, "", false
+}
+
+// isProOnlyError reports whether err is BunkerWeb rejecting a setting that
+// requires a Pro license.
+func isProOnlyError(err error) bool {
+	t, _, ok := translateAPIError(err)
+	return ok && t.kind == apiErrorProOnly
+}
+
+// addAPIError reports err under summary, or as a translated diagnostic when
+// the message is a known one. attributes scopes each kind of error to an
+// attribute; settings errors on a map attribute are scoped to the setting's
+// key.
+func addAPIError(diags *diag.Diagnostics, summary string, err error, attributes map[apiErrorKind]path.Path) {
+	t, setting, ok := translateAPIError(err)
+	if !ok {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	detail := fmt.Sprintf("%s: %s\n\n%s\n\nSee %s", summary, err, t.detail, t.docs)
+	attr, scoped := attributes[t.kind]
+	if !scoped {
+		diags.AddError(t.summary, detail)
+		return
+	}
+	if t.kind == apiErrorSetting && setting != "" && attr.Equal(path.Root("variables")) {
+		attr = attr.AtMapKey(setting)
+	}
+	diags.AddAttributeError(attr, t.summary, detail)
+}
@@ -0,0 +1,68 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestTranslateAPIError(t *testing.T) {
+	cases := []struct {
+		err     error
+		kind    apiErrorKind
+		setting string
+	}{
+		{&bunkerWebAPIError{StatusCode: 422, Message: "Invalid value for setting USE_ANTIBOT"}, apiErrorSetting, "USE_ANTIBOT"},
+		{&bunkerWebAPIError{StatusCode: 400, Message: "Setting AUTO_LETS_ENCRYPT value doesn't match regex ^(yes|no)$"}, apiErrorSetting, "AUTO_LETS_ENCRYPT"},
+		{&bunkerWebAPIError{StatusCode: 422, Message: "Config name modsec-crs is reserved"}, apiErrorConfigName, ""},
+		{&bunkerWebAPIError{StatusCode: 400, Message: "Invalid name"}, apiErrorConfigName, ""},
+		{&bunkerWebAPIError{StatusCode: 403, Message: "Only draft services can be deleted"}, apiErrorDraftOnly, ""},
+		{&bunkerWebAPIError{StatusCode: 403, Message: "Config headers is not editable, it was created by the scheduler"}, apiErrorReadOnly, ""},
+		{&bunkerWebAPIError{StatusCode: 403, Message: "Setting USE_REPORTING requires a BunkerWeb PRO license"}, apiErrorProOnly, "USE_REPORTING"},
+		{&bunkerWebAPIError{StatusCode: 403, Message: "Invalid setting ANTIBOT_CAPTCHA_THEME: PRO only"}, apiErrorProOnly, "ANTIBOT_CAPTCHA_THEME"},
+	}
+	for _, tc := range cases {
+		got, setting, ok := translateAPIError(tc.err)
+		if !ok || got.kind != tc.kind || setting != tc.setting {
+			t.Errorf("translateAPIError(%q) = kind %d setting %q ok %t, want kind %d setting %q", tc.err, got.kind, setting, ok, tc.kind, tc.setting)
+		}
+	}
+
+	for _, err := range []error{
+		errors.New("invalid setting value"),
+		&bunkerWebAPIError{StatusCode: 500, Message: "Invalid value for setting USE_ANTIBOT"},
+		&bunkerWebAPIError{StatusCode: 400, Message: "invalid request body"},
+		&bunkerWebAPIError{StatusCode: 404, Message: "Not Found"},
+	} {
+		if _, _, ok := translateAPIError(err); ok {
+			t.Errorf("expected %q not to be translated", err)
+		}
+	}
+}
+
+func TestAddAPIError(t *testing.T) {
+	var diags diag.Diagnostics
+	addAPIError(&diags, "Unable to Update Service", &bunkerWebAPIError{StatusCode: 422, Message: "Invalid value for setting USE_ANTIBOT"}, serviceAPIErrorAttributes)
+	if len(diags) != 1 {
+		t.Fatalf("expected one diagnostic, got %v", diags)
+	}
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("variables").AtMapKey("USE_ANTIBOT")) {
+		t.Fatalf("expected the error to be scoped to variables[\"USE_ANTIBOT\"], got %v", diags[0])
+	}
+	if diags[0].Summary() != "Invalid Setting Value" || !strings.Contains(diags[0].Detail(), "https://docs.bunkerweb.io/") {
+		t.Fatalf("unexpected diagnostic %q: %q", diags[0].Summary(), diags[0].Detail())
+	}
+
+	diags = nil
+	addAPIError(&diags, "Unable to Create Config", &bunkerWebAPIError{StatusCode: 500, Message: "boom"}, configAPIErrorAttributes)
+	if diags[0].Summary() != "Unable to Create Config" || diags[0].Detail() != "bunkerweb api error (500): boom" {
+		t.Fatalf("expected untranslated errors to pass through, got %q: %q", diags[0].Summary(), diags[0].Detail())
+	}
+}
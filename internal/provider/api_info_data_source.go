@@ -0,0 +1,149 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiInfoFeatures names the version-gated features reported in the
+// `features` attribute of bunkerweb_api_info.
+var apiInfoFeatures = map[string]endpointFeature{
+	"job_history":            featureJobHistory,
+	"request_reports":        featureRequestReports,
+	"metrics":                featureMetrics,
+	"logs":                   featureLogs,
+	"api_credentials":        featureAPICredentials,
+	"users":                  featureUsers,
+	"config_upload_update":   featureConfigUploadUpdate,
+	"service_variable_patch": featureServiceVariablePatch,
+}
+
+var _ datasource.DataSource = &BunkerWebAPIInfoDataSource{}
+
+// BunkerWebAPIInfoDataSource reports the capabilities of the target BunkerWeb API.
+type BunkerWebAPIInfoDataSource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebAPIInfoDataSourceModel holds state.
+type BunkerWebAPIInfoDataSourceModel struct {
+	Version    types.String `tfsdk:"version"`
+	Features   types.Map    `tfsdk:"features"`
+	ProPlugins types.List   `tfsdk:"pro_plugins"`
+	Endpoints  types.Map    `tfsdk:"endpoints"`
+}
+
+func NewBunkerWebAPIInfoDataSource() datasource.DataSource {
+	return &BunkerWebAPIInfoDataSource{}
+}
+
+func (d *BunkerWebAPIInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_info"
+}
+
+func (d *BunkerWebAPIInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the capabilities of the target BunkerWeb deployment: the version detected during provider setup, the features it supports, its pro plugins and the endpoints listed in its OpenAPI document. Use it to create resources conditionally, for example only when a pro plugin is installed.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "BunkerWeb version reported by `/health` or `/ping` (null when the API reports none).",
+			},
+			"features": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.BoolType,
+				MarkdownDescription: "Whether each version-gated feature is available, keyed by `job_history`, `request_reports`, `metrics`, `logs`, `api_credentials`, `users` and `config_upload_update`. A feature is assumed available when neither the version nor the endpoints could be detected.",
+			},
+			"pro_plugins": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Identifiers of the installed pro plugins, sorted.",
+			},
+			"endpoints": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "HTTP methods of each route in the API's OpenAPI document, keyed by route relative to the API base (e.g. `users/{username}`). Null when the document could not be fetched.",
+			},
+		},
+	}
+}
+
+func (d *BunkerWebAPIInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.state = data.state
+}
+
+func (d *BunkerWebAPIInfoDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	plugins, err := d.client.ListPlugins(ctx, "pro", false)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Plugins", err.Error())
+		return
+	}
+	proPlugins := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		proPlugins = append(proPlugins, plugin.ID)
+	}
+	slices.Sort(proPlugins)
+
+	features := make(map[string]bool, len(apiInfoFeatures))
+	for key, feature := range apiInfoFeatures {
+		features[key] = d.client.CheckFeature(feature) == nil
+	}
+
+	data := BunkerWebAPIInfoDataSourceModel{
+		Version:   types.StringNull(),
+		Endpoints: types.MapNull(types.ListType{ElemType: types.StringType}),
+	}
+	if version := d.state.version; version != "" {
+		data.Version = types.StringValue(version)
+	}
+
+	featuresValue, diags := types.MapValueFrom(ctx, types.BoolType, features)
+	resp.Diagnostics.Append(diags...)
+	proPluginsValue, diags := types.ListValueFrom(ctx, types.StringType, proPlugins)
+	resp.Diagnostics.Append(diags...)
+	if known := d.state.endpoints; known != nil {
+		endpoints := make(map[string][]string, len(known))
+		for route, methods := range known {
+			methods = slices.Clone(methods)
+			slices.Sort(methods)
+			endpoints[route] = methods
+		}
+		data.Endpoints, diags = types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, endpoints)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Features = featuresValue
+	data.ProPlugins = proPluginsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
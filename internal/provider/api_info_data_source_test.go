@@ -0,0 +1,50 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebAPIInfoDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.SetVersion("1.6.0")
+	fakeAPI.SetOpenAPIPaths("/jobs/history", "/users/{username}")
+	fakeAPI.AddPlugin(bunkerWebPlugin{ID: "reporting", Type: "pro", Version: "1.0.0"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebAPIInfoDataSourceConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "version", "1.6.0"),
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "features.job_history", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "features.users", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "features.logs", "false"),
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "features.config_upload_update", "false"),
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "pro_plugins.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "pro_plugins.0", "reporting"),
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "endpoints.%", "2"),
+					resource.TestCheckResourceAttr("data.bunkerweb_api_info.target", "endpoints.users/{username}.0", "GET"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebAPIInfoDataSourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_api_info" "target" {}
+`, endpoint)
+}
@@ -0,0 +1,166 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// endpointFeature records the BunkerWeb release that introduced an API
+// feature, so a 404/422 from an older server can be explained and plans can
+// fail before calling it.
+type endpointFeature struct {
+	// Prefix is the endpoint serving the feature, when it has its own.
+	Prefix     string
+	Feature    string
+	MinVersion string
+}
+
+var (
+	featureJobHistory           = endpointFeature{Prefix: "jobs/history", Feature: "job run history", MinVersion: "1.6.5"}
+	featureRequestReports       = endpointFeature{Prefix: "metrics/requests", Feature: "request reports", MinVersion: "1.6.5"}
+	featureMetrics              = endpointFeature{Prefix: "metrics", Feature: "plugin metrics", MinVersion: "1.6.5"}
+	featureLogs                 = endpointFeature{Prefix: "logs", Feature: "log retrieval", MinVersion: "1.6.5"}
+	featureAPICredentials       = endpointFeature{Prefix: "api_credentials", Feature: "API credentials", MinVersion: "1.6.5"}
+	featureUsers                = endpointFeature{Prefix: "users", Feature: "web UI user management", MinVersion: "1.6.5"}
+	featureConfigUploadUpdate   = endpointFeature{Feature: "replacing custom configs from uploaded files", MinVersion: "1.6.1"}
+	featureServiceVariablePatch = endpointFeature{Feature: "partial service variable updates", MinVersion: "1.6.1"}
+)
+
+// endpointFeatures lists endpoints added after the first API release. Longer
+// prefixes come first.
+var endpointFeatures = []endpointFeature{
+	featureJobHistory,
+	featureRequestReports,
+	featureMetrics,
+	featureLogs,
+	featureAPICredentials,
+	featureUsers,
+}
+
+// DetectVersion records the BunkerWeb version reported by /health, or /ping
+// as a fallback. Failures are logged and leave the version unknown.
+func (c *bunkerWebClient) DetectVersion(ctx context.Context) string {
+	for _, probe := range []func(context.Context) (map[string]any, error){c.Health, c.Ping} {
+		payload, err := probe(ctx)
+		if err != nil {
+			tflog.Debug(ctx, "unable to detect bunkerweb version", map[string]any{"error": err.Error()})
+			continue
+		}
+		for _, key := range []string{"version", "bunkerweb_version"} {
+			if v := strings.TrimSpace(stringifyValue(payload[key])); v != "" {
+				c.version = v
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// withVersionHint explains a 404/422 on an endpoint the detected BunkerWeb
+// version predates or its OpenAPI document lacks.
+func (c *bunkerWebClient) withVersionHint(req *http.Request, apiErr *bunkerWebAPIError) *bunkerWebAPIError {
+	if (c.version == "" && c.endpoints == nil) || (apiErr.StatusCode != http.StatusNotFound && apiErr.StatusCode != http.StatusUnprocessableEntity) {
+		return apiErr
+	}
+
+	endpoint := c.withoutTenant(strings.TrimPrefix(req.URL.Path, c.baseURL.Path))
+	for _, feature := range endpointFeatures {
+		if endpoint != feature.Prefix && !strings.HasPrefix(endpoint, feature.Prefix+"/") {
+			continue
+		}
+		if err := c.CheckFeature(feature); err != nil {
+			apiErr.Message = fmt.Sprintf("%s (%s)", firstNonEmpty(apiErr.Message, http.StatusText(apiErr.StatusCode)), err)
+		}
+		break
+	}
+
+	return apiErr
+}
+
+// CheckFeature fails when the API's OpenAPI document lacks the feature's
+// endpoint or the detected BunkerWeb version predates it. Both are fetched
+// once during provider setup, so the check issues no request; it passes when
+// neither is known.
+func (c *bunkerWebClient) CheckFeature(feature endpointFeature) error {
+	if feature.Prefix != "" {
+		if found, known := c.hasEndpointPrefix(feature.Prefix); known && !found {
+			return fmt.Errorf("your BunkerWeb API does not expose %s (no /%s endpoint in its OpenAPI document)", feature.Feature, feature.Prefix)
+		}
+	}
+	if c.version == "" {
+		return nil
+	}
+	if older, ok := versionLess(c.version, feature.MinVersion); ok && older {
+		return fmt.Errorf("your BunkerWeb %s does not support %s, requires >= %s", c.version, feature.Feature, feature.MinVersion)
+	}
+	return nil
+}
+
+// SupportsFeature reports whether the detected BunkerWeb version is known to
+// provide feature. Unlike CheckFeature it is false when the version could not
+// be detected, for behavior that is only safe on a confirmed release.
+func (c *bunkerWebClient) SupportsFeature(feature endpointFeature) bool {
+	if c.version == "" || c.CheckFeature(feature) != nil {
+		return false
+	}
+	older, ok := versionLess(c.version, feature.MinVersion)
+	return ok && !older
+}
+
+// addFeatureCheck reports an error when the detected BunkerWeb version
+// predates feature, and returns false in that case.
+func addFeatureCheck(diags *diag.Diagnostics, client BunkerWebAPI, feature endpointFeature) bool {
+	if err := client.CheckFeature(feature); err != nil {
+		diags.AddError("Unsupported BunkerWeb Version", err.Error()+". Upgrade BunkerWeb or remove this configuration.")
+		return false
+	}
+	return true
+}
+
+// versionLess reports whether version a is older than b. ok is false when
+// either is not a dotted numeric version ("v" prefixes and pre-release
+// suffixes such as "-rc1" are ignored).
+func versionLess(a, b string) (less bool, ok bool) {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+	if !okA || !okB {
+		return false, false
+	}
+	for i := range pa {
+		if pa[i] != pb[i] {
+			return pa[i] < pb[i], true
+		}
+	}
+	return false, true
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+
+	v = strings.TrimPrefix(strings.TrimSpace(strings.ToLower(v)), "v")
+	if idx := strings.IndexAny(v, "-+ "); idx >= 0 {
+		v = v[:idx]
+	}
+
+	fields := strings.Split(v, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
@@ -0,0 +1,191 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBunkerWebClientDetectVersion(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	api.SetVersion("1.6.1")
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	if got := client.DetectVersion(context.Background()); got != "1.6.1" {
+		t.Fatalf("expected version 1.6.1, got %q", got)
+	}
+}
+
+func TestBunkerWebClientVersionHint(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	ctx := context.Background()
+
+	cases := []struct {
+		version  string
+		wantHint bool
+	}{
+		{version: "", wantHint: false},
+		{version: "1.6.0", wantHint: true},
+		{version: "v1.6.5-rc1", wantHint: false},
+		{version: "1.7", wantHint: false},
+		{version: "unknown", wantHint: false},
+	}
+
+	for _, tc := range cases {
+		client.version = tc.version
+
+		_, err := client.GetUser(ctx, "missing")
+		var apiErr *bunkerWebAPIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Fatalf("version %q: expected 404 API error, got %v", tc.version, err)
+		}
+
+		hinted := strings.Contains(apiErr.Message, "does not support web UI user management, requires >= 1.6.5")
+		if hinted != tc.wantHint {
+			t.Fatalf("version %q: unexpected message %q", tc.version, apiErr.Message)
+		}
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		less, ok bool
+	}{
+		{a: "1.6.0", b: "1.6.5", less: true, ok: true},
+		{a: "v1.6.5", b: "1.6.5", less: false, ok: true},
+		{a: "1.6.5-rc2", b: "1.6.5", less: false, ok: true},
+		{a: "1.5", b: "1.6.0", less: true, ok: true},
+		{a: "2.0.0", b: "1.6.5", less: false, ok: true},
+		{a: "dev", b: "1.6.5", less: false, ok: false},
+		{a: "1.2.3.4", b: "1.6.5", less: false, ok: false},
+	}
+
+	for _, tc := range cases {
+		less, ok := versionLess(tc.a, tc.b)
+		if less != tc.less || ok != tc.ok {
+			t.Fatalf("versionLess(%q, %q) = %v, %v; want %v, %v", tc.a, tc.b, less, ok, tc.less, tc.ok)
+		}
+	}
+}
+
+func TestBunkerWebClientCheckFeature(t *testing.T) {
+	client := &bunkerWebClient{}
+	if err := client.CheckFeature(featureUsers); err != nil {
+		t.Fatalf("expected unknown version to pass, got %v", err)
+	}
+
+	client.version = "1.6.4"
+	err := client.CheckFeature(featureUsers)
+	if err == nil || !strings.Contains(err.Error(), "your BunkerWeb 1.6.4 does not support web UI user management, requires >= 1.6.5") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.version = "1.6.5"
+	if err := client.CheckFeature(featureUsers); err != nil {
+		t.Fatalf("expected 1.6.5 to pass, got %v", err)
+	}
+}
+
+func TestBunkerWebClientSupportsFeature(t *testing.T) {
+	client := &bunkerWebClient{}
+	if client.SupportsFeature(featureServiceVariablePatch) {
+		t.Fatal("expected an unknown version not to support the feature")
+	}
+
+	for version, want := range map[string]bool{"1.6.0": false, "1.6.1": true, "v1.6.5": true, "unknown": false} {
+		client.version = version
+		if got := client.SupportsFeature(featureServiceVariablePatch); got != want {
+			t.Errorf("SupportsFeature() with version %q = %t, want %t", version, got, want)
+		}
+	}
+}
+
+func TestAccBunkerWebProviderMinimumAPIVersion(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebMinimumAPIVersionConfig(fakeAPI.URL(), "1.6"),
+				ExpectError: regexp.MustCompile(`Unable to Verify BunkerWeb Version`),
+			},
+			{
+				PreConfig:   func() { fakeAPI.SetVersion("1.5.9") },
+				Config:      testAccBunkerWebMinimumAPIVersionConfig(fakeAPI.URL(), "1.6"),
+				ExpectError: regexp.MustCompile(`older than the required 1.6`),
+			},
+			{
+				Config:      testAccBunkerWebMinimumAPIVersionConfig(fakeAPI.URL(), "latest"),
+				ExpectError: regexp.MustCompile(`Invalid Minimum API Version`),
+			},
+			{
+				PreConfig: func() { fakeAPI.SetVersion("1.6.0") },
+				Config:    testAccBunkerWebMinimumAPIVersionConfig(fakeAPI.URL(), "1.6"),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebUserResourceUnsupportedVersion(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.SetVersion("1.6.0")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_user" "ops" {
+  username = "ops"
+  password = "s3cret-pass"
+}
+`, fakeAPI.URL()),
+				ExpectError: regexp.MustCompile(`does not support web UI user management, requires >= 1.6.5`),
+			},
+		},
+	})
+
+	if _, _, ok := fakeAPI.User("ops"); ok {
+		t.Fatalf("expected the plan to fail before creating the user")
+	}
+}
+
+func testAccBunkerWebMinimumAPIVersionConfig(endpoint, minimum string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint        = "%s"
+  api_token           = "test-token"
+  minimum_api_version = "%s"
+}
+
+data "bunkerweb_plugins" "all" {}
+`, endpoint, minimum)
+}
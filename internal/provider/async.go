@@ -0,0 +1,122 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// asyncTaskResponse is the part of a 202 Accepted or task status payload used
+// to follow an asynchronous operation.
+type asyncTaskResponse struct {
+	TaskID  string          `json:"task_id"`
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Detail  json.RawMessage `json:"detail"`
+}
+
+// asyncTaskEndpoint returns the endpoint to poll for an asynchronous
+// operation: the Location header when set, else tasks/{task_id}. It is empty
+// when the 202 response references no task, in which case the operation is
+// treated as complete. A Location on another origin than the API is refused,
+// as polls carry the client's credentials.
+func (c *bunkerWebClient) asyncTaskEndpoint(header http.Header, body []byte) (string, error) {
+	if location := strings.TrimSpace(header.Get("Location")); location != "" {
+		ref, err := url.Parse(location)
+		if err != nil {
+			return "", fmt.Errorf("parse asynchronous task location %q: %w", location, err)
+		}
+		if ref.Scheme != "" || ref.Host != "" {
+			resolved := c.baseURL.ResolveReference(ref)
+			if !strings.EqualFold(resolved.Scheme, c.baseURL.Scheme) || !strings.EqualFold(resolved.Host, c.baseURL.Host) {
+				return "", fmt.Errorf("asynchronous task location %q is not on the API origin %s://%s; refusing to poll it", location, c.baseURL.Scheme, c.baseURL.Host)
+			}
+			return resolved.String(), nil
+		}
+		if strings.HasPrefix(ref.Path, "/") {
+			// Root-relative locations include the API base path.
+			ref.Path = c.withoutTenant(strings.TrimPrefix(strings.TrimPrefix(ref.Path, c.baseURL.Path), "/"))
+			return ref.String(), nil
+		}
+		return location, nil
+	}
+
+	var task asyncTaskResponse
+	if err := json.Unmarshal(body, &task); err != nil || strings.TrimSpace(task.TaskID) == "" {
+		return "", nil
+	}
+	return "tasks/" + url.PathEscape(strings.TrimSpace(task.TaskID)), nil
+}
+
+// awaitAsyncTask polls endpoint until the task it describes leaves the
+// pending states, then decodes the final payload into out. Task failures are
+// returned as *bunkerWebAPIError with the original request's status code.
+func (c *bunkerWebClient) awaitAsyncTask(ctx context.Context, endpoint string, out interface{}) error {
+	tflog.Debug(ctx, "waiting for asynchronous bunkerweb operation", map[string]any{"task": endpoint})
+
+	var result []byte
+	w := c.asyncWaiter
+	w.ContinueOnError = isTransientPollError
+	polls := 0
+	err := w.waitFor(ctx, "BunkerWeb task "+endpoint, func(ctx context.Context) (bool, error) {
+		req, err := c.newRequest(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return false, err
+		}
+
+		if polls++; polls > 1 {
+			c.stats.recordRetry()
+		}
+		resp, body, err := c.sendOnce(ctx, req)
+		if err != nil {
+			return false, err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return false, c.decodeResponse(req, resp, body, nil)
+		}
+
+		var task asyncTaskResponse
+		_ = json.Unmarshal(body, &task)
+		switch strings.ToLower(strings.TrimSpace(task.Status)) {
+		case "pending", "queued", "running", "in_progress", "processing", "accepted":
+			return false, nil
+		case "error", "failed", "failure":
+			return false, &bunkerWebAPIError{
+				StatusCode: http.StatusAccepted,
+				Message:    firstNonEmpty(strings.TrimSpace(task.Message), detailToString(task.Detail), "asynchronous operation failed"),
+			}
+		}
+		result = body
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(result, out); err != nil {
+		return fmt.Errorf("decode response payload: %w", err)
+	}
+	return nil
+}
+
+// isTransientPollError retries task polls failing with a server error, which
+// the control plane returns briefly while the scheduler restarts.
+func isTransientPollError(err error) bool {
+	if errors.Is(err, errAPIUnavailable) {
+		return false
+	}
+	var apiErr *bunkerWebAPIError
+	return !errors.As(err, &apiErr) || apiErr.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,126 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBunkerWebClientAsyncOperations(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	api.AddService(bunkerWebService{ID: "app.example.com", ServerName: "app.example.com"})
+	api.SetAsyncWrites(2)
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.asyncWaiter = waiter{Delay: time.Millisecond}
+	ctx := context.Background()
+
+	service, err := client.ConvertService(ctx, "app.example.com", "draft")
+	if err != nil {
+		t.Fatalf("ConvertService: %v", err)
+	}
+	if !service.IsDraft {
+		t.Fatalf("expected the task result to be decoded, got %+v", service)
+	}
+	if got := api.AsyncTaskPolls(); got != 3 {
+		t.Fatalf("expected 3 task polls (2 pending, 1 done), got %d", got)
+	}
+
+	err = client.DeleteService(ctx, "missing.example.com")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected the task failure to be reported, got %v", err)
+	}
+
+	client.asyncWaiter = waiter{Timeout: 20 * time.Millisecond, Delay: 5 * time.Millisecond}
+	api.SetAsyncWrites(1000)
+	err = client.DeleteService(ctx, "app.example.com")
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
+
+func TestAsyncTaskEndpoint(t *testing.T) {
+	client, err := newBunkerWebClient("https://bw.example.com/api", nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		location string
+		body     string
+		want     string
+		wantErr  bool
+	}{
+		"root-relative location":     {location: "/api/tasks/7", want: "tasks/7"},
+		"relative location":          {location: "tasks/7?wait=1", want: "tasks/7?wait=1"},
+		"absolute location":          {location: "https://bw.example.com/api/tasks/7", want: "https://bw.example.com/api/tasks/7"},
+		"foreign absolute location":  {location: "https://evil.example.com/api/tasks/7", wantErr: true},
+		"foreign scheme location":    {location: "http://bw.example.com/api/tasks/7", wantErr: true},
+		"protocol-relative location": {location: "//evil.example.com/tasks/7", wantErr: true},
+		"task id":                    {body: `{"status":"accepted","task_id":"a b"}`, want: "tasks/a%20b"},
+		"no reference":               {body: `{"status":"success"}`, want: ""},
+	} {
+		header := http.Header{}
+		if tc.location != "" {
+			header.Set("Location", tc.location)
+		}
+		got, err := client.asyncTaskEndpoint(header, []byte(tc.body))
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected asyncTaskEndpoint() to refuse %q, got %q", name, tc.location, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%s: asyncTaskEndpoint() = %q, %v, want %q", name, got, err, tc.want)
+		}
+	}
+}
+
+func TestBunkerWebClientAsyncForeignLocation(t *testing.T) {
+	var foreignHits, foreignAuth atomic.Int32
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignHits.Add(1)
+		if r.Header.Get("Authorization") != "" {
+			foreignAuth.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	t.Cleanup(foreign.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", foreign.URL+"/tasks/7")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted","task_id":"7"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newBunkerWebClient(server.URL, nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.asyncWaiter = waiter{Delay: time.Millisecond}
+
+	err = client.DeleteService(context.Background(), "app.example.com")
+	if err == nil || !strings.Contains(err.Error(), "not on the API origin") {
+		t.Fatalf("expected the foreign task location to be refused, got %v", err)
+	}
+	if got := foreignHits.Load(); got != 0 {
+		t.Fatalf("expected no request to the foreign host, got %d", got)
+	}
+	if got := foreignAuth.Load(); got != 0 {
+		t.Fatalf("expected no Authorization header sent to the foreign host, got %d", got)
+	}
+}
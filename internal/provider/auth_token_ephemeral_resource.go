@@ -0,0 +1,126 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &BunkerWebAuthTokenEphemeralResource{}
+
+// BunkerWebAuthTokenEphemeralResource issues a short-lived API token.
+type BunkerWebAuthTokenEphemeralResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebAuthTokenEphemeralResourceModel captures Terraform shape.
+type BunkerWebAuthTokenEphemeralResourceModel struct {
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Token       types.String `tfsdk:"token"`
+	APIEndpoint types.String `tfsdk:"api_endpoint"`
+}
+
+func NewBunkerWebAuthTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &BunkerWebAuthTokenEphemeralResource{}
+}
+
+func (r *BunkerWebAuthTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_token"
+}
+
+func (r *BunkerWebAuthTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exchanges a username and password for a short-lived API token via `POST /auth`, for other providers or provisioners that call the BunkerWeb API directly during the same run. The token is never stored in state.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "API username. Defaults to the provider `api_username`.",
+			},
+			"password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "API password. Defaults to the provider `api_password`.",
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Bearer token issued by the API.",
+			},
+			"api_endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Base URL of the API the token was issued by.",
+			},
+		},
+	}
+}
+
+func (r *BunkerWebAuthTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebAuthTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebAuthTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defaultUsername, defaultPassword := r.state.username, r.state.password
+	username := defaultUsername
+	if !data.Username.IsNull() && !data.Username.IsUnknown() {
+		username = data.Username.ValueString()
+	}
+	password := defaultPassword
+	if !data.Password.IsNull() && !data.Password.IsUnknown() {
+		password = data.Password.ValueString()
+	}
+	if strings.TrimSpace(username) == "" || strings.TrimSpace(password) == "" {
+		resp.Diagnostics.AddError("Missing Credentials", "Set username and password, or configure api_username and api_password on the provider.")
+		return
+	}
+
+	// IssueToken leaves the provider's own authentication untouched.
+	token, err := r.client.IssueToken(ctx, username, password)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Issue API Token", err.Error())
+		return
+	}
+
+	data.Username = types.StringValue(username)
+	data.Token = types.StringValue(token)
+	data.APIEndpoint = types.StringValue(r.state.endpoint)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *BunkerWebAuthTokenEphemeralResource) Close(context.Context, ephemeral.CloseRequest, *ephemeral.CloseResponse) {
+	// Tokens expire on their own; the API has no revocation endpoint.
+}
@@ -0,0 +1,69 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBunkerWebAuthTokenEphemeralResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebAuthTokenEphemeralResourceConfig(fakeAPI.URL()),
+			},
+		},
+	})
+
+	expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:secret"))
+	if fakeAPI.LastAuthorization() != expectedAuth {
+		t.Fatalf("expected token to be issued with basic auth, got %q", fakeAPI.LastAuthorization())
+	}
+}
+
+func TestBunkerWebClientIssueTokenKeepsAuth(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "provider-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	token, err := client.IssueToken(context.Background(), "admin", "secret")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+	if token != "token-admin" {
+		t.Fatalf("unexpected token: %s", token)
+	}
+	if client.token() != "provider-token" {
+		t.Fatalf("expected provider token to be kept, got %q", client.token())
+	}
+}
+
+func testAccBunkerWebAuthTokenEphemeralResourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_auth_token" "ci" {
+  username = "admin"
+  password = "secret"
+}
+`, endpoint)
+}
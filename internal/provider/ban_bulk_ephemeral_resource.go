@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -16,17 +17,31 @@ import (
 )
 
 var _ ephemeral.EphemeralResource = &BunkerWebBanBulkEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &BunkerWebBanBulkEphemeralResource{}
 
 // BunkerWebBanBulkEphemeralResource processes batch ban/unban operations.
 type BunkerWebBanBulkEphemeralResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
+	state  *providerState
 }
 
 // BunkerWebBanBulkEphemeralResourceModel maps Terraform inputs/results.
 type BunkerWebBanBulkEphemeralResourceModel struct {
-	Bans   []BunkerWebBanBulkEntryModel `tfsdk:"bans"`
-	Unbans []BunkerWebUnbanEntryModel   `tfsdk:"unbans"`
-	Result types.String                 `tfsdk:"result"`
+	Bans      []BunkerWebBanBulkEntryModel `tfsdk:"bans"`
+	Unbans    []BunkerWebUnbanEntryModel   `tfsdk:"unbans"`
+	BansText  types.String                 `tfsdk:"bans_from_text"`
+	UnbanText types.String                 `tfsdk:"unbans_from_text"`
+	Service   types.String                 `tfsdk:"service"`
+	Reason    types.String                 `tfsdk:"reason"`
+	ExpiresIn types.Int64                  `tfsdk:"expires_in"`
+	Permanent types.Bool                   `tfsdk:"permanent"`
+	DiffOnly  types.Bool                   `tfsdk:"diff_only"`
+	Mirror    *BunkerWebBanMirrorModel     `tfsdk:"mirror_from"`
+	Mirrored  types.Int64                  `tfsdk:"mirrored"`
+	Added     types.Int64                  `tfsdk:"added"`
+	Removed   types.Int64                  `tfsdk:"removed"`
+	Unchanged types.Int64                  `tfsdk:"unchanged"`
+	Result    types.String                 `tfsdk:"result"`
 }
 
 // BunkerWebBanBulkEntryModel describes a single ban request.
@@ -35,6 +50,16 @@ type BunkerWebBanBulkEntryModel struct {
 	Service   types.String `tfsdk:"service"`
 	Reason    types.String `tfsdk:"reason"`
 	ExpiresIn types.Int64  `tfsdk:"expires_in"`
+	Permanent types.Bool   `tfsdk:"permanent"`
+}
+
+// BunkerWebBanMirrorModel describes the BunkerWeb API bans are mirrored from.
+type BunkerWebBanMirrorModel struct {
+	APIEndpoint types.String `tfsdk:"api_endpoint"`
+	APIToken    types.String `tfsdk:"api_token"`
+	APIUsername types.String `tfsdk:"api_username"`
+	APIPassword types.String `tfsdk:"api_password"`
+	Prune       types.Bool   `tfsdk:"prune"`
 }
 
 // BunkerWebUnbanEntryModel describes a single unban request.
@@ -74,7 +99,11 @@ func (r *BunkerWebBanBulkEphemeralResource) Schema(_ context.Context, _ ephemera
 						},
 						"expires_in": schema.Int64Attribute{
 							Optional:            true,
-							MarkdownDescription: "Expiration in seconds; zero makes the ban permanent.",
+							MarkdownDescription: "Expiration in seconds, at least 1. Defaults to the API's. Conflicts with `permanent`.",
+						},
+						"permanent": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Ban the address until it is unbanned. Conflicts with `expires_in`.",
 						},
 					},
 				},
@@ -95,6 +124,79 @@ func (r *BunkerWebBanBulkEphemeralResource) Schema(_ context.Context, _ ephemera
 					},
 				},
 			},
+			"bans_from_text": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Newline-delimited IP addresses to ban, as found in plain-text threat feeds. Empty lines and `#` comments are ignored. Combined with `bans`.",
+			},
+			"unbans_from_text": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Newline-delimited IP addresses to unban, in the same format as `bans_from_text`. Combined with `unbans`.",
+			},
+			"service": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Service scoping the addresses read from `bans_from_text` and `unbans_from_text`.",
+			},
+			"reason": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Reason recorded with the bans read from `bans_from_text`.",
+			},
+			"expires_in": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Expiration in seconds of the bans read from `bans_from_text`, at least 1. Conflicts with `permanent`.",
+			},
+			"permanent": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Make the bans read from `bans_from_text` permanent. Conflicts with `expires_in`.",
+			},
+			"diff_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When true, the current bans are fetched first and only the delta is sent: bans already in place and unbans of addresses that are not banned are skipped. Recommended for large threat feeds.",
+			},
+			"mirror_from": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Reads the active bans of another BunkerWeb API, for example the one of another region, and applies them here with their " +
+					"remaining duration, keeping regions in sync. Bans already in place are skipped as with `diff_only`. Combined with `bans` and `unbans`.",
+				Attributes: map[string]schema.Attribute{
+					"api_endpoint": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Base URL of the API to read bans from, such as the `api_endpoint` of another provider alias.",
+					},
+					"api_token": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						MarkdownDescription: "Bearer token for the source API. Conflicts with `api_username` and `api_password`.",
+					},
+					"api_username": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Basic authentication username for the source API. Requires `api_password`.",
+					},
+					"api_password": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						MarkdownDescription: "Basic authentication password for the source API. Requires `api_username`.",
+					},
+					"prune": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "When true, bans of this API that the source does not have are removed, unless listed in `bans`. Defaults to `false`.",
+					},
+				},
+			},
+			"mirrored": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of bans read from `mirror_from` (zero when it is not set).",
+			},
+			"added": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of bans sent to the API.",
+			},
+			"removed": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of unbans sent to the API.",
+			},
+			"unchanged": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of requested bans and unbans skipped because they were already applied (always zero unless `diff_only` is set).",
+			},
 			"result": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "JSON encoded summary of performed operations.",
@@ -103,21 +205,71 @@ func (r *BunkerWebBanBulkEphemeralResource) Schema(_ context.Context, _ ephemera
 	}
 }
 
+// ValidateConfig summarises the bans and unbans to send, once every value is
+// known.
+func (r *BunkerWebBanBulkEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data BunkerWebBanBulkEphemeralResourceModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(data.Mirror.validate()...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	banReqs, banDiags := data.toBanRequests()
+	unbanReqs, unbanDiags := data.toUnbanRequests()
+	if banDiags.HasError() || unbanDiags.HasError() {
+		// Open reports these.
+		return
+	}
+
+	var lines []string
+	if len(banReqs) > 0 {
+		ips := make([]string, 0, len(banReqs))
+		for _, ban := range banReqs {
+			ips = append(ips, ban.IP)
+		}
+		lines = append(lines, "ban "+summarizeTargets(len(banReqs), "address", ips))
+	}
+	if len(unbanReqs) > 0 {
+		ips := make([]string, 0, len(unbanReqs))
+		for _, unban := range unbanReqs {
+			ips = append(ips, unban.IP)
+		}
+		lines = append(lines, "unban "+summarizeTargets(len(unbanReqs), "address", ips))
+	}
+	if data.Mirror != nil {
+		lines = append(lines, "ban the addresses banned by "+data.Mirror.APIEndpoint.ValueString()+" (mirror_from)")
+		if data.Mirror.Prune.ValueBool() {
+			lines = append(lines, "unban the addresses "+data.Mirror.APIEndpoint.ValueString()+" does not ban (prune)")
+		}
+	}
+	if len(lines) > 0 && data.DiffOnly.ValueBool() {
+		lines = append(lines, "skip the bans already in place and the unbans of addresses that are not banned (diff_only)")
+	}
+	addPlanSummary(&resp.Diagnostics, "bunkerweb_ban_bulk", lines)
+}
+
 func (r *BunkerWebBanBulkEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Ephemeral Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.state = data.state
 }
 
 func (r *BunkerWebBanBulkEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
@@ -144,9 +296,44 @@ func (r *BunkerWebBanBulkEphemeralResource) Open(ctx context.Context, req epheme
 		return
 	}
 
+	mirrored := 0
+	if data.Mirror != nil {
+		resp.Diagnostics.Append(data.Mirror.validate()...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		source, err := r.state.mirrorClient(data.Mirror)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("mirror_from").AtName("api_endpoint"), "Invalid Mirror Endpoint", err.Error())
+			return
+		}
+		sourceBans, err := source.ListBans(ctx, BanListOptions{})
+		if err != nil {
+			resp.Diagnostics.AddError("List Mirrored Bans", fmt.Sprintf("Unable to read the bans of %s: %s", data.Mirror.APIEndpoint.ValueString(), err))
+			return
+		}
+		mirrored = len(sourceBans)
+		banReqs = append(banReqs, mirroredBanRequests(sourceBans)...)
+	}
+
+	unchanged := 0
+	if data.DiffOnly.ValueBool() || data.Mirror != nil {
+		current, err := r.client.ListBans(ctx, BanListOptions{})
+		if err != nil {
+			resp.Diagnostics.AddError("List Bans", err.Error())
+			return
+		}
+		if data.Mirror != nil && data.Mirror.Prune.ValueBool() {
+			unbanReqs = append(unbanReqs, pruneBanRequests(current, banReqs, unbanReqs)...)
+		}
+		banReqs, unbanReqs, unchanged = diffBanRequests(current, banReqs, unbanReqs)
+	}
+
 	summary := map[string]any{
-		"bans":   len(banReqs),
-		"unbans": len(unbanReqs),
+		"mirrored":  mirrored,
+		"bans":      len(banReqs),
+		"unbans":    len(unbanReqs),
+		"unchanged": unchanged,
 	}
 
 	if len(banReqs) > 0 {
@@ -169,6 +356,10 @@ func (r *BunkerWebBanBulkEphemeralResource) Open(ctx context.Context, req epheme
 		return
 	}
 
+	data.Mirrored = types.Int64Value(int64(mirrored))
+	data.Added = types.Int64Value(int64(len(banReqs)))
+	data.Removed = types.Int64Value(int64(len(unbanReqs)))
+	data.Unchanged = types.Int64Value(int64(unchanged))
 	data.Result = types.StringValue(encoded)
 	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
 }
@@ -178,13 +369,18 @@ func (r *BunkerWebBanBulkEphemeralResource) Close(context.Context, ephemeral.Clo
 }
 
 func (m *BunkerWebBanBulkEphemeralResourceModel) toBanRequests() ([]BanRequest, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
-	if len(m.Bans) == 0 {
+	ips, diags := parseIPList(path.Root("bans_from_text"), m.BansText)
+	exp, expDiags := banExpiration(path.Root("expires_in"), m.ExpiresIn, path.Root("permanent"), m.Permanent)
+	diags.Append(expDiags...)
+	if len(m.Bans) == 0 && len(ips) == 0 {
 		return nil, diags
 	}
 
-	reqs := make([]BanRequest, 0, len(m.Bans))
+	reqs := make([]BanRequest, 0, len(m.Bans)+len(ips))
+	for _, ip := range ips {
+		reqs = append(reqs, BanRequest{IP: ip, Service: nonEmptyString(m.Service), Reason: nonEmptyString(m.Reason), Exp: exp})
+	}
+
 	for idx, entry := range m.Bans {
 		if entry.IP.IsNull() || entry.IP.IsUnknown() || strings.TrimSpace(entry.IP.ValueString()) == "" {
 			diags.AddAttributeError(path.Root("bans").AtListIndex(idx).AtName("ip"), "Missing IP", "Each ban entry requires a non-empty IP address.")
@@ -204,10 +400,10 @@ func (m *BunkerWebBanBulkEphemeralResourceModel) toBanRequests() ([]BanRequest,
 				req.Reason = &reason
 			}
 		}
-		if !entry.ExpiresIn.IsNull() && !entry.ExpiresIn.IsUnknown() {
-			exp := int(entry.ExpiresIn.ValueInt64())
-			req.Exp = &exp
-		}
+		entryPath := path.Root("bans").AtListIndex(idx)
+		exp, expDiags := banExpiration(entryPath.AtName("expires_in"), entry.ExpiresIn, entryPath.AtName("permanent"), entry.Permanent)
+		diags.Append(expDiags...)
+		req.Exp = exp
 		reqs = append(reqs, req)
 	}
 
@@ -215,13 +411,16 @@ func (m *BunkerWebBanBulkEphemeralResourceModel) toBanRequests() ([]BanRequest,
 }
 
 func (m *BunkerWebBanBulkEphemeralResourceModel) toUnbanRequests() ([]UnbanRequest, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
-	if len(m.Unbans) == 0 {
+	ips, diags := parseIPList(path.Root("unbans_from_text"), m.UnbanText)
+	if len(m.Unbans) == 0 && len(ips) == 0 {
 		return nil, diags
 	}
 
-	reqs := make([]UnbanRequest, 0, len(m.Unbans))
+	reqs := make([]UnbanRequest, 0, len(m.Unbans)+len(ips))
+	for _, ip := range ips {
+		reqs = append(reqs, UnbanRequest{IP: ip, Service: nonEmptyString(m.Service)})
+	}
+
 	for idx, entry := range m.Unbans {
 		if entry.IP.IsNull() || entry.IP.IsUnknown() || strings.TrimSpace(entry.IP.ValueString()) == "" {
 			diags.AddAttributeError(path.Root("unbans").AtListIndex(idx).AtName("ip"), "Missing IP", "Each unban entry requires a non-empty IP address.")
@@ -240,3 +439,167 @@ func (m *BunkerWebBanBulkEphemeralResourceModel) toUnbanRequests() ([]UnbanReque
 
 	return reqs, diags
 }
+
+// parseIPList reads newline-delimited IP addresses, ignoring blank lines and
+// `#` comments (whole-line or trailing). Duplicates are dropped.
+func parseIPList(attr path.Path, text types.String) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if text.IsNull() || text.IsUnknown() {
+		return nil, diags
+	}
+
+	lines := strings.Split(text.ValueString(), "\n")
+	seen := make(map[string]struct{}, len(lines))
+	ips := make([]string, 0, len(lines))
+	for idx, line := range lines {
+		if hash := strings.IndexByte(line, '#'); hash >= 0 {
+			line = line[:hash]
+		}
+		ip := strings.TrimSpace(line)
+		if ip == "" {
+			continue
+		}
+		if net.ParseIP(ip) == nil {
+			diags.AddAttributeError(attr, "Invalid IP Address", fmt.Sprintf("Line %d: %q is not an IP address.", idx+1, ip))
+			continue
+		}
+		if _, ok := seen[ip]; ok {
+			continue
+		}
+		seen[ip] = struct{}{}
+		ips = append(ips, ip)
+	}
+
+	return ips, diags
+}
+
+// validate checks the credentials of the source API, if any.
+func (m *BunkerWebBanMirrorModel) validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+	if m == nil {
+		return diags
+	}
+
+	attr := path.Root("mirror_from")
+	hasToken := m.APIToken.ValueString() != ""
+	hasUsername := m.APIUsername.ValueString() != ""
+	hasPassword := m.APIPassword.ValueString() != ""
+	switch {
+	case strings.TrimSpace(m.APIEndpoint.ValueString()) == "":
+		diags.AddAttributeError(attr.AtName("api_endpoint"), "Missing Mirror Endpoint", "`api_endpoint` cannot be empty.")
+	case hasToken && (hasUsername || hasPassword):
+		diags.AddAttributeError(attr, "Conflicting Authentication Methods", "Use either `api_token` or `api_username` and `api_password` for the source API, not both.")
+	case hasUsername != hasPassword:
+		diags.AddAttributeError(attr, "Incomplete Basic Authentication", "Basic authentication requires both `api_username` and `api_password`.")
+	case !hasToken && !hasUsername:
+		diags.AddAttributeError(attr, "Missing Authentication Credentials", "Either `api_token` or both `api_username` and `api_password` must be provided for the source API.")
+	}
+	return diags
+}
+
+// mirrorClient returns a client for the source API of m. It shares the HTTP
+// transport, retry policy and tracer of the provider, but none of its tenant,
+// header or signing settings, which belong to the provider's endpoint.
+func (s *providerState) mirrorClient(m *BunkerWebBanMirrorModel) (BunkerWebAPI, error) {
+	source, err := newBunkerWebClient(strings.TrimSpace(m.APIEndpoint.ValueString()), s.httpClient, m.APIToken.ValueString(), m.APIUsername.ValueString(), m.APIPassword.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	source.retries = s.retries
+	source.tracer = s.tracer
+	return source, nil
+}
+
+// mirroredBanRequests turns the bans of another API into requests applying
+// them with their remaining duration; permanent bans stay permanent.
+func mirroredBanRequests(bans []bunkerWebBan) []BanRequest {
+	reqs := make([]BanRequest, 0, len(bans))
+	for _, ban := range bans {
+		exp := max(ban.Exp, 0)
+		req := BanRequest{IP: strings.TrimSpace(ban.IP), Exp: &exp}
+		if ban.Reason != "" {
+			reason := ban.Reason
+			req.Reason = &reason
+		}
+		if ban.Service != nil && strings.TrimSpace(*ban.Service) != "" && !strings.EqualFold(strings.TrimSpace(*ban.Service), "global") {
+			service := strings.TrimSpace(*ban.Service)
+			req.Service = &service
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+// pruneBanRequests returns unbans for the current bans that neither bans nor
+// unbans mention.
+func pruneBanRequests(current []bunkerWebBan, bans []BanRequest, unbans []UnbanRequest) []UnbanRequest {
+	wanted := make(map[string]struct{}, len(bans)+len(unbans))
+	for _, req := range bans {
+		wanted[banKey(req.IP, req.Service)] = struct{}{}
+	}
+	for _, req := range unbans {
+		wanted[banKey(req.IP, req.Service)] = struct{}{}
+	}
+
+	var reqs []UnbanRequest
+	for _, ban := range current {
+		if _, ok := wanted[banKey(ban.IP, ban.Service)]; ok {
+			continue
+		}
+		reqs = append(reqs, UnbanRequest{IP: ban.IP, Service: ban.Service})
+	}
+	return reqs
+}
+
+// nonEmptyString returns a pointer to the trimmed value, or nil when unset or blank.
+func nonEmptyString(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	trimmed := strings.TrimSpace(value.ValueString())
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// diffBanRequests drops bans that are already active and unbans of addresses
+// that are not banned, returning the remaining requests and how many were
+// dropped.
+func diffBanRequests(current []bunkerWebBan, bans []BanRequest, unbans []UnbanRequest) ([]BanRequest, []UnbanRequest, int) {
+	active := make(map[string]struct{}, len(current))
+	for _, ban := range current {
+		active[banKey(ban.IP, ban.Service)] = struct{}{}
+	}
+
+	unchanged := 0
+	toBan := make([]BanRequest, 0, len(bans))
+	for _, req := range bans {
+		if _, ok := active[banKey(req.IP, req.Service)]; ok {
+			unchanged++
+			continue
+		}
+		toBan = append(toBan, req)
+	}
+
+	toUnban := make([]UnbanRequest, 0, len(unbans))
+	for _, req := range unbans {
+		if _, ok := active[banKey(req.IP, req.Service)]; !ok {
+			unchanged++
+			continue
+		}
+		toUnban = append(toUnban, req)
+	}
+
+	return toBan, toUnban, unchanged
+}
+
+// banKey identifies a ban by address and scope; global bans have no service.
+func banKey(ip string, service *string) string {
+	scope := ""
+	if service != nil && !strings.EqualFold(strings.TrimSpace(*service), "global") {
+		scope = strings.TrimSpace(*service)
+	}
+	return strings.TrimSpace(ip) + "|" + scope
+}
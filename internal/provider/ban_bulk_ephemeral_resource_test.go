@@ -4,9 +4,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
@@ -34,6 +38,9 @@ func TestAccBunkerWebBanBulkEphemeralResource(t *testing.T) {
 	if len(created[0]) != 2 {
 		t.Fatalf("expected two bans in first batch, got %d", len(created[0]))
 	}
+	if exp := created[0][0].Exp; exp == nil || *exp != 600 {
+		t.Fatalf("expected the first ban to expire in 600 seconds, got %v", exp)
+	}
 
 	deleted := fakeAPI.DeletedBanBatches()
 	if len(deleted) == 0 {
@@ -44,6 +51,276 @@ func TestAccBunkerWebBanBulkEphemeralResource(t *testing.T) {
 	}
 }
 
+func TestBanBulkPermanent(t *testing.T) {
+	model := BunkerWebBanBulkEphemeralResourceModel{
+		BansText:  types.StringValue("203.0.113.10\n"),
+		Permanent: types.BoolValue(true),
+		Bans: []BunkerWebBanBulkEntryModel{
+			{IP: types.StringValue("203.0.113.11"), ExpiresIn: types.Int64Value(0)},
+			{IP: types.StringValue("203.0.113.12"), ExpiresIn: types.Int64Value(60), Permanent: types.BoolValue(true)},
+		},
+	}
+	reqs, diags := model.toBanRequests()
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected the zero and conflicting expirations to be rejected, got %v", diags)
+	}
+	if len(reqs) != 3 || reqs[0].Exp == nil || *reqs[0].Exp != 0 {
+		t.Fatalf("expected bans_from_text to be sent as permanent, got %#v", reqs)
+	}
+}
+
+func TestParseIPList(t *testing.T) {
+	ips, diags := parseIPList(path.Root("bans_from_text"), types.StringValue(`# feed generated 2026-10-15
+203.0.113.10
+  203.0.113.11   # scanner
+
+2001:db8::1
+203.0.113.10
+`))
+	if diags.HasError() {
+		t.Fatalf("parseIPList: %v", diags)
+	}
+	want := []string{"203.0.113.10", "203.0.113.11", "2001:db8::1"}
+	if !slices.Equal(ips, want) {
+		t.Fatalf("expected %v, got %v", want, ips)
+	}
+
+	_, diags = parseIPList(path.Root("bans_from_text"), types.StringValue("203.0.113.10\nnot-an-ip\n"))
+	if !diags.HasError() {
+		t.Fatalf("expected an error for an invalid line")
+	}
+}
+
+func TestDiffBanRequests(t *testing.T) {
+	frontend := "frontend"
+	global := "global"
+	current := []bunkerWebBan{
+		{IP: "203.0.113.10"},
+		{IP: "203.0.113.11", Service: &frontend},
+		{IP: "203.0.113.13"},
+	}
+
+	bans, unbans, unchanged := diffBanRequests(current,
+		[]BanRequest{{IP: "203.0.113.10", Service: &global}, {IP: "203.0.113.11"}, {IP: "203.0.113.12"}},
+		[]UnbanRequest{{IP: "203.0.113.13"}, {IP: "203.0.113.14"}},
+	)
+
+	if len(bans) != 2 || bans[0].IP != "203.0.113.11" || bans[1].IP != "203.0.113.12" {
+		t.Fatalf("unexpected bans %#v", bans)
+	}
+	if len(unbans) != 1 || unbans[0].IP != "203.0.113.13" {
+		t.Fatalf("unexpected unbans %#v", unbans)
+	}
+	if unchanged != 2 {
+		t.Fatalf("expected two unchanged entries, got %d", unchanged)
+	}
+}
+
+func TestAccBunkerWebBanBulkEphemeralResourceDiffOnly(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddBan(bunkerWebBan{IP: "203.0.113.10", Reason: "automation"})
+	fakeAPI.AddBan(bunkerWebBan{IP: "203.0.113.13", Reason: "automation"})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebBanBulkEphemeralResourceDiffOnlyConfig(fakeAPI.URL()),
+			},
+		},
+	})
+
+	created := fakeAPI.CreatedBanBatches()
+	if len(created) == 0 || len(created[0]) != 1 || created[0][0].IP != "203.0.113.12" {
+		t.Fatalf("expected only the new ban to be sent, got %#v", created)
+	}
+
+	deleted := fakeAPI.DeletedBanBatches()
+	if len(deleted) == 0 || len(deleted[0]) != 1 || deleted[0][0].IP != "203.0.113.13" {
+		t.Fatalf("expected only the active ban to be lifted, got %#v", deleted)
+	}
+}
+
+func TestBanBulkMirror(t *testing.T) {
+	source := newFakeBunkerWebAPI(t)
+	frontend := "frontend"
+	source.AddBan(bunkerWebBan{IP: "198.51.100.1", Reason: "scanner", Exp: 600})
+	source.AddBan(bunkerWebBan{IP: "198.51.100.2", Service: &frontend})
+
+	client, err := newBunkerWebClient("http://127.0.0.1:1", nil, "other-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	mirror := &BunkerWebBanMirrorModel{APIEndpoint: types.StringValue(source.URL()), APIToken: types.StringValue("test-token")}
+	if diags := mirror.validate(); diags.HasError() {
+		t.Fatalf("validate: %v", diags)
+	}
+	sourceClient, err := newProviderState(client).mirrorClient(mirror)
+	if err != nil {
+		t.Fatalf("mirrorClient: %v", err)
+	}
+	bans, err := sourceClient.ListBans(context.Background(), BanListOptions{})
+	if err != nil {
+		t.Fatalf("ListBans: %v", err)
+	}
+
+	reqs := mirroredBanRequests(bans)
+	if len(reqs) != 2 {
+		t.Fatalf("expected both source bans, got %#v", reqs)
+	}
+	for _, req := range reqs {
+		switch req.IP {
+		case "198.51.100.1":
+			if req.Exp == nil || *req.Exp != 600 || req.Reason == nil || *req.Reason != "scanner" || req.Service != nil {
+				t.Errorf("unexpected mirrored ban %#v", req)
+			}
+		case "198.51.100.2":
+			if req.Exp == nil || *req.Exp != 0 || req.Service == nil || *req.Service != "frontend" {
+				t.Errorf("expected a permanent frontend ban, got %#v", req)
+			}
+		}
+	}
+
+	current := []bunkerWebBan{{IP: "198.51.100.1"}, {IP: "203.0.113.50"}, {IP: "203.0.113.51"}}
+	pruned := pruneBanRequests(current, append(reqs, BanRequest{IP: "203.0.113.51"}), nil)
+	if len(pruned) != 1 || pruned[0].IP != "203.0.113.50" {
+		t.Fatalf("expected only the ban missing from the source to be pruned, got %#v", pruned)
+	}
+
+	for _, invalid := range []*BunkerWebBanMirrorModel{
+		{APIEndpoint: types.StringValue(source.URL())},
+		{APIEndpoint: types.StringValue(source.URL()), APIToken: types.StringValue("t"), APIUsername: types.StringValue("u"), APIPassword: types.StringValue("p")},
+		{APIEndpoint: types.StringValue(source.URL()), APIUsername: types.StringValue("u")},
+	} {
+		if diags := invalid.validate(); !diags.HasError() {
+			t.Errorf("expected %#v to be rejected", invalid)
+		}
+	}
+}
+
+func TestAccBunkerWebBanBulkEphemeralResourceMirror(t *testing.T) {
+	source := newFakeBunkerWebAPI(t)
+	source.AddBan(bunkerWebBan{IP: "198.51.100.1", Reason: "scanner", Exp: 600})
+	source.AddBan(bunkerWebBan{IP: "198.51.100.2", Reason: "scanner", Exp: 600})
+
+	target := newFakeBunkerWebAPI(t)
+	target.AddBan(bunkerWebBan{IP: "198.51.100.1", Reason: "scanner"})
+	target.AddBan(bunkerWebBan{IP: "203.0.113.50", Reason: "stale"})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebBanBulkEphemeralResourceMirrorConfig(target.URL(), source.URL()),
+			},
+		},
+	})
+
+	created := target.CreatedBanBatches()
+	if len(created) == 0 || len(created[0]) != 1 || created[0][0].IP != "198.51.100.2" {
+		t.Fatalf("expected only the missing source ban to be sent, got %#v", created)
+	}
+	deleted := target.DeletedBanBatches()
+	if len(deleted) == 0 || len(deleted[0]) != 1 || deleted[0][0].IP != "203.0.113.50" {
+		t.Fatalf("expected the ban missing from the source to be pruned, got %#v", deleted)
+	}
+	if len(source.CreatedBanBatches()) != 0 || len(source.DeletedBanBatches()) != 0 {
+		t.Fatal("expected the source API to be left untouched")
+	}
+}
+
+func testAccBunkerWebBanBulkEphemeralResourceMirrorConfig(endpoint, sourceEndpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_ban_bulk" "mirror" {
+  mirror_from = {
+    api_endpoint = "%s"
+    api_token    = "test-token"
+    prune        = true
+  }
+}
+`, endpoint, sourceEndpoint)
+}
+
+func TestAccBunkerWebBanBulkEphemeralResourceFromText(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebBanBulkEphemeralResourceFromTextConfig(fakeAPI.URL()),
+			},
+		},
+	})
+
+	created := fakeAPI.CreatedBanBatches()
+	if len(created) == 0 || len(created[0]) != 2 {
+		t.Fatalf("expected the two feed entries to be banned, got %#v", created)
+	}
+	if reason := created[0][0].Reason; reason == nil || *reason != "threat-feed" {
+		t.Fatalf("expected the shared reason to be applied, got %#v", created[0][0])
+	}
+}
+
+func testAccBunkerWebBanBulkEphemeralResourceFromTextConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_ban_bulk" "feed" {
+  reason         = "threat-feed"
+  expires_in     = 3600
+  bans_from_text = <<-EOT
+    # drop list
+    203.0.113.20
+    203.0.113.21 # scanner
+  EOT
+}
+`, endpoint)
+}
+
+func testAccBunkerWebBanBulkEphemeralResourceDiffOnlyConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_ban_bulk" "feed" {
+  diff_only = true
+
+  bans = [
+    { ip = "203.0.113.10" },
+    { ip = "203.0.113.12" },
+  ]
+
+  unbans = [
+    { ip = "203.0.113.13" },
+    { ip = "203.0.113.14" },
+  ]
+}
+`, endpoint)
+}
+
 func testAccBunkerWebBanBulkEphemeralResourceConfig(endpoint string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -59,8 +336,9 @@ ephemeral "bunkerweb_ban_bulk" "batch" {
       expires_in = 600
     },
     {
-      ip      = "203.0.113.11"
-      service = "frontend"
+      ip        = "203.0.113.11"
+      service   = "frontend"
+      permanent = true
     }
   ]
 
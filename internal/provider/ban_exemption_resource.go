@@ -0,0 +1,324 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Settings of the BunkerWeb whitelist plugin.
+const (
+	whitelistSetting   = "USE_WHITELIST"
+	whitelistIPSetting = "WHITELIST_IP"
+)
+
+// whitelistMu serialises the read-modify-write cycles on WHITELIST_IP so
+// exemptions applied in parallel do not overwrite each other.
+var whitelistMu sync.Mutex
+
+var _ resource.Resource = &BunkerWebBanExemptionResource{}
+var _ resource.ResourceWithImportState = &BunkerWebBanExemptionResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebBanExemptionResource{}
+
+// BunkerWebBanExemptionResource keeps an address out of automated bans by
+// adding it to the BunkerWeb whitelist.
+type BunkerWebBanExemptionResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebBanExemptionResourceModel is the Terraform state.
+type BunkerWebBanExemptionResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	CIDR    types.String `tfsdk:"cidr"`
+	Service types.String `tfsdk:"service"`
+	Retries types.Object `tfsdk:"retries"`
+}
+
+func NewBunkerWebBanExemptionResource() resource.Resource {
+	return &BunkerWebBanExemptionResource{}
+}
+
+func (r *BunkerWebBanExemptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ban_exemption"
+}
+
+func (r *BunkerWebBanExemptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Guarantees an IP address or network is never banned by adding it to the BunkerWeb whitelist " +
+			"(`WHITELIST_IP`), globally or for a single service. Other whitelist entries are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Internal identifier composed of cidr/service.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "IPv4/IPv6 address or network in CIDR notation (for example `203.0.113.0/24`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Service whose whitelist receives the entry. Defaults to `global`.",
+				Default:             stringdefault.StaticString("global"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebBanExemptionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebBanExemptionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebBanExemptionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.CIDR.IsNull() || config.CIDR.IsUnknown() {
+		return
+	}
+
+	if !validIPOrCIDR(config.CIDR.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid Address", fmt.Sprintf("%q is not an IP address or CIDR network.", config.CIDR.ValueString()))
+	}
+}
+
+func (r *BunkerWebBanExemptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebBanExemptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cidr := strings.TrimSpace(plan.CIDR.ValueString())
+	service := normalizeTFService(plan.Service)
+
+	err := r.modifyWhitelist(ctx, service, func(entries []string) []string {
+		if slices.Contains(entries, cidr) {
+			return entries
+		}
+		return append(entries, cidr)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create Ban Exemption", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(buildBanID(cidr, service))
+	plan.Service = types.StringValue(service)
+
+	tflog.Info(ctx, "created bunkerweb ban exemption", map[string]any{"id": plan.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebBanExemptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebBanExemptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := normalizeTFService(state.Service)
+	enabled, entries, err := r.readWhitelist(ctx, service)
+	if err != nil {
+		var apiErr *bunkerWebAPIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read Ban Exemption", err.Error())
+		return
+	}
+
+	if !enabled || !slices.Contains(entries, strings.TrimSpace(state.CIDR.ValueString())) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only records a new retries attribute: every other attribute requires
+// replacement.
+func (r *BunkerWebBanExemptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state BunkerWebBanExemptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Retries = plan.Retries
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BunkerWebBanExemptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebBanExemptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cidr := strings.TrimSpace(state.CIDR.ValueString())
+	err := r.modifyWhitelist(ctx, normalizeTFService(state.Service), func(entries []string) []string {
+		return slices.DeleteFunc(entries, func(entry string) bool { return entry == cidr })
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Delete Ban Exemption", err.Error())
+	}
+}
+
+func (r *BunkerWebBanExemptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// CIDR networks contain a slash themselves, so the service is split off
+	// the end: "10.0.0.0/8" (global) or "10.0.0.0/8/app.example.com".
+	id := strings.TrimSpace(req.ID)
+	cidr, service := id, "global"
+	if idx := strings.LastIndex(id, "/"); idx > 0 && !validIPOrCIDR(id) {
+		cidr, service = id[:idx], id[idx+1:]
+	}
+
+	if !validIPOrCIDR(cidr) || service == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected cidr or cidr/service, got %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &BunkerWebBanExemptionResourceModel{
+		ID:      types.StringValue(buildBanID(cidr, service)),
+		CIDR:    types.StringValue(cidr),
+		Service: types.StringValue(service),
+		Retries: types.ObjectNull(retriesAttrTypes),
+	})...)
+}
+
+// readWhitelist returns whether the whitelist is enabled and its entries for
+// the given service ("global" for the global configuration).
+func (r *BunkerWebBanExemptionResource) readWhitelist(ctx context.Context, service string) (bool, []string, error) {
+	if service == "global" {
+		settings, err := r.client.GetGlobalConfig(ctx, true, false)
+		if err != nil {
+			return false, nil, err
+		}
+		return isAffirmative(stringifyValue(settings[whitelistSetting])), strings.Fields(stringifyValue(settings[whitelistIPSetting])), nil
+	}
+
+	got, err := r.client.GetService(ctx, service)
+	if err != nil {
+		return false, nil, err
+	}
+	enabled, _ := lookupServiceSetting(got.Config, got.Service, whitelistSetting)
+	entries, _ := lookupServiceSetting(got.Config, got.Service, whitelistIPSetting)
+
+	return isAffirmative(enabled), strings.Fields(entries), nil
+}
+
+// modifyWhitelist applies update to the current whitelist entries and writes
+// the result back, enabling the whitelist on the way.
+func (r *BunkerWebBanExemptionResource) modifyWhitelist(ctx context.Context, service string, update func([]string) []string) error {
+	whitelistMu.Lock()
+	defer whitelistMu.Unlock()
+
+	_, entries, err := r.readWhitelist(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	value := strings.Join(update(entries), " ")
+
+	if service == "global" {
+		_, err = r.client.UpdateGlobalConfig(ctx, map[string]any{
+			whitelistSetting:   "yes",
+			whitelistIPSetting: value,
+		})
+		return err
+	}
+
+	return patchServiceVariables(ctx, r.client, service, map[string]string{
+		whitelistSetting:   "yes",
+		whitelistIPSetting: value,
+	})
+}
+
+// validIPOrCIDR reports whether value is a single IP address or a CIDR network.
+func validIPOrCIDR(value string) bool {
+	value = strings.TrimSpace(value)
+	if net.ParseIP(value) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
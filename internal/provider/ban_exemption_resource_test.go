@@ -0,0 +1,95 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestValidIPOrCIDR(t *testing.T) {
+	for value, want := range map[string]bool{
+		"192.0.2.10":       true,
+		"203.0.113.0/24":   true,
+		"2001:db8::/32":    true,
+		"203.0.113.0/24/x": false,
+		"not-an-ip":        false,
+	} {
+		if got := validIPOrCIDR(value); got != want {
+			t.Fatalf("validIPOrCIDR(%q) = %t, want %t", value, got, want)
+		}
+	}
+}
+
+func TestBanExemptionKeepsOtherServiceVariables(t *testing.T) {
+	// Without a detected release supporting partial patches, the fake
+	// replaces the service's variables with the payload.
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddService(bunkerWebService{ID: "app.example.com", ServerName: "app.example.com", Variables: map[string]string{"USE_GZIP": "yes"}})
+	exemption := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_ban_exemption")
+
+	if errs := exemption.apply(map[string]tftypes.Value{
+		"service": tftypes.NewValue(tftypes.String, "app.example.com"),
+		"cidr":    tftypes.NewValue(tftypes.String, "192.0.2.0/24"),
+	}); len(errs) > 0 {
+		t.Fatalf("apply: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	got := fakeAPI.ServiceVariables("app.example.com")
+	if got["USE_GZIP"] != "yes" || got[whitelistIPSetting] != "192.0.2.0/24" {
+		t.Fatalf("expected the whitelist entry alongside the existing variables, got %v", got)
+	}
+}
+
+func TestAccBunkerWebBanExemptionResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebBanExemptionResourceConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_ban_exemption.office", "id", "203.0.113.0/24/global"),
+					resource.TestCheckResourceAttr("bunkerweb_ban_exemption.office", "service", "global"),
+					resource.TestCheckResourceAttr("bunkerweb_ban_exemption.vpn", "id", "198.51.100.7/global"),
+					func(_ *terraform.State) error {
+						patch := fakeAPI.LastGlobalPatch()
+						if patch[whitelistSetting] != "yes" {
+							return fmt.Errorf("expected whitelist to be enabled, got %v", patch)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "bunkerweb_ban_exemption.office",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccBunkerWebBanExemptionResourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_ban_exemption" "office" {
+  cidr = "203.0.113.0/24"
+}
+
+resource "bunkerweb_ban_exemption" "vpn" {
+  cidr = "198.51.100.7"
+}
+`, endpoint)
+}
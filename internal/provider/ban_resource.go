@@ -9,9 +9,12 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -21,10 +24,25 @@ import (
 
 var _ resource.Resource = &BunkerWebBanResource{}
 var _ resource.ResourceWithImportState = &BunkerWebBanResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebBanResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebBanResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebBanResource{}
+var _ resource.ResourceWithUpgradeState = &BunkerWebBanResource{}
+
+// banStateMigrations upgrade stored bunkerweb_ban state; the schema version
+// is their count.
+var banStateMigrations = []stateMigration{
+	0: canonicalBanID,
+}
+
+// defaultBanExpiration is the expiration_seconds of a bunkerweb_ban that sets
+// neither it nor permanent.
+const defaultBanExpiration = 86400
 
 // BunkerWebBanResource models the ban lifecycle via the API.
 type BunkerWebBanResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
+	state  *providerState
 }
 
 // BunkerWebBanResourceModel carries Terraform state.
@@ -34,6 +52,16 @@ type BunkerWebBanResourceModel struct {
 	Service           types.String `tfsdk:"service"`
 	Reason            types.String `tfsdk:"reason"`
 	ExpirationSeconds types.Int64  `tfsdk:"expiration_seconds"`
+	Permanent         types.Bool   `tfsdk:"permanent"`
+	Country           types.String `tfsdk:"country"`
+	ASN               types.Int64  `tfsdk:"asn"`
+	Retries           types.Object `tfsdk:"retries"`
+}
+
+// banIdentityModel is the resource identity of bunkerweb_ban.
+type banIdentityModel struct {
+	IP      types.String `tfsdk:"ip"`
+	Service types.String `tfsdk:"service"`
 }
 
 func NewBunkerWebBanResource() resource.Resource {
@@ -46,6 +74,7 @@ func (r *BunkerWebBanResource) Metadata(_ context.Context, req resource.Metadata
 
 func (r *BunkerWebBanResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             int64(len(banStateMigrations)),
 		MarkdownDescription: "Manages a BunkerWeb ban across instances.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -65,7 +94,7 @@ func (r *BunkerWebBanResource) Schema(_ context.Context, _ resource.SchemaReques
 			"service": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Optional service identifier for service-specific bans.",
+				MarkdownDescription: "Optional service identifier for service-specific bans. Plans and applies warn when no such service exists, since the API accepts the ban but it never matches traffic.",
 				Default:             stringdefault.StaticString(""),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -80,8 +109,40 @@ func (r *BunkerWebBanResource) Schema(_ context.Context, _ resource.SchemaReques
 			"expiration_seconds": schema.Int64Attribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Ban expiration in seconds. Zero makes the ban permanent.",
-				Default:             int64default.StaticInt64(86400),
+				MarkdownDescription: "Ban expiration in seconds, at least 1. Defaults to `86400`, or `0` when `permanent` is set. Conflicts with `permanent`.",
+			},
+			"permanent": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Ban the address until the resource is destroyed. Conflicts with `expiration_seconds`.",
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"country": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Country of the banned address as an ISO 3166-1 alpha-2 code, when the API reports it.",
+			},
+			"asn": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Autonomous system number of the banned address, when the API reports it.",
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebBanResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"ip": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Banned IP address.",
+			},
+			"service": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Service of a service-specific ban, `global` for global bans.",
 			},
 		},
 	}
@@ -92,16 +153,80 @@ func (r *BunkerWebBanResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebBanResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data BunkerWebBanResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := banExpiration(path.Root("expiration_seconds"), data.ExpirationSeconds, path.Root("permanent"), data.Permanent)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ModifyPlan fills in expiration_seconds from permanent, and warns when the
+// ban is scoped to a service the API does not know.
+func (r *BunkerWebBanResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BunkerWebBanResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ExpirationSeconds.IsUnknown() && !plan.Permanent.IsUnknown() {
+		expiration := int64(defaultBanExpiration)
+		if plan.Permanent.ValueBool() {
+			expiration = 0
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expiration_seconds"), expiration)...)
+	}
+
+	if r.client == nil {
+		return
+	}
+	addUnknownBanServiceWarning(ctx, &resp.Diagnostics, r.client, plan.Service)
+}
+
+// addUnknownBanServiceWarning warns when service is set but matches no
+// service. Lookup failures are logged and skip the check.
+func addUnknownBanServiceWarning(ctx context.Context, diags *diag.Diagnostics, client BunkerWebAPI, value types.String) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	service := strings.TrimSpace(value.ValueString())
+	if service == "" {
+		return
+	}
+
+	exists, err := client.ServiceExists(ctx, service)
+	if err != nil {
+		tflog.Debug(ctx, "unable to list bunkerweb services for ban validation", map[string]any{"error": err.Error()})
+		return
+	}
+	if !exists {
+		diags.AddAttributeWarning(
+			path.Root("service"),
+			"Unknown Ban Service",
+			fmt.Sprintf("No BunkerWeb service is named %q. The API accepts the ban, but it will never match traffic. Check the service identifier (the first server_name), or ignore this warning if the service is created in the same apply.", service),
+		)
+	}
 }
 
 func (r *BunkerWebBanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -116,6 +241,11 @@ func (r *BunkerWebBanResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	banReq := BanRequest{
 		IP: plan.IP.ValueString(),
 	}
@@ -124,7 +254,9 @@ func (r *BunkerWebBanResource) Create(ctx context.Context, req resource.CreateRe
 		reason := plan.Reason.ValueString()
 		banReq.Reason = &reason
 	}
-	if !plan.ExpirationSeconds.IsNull() && !plan.ExpirationSeconds.IsUnknown() {
+	if plan.Permanent.ValueBool() {
+		banReq.Exp = new(int)
+	} else if !plan.ExpirationSeconds.IsNull() && !plan.ExpirationSeconds.IsUnknown() {
 		exp := int(plan.ExpirationSeconds.ValueInt64())
 		banReq.Exp = &exp
 	}
@@ -135,6 +267,8 @@ func (r *BunkerWebBanResource) Create(ctx context.Context, req resource.CreateRe
 		}
 	}
 
+	addUnknownBanServiceWarning(ctx, &resp.Diagnostics, r.client, plan.Service)
+
 	if err := r.client.Ban(ctx, banReq); err != nil {
 		resp.Diagnostics.AddError("Unable to Create Ban", err.Error())
 		return
@@ -148,6 +282,7 @@ func (r *BunkerWebBanResource) Create(ctx context.Context, req resource.CreateRe
 	tflog.Info(ctx, "created bunkerweb ban", map[string]any{"id": plan.ID.ValueString()})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 }
 
 func (r *BunkerWebBanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -162,6 +297,11 @@ func (r *BunkerWebBanResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags := state.refreshFromAPI(ctx, r.client)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -174,10 +314,26 @@ func (r *BunkerWebBanResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
 }
 
-func (r *BunkerWebBanResource) Update(ctx context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update Not Supported", "BunkerWeb bans cannot be updated in-place; recreate the resource with new arguments.")
+// Update only records a new retries attribute; bans themselves cannot be
+// changed in place.
+func (r *BunkerWebBanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state BunkerWebBanResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Reason.Equal(state.Reason) || !plan.ExpirationSeconds.Equal(state.ExpirationSeconds) {
+		resp.Diagnostics.AddError("Update Not Supported", "BunkerWeb bans cannot be updated in-place; recreate the resource with new arguments.")
+		return
+	}
+
+	state.Retries = plan.Retries
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *BunkerWebBanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -192,6 +348,11 @@ func (r *BunkerWebBanResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if state.IP.IsNull() || state.IP.IsUnknown() {
 		return
 	}
@@ -211,28 +372,56 @@ func (r *BunkerWebBanResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *BunkerWebBanResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.Split(req.ID, "/")
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	id, diags := importIdentifier(ctx, req, "ip", "service")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parts := strings.Split(id, "/")
 	if len(parts) > 2 {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected ip or ip/service, got %q", req.ID),
+			fmt.Sprintf("Expected ip or ip/service, got %q", id),
 		)
 		return
 	}
 
+	// ip/global, like ip/, addresses a global ban, as for bunkerweb_config.
 	service := ""
-	if len(parts) == 2 {
+	if len(parts) == 2 && !strings.EqualFold(parts[1], "global") {
 		service = parts[1]
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &BunkerWebBanResourceModel{
-		ID:      types.StringValue(buildBanID(parts[0], service)),
+	// Fetch the ban now so a typo fails the import instead of leaving an
+	// empty resource, and so reason, expiration_seconds and permanent match
+	// the API.
+	state := BunkerWebBanResourceModel{
 		IP:      types.StringValue(parts[0]),
 		Service: types.StringValue(service),
-	})...)
+		Retries: types.ObjectNull(retriesAttrTypes),
+	}
+	resp.Diagnostics.Append(state.refreshFromAPI(ctx, r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.ID.IsNull() {
+		resp.Diagnostics.AddError(
+			"Ban Not Found",
+			fmt.Sprintf("ban not found: no active ban for %q", id),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func (m *BunkerWebBanResourceModel) refreshFromAPI(ctx context.Context, client *bunkerWebClient) diag.Diagnostics {
+func (m *BunkerWebBanResourceModel) refreshFromAPI(ctx context.Context, client BunkerWebAPI) diag.Diagnostics {
 	if m.IP.IsNull() || m.IP.IsUnknown() {
 		return diag.Diagnostics{diag.NewErrorDiagnostic("Refresh Ban", "IP must be known")}
 	}
@@ -242,7 +431,12 @@ func (m *BunkerWebBanResourceModel) refreshFromAPI(ctx context.Context, client *
 		service = strings.TrimSpace(m.Service.ValueString())
 	}
 
-	bans, err := client.ListBans(ctx)
+	var opts BanListOptions
+	if service != "" {
+		opts.Service = &service
+	}
+
+	bans, err := client.ListBans(ctx, opts)
 	if err != nil {
 		return diag.Diagnostics{diag.NewErrorDiagnostic("List Bans", err.Error())}
 	}
@@ -268,6 +462,8 @@ func (m *BunkerWebBanResourceModel) refreshFromAPI(ctx context.Context, client *
 			m.Reason = types.StringValue("api")
 		}
 		m.ExpirationSeconds = types.Int64Value(int64(ban.Exp))
+		m.Permanent = types.BoolValue(ban.Exp == 0)
+		m.Country, m.ASN = banOrigin(ban)
 		return nil
 	}
 
@@ -275,6 +471,73 @@ func (m *BunkerWebBanResourceModel) refreshFromAPI(ctx context.Context, client *
 	return nil
 }
 
+// banExpiration returns the exp to send for a ban configured with an
+// expiration and a permanent flag: nil for the API default, zero for a
+// permanent ban. A zero expiration is rejected rather than read as permanent,
+// so a value computed down to zero cannot ban an address forever.
+func banExpiration(expirationPath path.Path, expiration types.Int64, permanentPath path.Path, permanent types.Bool) (*int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if permanent.ValueBool() {
+		if !expiration.IsNull() {
+			diags.AddAttributeError(permanentPath, "Conflicting Ban Expiration", fmt.Sprintf("`%s` cannot be set on a permanent ban.", expirationPath))
+			return nil, diags
+		}
+		return new(int), diags
+	}
+	if expiration.IsNull() || expiration.IsUnknown() {
+		return nil, diags
+	}
+
+	exp := expiration.ValueInt64()
+	if exp == 0 {
+		diags.AddAttributeError(expirationPath, "Invalid Ban Expiration", fmt.Sprintf("An expiration of 0 no longer makes a ban permanent; set `%s = true` instead.", permanentPath))
+		return nil, diags
+	}
+	if exp < 0 {
+		diags.AddAttributeError(expirationPath, "Invalid Ban Expiration", fmt.Sprintf("Expected a positive number of seconds, got %d.", exp))
+		return nil, diags
+	}
+	value := int(exp)
+	return &value, diags
+}
+
+// banOrigin returns the country and AS number of ban, null when the API does
+// not report them.
+func banOrigin(ban bunkerWebBan) (types.String, types.Int64) {
+	country := types.StringNull()
+	if c := strings.TrimSpace(ban.Country); c != "" {
+		country = types.StringValue(strings.ToUpper(c))
+	}
+	asn := types.Int64Null()
+	if n, ok := ban.asNumber(); ok {
+		asn = types.Int64Value(n)
+	}
+	return country, asn
+}
+
+// identity names a global ban's service "global", as bunkerweb_config does.
+func (m *BunkerWebBanResourceModel) identity() banIdentityModel {
+	service := m.Service
+	if service.ValueString() == "" {
+		service = types.StringValue("global")
+	}
+	return banIdentityModel{IP: m.IP, Service: service}
+}
+
+func (r *BunkerWebBanResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(banStateMigrations...)
+}
+
+// canonicalBanID rebuilds the ID of version 0 states from ip and service, as
+// Read does, so that every stored ban uses the ip[/service] format.
+func canonicalBanID(_ context.Context, state map[string]any) error {
+	if ip := strings.TrimSpace(stateString(state, "ip")); ip != "" {
+		state["id"] = buildBanID(ip, strings.TrimSpace(stateString(state, "service")))
+	}
+	return nil
+}
+
 func buildBanID(ip, service string) string {
 	if service == "" {
 		return ip
@@ -4,10 +4,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccBunkerWebBanResource(t *testing.T) {
@@ -31,10 +39,192 @@ func TestAccBunkerWebBanResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:  "bunkerweb_ban.block",
+				ImportState:   true,
+				ImportStateId: "192.0.2.99/maintenance",
+				ExpectError:   regexp.MustCompile("ban not found"),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebBanResourceImportBlock(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebBanResourceConfig(fakeAPI.URL(), "192.0.2.11", "maintenance", 3600),
+			},
+			{
+				ResourceName:    "bunkerweb_ban.block",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			{
+				ResourceName:    "bunkerweb_ban.block",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
 		},
 	})
 }
 
+func TestAccBunkerWebBanResourceImportGlobal(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebBanResourceConfig(fakeAPI.URL(), "192.0.2.12", "", 3600),
+			},
+			{
+				ResourceName:      "bunkerweb_ban.block",
+				ImportState:       true,
+				ImportStateId:     "192.0.2.12/global",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:    "bunkerweb_ban.block",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestBanImportGlobalByIdentity(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	ban := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_ban")
+	if errs := ban.apply(map[string]tftypes.Value{
+		"ip": tftypes.NewValue(tftypes.String, "192.0.2.12"),
+	}); len(errs) > 0 {
+		t.Fatalf("apply: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	identity, err := ban.identity.IdentityData.Unmarshal(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"ip": tftypes.String, "service": tftypes.String}})
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	var attributes map[string]tftypes.Value
+	var service string
+	if err := identity.As(&attributes); err != nil || attributes["service"].As(&service) != nil || service != "global" {
+		t.Fatalf("expected the global ban identity to name the global service, got %v", identity)
+	}
+
+	resp, err := ban.server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: "bunkerweb_ban",
+		Identity: ban.identity,
+	})
+	if err != nil {
+		t.Fatalf("ImportResourceState: %v", err)
+	}
+	protocolDiagnostics(t, "ImportResourceState", resp.Diagnostics)
+	if len(resp.ImportedResources) != 1 {
+		t.Fatalf("expected one imported resource, got %d", len(resp.ImportedResources))
+	}
+	value, err := resp.ImportedResources[0].State.Unmarshal(ban.objType)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	var id string
+	if err := value.As(&attributes); err != nil || attributes["id"].As(&id) != nil || attributes["service"].As(&service) != nil {
+		t.Fatalf("unexpected imported state %v", value)
+	}
+	if id != ban.attribute("id") || service != ban.attribute("service") {
+		t.Fatalf("expected the import by identity to restore id %q and service %q, got %q and %q", ban.attribute("id"), ban.attribute("service"), id, service)
+	}
+}
+
+func TestAccBunkerWebBanResourcePermanent(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebBanResourceConfig(fakeAPI.URL(), "192.0.2.12", "maintenance", 0),
+				ExpectError: regexp.MustCompile("no longer makes a ban permanent"),
+			},
+			{
+				Config:      testAccBunkerWebBanResourcePermanentConfig(fakeAPI.URL(), "expiration_seconds = 3600"),
+				ExpectError: regexp.MustCompile("cannot be set on a permanent ban"),
+			},
+			{
+				Config: testAccBunkerWebBanResourcePermanentConfig(fakeAPI.URL(), ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_ban.forever", "permanent", "true"),
+					resource.TestCheckResourceAttr("bunkerweb_ban.forever", "expiration_seconds", "0"),
+				),
+			},
+			{
+				ResourceName:      "bunkerweb_ban.forever",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccBunkerWebBanResourcePermanentConfig(endpoint, extra string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_ban" "forever" {
+  ip        = "192.0.2.13"
+  permanent = true
+  %s
+}
+`, endpoint, extra)
+}
+
+func TestBanExpiration(t *testing.T) {
+	expPath, permPath := path.Root("expires_in"), path.Root("permanent")
+	cases := []struct {
+		name       string
+		expiration types.Int64
+		permanent  types.Bool
+		want       *int
+		wantError  bool
+	}{
+		{name: "default", expiration: types.Int64Null(), permanent: types.BoolNull()},
+		{name: "expiration", expiration: types.Int64Value(600), permanent: types.BoolValue(false), want: intPointer(600)},
+		{name: "permanent", expiration: types.Int64Null(), permanent: types.BoolValue(true), want: intPointer(0)},
+		{name: "zero", expiration: types.Int64Value(0), permanent: types.BoolNull(), wantError: true},
+		{name: "negative", expiration: types.Int64Value(-1), permanent: types.BoolNull(), wantError: true},
+		{name: "both", expiration: types.Int64Value(600), permanent: types.BoolValue(true), wantError: true},
+	}
+	for _, tc := range cases {
+		got, diags := banExpiration(expPath, tc.expiration, permPath, tc.permanent)
+		if diags.HasError() != tc.wantError {
+			t.Errorf("%s: error = %t, want %t (%v)", tc.name, diags.HasError(), tc.wantError, diags)
+			continue
+		}
+		if (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+			t.Errorf("%s: exp = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
 func testAccBunkerWebBanResourceConfig(endpoint, ip, service string, exp int) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -50,3 +240,35 @@ resource "bunkerweb_ban" "block" {
 }
 `, endpoint, ip, service, exp)
 }
+
+func TestUnknownBanServiceWarning(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	api.AddService(bunkerWebService{ID: "app.example.com", ServerName: "app.example.com"})
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	for value, wantWarning := range map[types.String]bool{
+		types.StringValue("app.example.com"):  false,
+		types.StringValue(""):                 false,
+		types.StringUnknown():                 false,
+		types.StringValue("shop.example.com"): true,
+	} {
+		var diags diag.Diagnostics
+		addUnknownBanServiceWarning(ctx, &diags, client, value)
+		if got := diags.WarningsCount() > 0; got != wantWarning {
+			t.Errorf("service %s: warning = %t, want %t (%v)", value, got, wantWarning, diags)
+		}
+	}
+
+	// Creating a service through the client refreshes the cached list.
+	if _, err := client.CreateService(ctx, ServiceCreateRequest{ServerName: "shop.example.com"}); err != nil {
+		t.Fatalf("CreateService: %v", err)
+	}
+	if exists, err := client.ServiceExists(ctx, "shop.example.com"); err != nil || !exists {
+		t.Fatalf("expected shop.example.com to exist after creation, got %t (%v)", exists, err)
+	}
+}
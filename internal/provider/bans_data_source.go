@@ -0,0 +1,241 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BunkerWebBansDataSource{}
+
+// BunkerWebBansDataSource lists active bans.
+type BunkerWebBansDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebBansDataSourceModel holds state.
+type BunkerWebBansDataSourceModel struct {
+	Service types.String `tfsdk:"service"`
+	Reason  types.String `tfsdk:"reason"`
+	Since   types.String `tfsdk:"since"`
+	Until   types.String `tfsdk:"until"`
+	Country types.String `tfsdk:"country"`
+	ASN     types.Int64  `tfsdk:"asn"`
+	Limit   types.Int64  `tfsdk:"limit"`
+	Offset  types.Int64  `tfsdk:"offset"`
+	Total   types.Int64  `tfsdk:"total_count"`
+	Bans    types.List   `tfsdk:"bans"`
+}
+
+func NewBunkerWebBansDataSource() datasource.DataSource {
+	return &BunkerWebBansDataSource{}
+}
+
+func (d *BunkerWebBansDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bans"
+}
+
+func (d *BunkerWebBansDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists active bans, optionally filtered by service, reason and ban date. Filters are sent to the API so only matching bans are downloaded.",
+		Attributes: map[string]schema.Attribute{
+			"service": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return bans scoped to this service.",
+			},
+			"reason": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return bans with this reason (for example `bad behavior` or `api`).",
+			},
+			"since": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return bans created at or after this RFC 3339 timestamp.",
+			},
+			"until": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return bans created at or before this RFC 3339 timestamp.",
+			},
+			"country": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return bans of addresses in this country (ISO 3166-1 alpha-2 code). Bans without a reported country never match. Filtered by the provider.",
+			},
+			"asn": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return bans of addresses in this autonomous system. Bans without a reported AS never match. Filtered by the provider.",
+			},
+			"bans": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Bans matching the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Banned IP address.",
+						},
+						"service": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Service the ban applies to (empty for global bans).",
+						},
+						"reason": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Reason recorded for the ban.",
+						},
+						"date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "RFC 3339 timestamp of the ban when reported by the API.",
+						},
+						"exp": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Remaining ban duration in seconds (`0` for permanent bans).",
+						},
+						"country": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Country of the banned address as an ISO 3166-1 alpha-2 code, when the API reports it.",
+						},
+						"asn": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Autonomous system number of the banned address, when the API reports it.",
+						},
+					},
+				},
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, paginationSchemaAttributes("bans"))
+}
+
+func (d *BunkerWebBansDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebBansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebBansDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := BanListOptions{
+		Service: optionalString(data.Service),
+		Reason:  optionalString(data.Reason),
+	}
+	for _, bound := range []struct {
+		name   string
+		value  types.String
+		target **time.Time
+	}{
+		{"since", data.Since, &opts.Since},
+		{"until", data.Until, &opts.Until},
+	} {
+		if bound.value.IsNull() || bound.value.IsUnknown() {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(bound.name), "Invalid Timestamp", fmt.Sprintf("Expected an RFC 3339 timestamp: %s", err))
+			continue
+		}
+		*bound.target = &parsed
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bans, err := d.client.ListBans(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Bans", err.Error())
+		return
+	}
+
+	// The API has no origin filters, so apply them before paginating.
+	if !data.Country.IsNull() || !data.ASN.IsNull() {
+		bans = slices.DeleteFunc(bans, func(ban bunkerWebBan) bool {
+			country, asn := banOrigin(ban)
+			return (!data.Country.IsNull() && !strings.EqualFold(country.ValueString(), strings.TrimSpace(data.Country.ValueString()))) ||
+				(!data.ASN.IsNull() && !asn.Equal(data.ASN))
+		})
+	}
+
+	attrTypes := map[string]attr.Type{
+		"ip":      types.StringType,
+		"service": types.StringType,
+		"reason":  types.StringType,
+		"date":    types.StringType,
+		"exp":     types.Int64Type,
+		"country": types.StringType,
+		"asn":     types.Int64Type,
+	}
+
+	start, end, diags := pageBounds(data.Limit, data.Offset, len(bans))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Total = types.Int64Value(int64(len(bans)))
+
+	objs := make([]attr.Value, 0, end-start)
+	for _, ban := range bans[start:end] {
+		service := ""
+		if ban.Service != nil {
+			service = *ban.Service
+		}
+		country, asn := banOrigin(ban)
+		date := types.StringNull()
+		if ban.Date != 0 {
+			date = types.StringValue(time.Unix(ban.Date, 0).UTC().Format(time.RFC3339))
+		}
+
+		obj, diags := types.ObjectValue(attrTypes, map[string]attr.Value{
+			"ip":      types.StringValue(ban.IP),
+			"service": types.StringValue(service),
+			"reason":  types.StringValue(ban.Reason),
+			"date":    date,
+			"exp":     types.Int64Value(int64(ban.Exp)),
+			"country": country,
+			"asn":     asn,
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		objs = append(objs, obj)
+	}
+
+	data.Bans, diags = types.ListValue(types.ObjectType{AttrTypes: attrTypes}, objs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,113 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebBansDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	service := "app.example.com"
+	fakeAPI.AddBan(bunkerWebBan{IP: "10.0.0.1", Reason: "bad behavior", Date: time.Now().Add(-time.Hour).Unix(), Service: &service})
+	fakeAPI.AddBan(bunkerWebBan{IP: "10.0.0.2", Reason: "api", Date: time.Now().Add(-time.Hour).Unix(), Service: &service})
+	fakeAPI.AddBan(bunkerWebBan{IP: "10.0.0.3", Reason: "bad behavior", Date: time.Now().Add(-72 * time.Hour).Unix()})
+
+	since := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebBansDataSourceConfig(fakeAPI.URL(), since),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.recent", "bans.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.recent", "bans.0.ip", "10.0.0.1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.recent", "bans.0.service", "app.example.com"),
+					resource.TestCheckResourceAttrSet("data.bunkerweb_bans.recent", "bans.0.date"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebBansDataSourceOrigin(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddBan(bunkerWebBan{IP: "203.0.113.1", Reason: "bad behavior", Country: "fr", ASN: float64(64500)})
+	fakeAPI.AddBan(bunkerWebBan{IP: "203.0.113.2", Reason: "bad behavior", Country: "DE", ASN: "AS64501"})
+	fakeAPI.AddBan(bunkerWebBan{IP: "203.0.113.3", Reason: "bad behavior"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_bans" "all" {}
+
+data "bunkerweb_bans" "asn" {
+  asn = 64501
+}
+
+data "bunkerweb_bans" "france" {
+  country = "FR"
+}
+`, fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.all", "bans.#", "3"),
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.asn", "bans.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.asn", "bans.0.ip", "203.0.113.2"),
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.asn", "bans.0.country", "DE"),
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.france", "bans.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_bans.france", "bans.0.asn", "64500"),
+				),
+			},
+		},
+	})
+}
+
+func TestBanOrigin(t *testing.T) {
+	cases := []struct {
+		ban     bunkerWebBan
+		country string
+		asn     int64
+	}{
+		{bunkerWebBan{Country: "fr", ASN: float64(64500)}, "FR", 64500},
+		{bunkerWebBan{ASN: "AS64501"}, "", 64501},
+		{bunkerWebBan{ASN: "64502"}, "", 64502},
+		{bunkerWebBan{ASN: "unknown"}, "", 0},
+		{bunkerWebBan{}, "", 0},
+	}
+	for _, tc := range cases {
+		country, asn := banOrigin(tc.ban)
+		if country.ValueString() != tc.country || asn.ValueInt64() != tc.asn || country.IsNull() != (tc.country == "") || asn.IsNull() != (tc.asn == 0) {
+			t.Errorf("banOrigin(%+v) = %s, %s; want %q, %d", tc.ban, country, asn, tc.country, tc.asn)
+		}
+	}
+}
+
+func testAccBunkerWebBansDataSourceConfig(endpoint, since string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_bans" "recent" {
+  service = "app.example.com"
+  reason  = "bad behavior"
+  since   = "%s"
+}
+`, endpoint, since)
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/url"
 	"strings"
 
@@ -19,7 +20,7 @@ var _ datasource.DataSource = &BunkerWebCacheDataSource{}
 
 // BunkerWebCacheDataSource lists cached job artefacts.
 type BunkerWebCacheDataSource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebCacheDataSourceModel holds state.
@@ -28,6 +29,9 @@ type BunkerWebCacheDataSourceModel struct {
 	Plugin   types.String `tfsdk:"plugin"`
 	JobName  types.String `tfsdk:"job_name"`
 	WithData types.Bool   `tfsdk:"with_data"`
+	Limit    types.Int64  `tfsdk:"limit"`
+	Offset   types.Int64  `tfsdk:"offset"`
+	Total    types.Int64  `tfsdk:"total_count"`
 	Entries  types.List   `tfsdk:"entries"`
 }
 
@@ -89,6 +93,7 @@ func (d *BunkerWebCacheDataSource) Schema(_ context.Context, _ datasource.Schema
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, paginationSchemaAttributes("entries"))
 }
 
 func (d *BunkerWebCacheDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -96,16 +101,16 @@ func (d *BunkerWebCacheDataSource) Configure(_ context.Context, req datasource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *BunkerWebCacheDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -160,22 +165,38 @@ func (d *BunkerWebCacheDataSource) Read(ctx context.Context, req datasource.Read
 		"file_name": types.StringType,
 		"data":      types.StringType,
 	}
-	objs := make([]attr.Value, 0, len(entries))
-	for _, entry := range entries {
+	start, end, diags := pageBounds(data.Limit, data.Offset, len(entries))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Total = types.Int64Value(int64(len(entries)))
+
+	objs := make([]attr.Value, 0, end-start)
+	for _, entry := range entries[start:end] {
 		dataVal := types.StringNull()
 		if entry.Data != nil {
 			dataVal = types.StringValue(*entry.Data)
 		}
-		objs = append(objs, types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		obj, diags := types.ObjectValue(attrTypes, map[string]attr.Value{
 			"service":   types.StringValue(entry.Service),
 			"plugin":    types.StringValue(entry.Plugin),
 			"job_name":  types.StringValue(entry.JobName),
 			"file_name": types.StringValue(entry.FileName),
 			"data":      dataVal,
-		}))
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		objs = append(objs, obj)
 	}
 
-	data.Entries = types.ListValueMust(types.ObjectType{AttrTypes: attrTypes}, objs)
+	data.Entries, diags = types.ListValue(types.ObjectType{AttrTypes: attrTypes}, objs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,173 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &BunkerWebCachePurgeEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &BunkerWebCachePurgeEphemeralResource{}
+
+// BunkerWebCachePurgeEphemeralResource deletes job cache files.
+type BunkerWebCachePurgeEphemeralResource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebCachePurgeModel captures Terraform configuration.
+type BunkerWebCachePurgeModel struct {
+	Plugin   types.String `tfsdk:"plugin"`
+	JobName  types.String `tfsdk:"job_name"`
+	Service  types.String `tfsdk:"service"`
+	FileName types.String `tfsdk:"file_name"`
+	Deleted  types.List   `tfsdk:"deleted"`
+}
+
+func NewBunkerWebCachePurgeEphemeralResource() ephemeral.EphemeralResource {
+	return &BunkerWebCachePurgeEphemeralResource{}
+}
+
+func (r *BunkerWebCachePurgeEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cache_purge"
+}
+
+func (r *BunkerWebCachePurgeEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes the cache files stored by a plugin's jobs, for example to make a job regenerate them on its next run after its inputs changed. " +
+			"Matching no file is not an error.",
+		Attributes: map[string]schema.Attribute{
+			"plugin": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Plugin owning the cache files.",
+			},
+			"job_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only delete the files of this job.",
+			},
+			"service": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only delete the files stored for this service (`global` for the files not tied to a service).",
+			},
+			"file_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only delete files with this name.",
+			},
+			"deleted": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Deleted files, as `service/plugin/job_name/file_name`.",
+			},
+		},
+	}
+}
+
+func (r *BunkerWebCachePurgeEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+// ValidateConfig summarises the files to delete, once every value is known.
+func (r *BunkerWebCachePurgeEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data BunkerWebCachePurgeModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Plugin.IsUnknown() {
+		return
+	}
+
+	if strings.TrimSpace(data.Plugin.ValueString()) == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("plugin"), "Missing Plugin", "Set `plugin` to the plugin whose cache files to delete.")
+		return
+	}
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	target := strings.Join([]string{
+		firstNonEmpty(strings.TrimSpace(data.Service.ValueString()), "*"),
+		strings.TrimSpace(data.Plugin.ValueString()),
+		firstNonEmpty(strings.TrimSpace(data.JobName.ValueString()), "*"),
+		firstNonEmpty(strings.TrimSpace(data.FileName.ValueString()), "*"),
+	}, "/")
+	addPlanSummary(&resp.Diagnostics, "bunkerweb_cache_purge", []string{"delete cache files matching " + target})
+}
+
+func (r *BunkerWebCachePurgeEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebCachePurgeModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters := url.Values{"plugin": {strings.TrimSpace(data.Plugin.ValueString())}}
+	if job := strings.TrimSpace(data.JobName.ValueString()); job != "" {
+		filters.Set("job_name", job)
+	}
+	if service := strings.TrimSpace(data.Service.ValueString()); service != "" {
+		filters.Set("service", service)
+	}
+
+	entries, err := r.client.ListCacheEntries(ctx, filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Cache Entries", err.Error())
+		return
+	}
+
+	fileName := strings.TrimSpace(data.FileName.ValueString())
+	keys := make([]CacheFileKey, 0, len(entries))
+	deleted := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if fileName != "" && entry.FileName != fileName {
+			continue
+		}
+		service := firstNonEmpty(entry.Service, "global")
+		keys = append(keys, CacheFileKey{
+			Service:  stringPointer(service),
+			Plugin:   entry.Plugin,
+			JobName:  entry.JobName,
+			FileName: entry.FileName,
+		})
+		deleted = append(deleted, strings.Join([]string{service, entry.Plugin, entry.JobName, entry.FileName}, "/"))
+	}
+
+	if len(keys) > 0 {
+		if err := r.client.DeleteCacheFiles(ctx, keys); err != nil {
+			resp.Diagnostics.AddError("Unable to Delete Cache Files", err.Error())
+			return
+		}
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, deleted)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Deleted = list
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,74 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestBunkerWebClientDeleteCacheFiles(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	api.AddCacheEntry(bunkerWebCacheEntry{Service: "app.example.com", Plugin: "letsencrypt", JobName: "certbot-new", FileName: "cert.pem"})
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := client.DeleteCacheFiles(ctx, nil); err == nil {
+		t.Fatalf("expected an error without cache files")
+	}
+
+	service := "app.example.com"
+	if err := client.DeleteCacheFiles(ctx, []CacheFileKey{{Service: &service, Plugin: "letsencrypt", JobName: "certbot-new", FileName: "cert.pem"}}); err != nil {
+		t.Fatalf("DeleteCacheFiles: %v", err)
+	}
+	if got, want := api.CacheFiles(), []string{"global|reporter|daily|summary.txt"}; !slices.Equal(got, want) {
+		t.Fatalf("cache files = %v, want %v", got, want)
+	}
+
+	err = client.DeleteCacheFiles(ctx, []CacheFileKey{{Plugin: "letsencrypt", JobName: "certbot-new", FileName: "cert.pem"}})
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestAccBunkerWebCachePurgeEphemeralResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddCacheEntry(bunkerWebCacheEntry{Service: "global", Plugin: "reporter", JobName: "weekly", FileName: "summary.txt"})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_cache_purge" "daily" {
+  plugin   = "reporter"
+  job_name = "daily"
+}
+`, fakeAPI.URL()),
+			},
+		},
+	})
+
+	if got, want := fakeAPI.CacheFiles(), []string{"global|reporter|weekly|summary.txt"}; !slices.Equal(got, want) {
+		t.Fatalf("cache files = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,443 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Settings of the BunkerWeb customcert plugin. The certificate and key are
+// stored base64-encoded in the *_DATA settings so no file has to exist on the
+// instances.
+const (
+	customSSLSetting         = "USE_CUSTOM_SSL"
+	customSSLPrioritySetting = "CUSTOM_SSL_CERT_PRIORITY"
+	customSSLCertSetting     = "CUSTOM_SSL_CERT_DATA"
+	customSSLKeySetting      = "CUSTOM_SSL_KEY_DATA"
+)
+
+var _ resource.Resource = &BunkerWebCertificateResource{}
+var _ resource.ResourceWithImportState = &BunkerWebCertificateResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebCertificateResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebCertificateResource{}
+
+// BunkerWebCertificateResource manages the custom TLS certificate of a service.
+type BunkerWebCertificateResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebCertificateResourceModel is the Terraform state.
+type BunkerWebCertificateResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Service         types.String `tfsdk:"service"`
+	Certificate     types.String `tfsdk:"certificate"`
+	PrivateKey      types.String `tfsdk:"private_key"`
+	RenewBeforeDays types.Int64  `tfsdk:"renew_before_days"`
+	NotAfter        types.String `tfsdk:"not_after"`
+	NeedsRenewal    types.Bool   `tfsdk:"needs_renewal"`
+	Issuer          types.String `tfsdk:"issuer"`
+	Retries         types.Object `tfsdk:"retries"`
+}
+
+func NewBunkerWebCertificateResource() resource.Resource {
+	return &BunkerWebCertificateResource{}
+}
+
+func (r *BunkerWebCertificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate"
+}
+
+func (r *BunkerWebCertificateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a custom TLS certificate/key pair for a BunkerWeb service using the `customcert` plugin settings.\n\n" +
+			"Once the certificate enters the `renew_before_days` window, `needs_renewal` becomes true and every plan warns about the expiry " +
+			"until a renewed certificate is supplied.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the certificate (the service identifier).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the service the certificate is attached to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "PEM-encoded certificate, optionally followed by its intermediate chain.",
+			},
+			"private_key": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "PEM-encoded private key matching `certificate`.",
+			},
+			"renew_before_days": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Number of days before `not_after` from which `needs_renewal` is true. Zero disables the check. Defaults to `30`.",
+				Default:             int64default.StaticInt64(30),
+			},
+			"not_after": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Expiry of the leaf certificate (RFC 3339).",
+			},
+			"needs_renewal": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the certificate expires within `renew_before_days`, as of the last plan or refresh.",
+			},
+			"issuer": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Distinguished name of the leaf certificate issuer.",
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebCertificateResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = serviceIdentitySchema()
+}
+
+func (r *BunkerWebCertificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebCertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compute on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BunkerWebCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Certificate.IsNull() || plan.Certificate.IsUnknown() {
+		return
+	}
+
+	cert, err := parseCertificatePEM(plan.Certificate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate"), "Invalid Certificate", err.Error())
+		return
+	}
+
+	if !plan.PrivateKey.IsNull() && !plan.PrivateKey.IsUnknown() {
+		if _, err := tls.X509KeyPair([]byte(plan.Certificate.ValueString()), []byte(plan.PrivateKey.ValueString())); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("private_key"), "Invalid Private Key", fmt.Sprintf("The private key does not match the certificate: %v", err))
+			return
+		}
+	}
+
+	plan.setCertificateInfo(cert)
+	plan.setNeedsRenewal(cert, time.Now())
+
+	if plan.NeedsRenewal.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("certificate"),
+			"Certificate Near Expiry",
+			fmt.Sprintf("The certificate for service %q expires at %s; supply a renewed certificate.", plan.Service.ValueString(), plan.NotAfter.ValueString()),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "uploaded bunkerweb certificate", map[string]any{"service": plan.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+}
+
+func (r *BunkerWebCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebCertificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.client.GetService(ctx, state.Service.ValueString())
+	if err != nil {
+		var apiErr *bunkerWebAPIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read Certificate", err.Error())
+		return
+	}
+
+	enabled, _ := lookupServiceSetting(got.Config, got.Service, customSSLSetting)
+	encoded, _ := lookupServiceSetting(got.Config, got.Service, customSSLCertSetting)
+	if !isAffirmative(enabled) || strings.TrimSpace(encoded) == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	pemData := decodeCertificateSetting(encoded)
+	if strings.TrimSpace(pemData) != strings.TrimSpace(state.Certificate.ValueString()) {
+		state.Certificate = types.StringValue(pemData)
+	}
+
+	state.ID = types.StringValue(got.Service)
+	state.Service = types.StringValue(got.Service)
+
+	cert, err := parseCertificatePEM(state.Certificate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Parse Stored Certificate", err.Error())
+		return
+	}
+	state.setCertificateInfo(cert)
+	state.setNeedsRenewal(cert, time.Now())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
+}
+
+func (r *BunkerWebCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+}
+
+func (r *BunkerWebCertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebCertificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := patchServiceVariables(ctx, r.client, state.Service.ValueString(), map[string]string{
+		customSSLSetting:     "no",
+		customSSLCertSetting: "",
+		customSSLKeySetting:  "",
+	}); err != nil {
+		resp.Diagnostics.AddError("Unable to Delete Certificate", err.Error())
+	}
+}
+
+func (r *BunkerWebCertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := importIdentifier(ctx, req, "service")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := strings.TrimSpace(id)
+	if service == "" {
+		resp.Diagnostics.AddError("Invalid Import Identifier", "Expected a non-empty service identifier.")
+		return
+	}
+
+	// The private key is write-only on the API side and must be supplied in
+	// configuration after import.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &BunkerWebCertificateResourceModel{
+		ID:              types.StringValue(service),
+		Service:         types.StringValue(service),
+		Certificate:     types.StringNull(),
+		PrivateKey:      types.StringNull(),
+		RenewBeforeDays: types.Int64Value(30),
+		NotAfter:        types.StringNull(),
+		NeedsRenewal:    types.BoolNull(),
+		Issuer:          types.StringNull(),
+		Retries:         types.ObjectNull(retriesAttrTypes),
+	})...)
+}
+
+// apply validates the pair and writes it to the service settings.
+func (r *BunkerWebCertificateResource) apply(ctx context.Context, plan *BunkerWebCertificateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	certPEM := plan.Certificate.ValueString()
+	keyPEM := plan.PrivateKey.ValueString()
+
+	cert, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		diags.AddAttributeError(path.Root("certificate"), "Invalid Certificate", err.Error())
+		return diags
+	}
+	if _, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM)); err != nil {
+		diags.AddAttributeError(path.Root("private_key"), "Invalid Private Key", fmt.Sprintf("The private key does not match the certificate: %v", err))
+		return diags
+	}
+
+	service := strings.TrimSpace(plan.Service.ValueString())
+	if err := patchServiceVariables(ctx, r.client, service, map[string]string{
+		customSSLSetting:         "yes",
+		customSSLPrioritySetting: "data",
+		customSSLCertSetting:     base64.StdEncoding.EncodeToString([]byte(certPEM)),
+		customSSLKeySetting:      base64.StdEncoding.EncodeToString([]byte(keyPEM)),
+	}); err != nil {
+		diags.AddError("Unable to Upload Certificate", err.Error())
+		return diags
+	}
+
+	plan.ID = types.StringValue(service)
+	plan.Service = types.StringValue(service)
+	plan.setCertificateInfo(cert)
+	// Keep the value of the plan, which a later clock could contradict.
+	if plan.NeedsRenewal.IsUnknown() {
+		plan.setNeedsRenewal(cert, time.Now())
+	}
+
+	return diags
+}
+
+func (m *BunkerWebCertificateResourceModel) setCertificateInfo(cert *x509.Certificate) {
+	m.NotAfter = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+	m.Issuer = types.StringValue(cert.Issuer.String())
+}
+
+func (m *BunkerWebCertificateResourceModel) setNeedsRenewal(cert *x509.Certificate, now time.Time) {
+	m.NeedsRenewal = types.BoolValue(certificateNeedsRenewal(cert, m.RenewBeforeDays, now))
+}
+
+func (m *BunkerWebCertificateResourceModel) identity() serviceIdentityModel {
+	return serviceIdentityModel{Service: m.Service}
+}
+
+// parseCertificatePEM returns the first certificate (the leaf) of a PEM bundle.
+func parseCertificatePEM(data string) (*x509.Certificate, error) {
+	rest := []byte(strings.TrimSpace(data))
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM encoded CERTIFICATE block found")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate: %w", err)
+		}
+		return cert, nil
+	}
+}
+
+// certificateNeedsRenewal reports whether cert expires within renewBeforeDays of now.
+func certificateNeedsRenewal(cert *x509.Certificate, renewBeforeDays types.Int64, now time.Time) bool {
+	if renewBeforeDays.IsNull() || renewBeforeDays.IsUnknown() || renewBeforeDays.ValueInt64() <= 0 {
+		return false
+	}
+
+	window := time.Duration(renewBeforeDays.ValueInt64()) * 24 * time.Hour
+	return cert.NotAfter.Sub(now) < window
+}
+
+// decodeCertificateSetting reverses the base64 encoding applied on upload,
+// tolerating certificates that were stored as raw PEM out-of-band.
+func decodeCertificateSetting(value string) string {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return value
+	}
+	return string(decoded)
+}
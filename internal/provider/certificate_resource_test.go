@@ -0,0 +1,236 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBunkerWebCertificateNeedsRenewal(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	certPEM, _ := testSelfSignedCertificate(t, now.Add(10*24*time.Hour))
+
+	cert, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		t.Fatalf("parseCertificatePEM: %v", err)
+	}
+	if got := cert.Issuer.CommonName; got != "bunkerweb-test" {
+		t.Fatalf("unexpected issuer common name %q", got)
+	}
+
+	if !certificateNeedsRenewal(cert, types.Int64Value(30), now) {
+		t.Fatalf("expected certificate expiring in 10 days to need renewal with a 30 day window")
+	}
+	if certificateNeedsRenewal(cert, types.Int64Value(5), now) {
+		t.Fatalf("expected certificate expiring in 10 days not to need renewal with a 5 day window")
+	}
+	if certificateNeedsRenewal(cert, types.Int64Value(0), now) {
+		t.Fatalf("expected a zero window to disable renewal checks")
+	}
+
+	if _, err := parseCertificatePEM("not a certificate"); err == nil {
+		t.Fatalf("expected error for invalid PEM input")
+	}
+}
+
+func TestAccBunkerWebCertificateResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	certPEM, keyPEM := testSelfSignedCertificate(t, time.Now().Add(365*24*time.Hour))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebCertificateResourceConfig(fakeAPI.URL(), certPEM, keyPEM),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_certificate.app", "id", "app.example.com"),
+					resource.TestCheckResourceAttr("bunkerweb_certificate.app", "issuer", "CN=bunkerweb-test"),
+					resource.TestCheckResourceAttrSet("bunkerweb_certificate.app", "not_after"),
+					resource.TestCheckResourceAttr("bunkerweb_certificate.app", "needs_renewal", "false"),
+				),
+			},
+			{
+				ResourceName:            "bunkerweb_certificate.app",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key"},
+			},
+		},
+	})
+}
+
+func TestCertificateNearExpiryIsReportedWithoutReplacement(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddService(bunkerWebService{ID: "app.example.com", ServerName: "app.example.com"})
+	certPEM, keyPEM := testSelfSignedCertificate(t, time.Now().Add(10*24*time.Hour))
+	certificate := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_certificate")
+
+	attributes := map[string]tftypes.Value{
+		"service":     tftypes.NewValue(tftypes.String, "app.example.com"),
+		"certificate": tftypes.NewValue(tftypes.String, certPEM),
+		"private_key": tftypes.NewValue(tftypes.String, keyPEM),
+	}
+	if errs := certificate.apply(attributes); len(errs) > 0 {
+		t.Fatalf("apply: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+	if got := certificate.attribute("not_after"); got == "" {
+		t.Fatal("expected not_after to be set")
+	}
+
+	config := protocolValue(t, certificate.objType, attributes)
+	plan := certificate.plan(certificate.state, config, certificate.private, certificate.identity)
+	if len(plan.RequiresReplace) > 0 {
+		t.Fatalf("expected no replacement of an unchanged certificate, got %v", plan.RequiresReplace)
+	}
+	var warned bool
+	for _, d := range plan.Diagnostics {
+		warned = warned || d.Summary == "Certificate Near Expiry"
+	}
+	if !warned {
+		t.Fatal("expected a near expiry warning")
+	}
+
+	value, err := certificate.state.Unmarshal(certificate.objType)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	var state map[string]tftypes.Value
+	var needsRenewal bool
+	if err := value.As(&state); err != nil || state["needs_renewal"].As(&needsRenewal) != nil || !needsRenewal {
+		t.Fatalf("expected needs_renewal to be true, got %v", state["needs_renewal"])
+	}
+}
+
+func TestCertificateKeepsOtherServiceVariables(t *testing.T) {
+	// Without a detected release supporting partial patches, the fake
+	// replaces the service's variables with the payload.
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddService(bunkerWebService{ID: "app.example.com", ServerName: "app.example.com", Variables: map[string]string{"USE_GZIP": "yes"}})
+	certPEM, keyPEM := testSelfSignedCertificate(t, time.Now().Add(365*24*time.Hour))
+	certificate := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_certificate")
+
+	if errs := certificate.apply(map[string]tftypes.Value{
+		"service":     tftypes.NewValue(tftypes.String, "app.example.com"),
+		"certificate": tftypes.NewValue(tftypes.String, certPEM),
+		"private_key": tftypes.NewValue(tftypes.String, keyPEM),
+	}); len(errs) > 0 {
+		t.Fatalf("apply: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	got := fakeAPI.ServiceVariables("app.example.com")
+	if got["USE_GZIP"] != "yes" || got[customSSLSetting] != "yes" {
+		t.Fatalf("expected the certificate to be added to the existing variables, got %v", got)
+	}
+}
+
+func TestCertificateSurvivesServiceUpdate(t *testing.T) {
+	// Without a detected release supporting partial patches, the fake
+	// replaces the service's variables with the payload.
+	fakeAPI := newFakeBunkerWebAPI(t)
+	certPEM, keyPEM := testSelfSignedCertificate(t, time.Now().Add(365*24*time.Hour))
+	service := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_service")
+	certificate := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_certificate")
+
+	serviceConfig := func(gzip string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"server_name": tftypes.NewValue(tftypes.String, "app.example.com"),
+			"variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"USE_GZIP": tftypes.NewValue(tftypes.String, gzip),
+			}),
+		}
+	}
+	if errs := service.apply(serviceConfig("yes")); len(errs) > 0 {
+		t.Fatalf("create service: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+	if errs := certificate.apply(map[string]tftypes.Value{
+		"service":     tftypes.NewValue(tftypes.String, "app.example.com"),
+		"certificate": tftypes.NewValue(tftypes.String, certPEM),
+		"private_key": tftypes.NewValue(tftypes.String, keyPEM),
+	}); len(errs) > 0 {
+		t.Fatalf("create certificate: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	// A later run updates the service from a new provider process.
+	next := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_service")
+	next.state, next.identity, next.private = service.state, service.identity, service.private
+	if errs := next.apply(serviceConfig("no")); len(errs) > 0 {
+		t.Fatalf("update service: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	if got := fakeAPI.ServiceVariables("app.example.com"); got["USE_GZIP"] != "no" || got[customSSLSetting] != "yes" {
+		t.Fatalf("expected the service update to keep the certificate settings, got %v", got)
+	}
+	certificate.refresh()
+	if value, err := certificate.state.Unmarshal(certificate.objType); err != nil || value.IsNull() {
+		t.Fatalf("expected the certificate to stay in state, got %v (%v)", value, err)
+	}
+}
+
+func testAccBunkerWebCertificateResourceConfig(endpoint, certPEM, keyPEM string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+}
+
+resource "bunkerweb_certificate" "app" {
+  service     = bunkerweb_service.app.id
+  certificate = <<EOT
+%sEOT
+  private_key = <<EOT
+%sEOT
+}
+`, endpoint, certPEM, keyPEM)
+}
+
+// testSelfSignedCertificate returns a PEM certificate/key pair valid until notAfter.
+func testSelfSignedCertificate(t *testing.T, notAfter time.Time) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "bunkerweb-test"},
+		DNSNames:     []string{"app.example.com"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
@@ -0,0 +1,121 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultCircuitBreakerThreshold = 5
+	defaultCircuitBreakerCooldown  = 30 * time.Second
+)
+
+// circuitBreaker stops sending requests once the API failed threshold times
+// in a row, so that during an outage every remaining operation fails at once
+// instead of each waiting for its own timeout. After cooldown a single probe
+// request is let through; its success closes the breaker again.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	lastErr   error
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// errAPIUnavailable is returned, wrapped in *circuitOpenError, for requests
+// refused while the breaker is open.
+var errAPIUnavailable = errors.New("BunkerWeb API unavailable")
+
+type circuitOpenError struct {
+	failures int
+	until    time.Time
+	lastErr  error
+}
+
+func (e *circuitOpenError) Error() string {
+	return fmt.Sprintf("%s: the last %d requests failed (latest: %s); no request is sent before %s",
+		errAPIUnavailable, e.failures, e.lastErr, e.until.Format(time.RFC3339))
+}
+
+func (e *circuitOpenError) Unwrap() []error {
+	return []error{errAPIUnavailable, e.lastErr}
+}
+
+// allow returns a *circuitOpenError when the request must not be sent.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return &circuitOpenError{failures: b.failures, until: b.openUntil, lastErr: b.lastErr}
+	}
+	b.probing = true
+	return nil
+}
+
+// record counts the outcome of a request sent after allow. err is the
+// transport error, if any, and status the HTTP status code otherwise.
+func (b *circuitBreaker) record(ctx context.Context, err error, status int) {
+	if b == nil {
+		return
+	}
+	// A request cancelled by Terraform says nothing about the API.
+	if err != nil && ctx.Err() != nil {
+		b.mu.Lock()
+		b.probing = false
+		b.mu.Unlock()
+		return
+	}
+
+	failed := err != nil || status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed {
+		if b.failures >= b.threshold {
+			tflog.Info(ctx, "bunkerweb api reachable again, closing the circuit breaker")
+		}
+		b.failures = 0
+		b.lastErr = nil
+		return
+	}
+
+	b.failures++
+	if err != nil {
+		b.lastErr = err
+	} else {
+		b.lastErr = fmt.Errorf("HTTP %d %s", status, http.StatusText(status))
+	}
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		if b.failures == b.threshold {
+			tflog.Warn(ctx, "bunkerweb api failing, failing requests fast", map[string]any{"failures": b.failures, "until": b.openUntil.Format(time.RFC3339)})
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBunkerWebClientCircuitBreaker(t *testing.T) {
+	var status atomic.Int32
+	var hits atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(`{"status":"success","message":"ok","data":{}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newBunkerWebClient(server.URL, nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.breaker = newCircuitBreaker(3, 50*time.Millisecond)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Ping(ctx); err == nil || errors.Is(err, errAPIUnavailable) {
+			t.Fatalf("request %d: expected the API error, got %v", i, err)
+		}
+	}
+
+	_, err = client.Ping(ctx)
+	if !errors.Is(err, errAPIUnavailable) || !strings.Contains(err.Error(), "HTTP 503") {
+		t.Fatalf("expected the breaker to fail fast, got %v", err)
+	}
+	if got := hits.Load(); got != 3 {
+		t.Fatalf("expected no request while the breaker is open, got %d", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	status.Store(http.StatusOK)
+	if _, err := client.Ping(ctx); err != nil {
+		t.Fatalf("expected the probe to succeed, got %v", err)
+	}
+	if _, err := client.Ping(ctx); err != nil {
+		t.Fatalf("expected the breaker to be closed, got %v", err)
+	}
+	if got := hits.Load(); got != 5 {
+		t.Fatalf("expected 5 requests, got %d", got)
+	}
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	ctx := context.Background()
+	breaker := newCircuitBreaker(2, time.Minute)
+	for i := 0; i < 5; i++ {
+		breaker.record(ctx, nil, http.StatusNotFound)
+	}
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected 4xx responses not to trip the breaker, got %v", err)
+	}
+
+	breaker.record(ctx, errors.New("connection refused"), 0)
+	breaker.record(ctx, nil, http.StatusOK)
+	breaker.record(ctx, errors.New("connection refused"), 0)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected a success to reset the failure count, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	breaker.record(cancelled, context.Canceled, 0)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected cancelled requests not to count, got %v", err)
+	}
+
+	breaker.record(ctx, errors.New("connection refused"), 0)
+	if err := breaker.allow(); !errors.Is(err, errAPIUnavailable) {
+		t.Fatalf("expected the breaker to be open, got %v", err)
+	}
+
+	disabled := newCircuitBreaker(0, time.Minute)
+	disabled.record(ctx, errors.New("connection refused"), 0)
+	if err := disabled.allow(); err != nil {
+		t.Fatalf("expected a disabled breaker to allow requests, got %v", err)
+	}
+}
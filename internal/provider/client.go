@@ -6,16 +6,21 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -24,9 +29,86 @@ import (
 type bunkerWebClient struct {
 	baseURL     *url.URL
 	httpClient  *http.Client
-	apiToken    string
 	apiUsername string
 	apiPassword string
+
+	// tokenMu guards apiToken, which Login replaces while other requests
+	// may be in flight; see token and reauthorize.
+	tokenMu  sync.RWMutex
+	apiToken string
+
+	// hmacKey signs every request for an HMAC-verifying gateway (provider
+	// hmac_key); see signRequest.
+	hmacKey    []byte
+	hmacHeader string
+
+	// extraHeaders are added to every request (provider extra_headers).
+	extraHeaders map[string]string
+
+	// tenant scopes every request (provider tenant), through tenantHeader or,
+	// when tenantInPath is set, an endpoint prefix; see withTenant.
+	tenant       string
+	tenantHeader string
+	tenantInPath bool
+
+	// multisite caches MULTISITE from the global configuration; see
+	// MultisiteMode.
+	multisiteMu sync.Mutex
+	multisite   *bool
+
+	settingTypesMu sync.Mutex
+	settingTypes   map[string]string
+
+	// services caches the GET /services listing by identifier; see
+	// serviceSummaries.
+	servicesMu sync.Mutex
+	services   map[string]bunkerWebService
+
+	// Deletions requested concurrently are coalesced into the bulk
+	// endpoints; see BatchDeleteConfig and BatchDeleteInstance.
+	deleteBatchersOnce sync.Once
+	configDeletes      *deleteBatcher[ConfigKey]
+	instanceDeletes    *deleteBatcher[string]
+
+	// stats counts the requests sent by this client; see ReportRequestStats.
+	stats *requestStats
+
+	// breaker fails requests fast while the API is down (provider
+	// circuit_breaker_threshold); nil disables it.
+	breaker *circuitBreaker
+
+	// tracer propagates the OpenTelemetry trace context to the API; nil
+	// disables tracing.
+	tracer *tracer
+
+	// retries is the retry policy of requests (provider retries), which
+	// resources override with withRetries.
+	retries retryPolicy
+
+	// lockTTL enables the advisory global config lock (provider
+	// global_config_lock_ttl); see lockGlobalConfig.
+	lockTTL     time.Duration
+	lockOwner   string
+	lockMu      sync.Mutex
+	lockExpires time.Time
+
+	// asyncWaiter bounds the polling of operations answered with 202
+	// Accepted (provider async_operation_timeout); see awaitAsyncTask.
+	asyncWaiter waiter
+
+	// version is the BunkerWeb version detected during provider setup, used
+	// to explain errors from endpoints the server predates.
+	version string
+
+	// endpoints maps the routes of the API's OpenAPI document (relative to
+	// baseURL) to their methods; nil when discovery failed. See
+	// DiscoverEndpoints.
+	endpoints map[string][]string
+
+	// unmodeledReported holds the API object fields already reported by
+	// reportUnmodeledFields.
+	unmodeledMu       sync.Mutex
+	unmodeledReported map[string]struct{}
 }
 
 type bunkerWebAPIError struct {
@@ -51,6 +133,14 @@ type bunkerWebService struct {
 	ServerName string            `json:"server_name"`
 	IsDraft    bool              `json:"is_draft"`
 	Variables  map[string]string `json:"variables"`
+	// Method is how the service was last modified (ui, api, scheduler...).
+	// It and the dates (Unix seconds) are only set in GET /services entries.
+	Method       string `json:"method,omitempty"`
+	CreationDate int64  `json:"creation_date,omitempty"`
+	LastUpdate   int64  `json:"last_update,omitempty"`
+
+	// unmodeled lists the keys of the decoded object no field above holds.
+	unmodeled []string
 }
 
 type bunkerWebServicesPayload struct {
@@ -66,13 +156,18 @@ type bunkerWebServiceConfig struct {
 }
 
 type bunkerWebInstance struct {
-	Hostname    string  `json:"hostname"`
-	Name        *string `json:"name,omitempty"`
-	Port        *int    `json:"port,omitempty"`
-	ListenHTTPS *bool   `json:"listen_https,omitempty"`
-	HTTPSPort   *int    `json:"https_port,omitempty"`
-	ServerName  *string `json:"server_name,omitempty"`
-	Method      *string `json:"method,omitempty"`
+	Hostname    string            `json:"hostname"`
+	Name        *string           `json:"name,omitempty"`
+	Port        *int              `json:"port,omitempty"`
+	ListenHTTPS *bool             `json:"listen_https,omitempty"`
+	HTTPSPort   *int              `json:"https_port,omitempty"`
+	ServerName  *string           `json:"server_name,omitempty"`
+	Method      *string           `json:"method,omitempty"`
+	Type        *string           `json:"type,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+
+	// unmodeled lists the keys of the decoded object no field above holds.
+	unmodeled []string
 }
 
 type bunkerWebInstancePayload struct {
@@ -108,19 +203,92 @@ type bunkerWebConfigsPayload struct {
 type bunkerWebBan struct {
 	IP      string  `json:"ip"`
 	Reason  string  `json:"reason,omitempty"`
+	Date    int64   `json:"date,omitempty"`
 	Exp     int     `json:"exp,omitempty"`
 	Service *string `json:"service,omitempty"`
+	// Country and ASN are only reported when the API enriches bans with
+	// geolocation data; ASN is either a number or a string such as "AS13335".
+	Country string `json:"country,omitempty"`
+	ASN     any    `json:"asn,omitempty"`
+}
+
+// asNumber returns the ban's autonomous system number, if reported.
+func (b bunkerWebBan) asNumber() (int64, bool) {
+	switch v := b.ASN.(type) {
+	case float64:
+		return int64(v), v > 0
+	case string:
+		n, err := strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(v)), "AS"), 10, 64)
+		return n, err == nil && n > 0
+	}
+	return 0, false
 }
 
 type bunkerWebBansPayload struct {
 	Bans []bunkerWebBan `json:"bans"`
 }
 
+// bunkerWebRequestRecord is one request reported by the `requests` metrics.
+type bunkerWebRequestRecord struct {
+	ID         string  `json:"id"`
+	Date       float64 `json:"date"`
+	IP         string  `json:"ip"`
+	Country    string  `json:"country"`
+	Method     string  `json:"method"`
+	URL        string  `json:"url"`
+	Status     int     `json:"status"`
+	Reason     string  `json:"reason"`
+	ServerName string  `json:"server_name"`
+}
+
+type bunkerWebRequestsPayload struct {
+	Requests []bunkerWebRequestRecord `json:"requests"`
+}
+
+type bunkerWebLogsPayload struct {
+	Logs []string `json:"logs"`
+}
+
+type bunkerWebMetricsPayload struct {
+	Metrics map[string]any `json:"metrics"`
+}
+
 type bunkerWebPlugin struct {
 	ID          string `json:"id"`
 	Type        string `json:"type"`
 	Version     string `json:"version,omitempty"`
 	Description string `json:"description,omitempty"`
+
+	Settings map[string]bunkerWebPluginSetting `json:"settings,omitempty"`
+	Jobs     []bunkerWebPluginJob              `json:"jobs,omitempty"`
+	Page     bool                              `json:"page,omitempty"`
+
+	// Data is the base64-encoded plugin package and Checksum its SHA-256,
+	// only returned with with_data=true for plugins that were installed
+	// from a package (not core plugins).
+	Data     string `json:"data,omitempty"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// bunkerWebPluginJob describes one job declared in a plugin.json.
+type bunkerWebPluginJob struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Every  string `json:"every"`
+	Reload bool   `json:"reload"`
+}
+
+// bunkerWebPluginSetting describes one setting declared in a plugin.json.
+type bunkerWebPluginSetting struct {
+	ID       string   `json:"id"`
+	Context  string   `json:"context"`
+	Default  string   `json:"default"`
+	Help     string   `json:"help,omitempty"`
+	Label    string   `json:"label,omitempty"`
+	Regex    string   `json:"regex,omitempty"`
+	Type     string   `json:"type"`
+	Multiple string   `json:"multiple,omitempty"`
+	Select   []string `json:"select,omitempty"`
 }
 
 type bunkerWebPluginsPayload struct {
@@ -150,6 +318,47 @@ type bunkerWebJobsPayload struct {
 	Jobs []bunkerWebJob `json:"jobs"`
 }
 
+// bunkerWebJobRun is one execution of a scheduler job. Dates are Unix
+// timestamps.
+type bunkerWebJobRun struct {
+	Plugin    string `json:"plugin"`
+	Name      string `json:"name"`
+	Success   bool   `json:"success"`
+	StartDate int64  `json:"start_date,omitempty"`
+	EndDate   int64  `json:"end_date,omitempty"`
+}
+
+type bunkerWebJobRunsPayload struct {
+	Runs []bunkerWebJobRun `json:"runs"`
+}
+
+// bunkerWebAPICredential is an API token issued for CI or a single instance.
+// Token is only returned when the credential is created.
+type bunkerWebAPICredential struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Instance  *string `json:"instance,omitempty"`
+	Token     string  `json:"token,omitempty"`
+	CreatedAt int64   `json:"created_at,omitempty"`
+}
+
+type bunkerWebAPICredentialPayload struct {
+	Credential bunkerWebAPICredential `json:"credential"`
+}
+
+// bunkerWebUser is a web UI operator account. Passwords are never returned.
+type bunkerWebUser struct {
+	Username     string  `json:"username"`
+	Role         string  `json:"role"`
+	Email        *string `json:"email,omitempty"`
+	TOTPRequired bool    `json:"totp_required"`
+	TOTPEnabled  bool    `json:"totp_enabled"`
+}
+
+type bunkerWebUserPayload struct {
+	User bunkerWebUser `json:"user"`
+}
+
 type bunkerWebLoginPayload struct {
 	Token string `json:"token"`
 }
@@ -165,6 +374,29 @@ type bunkerWebAPIResponse struct {
 	Detail  json.RawMessage `json:"detail"`
 }
 
+// idempotencyKeyHeader carries a key identifying one logical write operation.
+// API versions that support it replay the original response for a repeated key
+// instead of applying the write twice; older versions ignore the header.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// withIdempotencyKey returns a context whose POST/PATCH requests all carry the
+// same idempotency key, so every attempt of a logical operation is recognised
+// as the same write. An existing key on ctx is kept.
+func withIdempotencyKey(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(idempotencyKeyContextKey{}).(string); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, newIdempotencyKey())
+}
+
+func newIdempotencyKey() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
 func newBunkerWebClient(endpoint string, httpClient *http.Client, token, username, password string) (*bunkerWebClient, error) {
 	if endpoint == "" {
 		return nil, fmt.Errorf("api endpoint must be provided")
@@ -198,7 +430,7 @@ func newBunkerWebClient(endpoint string, httpClient *http.Client, token, usernam
 }
 
 func (c *bunkerWebClient) withEndpoint(endpoint string) (string, error) {
-	rel, err := url.Parse(strings.TrimPrefix(endpoint, "/"))
+	rel, err := url.Parse(c.withTenant(strings.TrimPrefix(endpoint, "/")))
 	if err != nil {
 		return "", err
 	}
@@ -207,6 +439,19 @@ func (c *bunkerWebClient) withEndpoint(endpoint string) (string, error) {
 	return resolved.String(), nil
 }
 
+// reservedHeader reports whether the client sets header itself, so
+// extra_headers cannot override it.
+func (c *bunkerWebClient) reservedHeader(header string) bool {
+	reserved := []string{"Authorization", "Content-Type", idempotencyKeyHeader, hmacTimestampHeader, firstNonEmpty(c.hmacHeader, defaultHMACHeader)}
+	if c.tenantHeader != "" {
+		reserved = append(reserved, c.tenantHeader)
+	}
+	if c.tracer != nil {
+		reserved = append(reserved, traceparentHeader, tracestateHeader)
+	}
+	return containsFold(reserved, strings.TrimSpace(header))
+}
+
 func (c *bunkerWebClient) newRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Request, error) {
 	var reader io.Reader
 	contentType := ""
@@ -228,19 +473,52 @@ func (c *bunkerWebClient) newRawRequest(ctx context.Context, method, endpoint st
 		return nil, fmt.Errorf("build request url: %w", err)
 	}
 
+	// Signing needs the whole body up front.
+	var signedBody []byte
+	if len(c.hmacKey) > 0 && body != nil {
+		if signedBody, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		body = bytes.NewReader(signedBody)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, target, body)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
+	for name, value := range c.extraHeaders {
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	if c.tenantHeader != "" {
+		req.Header.Set(c.tenantHeader, c.tenant)
+	}
+
 	if contentType != "" {
 		req.Header.Set("Content-Type", contentType)
 	}
 
+	// Writes carry an idempotency key. Besides letting the API deduplicate,
+	// the header marks the request as replayable for net/http, which resends
+	// it (with the same key) when a kept-alive connection drops before any
+	// response is received.
+	if method == http.MethodPost || method == http.MethodPatch {
+		key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+		if !ok {
+			key = newIdempotencyKey()
+		}
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+
 	// Set authentication header
-	if c.apiToken != "" {
+	if token := c.token(); token != "" {
 		// Bearer token authentication
-		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+		req.Header.Set("Authorization", "Bearer "+token)
 	} else if c.apiUsername != "" && c.apiPassword != "" {
 		// HTTP Basic authentication
 		credentials := c.apiUsername + ":" + c.apiPassword
@@ -248,6 +526,8 @@ func (c *bunkerWebClient) newRawRequest(ctx context.Context, method, endpoint st
 		req.Header.Set("Authorization", "Basic "+encoded)
 	}
 
+	c.signRequest(req, signedBody)
+
 	return req, nil
 }
 
@@ -257,17 +537,85 @@ func (c *bunkerWebClient) do(ctx context.Context, req *http.Request, out interfa
 		"url":    req.URL.String(),
 	})
 
-	resp, err := c.httpClient.Do(req)
+	resp, body, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		if retry := c.reauthorize(req); retry != nil {
+			return c.do(ctx, retry, out)
+		}
+	}
+
+	// Long-running operations answer 202 with a task to poll; report them
+	// once the scheduler has actually applied the change.
+	if resp.StatusCode == http.StatusAccepted {
+		endpoint, err := c.asyncTaskEndpoint(resp.Header, body)
+		if err != nil {
+			return err
+		}
+		if endpoint != "" {
+			return c.awaitAsyncTask(ctx, endpoint, out)
+		}
+	}
+
+	return c.decodeResponse(req, resp, body, out)
+}
+
+// sendOnce executes req and reads the whole response body, accounting for the
+// request in the stats and the circuit breaker.
+func (c *bunkerWebClient) sendOnce(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, nil, err
+	}
+
+	span := c.startSpan(req)
+	start := time.Now()
+	resp, err := c.httpClientFor(req.Context()).Do(req)
 	if err != nil {
-		return fmt.Errorf("execute request: %w", err)
+		c.stats.record(c.statsEndpoint(req), time.Since(start), true)
+		c.breaker.record(ctx, err, 0)
+		span.end(ctx, 0, err)
+		return nil, nil, fmt.Errorf("execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
+	c.stats.record(c.statsEndpoint(req), time.Since(start), err != nil || resp.StatusCode >= http.StatusBadRequest)
+	c.breaker.record(ctx, err, resp.StatusCode)
+	span.end(ctx, resp.StatusCode, err)
 	if err != nil {
-		return fmt.Errorf("read response: %w", err)
+		return nil, nil, fmt.Errorf("read response: %w", err)
 	}
+	return resp, body, nil
+}
 
+// Call sends a request to an arbitrary endpoint and returns the response
+// status and body without interpreting them; see bunkerweb_api_call.
+func (c *bunkerWebClient) Call(ctx context.Context, method, endpoint string, body []byte) (int, []byte, error) {
+	var reader io.Reader
+	contentType := ""
+	if len(body) > 0 {
+		reader = bytes.NewReader(body)
+		contentType = "application/json"
+	}
+
+	req, err := c.newRawRequest(ctx, method, endpoint, reader, contentType)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	resp, payload, err := c.send(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, payload, nil
+}
+
+// decodeResponse gates a response on its HTTP and envelope status and decodes
+// its payload into out.
+func (c *bunkerWebClient) decodeResponse(req *http.Request, resp *http.Response, body []byte, out interface{}) error {
 	statusCode := resp.StatusCode
 	httpOK := statusCode >= 200 && statusCode < 300
 
@@ -276,7 +624,7 @@ func (c *bunkerWebClient) do(ctx context.Context, req *http.Request, out interfa
 			return nil
 		}
 
-		return &bunkerWebAPIError{StatusCode: statusCode, Message: strings.TrimSpace(resp.Status)}
+		return c.withVersionHint(req, &bunkerWebAPIError{StatusCode: statusCode, Message: strings.TrimSpace(resp.Status)})
 	}
 
 	// Best-effort decode of the top-level envelope fields used only for error
@@ -298,7 +646,7 @@ func (c *bunkerWebClient) do(ctx context.Context, req *http.Request, out interfa
 			strings.TrimSpace(string(body)),
 			strings.TrimSpace(resp.Status),
 		)
-		return &bunkerWebAPIError{StatusCode: statusCode, Message: msg}
+		return c.withVersionHint(req, &bunkerWebAPIError{StatusCode: statusCode, Message: msg})
 	}
 
 	if out == nil {
@@ -344,7 +692,9 @@ func (c *bunkerWebClient) CreateService(ctx context.Context, reqPayload ServiceC
 	// The API responds with {"status":"success","changed_plugins":[...]} and no
 	// service object. The identifier is the first whitespace token of server_name
 	// (matching the API: server_name.split(" ")[0]).
-	if err := c.do(ctx, req, nil); err != nil {
+	err = c.do(ctx, req, nil)
+	c.forgetServices()
+	if err != nil {
 		return nil, err
 	}
 
@@ -383,7 +733,9 @@ func (c *bunkerWebClient) UpdateService(ctx context.Context, id string, reqPaylo
 	}
 
 	// PATCH returns status only; reconstruct the resulting service from the request.
-	if err := c.do(ctx, req, nil); err != nil {
+	err = c.do(ctx, req, nil)
+	c.forgetServices()
+	if err != nil {
 		return nil, err
 	}
 
@@ -404,7 +756,9 @@ func (c *bunkerWebClient) DeleteService(ctx context.Context, id string) error {
 		return err
 	}
 
-	return c.do(ctx, req, nil)
+	err = c.do(ctx, req, nil)
+	c.forgetServices()
+	return err
 }
 
 func (c *bunkerWebClient) ListServices(ctx context.Context, includeDrafts bool) ([]bunkerWebService, error) {
@@ -422,10 +776,67 @@ func (c *bunkerWebClient) ListServices(ctx context.Context, includeDrafts bool)
 	if err := c.do(ctx, req, &payload); err != nil {
 		return nil, err
 	}
+	for _, service := range payload.Services {
+		c.reportUnmodeledFields(ctx, "service", service.unmodeled)
+	}
 
 	return payload.Services, nil
 }
 
+// GetServiceSummary returns the GET /services entry of a service, which
+// unlike GET /services/{id} carries its method and dates, or nil when no
+// service has that identifier.
+func (c *bunkerWebClient) GetServiceSummary(ctx context.Context, id string) (*bunkerWebService, error) {
+	services, err := c.serviceSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	service, ok := services[id]
+	if !ok {
+		return nil, nil
+	}
+	return &service, nil
+}
+
+// ServiceExists reports whether a service (draft or online) has the given
+// identifier.
+func (c *bunkerWebClient) ServiceExists(ctx context.Context, id string) (bool, error) {
+	services, err := c.serviceSummaries(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, ok := services[id]
+	return ok, nil
+}
+
+// serviceSummaries returns the services (draft or online) by identifier. The
+// list is fetched once per client and refetched after the client creates,
+// updates, converts or deletes a service, so refreshing many services costs
+// a single listing.
+func (c *bunkerWebClient) serviceSummaries(ctx context.Context) (map[string]bunkerWebService, error) {
+	c.servicesMu.Lock()
+	defer c.servicesMu.Unlock()
+
+	if c.services == nil {
+		list, err := c.ListServices(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		services := make(map[string]bunkerWebService, len(list))
+		for _, service := range list {
+			services[service.ID] = service
+		}
+		c.services = services
+	}
+	return c.services, nil
+}
+
+func (c *bunkerWebClient) forgetServices() {
+	c.servicesMu.Lock()
+	defer c.servicesMu.Unlock()
+	c.services = nil
+}
+
 type ServiceCreateRequest struct {
 	ServerName string            `json:"server_name"`
 	IsDraft    bool              `json:"is_draft"`
@@ -439,13 +850,17 @@ type ServiceUpdateRequest struct {
 }
 
 type InstanceCreateRequest struct {
-	Hostname    string  `json:"hostname"`
-	Name        *string `json:"name,omitempty"`
-	Port        *int    `json:"port,omitempty"`
-	ListenHTTPS *bool   `json:"listen_https,omitempty"`
-	HTTPSPort   *int    `json:"https_port,omitempty"`
-	ServerName  *string `json:"server_name,omitempty"`
-	Method      *string `json:"method,omitempty"`
+	Hostname    string            `json:"hostname"`
+	Name        *string           `json:"name,omitempty"`
+	Port        *int              `json:"port,omitempty"`
+	ListenHTTPS *bool             `json:"listen_https,omitempty"`
+	HTTPSPort   *int              `json:"https_port,omitempty"`
+	ServerName  *string           `json:"server_name,omitempty"`
+	Method      *string           `json:"method,omitempty"`
+	Type        *string           `json:"type,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	APIToken    *string           `json:"api_token,omitempty"`
+	APICert     *string           `json:"api_cert,omitempty"`
 }
 
 type InstanceUpdateRequest struct {
@@ -455,6 +870,11 @@ type InstanceUpdateRequest struct {
 	HTTPSPort   *int    `json:"https_port,omitempty"`
 	ServerName  *string `json:"server_name,omitempty"`
 	Method      *string `json:"method,omitempty"`
+	Type        *string `json:"type,omitempty"`
+	// Labels is a pointer so an empty map can be sent to clear them.
+	Labels   *map[string]string `json:"labels,omitempty"`
+	APIToken *string            `json:"api_token,omitempty"`
+	APICert  *string            `json:"api_cert,omitempty"`
 }
 
 type BanRequest struct {
@@ -523,6 +943,49 @@ type ConfigListOptions struct {
 	WithData   *bool
 }
 
+// BanListOptions narrows ListBans. Since and Until bound the ban date.
+type BanListOptions struct {
+	Service *string
+	Reason  *string
+	Since   *time.Time
+	Until   *time.Time
+}
+
+// LogsOptions selects which log lines GetLogs returns. An empty Instance reads
+// the scheduler logs.
+type LogsOptions struct {
+	Instance string
+	Service  *string
+	Lines    *int
+}
+
+// JobRunListOptions narrows ListJobRuns. Since bounds the run start date.
+type JobRunListOptions struct {
+	Plugin *string
+	Name   *string
+	Since  *time.Time
+}
+
+type APICredentialCreateRequest struct {
+	Name     string  `json:"name"`
+	Instance *string `json:"instance,omitempty"`
+}
+
+type UserCreateRequest struct {
+	Username     string  `json:"username"`
+	Password     string  `json:"password"`
+	Role         string  `json:"role"`
+	Email        *string `json:"email,omitempty"`
+	TOTPRequired bool    `json:"totp_required"`
+}
+
+type UserUpdateRequest struct {
+	Password     *string `json:"password,omitempty"`
+	Role         *string `json:"role,omitempty"`
+	Email        *string `json:"email,omitempty"`
+	TOTPRequired *bool   `json:"totp_required,omitempty"`
+}
+
 type PluginUploadFile struct {
 	FileName string
 	Content  []byte
@@ -579,25 +1042,58 @@ func (c *bunkerWebClient) GetGlobalConfig(ctx context.Context, full, methods boo
 	return ensureMap(payload.Settings), nil
 }
 
+// MultisiteMode reports whether BunkerWeb runs in multisite mode. known is
+// false when the API does not expose MULTISITE. Successful lookups are cached
+// for the lifetime of the client.
+func (c *bunkerWebClient) MultisiteMode(ctx context.Context) (enabled bool, known bool, err error) {
+	c.multisiteMu.Lock()
+	defer c.multisiteMu.Unlock()
+
+	if c.multisite != nil {
+		return *c.multisite, true, nil
+	}
+
+	settings, err := c.GetGlobalConfig(ctx, true, false)
+	if err != nil {
+		return false, false, err
+	}
+
+	value, ok := settings["MULTISITE"]
+	if !ok || value == nil {
+		return false, false, nil
+	}
+
+	enabled = isAffirmative(stringifyValue(value))
+	c.multisite = &enabled
+	return enabled, true, nil
+}
+
 func (c *bunkerWebClient) UpdateGlobalConfig(ctx context.Context, settings map[string]any) (map[string]any, error) {
 	if len(settings) == 0 {
 		return nil, fmt.Errorf("at least one setting must be provided")
 	}
 
-	req, err := c.newRequest(ctx, http.MethodPatch, "global_config", settings)
-	if err != nil {
+	if err := c.lockGlobalConfig(ctx); err != nil {
 		return nil, err
 	}
 
 	// PATCH /global_config returns status only; read the settings back so callers
 	// can observe the applied values.
-	if err := c.do(ctx, req, nil); err != nil {
+	if err := c.patchGlobalConfig(ctx, settings); err != nil {
 		return nil, err
 	}
 
 	return c.GetGlobalConfig(ctx, true, false)
 }
 
+func (c *bunkerWebClient) patchGlobalConfig(ctx context.Context, settings map[string]any) error {
+	req, err := c.newRequest(ctx, http.MethodPatch, "global_config", settings)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, nil)
+}
+
 func (c *bunkerWebClient) CreateInstance(ctx context.Context, reqPayload InstanceCreateRequest) (*bunkerWebInstance, error) {
 	req, err := c.newRequest(ctx, http.MethodPost, "instances", reqPayload)
 	if err != nil {
@@ -608,6 +1104,7 @@ func (c *bunkerWebClient) CreateInstance(ctx context.Context, reqPayload Instanc
 	if err := c.do(ctx, req, &payload); err != nil {
 		return nil, err
 	}
+	c.reportUnmodeledFields(ctx, "instance", payload.Instance.unmodeled)
 
 	return &payload.Instance, nil
 }
@@ -622,6 +1119,7 @@ func (c *bunkerWebClient) GetInstance(ctx context.Context, hostname string) (*bu
 	if err := c.do(ctx, req, &payload); err != nil {
 		return nil, err
 	}
+	c.reportUnmodeledFields(ctx, "instance", payload.Instance.unmodeled)
 
 	return &payload.Instance, nil
 }
@@ -636,6 +1134,7 @@ func (c *bunkerWebClient) UpdateInstance(ctx context.Context, hostname string, r
 	if err := c.do(ctx, req, &payload); err != nil {
 		return nil, err
 	}
+	c.reportUnmodeledFields(ctx, "instance", payload.Instance.unmodeled)
 
 	return &payload.Instance, nil
 }
@@ -649,6 +1148,13 @@ func (c *bunkerWebClient) DeleteInstance(ctx context.Context, hostname string) e
 	return c.do(ctx, req, nil)
 }
 
+// BatchDeleteInstance deletes an instance, sharing a DELETE /instances call
+// with the instances deleted at the same time.
+func (c *bunkerWebClient) BatchDeleteInstance(ctx context.Context, hostname string) error {
+	c.initDeleteBatchers()
+	return c.instanceDeletes.delete(ctx, hostname)
+}
+
 func (c *bunkerWebClient) DeleteInstances(ctx context.Context, hostnames []string) error {
 	if len(hostnames) == 0 {
 		return fmt.Errorf("at least one hostname is required")
@@ -673,6 +1179,9 @@ func (c *bunkerWebClient) ListInstances(ctx context.Context) ([]bunkerWebInstanc
 	if err := c.do(ctx, req, &payload); err != nil {
 		return nil, err
 	}
+	for _, instance := range payload.Instances {
+		c.reportUnmodeledFields(ctx, "instance", instance.unmodeled)
+	}
 
 	return payload.Instances, nil
 }
@@ -755,6 +1264,72 @@ func (c *bunkerWebClient) ReloadInstance(ctx context.Context, hostname string, t
 	return ensureMap(payload), nil
 }
 
+// RestartInstance restarts an instance through POST
+// /instances/{hostname}/restart, or by stopping then reloading it when the API
+// has no such endpoint.
+func (c *bunkerWebClient) RestartInstance(ctx context.Context, hostname string) (map[string]any, error) {
+	if strings.TrimSpace(hostname) == "" {
+		return nil, fmt.Errorf("hostname must be provided")
+	}
+
+	endpoint := path.Join("instances", hostname, "restart")
+	found, known := c.HasEndpoint(http.MethodPost, endpoint)
+	if found || !known {
+		req, err := c.newRequest(ctx, http.MethodPost, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var payload map[string]any
+		err = c.do(ctx, req, &payload)
+		if err == nil {
+			return ensureMap(payload), nil
+		}
+		var apiErr *bunkerWebAPIError
+		if known || !errors.As(err, &apiErr) || (apiErr.StatusCode != http.StatusNotFound && apiErr.StatusCode != http.StatusMethodNotAllowed) {
+			return nil, err
+		}
+	}
+
+	stopped, err := c.StopInstance(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("stop %s: %w", hostname, err)
+	}
+	test := false
+	reloaded, err := c.ReloadInstance(ctx, hostname, &test)
+	if err != nil {
+		return nil, fmt.Errorf("start %s after stopping it: %w", hostname, err)
+	}
+
+	return map[string]any{"stop": stopped, "reload": reloaded}, nil
+}
+
+// InstanceHealth reports whether an instance is registered and answers a
+// ping, with the ping latency. An unreachable instance is reported in the
+// payload rather than as an error.
+func (c *bunkerWebClient) InstanceHealth(ctx context.Context, hostname string) (map[string]any, error) {
+	instance, err := c.GetInstance(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	_, pingErr := c.PingInstance(ctx, hostname)
+	health := map[string]any{
+		"hostname":   instance.Hostname,
+		"reachable":  pingErr == nil,
+		"latency_ms": time.Since(start).Milliseconds(),
+	}
+	if instance.Method != nil {
+		health["method"] = *instance.Method
+	}
+	if pingErr != nil {
+		health["error"] = pingErr.Error()
+	}
+
+	return health, nil
+}
+
 func (c *bunkerWebClient) StopInstances(ctx context.Context) (map[string]any, error) {
 	req, err := c.newRequest(ctx, http.MethodPost, "instances/stop", nil)
 	if err != nil {
@@ -805,8 +1380,31 @@ func (c *bunkerWebClient) Unban(ctx context.Context, req UnbanRequest) error {
 	return c.do(ctx, request, nil)
 }
 
-func (c *bunkerWebClient) ListBans(ctx context.Context) ([]bunkerWebBan, error) {
-	req, err := c.newRequest(ctx, http.MethodGet, "bans", nil)
+func (c *bunkerWebClient) ListBans(ctx context.Context, opts BanListOptions) ([]bunkerWebBan, error) {
+	query := url.Values{}
+	if opts.Service != nil {
+		if trimmed := strings.TrimSpace(*opts.Service); trimmed != "" {
+			query.Set("service", trimmed)
+		}
+	}
+	if opts.Reason != nil {
+		if trimmed := strings.TrimSpace(*opts.Reason); trimmed != "" {
+			query.Set("reason", trimmed)
+		}
+	}
+	if opts.Since != nil {
+		query.Set("since", strconv.FormatInt(opts.Since.Unix(), 10))
+	}
+	if opts.Until != nil {
+		query.Set("until", strconv.FormatInt(opts.Until.Unix(), 10))
+	}
+
+	endpoint := "bans"
+	if encoded := query.Encode(); encoded != "" {
+		endpoint = endpoint + "?" + encoded
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -816,7 +1414,30 @@ func (c *bunkerWebClient) ListBans(ctx context.Context) ([]bunkerWebBan, error)
 		return nil, err
 	}
 
-	return payload.Bans, nil
+	// Older API versions ignore the filters, so apply them here as well.
+	return filterBans(payload.Bans, opts), nil
+}
+
+func filterBans(bans []bunkerWebBan, opts BanListOptions) []bunkerWebBan {
+	filtered := bans[:0:0]
+	for _, ban := range bans {
+		if opts.Service != nil && strings.TrimSpace(*opts.Service) != "" {
+			if ban.Service == nil || *ban.Service != strings.TrimSpace(*opts.Service) {
+				continue
+			}
+		}
+		if opts.Reason != nil && strings.TrimSpace(*opts.Reason) != "" && ban.Reason != strings.TrimSpace(*opts.Reason) {
+			continue
+		}
+		if opts.Since != nil && ban.Date != 0 && ban.Date < opts.Since.Unix() {
+			continue
+		}
+		if opts.Until != nil && ban.Date != 0 && ban.Date > opts.Until.Unix() {
+			continue
+		}
+		filtered = append(filtered, ban)
+	}
+	return filtered
 }
 
 func (c *bunkerWebClient) BanBulk(ctx context.Context, reqs []BanRequest) error {
@@ -938,6 +1559,20 @@ func (c *bunkerWebClient) DeleteConfig(ctx context.Context, key ConfigKey) error
 	return c.do(ctx, req, nil)
 }
 
+// BatchDeleteConfig deletes a custom config, sharing a DELETE /configs call
+// with the configs deleted at the same time.
+func (c *bunkerWebClient) BatchDeleteConfig(ctx context.Context, key ConfigKey) error {
+	c.initDeleteBatchers()
+	return c.configDeletes.delete(ctx, key)
+}
+
+func (c *bunkerWebClient) initDeleteBatchers() {
+	c.deleteBatchersOnce.Do(func() {
+		c.configDeletes = newDeleteBatcher("configs", c.DeleteConfigs, c.DeleteConfig)
+		c.instanceDeletes = newDeleteBatcher("instances", c.DeleteInstances, c.DeleteInstance)
+	})
+}
+
 func (c *bunkerWebClient) DeleteConfigs(ctx context.Context, keys []ConfigKey) error {
 	if len(keys) == 0 {
 		return fmt.Errorf("at least one config key is required")
@@ -976,9 +1611,22 @@ func uploadErrorsText(errs []map[string]any) string {
 	return strings.Join(parts, "; ")
 }
 
-// UploadConfigs uploads custom config files and returns the created config
-// identifiers ("service/type/name"); the API does not echo the config objects.
-func (c *bunkerWebClient) UploadConfigs(ctx context.Context, input ConfigUploadRequest) ([]string, error) {
+// ConfigUploadFileError reports a file the upload endpoint rejected.
+type ConfigUploadFileError struct {
+	File    string
+	Message string
+}
+
+// ConfigUploadResult lists the configs created by an upload ("service/type/name",
+// in upload order) and the files that were rejected.
+type ConfigUploadResult struct {
+	Created []string
+	Errors  []ConfigUploadFileError
+}
+
+// UploadConfigs uploads custom config files. The API does not echo the config
+// objects, only their identifiers and per-file errors.
+func (c *bunkerWebClient) UploadConfigs(ctx context.Context, input ConfigUploadRequest) (*ConfigUploadResult, error) {
 	if strings.TrimSpace(input.Type) == "" {
 		return nil, fmt.Errorf("type must be provided")
 	}
@@ -1016,17 +1664,27 @@ func (c *bunkerWebClient) UploadConfigs(ctx context.Context, input ConfigUploadR
 		return nil, fmt.Errorf("finalize multipart body: %w", err)
 	}
 
-	req, err := c.newRawRequest(ctx, http.MethodPost, "configs/upload", body, contentType)
+	uploadCtx, cancel := c.uploadContext(ctx, body.Len())
+	defer cancel()
+	req, err := c.newRawRequest(uploadCtx, http.MethodPost, "configs/upload", body, contentType)
 	if err != nil {
 		return nil, err
 	}
+	trackUploadProgress(uploadCtx, req)
 
 	var payload bunkerWebUploadResult
-	if err := c.do(ctx, req, &payload); err != nil {
+	if err := c.do(uploadCtx, req, &payload); err != nil {
 		return nil, err
 	}
 
-	return payload.Created, nil
+	result := &ConfigUploadResult{Created: payload.Created}
+	for _, e := range payload.Errors {
+		result.Errors = append(result.Errors, ConfigUploadFileError{
+			File:    firstNonEmpty(stringifyValue(e["file"]), stringifyValue(e["filename"]), stringifyValue(e["name"])),
+			Message: firstNonEmpty(stringifyValue(e["error"]), stringifyValue(e["message"]), "rejected"),
+		})
+	}
+	return result, nil
 }
 
 func (c *bunkerWebClient) UpdateConfigFromUpload(ctx context.Context, key ConfigKey, input ConfigUploadUpdateRequest) (*bunkerWebConfig, error) {
@@ -1067,14 +1725,17 @@ func (c *bunkerWebClient) UpdateConfigFromUpload(ctx context.Context, key Config
 	}
 
 	endpoint := path.Join(configPath(key), "upload")
-	req, err := c.newRawRequest(ctx, http.MethodPatch, endpoint, body, contentType)
+	uploadCtx, cancel := c.uploadContext(ctx, body.Len())
+	defer cancel()
+	req, err := c.newRawRequest(uploadCtx, http.MethodPatch, endpoint, body, contentType)
 	if err != nil {
 		return nil, err
 	}
+	trackUploadProgress(uploadCtx, req)
 
 	// PATCH .../upload returns only {"status":"success"}; read the (possibly
 	// renamed) config back to report its current state.
-	if err := c.do(ctx, req, nil); err != nil {
+	if err := c.do(uploadCtx, req, nil); err != nil {
 		return nil, err
 	}
 
@@ -1110,7 +1771,9 @@ func (c *bunkerWebClient) ConvertService(ctx context.Context, id string, convert
 
 	// Convert returns {"status":"success","changed_plugins":[...]} with no service
 	// object; derive the resulting draft flag from the requested target state.
-	if err := c.do(ctx, req, nil); err != nil {
+	err = c.do(ctx, req, nil)
+	c.forgetServices()
+	if err != nil {
 		return nil, err
 	}
 
@@ -1150,6 +1813,58 @@ func (c *bunkerWebClient) ListPlugins(ctx context.Context, pluginType string, wi
 	return payload.Plugins, nil
 }
 
+// GetPluginPackage returns an installed plugin together with its decoded
+// package, fetched from GET /plugins?with_data=true.
+func (c *bunkerWebClient) GetPluginPackage(ctx context.Context, id string) (*bunkerWebPlugin, []byte, error) {
+	plugins, err := c.ListPlugins(ctx, "all", true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range plugins {
+		plugin := &plugins[i]
+		if plugin.ID != id {
+			continue
+		}
+		if plugin.Data == "" {
+			return nil, nil, fmt.Errorf("plugin %q has no stored package; %s plugins ship with BunkerWeb", id, firstNonEmpty(plugin.Type, "core"))
+		}
+		content, err := base64.StdEncoding.DecodeString(plugin.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode package of plugin %q: %w", id, err)
+		}
+		return plugin, content, nil
+	}
+
+	return nil, nil, &bunkerWebAPIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("plugin %q not found", id)}
+}
+
+// SettingTypes returns the catalogue type ("check", "number", "text", ...) of
+// every setting declared by the installed plugins. The catalogue is fetched
+// once per client.
+func (c *bunkerWebClient) SettingTypes(ctx context.Context) (map[string]string, error) {
+	c.settingTypesMu.Lock()
+	defer c.settingTypesMu.Unlock()
+
+	if c.settingTypes != nil {
+		return c.settingTypes, nil
+	}
+
+	plugins, err := c.ListPlugins(ctx, "all", false)
+	if err != nil {
+		return nil, err
+	}
+
+	settingTypes := map[string]string{}
+	for _, plugin := range plugins {
+		for name, setting := range plugin.Settings {
+			settingTypes[name] = setting.Type
+		}
+	}
+	c.settingTypes = settingTypes
+	return settingTypes, nil
+}
+
 // UploadPlugins uploads plugin archives and returns the created plugin ids; the
 // API does not echo plugin objects.
 func (c *bunkerWebClient) UploadPlugins(ctx context.Context, input PluginUploadRequest) ([]string, error) {
@@ -1186,13 +1901,16 @@ func (c *bunkerWebClient) UploadPlugins(ctx context.Context, input PluginUploadR
 		return nil, fmt.Errorf("finalize multipart body: %w", err)
 	}
 
-	req, err := c.newRawRequest(ctx, http.MethodPost, "plugins/upload", body, contentType)
+	uploadCtx, cancel := c.uploadContext(ctx, body.Len())
+	defer cancel()
+	req, err := c.newRawRequest(uploadCtx, http.MethodPost, "plugins/upload", body, contentType)
 	if err != nil {
 		return nil, err
 	}
+	trackUploadProgress(uploadCtx, req)
 
 	var payload bunkerWebUploadResult
-	if err := c.do(ctx, req, &payload); err != nil {
+	if err := c.do(uploadCtx, req, &payload); err != nil {
 		return nil, err
 	}
 
@@ -1237,6 +1955,21 @@ func (c *bunkerWebClient) ListCacheEntries(ctx context.Context, filters url.Valu
 	return payload.Cache, nil
 }
 
+// DeleteCacheFiles removes job cache files, for example to force a job to
+// regenerate them on its next run.
+func (c *bunkerWebClient) DeleteCacheFiles(ctx context.Context, keys []CacheFileKey) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("at least one cache file is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodDelete, "cache", CacheFilesDeleteRequest{CacheFiles: keys})
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, nil)
+}
+
 func (c *bunkerWebClient) ListJobs(ctx context.Context) ([]bunkerWebJob, error) {
 	req, err := c.newRequest(ctx, http.MethodGet, "jobs", nil)
 	if err != nil {
@@ -1251,6 +1984,127 @@ func (c *bunkerWebClient) ListJobs(ctx context.Context) ([]bunkerWebJob, error)
 	return payload.Jobs, nil
 }
 
+// ListJobRuns returns past job executions, most recent first.
+func (c *bunkerWebClient) ListJobRuns(ctx context.Context, opts JobRunListOptions) ([]bunkerWebJobRun, error) {
+	query := url.Values{}
+	if opts.Plugin != nil {
+		if trimmed := strings.TrimSpace(*opts.Plugin); trimmed != "" {
+			query.Set("plugin", trimmed)
+		}
+	}
+	if opts.Name != nil {
+		if trimmed := strings.TrimSpace(*opts.Name); trimmed != "" {
+			query.Set("name", trimmed)
+		}
+	}
+	if opts.Since != nil {
+		query.Set("since", strconv.FormatInt(opts.Since.Unix(), 10))
+	}
+
+	endpoint := "jobs/history"
+	if encoded := query.Encode(); encoded != "" {
+		endpoint = endpoint + "?" + encoded
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebJobRunsPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	runs := filterJobRuns(payload.Runs, opts)
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartDate > runs[j].StartDate })
+	return runs, nil
+}
+
+func filterJobRuns(runs []bunkerWebJobRun, opts JobRunListOptions) []bunkerWebJobRun {
+	filtered := runs[:0:0]
+	for _, run := range runs {
+		if opts.Plugin != nil && strings.TrimSpace(*opts.Plugin) != "" && run.Plugin != strings.TrimSpace(*opts.Plugin) {
+			continue
+		}
+		if opts.Name != nil && strings.TrimSpace(*opts.Name) != "" && run.Name != strings.TrimSpace(*opts.Name) {
+			continue
+		}
+		if opts.Since != nil && run.StartDate != 0 && run.StartDate < opts.Since.Unix() {
+			continue
+		}
+		filtered = append(filtered, run)
+	}
+	return filtered
+}
+
+// GetLogs returns the latest scheduler or instance log lines, oldest first.
+func (c *bunkerWebClient) GetLogs(ctx context.Context, opts LogsOptions) ([]string, error) {
+	query := url.Values{}
+	if opts.Service != nil {
+		if trimmed := strings.TrimSpace(*opts.Service); trimmed != "" {
+			query.Set("service", trimmed)
+		}
+	}
+	if opts.Lines != nil {
+		query.Set("lines", strconv.Itoa(*opts.Lines))
+	}
+
+	endpoint := "logs/scheduler"
+	if instance := strings.TrimSpace(opts.Instance); instance != "" {
+		endpoint = path.Join("instances", instance, "logs")
+	}
+	if encoded := query.Encode(); encoded != "" {
+		endpoint = endpoint + "?" + encoded
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebLogsPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	return payload.Logs, nil
+}
+
+// GetMetrics returns the metrics aggregated by the API for a plugin.
+func (c *bunkerWebClient) GetMetrics(ctx context.Context, plugin string) (map[string]any, error) {
+	if strings.TrimSpace(plugin) == "" {
+		return nil, fmt.Errorf("plugin must be provided")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path.Join("metrics", plugin), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebMetricsPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	return ensureMap(payload.Metrics), nil
+}
+
+// ListRequests returns the blocked requests recorded by the instances.
+func (c *bunkerWebClient) ListRequests(ctx context.Context) ([]bunkerWebRequestRecord, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "metrics/requests", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebRequestsPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	return payload.Requests, nil
+}
+
 func (c *bunkerWebClient) RunJobs(ctx context.Context, jobs []JobItem) error {
 	if len(jobs) == 0 {
 		return fmt.Errorf("at least one job is required")
@@ -1312,7 +2166,145 @@ func (c *bunkerWebClient) Health(ctx context.Context) (map[string]any, error) {
 	return payload, nil
 }
 
+func (c *bunkerWebClient) CreateAPICredential(ctx context.Context, reqPayload APICredentialCreateRequest) (*bunkerWebAPICredential, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "api_credentials", reqPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebAPICredentialPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload.Credential, nil
+}
+
+func (c *bunkerWebClient) GetAPICredential(ctx context.Context, id string) (*bunkerWebAPICredential, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path.Join("api_credentials", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebAPICredentialPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload.Credential, nil
+}
+
+func (c *bunkerWebClient) DeleteAPICredential(ctx context.Context, id string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, path.Join("api_credentials", id), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, nil)
+}
+
+func (c *bunkerWebClient) CreateUser(ctx context.Context, reqPayload UserCreateRequest) (*bunkerWebUser, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "users", reqPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebUserPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload.User, nil
+}
+
+func (c *bunkerWebClient) GetUser(ctx context.Context, username string) (*bunkerWebUser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path.Join("users", username), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebUserPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload.User, nil
+}
+
+func (c *bunkerWebClient) UpdateUser(ctx context.Context, username string, reqPayload UserUpdateRequest) (*bunkerWebUser, error) {
+	req, err := c.newRequest(ctx, http.MethodPatch, path.Join("users", username), reqPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload bunkerWebUserPayload
+	if err := c.do(ctx, req, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload.User, nil
+}
+
+func (c *bunkerWebClient) DeleteUser(ctx context.Context, username string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, path.Join("users", username), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, nil)
+}
+
+// Login exchanges credentials for an API token and uses it for subsequent
+// requests.
 func (c *bunkerWebClient) Login(ctx context.Context, username, password string) (string, error) {
+	token, err := c.IssueToken(ctx, username, password)
+	if err != nil {
+		return "", err
+	}
+
+	c.tokenMu.Lock()
+	c.apiToken = token
+	c.tokenMu.Unlock()
+
+	return token, nil
+}
+
+// token returns the bearer token requests are currently sent with.
+func (c *bunkerWebClient) token() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.apiToken
+}
+
+// reauthorize returns a copy of req carrying the current token when req was
+// rejected with a token that Login has replaced since, so requests in flight
+// during a login pick up the new token instead of failing. It returns nil
+// when there is nothing newer to retry with or the body cannot be replayed.
+func (c *bunkerWebClient) reauthorize(req *http.Request) *http.Request {
+	sent, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	current := c.token()
+	if !ok || current == "" || current == sent {
+		return nil
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", "Bearer "+current)
+	return retry
+}
+
+// IssueToken exchanges credentials for an API token via POST /auth without
+// changing how the client authenticates.
+func (c *bunkerWebClient) IssueToken(ctx context.Context, username, password string) (string, error) {
 	if strings.TrimSpace(username) == "" {
 		return "", fmt.Errorf("username must be provided")
 	}
@@ -1339,7 +2331,5 @@ func (c *bunkerWebClient) Login(ctx context.Context, username, password string)
 		return "", err
 	}
 
-	c.apiToken = payload.Token
-
 	return payload.Token, nil
 }
@@ -0,0 +1,86 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/url"
+)
+
+//go:generate go run ./internal/mockgen -interface BunkerWebAPI -type mockBunkerWebAPI -out mock_api_test.go
+
+var _ BunkerWebAPI = (*bunkerWebClient)(nil)
+
+// BunkerWebAPI is what resources, data sources, ephemeral resources and
+// actions need from the BunkerWeb API. bunkerWebClient implements it over
+// HTTP; mockBunkerWebAPI, generated from this interface, lets tests exercise
+// resource logic without the fake API server. Provider settings that involve
+// no request live in providerState instead.
+type BunkerWebAPI interface {
+	Ban(ctx context.Context, req BanRequest) error
+	BanBulk(ctx context.Context, reqs []BanRequest) error
+	BatchDeleteConfig(ctx context.Context, key ConfigKey) error
+	BatchDeleteInstance(ctx context.Context, hostname string) error
+	Call(ctx context.Context, method, endpoint string, body []byte) (int, []byte, error)
+	CheckFeature(feature endpointFeature) error
+	ConvertService(ctx context.Context, id string, convertTo string) (*bunkerWebService, error)
+	CreateAPICredential(ctx context.Context, reqPayload APICredentialCreateRequest) (*bunkerWebAPICredential, error)
+	CreateConfig(ctx context.Context, input ConfigCreateRequest) (*bunkerWebConfig, error)
+	CreateInstance(ctx context.Context, reqPayload InstanceCreateRequest) (*bunkerWebInstance, error)
+	CreateService(ctx context.Context, reqPayload ServiceCreateRequest) (*bunkerWebService, error)
+	CreateUser(ctx context.Context, reqPayload UserCreateRequest) (*bunkerWebUser, error)
+	DeleteAPICredential(ctx context.Context, id string) error
+	DeleteCacheFiles(ctx context.Context, keys []CacheFileKey) error
+	DeleteConfig(ctx context.Context, key ConfigKey) error
+	DeleteConfigs(ctx context.Context, keys []ConfigKey) error
+	DeleteInstances(ctx context.Context, hostnames []string) error
+	DeletePlugin(ctx context.Context, pluginID string) error
+	DeleteService(ctx context.Context, id string) error
+	DeleteUser(ctx context.Context, username string) error
+	GetAPICredential(ctx context.Context, id string) (*bunkerWebAPICredential, error)
+	GetConfig(ctx context.Context, key ConfigKey, withData bool) (*bunkerWebConfig, error)
+	GetGlobalConfig(ctx context.Context, full, methods bool) (map[string]any, error)
+	GetInstance(ctx context.Context, hostname string) (*bunkerWebInstance, error)
+	GetLogs(ctx context.Context, opts LogsOptions) ([]string, error)
+	GetMetrics(ctx context.Context, plugin string) (map[string]any, error)
+	GetPluginPackage(ctx context.Context, id string) (*bunkerWebPlugin, []byte, error)
+	GetService(ctx context.Context, id string) (*bunkerWebServiceConfig, error)
+	GetServiceSummary(ctx context.Context, id string) (*bunkerWebService, error)
+	GetUser(ctx context.Context, username string) (*bunkerWebUser, error)
+	Health(ctx context.Context) (map[string]any, error)
+	InstanceHealth(ctx context.Context, hostname string) (map[string]any, error)
+	IssueToken(ctx context.Context, username, password string) (string, error)
+	ListBans(ctx context.Context, opts BanListOptions) ([]bunkerWebBan, error)
+	ListCacheEntries(ctx context.Context, filters url.Values) ([]bunkerWebCacheEntry, error)
+	ListConfigs(ctx context.Context, opts ConfigListOptions) ([]bunkerWebConfig, error)
+	ListInstances(ctx context.Context) ([]bunkerWebInstance, error)
+	ListJobRuns(ctx context.Context, opts JobRunListOptions) ([]bunkerWebJobRun, error)
+	ListJobs(ctx context.Context) ([]bunkerWebJob, error)
+	ListPlugins(ctx context.Context, pluginType string, withData bool) ([]bunkerWebPlugin, error)
+	ListRequests(ctx context.Context) ([]bunkerWebRequestRecord, error)
+	ListServices(ctx context.Context, includeDrafts bool) ([]bunkerWebService, error)
+	MultisiteMode(ctx context.Context) (enabled bool, known bool, err error)
+	Ping(ctx context.Context) (map[string]any, error)
+	PingInstance(ctx context.Context, hostname string) (map[string]any, error)
+	PingInstances(ctx context.Context) (map[string]any, error)
+	ReloadInstance(ctx context.Context, hostname string, test *bool) (map[string]any, error)
+	ReloadInstances(ctx context.Context, test *bool) (map[string]any, error)
+	RestartInstance(ctx context.Context, hostname string) (map[string]any, error)
+	RunJobs(ctx context.Context, jobs []JobItem) error
+	ServiceExists(ctx context.Context, id string) (bool, error)
+	SettingTypes(ctx context.Context) (map[string]string, error)
+	StopInstance(ctx context.Context, hostname string) (map[string]any, error)
+	StopInstances(ctx context.Context) (map[string]any, error)
+	SupportsFeature(feature endpointFeature) bool
+	Unban(ctx context.Context, req UnbanRequest) error
+	UnbanBulk(ctx context.Context, reqs []UnbanRequest) error
+	UpdateConfig(ctx context.Context, key ConfigKey, input ConfigUpdateRequest) (*bunkerWebConfig, error)
+	UpdateConfigFromUpload(ctx context.Context, key ConfigKey, input ConfigUploadUpdateRequest) (*bunkerWebConfig, error)
+	UpdateGlobalConfig(ctx context.Context, settings map[string]any) (map[string]any, error)
+	UpdateInstance(ctx context.Context, hostname string, reqPayload InstanceUpdateRequest) (*bunkerWebInstance, error)
+	UpdateService(ctx context.Context, id string, reqPayload ServiceUpdateRequest) (*bunkerWebService, error)
+	UpdateUser(ctx context.Context, username string, reqPayload UserUpdateRequest) (*bunkerWebUser, error)
+	UploadConfigs(ctx context.Context, input ConfigUploadRequest) (*ConfigUploadResult, error)
+	UploadPlugins(ctx context.Context, input PluginUploadRequest) ([]string, error)
+}
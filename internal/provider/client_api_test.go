@@ -0,0 +1,293 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newMockBunkerWebAPI returns a mock whose methods are left to each test.
+func newMockBunkerWebAPI(t *testing.T) *mockBunkerWebAPI {
+	return &mockBunkerWebAPI{t: t}
+}
+
+// mockResourceValue returns the schema of r and a value of it setting the
+// given attributes and leaving the others null.
+func mockResourceValue(t *testing.T, r resource.Resource, attributes map[string]tftypes.Value) (schema.Schema, tftypes.Value) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema: %v", schemaResp.Diagnostics)
+	}
+
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	for name := range attributes {
+		if _, ok := objType.AttributeTypes[name]; !ok {
+			t.Fatalf("no attribute %s in the schema", name)
+		}
+	}
+	values := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+	return schemaResp.Schema, tftypes.NewValue(objType, values)
+}
+
+func TestMultisiteMismatchMock(t *testing.T) {
+	api := newMockBunkerWebAPI(t)
+	ctx := context.Background()
+	attr := path.Root("variables")
+
+	api.MultisiteModeFunc = func(context.Context) (bool, bool, error) { return false, true, nil }
+	if diags := multisiteMismatch(ctx, api, attr, true, "A service setting"); diags.WarningsCount() != 1 || diags.HasError() {
+		t.Fatalf("expected a single-site warning, got %v", diags)
+	}
+	if diags := multisiteMismatch(ctx, api, attr, false, "A global setting"); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics when the mode matches, got %v", diags)
+	}
+
+	api.MultisiteModeFunc = func(context.Context) (bool, bool, error) { return false, false, errors.New("unreachable") }
+	if diags := multisiteMismatch(ctx, api, attr, true, "A service setting"); len(diags) != 0 {
+		t.Fatalf("expected lookup failures to be ignored, got %v", diags)
+	}
+}
+
+func TestApplyCustomConfigsMock(t *testing.T) {
+	api := newMockBunkerWebAPI(t)
+	var updated, deleted []string
+	api.CreateConfigFunc = func(context.Context, ConfigCreateRequest) (*bunkerWebConfig, error) {
+		return nil, &bunkerWebAPIError{StatusCode: http.StatusConflict, Message: "already exists"}
+	}
+	api.UpdateConfigFunc = func(_ context.Context, key ConfigKey, input ConfigUpdateRequest) (*bunkerWebConfig, error) {
+		updated = append(updated, key.Type+"/"+key.Name+"="+*input.Data)
+		return &bunkerWebConfig{}, nil
+	}
+	api.DeleteConfigFunc = func(_ context.Context, key ConfigKey) error {
+		deleted = append(deleted, key.Type+"/"+key.Name)
+		return &bunkerWebAPIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+
+	config := func(name, data string) serviceCustomConfigModel {
+		return serviceCustomConfigModel{Type: types.StringValue("server_http"), Name: types.StringValue(name), Data: types.StringValue(data)}
+	}
+	prior := []serviceCustomConfigModel{config("kept", "a"), config("removed", "b")}
+	planned := []serviceCustomConfigModel{config("kept", "a"), config("added", "c")}
+
+	created, err := applyCustomConfigs(context.Background(), api, "app.example.com", prior, planned)
+	if err != nil {
+		t.Fatalf("applyCustomConfigs: %v", err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("expected the overwritten config not to be reported as created, got %v", created)
+	}
+	if !slices.Equal(updated, []string{"server_http/added=c"}) {
+		t.Fatalf("expected the conflicting create to be turned into an update, got %v", updated)
+	}
+	if !slices.Equal(deleted, []string{"server_http/removed"}) {
+		t.Fatalf("expected the removed config to be deleted, got %v", deleted)
+	}
+	if calls := api.Calls(); !slices.Equal(calls, []string{"CreateConfig", "UpdateConfig", "DeleteConfig"}) {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
+
+func TestServiceCreateRollbackKeepsExistingConfigsMock(t *testing.T) {
+	api := newMockBunkerWebAPI(t)
+	var deleted []string
+	api.CreateServiceFunc = func(context.Context, ServiceCreateRequest) (*bunkerWebService, error) {
+		return &bunkerWebService{ID: "app.example.com", ServerName: "app.example.com"}, nil
+	}
+	api.CreateConfigFunc = func(_ context.Context, input ConfigCreateRequest) (*bunkerWebConfig, error) {
+		switch input.Name {
+		case "seeded":
+			return nil, &bunkerWebAPIError{StatusCode: http.StatusConflict, Message: "already exists"}
+		case "broken":
+			return nil, &bunkerWebAPIError{StatusCode: http.StatusBadRequest, Message: "invalid config"}
+		}
+		return &bunkerWebConfig{}, nil
+	}
+	api.UpdateConfigFunc = func(context.Context, ConfigKey, ConfigUpdateRequest) (*bunkerWebConfig, error) {
+		return &bunkerWebConfig{}, nil
+	}
+	api.DeleteConfigFunc = func(_ context.Context, key ConfigKey) error {
+		deleted = append(deleted, key.Type+"/"+key.Name)
+		return nil
+	}
+	api.DeleteServiceFunc = func(context.Context, string) error { return nil }
+
+	r := &BunkerWebResource{client: api, state: &providerState{}}
+	configType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"type": tftypes.String, "name": tftypes.String, "data": tftypes.String}}
+	config := func(name string) tftypes.Value {
+		return tftypes.NewValue(configType, map[string]tftypes.Value{
+			"type": tftypes.NewValue(tftypes.String, "server_http"),
+			"name": tftypes.NewValue(tftypes.String, name),
+			"data": tftypes.NewValue(tftypes.String, "# "+name),
+		})
+	}
+	s, plan := mockResourceValue(t, r, map[string]tftypes.Value{
+		"server_name":    tftypes.NewValue(tftypes.String, "app.example.com"),
+		"custom_configs": tftypes.NewValue(tftypes.List{ElementType: configType}, []tftypes.Value{config("seeded"), config("added"), config("broken")}),
+	})
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the failing custom config to fail the create")
+	}
+	if !slices.Equal(deleted, []string{"server_http/added"}) {
+		t.Fatalf("expected only the created config to be rolled back, got %v", deleted)
+	}
+	if calls := api.Calls(); calls[len(calls)-1] != "DeleteService" {
+		t.Fatalf("expected the service to be rolled back, got %v", calls)
+	}
+}
+
+func TestServiceCreateAdoptsOnConflictMock(t *testing.T) {
+	api := newMockBunkerWebAPI(t)
+	var adopted ServiceUpdateRequest
+	api.CreateServiceFunc = func(context.Context, ServiceCreateRequest) (*bunkerWebService, error) {
+		return nil, &bunkerWebAPIError{StatusCode: http.StatusConflict, Message: "service already exists"}
+	}
+	api.UpdateServiceFunc = func(_ context.Context, id string, req ServiceUpdateRequest) (*bunkerWebService, error) {
+		adopted = req
+		return &bunkerWebService{ID: id, ServerName: *req.ServerName, Variables: req.Variables}, nil
+	}
+	api.GetServiceSummaryFunc = func(_ context.Context, id string) (*bunkerWebService, error) {
+		return &bunkerWebService{ID: id, Method: "api"}, nil
+	}
+
+	r := &BunkerWebResource{client: api, state: &providerState{}}
+	s, plan := mockResourceValue(t, r, map[string]tftypes.Value{
+		"server_name":    tftypes.NewValue(tftypes.String, "app.example.com"),
+		"adopt_existing": tftypes.NewValue(tftypes.Bool, true),
+		"variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"USE_GZIP": tftypes.NewValue(tftypes.String, "yes"),
+		}),
+	})
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", resp.Diagnostics)
+	}
+	if adopted.Variables["USE_GZIP"] != "yes" || adopted.ServerName == nil || *adopted.ServerName != "app.example.com" {
+		t.Fatalf("expected the existing service to be updated with the planned settings, got %+v", adopted)
+	}
+	var id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+	if id.ValueString() != "app.example.com" {
+		t.Fatalf("expected the adopted service in state, got %s", id)
+	}
+}
+
+func TestConfigUpdateRecreatesOnDriftMock(t *testing.T) {
+	api := newMockBunkerWebAPI(t)
+	var created []ConfigCreateRequest
+	api.UpdateConfigFunc = func(context.Context, ConfigKey, ConfigUpdateRequest) (*bunkerWebConfig, error) {
+		return nil, &bunkerWebAPIError{StatusCode: http.StatusNotFound, Message: "config not found"}
+	}
+	api.CreateConfigFunc = func(_ context.Context, input ConfigCreateRequest) (*bunkerWebConfig, error) {
+		created = append(created, input)
+		return nil, nil
+	}
+	api.GetConfigFunc = func(_ context.Context, key ConfigKey, _ bool) (*bunkerWebConfig, error) {
+		return &bunkerWebConfig{Service: *key.Service, Type: key.Type, Name: key.Name, Data: "deny all;", Method: "api"}, nil
+	}
+
+	r := &BunkerWebConfigResource{client: api, state: &providerState{}}
+	attributes := map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, "app.example.com/server_http/blocked"),
+		"service":           tftypes.NewValue(tftypes.String, "app.example.com"),
+		"type":              tftypes.NewValue(tftypes.String, "server_http"),
+		"name":              tftypes.NewValue(tftypes.String, "blocked"),
+		"data":              tftypes.NewValue(tftypes.String, "deny all;"),
+		"recreate_on_drift": tftypes.NewValue(tftypes.Bool, true),
+	}
+	s, plan := mockResourceValue(t, r, attributes)
+
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+		State:  tfsdk.State{Schema: s, Raw: plan},
+		Config: tfsdk.Config{Schema: s, Raw: plan},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", resp.Diagnostics)
+	}
+	if len(created) != 1 || created[0].Name != "blocked" || created[0].Data != "deny all;" {
+		t.Fatalf("expected the config deleted out-of-band to be created again, got %+v", created)
+	}
+	if calls := api.Calls(); !slices.Equal(calls, []string{"UpdateConfig", "CreateConfig", "GetConfig"}) {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
+
+func TestServiceReadRemovesMissingMock(t *testing.T) {
+	api := newMockBunkerWebAPI(t)
+	api.GetServiceFunc = func(context.Context, string) (*bunkerWebServiceConfig, error) {
+		return nil, &bunkerWebAPIError{StatusCode: http.StatusNotFound, Message: "service not found"}
+	}
+
+	r := &BunkerWebResource{client: api, state: &providerState{}}
+	s, state := mockResourceValue(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "app.example.com"),
+		"server_name": tftypes.NewValue(tftypes.String, "app.example.com"),
+	})
+
+	resp := resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+	r.Read(context.Background(), resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Fatalf("expected the missing service to be removed from state, got %v", resp.State.Raw)
+	}
+}
+
+func TestReloadInstancesMock(t *testing.T) {
+	api := newMockBunkerWebAPI(t)
+	api.ReloadInstanceFunc = func(_ context.Context, hostname string, _ *bool) (map[string]any, error) {
+		if hostname == "bw-2" {
+			return nil, &bunkerWebAPIError{StatusCode: http.StatusBadGateway, Message: "instance unreachable"}
+		}
+		return map[string]any{"status": "success"}, nil
+	}
+
+	if _, err := reloadInstances(context.Background(), api, []string{"bw-1", "bw-2", "bw-3"}, nil); err == nil || !strings.Contains(err.Error(), "instance unreachable") {
+		t.Fatalf("expected the failed reload to be reported, got %v", err)
+	}
+	if calls := api.Calls(); !slices.Equal(calls, []string{"ReloadInstance", "ReloadInstance"}) {
+		t.Fatalf("expected the reloads to stop at the first failure, got %v", calls)
+	}
+}
+
+func TestValidateGlobalSettingsCatalogueUnavailable(t *testing.T) {
+	api := newMockBunkerWebAPI(t)
+	api.ListPluginsFunc = func(context.Context, string, bool) ([]bunkerWebPlugin, error) {
+		return nil, &bunkerWebAPIError{StatusCode: http.StatusServiceUnavailable, Message: "database locked"}
+	}
+
+	diags := validateGlobalSettings(context.Background(), api, path.Root("settings"), map[string]string{"USE_GZIP": "yes"})
+	if diags.HasError() || diags.WarningsCount() != 1 || !strings.Contains(diags[0].Detail(), "database locked") {
+		t.Fatalf("expected a warning and no error, got %v", diags)
+	}
+}
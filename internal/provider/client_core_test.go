@@ -8,9 +8,13 @@ import (
 	"encoding/base64"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestBunkerWebClientPing(t *testing.T) {
@@ -115,6 +119,87 @@ func TestBunkerWebClientLoginValidation(t *testing.T) {
 	}
 }
 
+func TestBunkerWebClientRetriesWithRefreshedToken(t *testing.T) {
+	var client *bunkerWebClient
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Header.Get("Authorization") {
+		case "Bearer fresh":
+			_, _ = w.Write([]byte(`{"status":"success"}`))
+		case "Bearer revoked":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			// Another goroutine logs in while this request is in flight.
+			client.tokenMu.Lock()
+			client.apiToken = "fresh"
+			client.tokenMu.Unlock()
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status":"error","message":"invalid token"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	var err error
+	client, err = newBunkerWebClient(server.URL, nil, "stale", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := client.Ban(ctx, BanRequest{IP: "192.0.2.1"}); err != nil {
+		t.Fatalf("expected the request to be resent with the refreshed token, got %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+
+	// A token that is still current is rejected without a retry.
+	hits.Store(0)
+	client.tokenMu.Lock()
+	client.apiToken = "revoked"
+	client.tokenMu.Unlock()
+	var apiErr *bunkerWebAPIError
+	if _, err := client.Ping(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("expected a single request, got %d", got)
+	}
+}
+
+func TestBunkerWebClientConcurrentLogin(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Login(ctx, "admin", "secret"); err != nil {
+				t.Errorf("Login: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := client.Ping(ctx); err != nil {
+				t.Errorf("Ping: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := client.token(); got != "token-admin" {
+		t.Fatalf("unexpected token: %s", got)
+	}
+}
+
 func TestBunkerWebClientDeleteInstances(t *testing.T) {
 	api := newFakeBunkerWebAPI(t)
 	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
@@ -258,6 +343,78 @@ func TestBunkerWebClientInstanceStopActions(t *testing.T) {
 	}
 }
 
+func TestBunkerWebClientRestartInstance(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.CreateInstance(ctx, InstanceCreateRequest{Hostname: "edge-1"}); err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+
+	// Without a restart endpoint the instance is stopped then reloaded.
+	payload, err := client.RestartInstance(ctx, "edge-1")
+	if err != nil {
+		t.Fatalf("RestartInstance fallback: %v", err)
+	}
+	if _, ok := payload["stop"]; !ok {
+		t.Fatalf("expected fallback payload to include the stop response, got %v", payload)
+	}
+	if hosts := api.StopHosts(); len(hosts) != 1 || hosts[0] != "edge-1" {
+		t.Fatalf("expected edge-1 to be stopped, got %v", hosts)
+	}
+	calls := api.ReloadHostCalls()
+	if len(calls) != 1 || calls[0].host != "edge-1" || calls[0].test {
+		t.Fatalf("expected a non-test reload of edge-1, got %v", calls)
+	}
+
+	api.SetRestartEndpoint(true)
+	if _, err := client.RestartInstance(ctx, "edge-1"); err != nil {
+		t.Fatalf("RestartInstance: %v", err)
+	}
+	if hosts := api.RestartHosts(); len(hosts) != 1 || hosts[0] != "edge-1" {
+		t.Fatalf("expected the restart endpoint to be called for edge-1, got %v", hosts)
+	}
+	if hosts := api.StopHosts(); len(hosts) != 1 {
+		t.Fatalf("expected no further stop calls, got %v", hosts)
+	}
+
+	if _, err := client.RestartInstance(ctx, "missing"); err == nil {
+		t.Fatalf("expected restarting an unknown instance to fail")
+	}
+}
+
+func TestBunkerWebClientInstanceHealth(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.CreateInstance(ctx, InstanceCreateRequest{Hostname: "edge-1"}); err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+
+	health, err := client.InstanceHealth(ctx, "edge-1")
+	if err != nil {
+		t.Fatalf("InstanceHealth: %v", err)
+	}
+	if health["hostname"] != "edge-1" || health["reachable"] != true {
+		t.Fatalf("expected edge-1 to be reachable, got %v", health)
+	}
+	if _, ok := health["latency_ms"]; !ok {
+		t.Fatalf("expected latency_ms in %v", health)
+	}
+
+	if _, err := client.InstanceHealth(ctx, "missing"); err == nil {
+		t.Fatalf("expected health of an unknown instance to fail")
+	}
+}
+
 func TestBunkerWebClientConvertService(t *testing.T) {
 	api := newFakeBunkerWebAPI(t)
 	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
@@ -404,10 +561,14 @@ func TestBunkerWebClientUploadConfigs(t *testing.T) {
 		{FileName: "Extra.cfg", Content: []byte("content-2")},
 	}
 
-	created, err := client.UploadConfigs(ctx, ConfigUploadRequest{Service: "web", Type: "http", Files: files})
+	result, err := client.UploadConfigs(ctx, ConfigUploadRequest{Service: "web", Type: "http", Files: files})
 	if err != nil {
 		t.Fatalf("UploadConfigs: %v", err)
 	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("expected no upload errors, got %#v", result.Errors)
+	}
+	created := result.Created
 
 	if len(created) != 2 {
 		t.Fatalf("expected two created configs, got %d", len(created))
@@ -590,7 +751,7 @@ func TestBunkerWebClientBanBulk(t *testing.T) {
 		t.Fatalf("expected one batch of two bans, got %#v", created)
 	}
 
-	bansList, err := client.ListBans(ctx)
+	bansList, err := client.ListBans(ctx, BanListOptions{})
 	if err != nil {
 		t.Fatalf("ListBans: %v", err)
 	}
@@ -608,7 +769,7 @@ func TestBunkerWebClientBanBulk(t *testing.T) {
 		t.Fatalf("expected one batch of two unbans, got %#v", deleted)
 	}
 
-	remaining, err := client.ListBans(ctx)
+	remaining, err := client.ListBans(ctx, BanListOptions{})
 	if err != nil {
 		t.Fatalf("ListBans after unban: %v", err)
 	}
@@ -767,3 +928,241 @@ func TestBunkerWebClientGetServiceConfig(t *testing.T) {
 		t.Fatalf("serviceFromConfig reconstruction mismatch: %#v", svc)
 	}
 }
+
+func TestBunkerWebClientGetServiceSummary(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.CreateService(ctx, ServiceCreateRequest{ServerName: "app.example.com", IsDraft: true}); err != nil {
+		t.Fatalf("CreateService: %v", err)
+	}
+
+	summary, err := client.GetServiceSummary(ctx, "app.example.com")
+	if err != nil {
+		t.Fatalf("GetServiceSummary: %v", err)
+	}
+	if summary == nil || summary.Method != "api" || summary.CreationDate == 0 || summary.LastUpdate == 0 {
+		t.Fatalf("expected method and dates for the draft service, got %#v", summary)
+	}
+
+	method, created, updated := serviceMetadata(summary)
+	if method.ValueString() != "api" || created.IsNull() || updated.IsNull() {
+		t.Fatalf("unexpected metadata values: %s %s %s", method, created, updated)
+	}
+
+	summary, err = client.GetServiceSummary(ctx, "missing.example.com")
+	if err != nil || summary != nil {
+		t.Fatalf("expected no summary for an unknown service, got %#v, %v", summary, err)
+	}
+	if method, created, _ := serviceMetadata(nil); !method.IsNull() || !created.IsNull() {
+		t.Fatalf("expected null metadata without a summary")
+	}
+
+	// The listing is reused until the client writes a service.
+	api.AddService(bunkerWebService{ID: "other.example.com", ServerName: "other.example.com"})
+	if summary, err := client.GetServiceSummary(ctx, "other.example.com"); err != nil || summary != nil {
+		t.Fatalf("expected the cached listing to be reused, got %#v, %v", summary, err)
+	}
+	if _, err := client.ConvertService(ctx, "app.example.com", "online"); err != nil {
+		t.Fatalf("ConvertService: %v", err)
+	}
+	if summary, err := client.GetServiceSummary(ctx, "other.example.com"); err != nil || summary == nil {
+		t.Fatalf("expected the listing to be fetched again after a write, got %#v, %v", summary, err)
+	}
+}
+
+func TestBunkerWebClientGetPluginPackage(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	api.AddPlugin(bunkerWebPlugin{ID: "general", Type: "core"})
+
+	ctx := context.Background()
+	if _, err := client.UploadPlugins(ctx, PluginUploadRequest{Files: []PluginUploadFile{{FileName: "discord.zip", Content: []byte("package")}}}); err != nil {
+		t.Fatalf("UploadPlugins: %v", err)
+	}
+
+	plugin, content, err := client.GetPluginPackage(ctx, "discord")
+	if err != nil {
+		t.Fatalf("GetPluginPackage: %v", err)
+	}
+	if string(content) != "package" || plugin.Checksum == "" {
+		t.Fatalf("unexpected package %q (checksum %q)", content, plugin.Checksum)
+	}
+
+	// Data is only requested by GetPluginPackage.
+	plugins, err := client.ListPlugins(ctx, "all", false)
+	if err != nil {
+		t.Fatalf("ListPlugins: %v", err)
+	}
+	for _, p := range plugins {
+		if p.Data != "" {
+			t.Fatalf("expected no package data without with_data, got it for %s", p.ID)
+		}
+	}
+
+	if _, _, err := client.GetPluginPackage(ctx, "general"); err == nil {
+		t.Fatalf("expected an error for a plugin without a package")
+	}
+	if _, _, err := client.GetPluginPackage(ctx, "missing"); !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestBunkerWebClientListBansFilters(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	service := "app.example.com"
+	now := time.Now()
+	api.AddBan(bunkerWebBan{IP: "10.0.0.1", Reason: "bad behavior", Date: now.Add(-time.Hour).Unix(), Service: &service})
+	api.AddBan(bunkerWebBan{IP: "10.0.0.2", Reason: "bad behavior", Date: now.Add(-48 * time.Hour).Unix(), Service: &service})
+	api.AddBan(bunkerWebBan{IP: "10.0.0.3", Reason: "api", Date: now.Add(-time.Hour).Unix()})
+
+	reason := "bad behavior"
+	since := now.Add(-24 * time.Hour)
+	bans, err := client.ListBans(context.Background(), BanListOptions{Service: &service, Reason: &reason, Since: &since})
+	if err != nil {
+		t.Fatalf("ListBans: %v", err)
+	}
+	if len(bans) != 1 || bans[0].IP != "10.0.0.1" {
+		t.Fatalf("expected only the recent service ban, got %#v", bans)
+	}
+
+	queries := api.BanQueries()
+	want := "reason=bad+behavior&service=app.example.com&since=" + strconv.FormatInt(since.Unix(), 10)
+	if len(queries) != 1 || queries[0] != want {
+		t.Fatalf("expected query %q, got %#v", want, queries)
+	}
+}
+
+func TestBunkerWebClientIdempotencyKeys(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.ListServices(ctx, true); err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	if keys := api.IdempotencyKeys(); len(keys) != 0 {
+		t.Fatalf("expected no idempotency key on reads, got %#v", keys)
+	}
+
+	opCtx := withIdempotencyKey(ctx)
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateService(opCtx, ServiceCreateRequest{ServerName: "app.example.com"}); err != nil {
+			t.Fatalf("CreateService: %v", err)
+		}
+	}
+	if _, err := client.CreateService(ctx, ServiceCreateRequest{ServerName: "other.example.com"}); err != nil {
+		t.Fatalf("CreateService: %v", err)
+	}
+
+	keys := api.IdempotencyKeys()
+	if len(keys) != 3 {
+		t.Fatalf("expected three keyed writes, got %#v", keys)
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected attempts of one operation to share a key, got %#v", keys)
+	}
+	if keys[2] == keys[0] {
+		t.Fatalf("expected a fresh key for a new operation, got %#v", keys)
+	}
+	if withIdempotencyKey(opCtx) != opCtx {
+		t.Fatalf("expected an existing key to be kept")
+	}
+}
+
+func TestBunkerWebClientCreateConflict(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.CreateService(ctx, ServiceCreateRequest{ServerName: "app.example.com"}); err != nil {
+		t.Fatalf("CreateService: %v", err)
+	}
+	_, err = client.CreateService(ctx, ServiceCreateRequest{ServerName: "app.example.com"})
+	if !isConflict(err) {
+		t.Fatalf("expected a conflict for an existing service, got %v", err)
+	}
+	if isConflict(nil) || isConflict(errors.New("boom")) {
+		t.Fatalf("expected only API 409 errors to be conflicts")
+	}
+}
+
+func TestBunkerWebClientListJobRuns(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	now := time.Now()
+	api.AddJobRun(bunkerWebJobRun{Plugin: "letsencrypt", Name: "certbot-renew", Success: true, StartDate: now.Add(-2 * time.Hour).Unix()})
+	api.AddJobRun(bunkerWebJobRun{Plugin: "letsencrypt", Name: "certbot-renew", Success: false, StartDate: now.Add(-time.Hour).Unix()})
+	api.AddJobRun(bunkerWebJobRun{Plugin: "letsencrypt", Name: "certbot-renew", Success: true, StartDate: now.Add(-48 * time.Hour).Unix()})
+	api.AddJobRun(bunkerWebJobRun{Plugin: "misc", Name: "download-plugins", Success: true, StartDate: now.Unix()})
+
+	plugin := "letsencrypt"
+	since := now.Add(-24 * time.Hour)
+	runs, err := client.ListJobRuns(context.Background(), JobRunListOptions{Plugin: &plugin, Since: &since})
+	if err != nil {
+		t.Fatalf("ListJobRuns: %v", err)
+	}
+
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %#v", runs)
+	}
+	if runs[0].Success || !runs[1].Success {
+		t.Fatalf("expected most recent run first, got %#v", runs)
+	}
+}
+
+func TestBunkerWebClientExtraHeaders(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.extraHeaders = map[string]string{"X-Tenant": "acme", "Host": "bunkerweb.internal"}
+
+	if _, err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if got := api.LastHeader("X-Tenant"); got != "acme" {
+		t.Fatalf("expected X-Tenant: acme, got %q", got)
+	}
+	if got := api.LastHeader("Host"); got != "bunkerweb.internal" {
+		t.Fatalf("expected the Host override, got %q", got)
+	}
+	if got := api.LastHeader("Authorization"); got != "Bearer test-token" {
+		t.Fatalf("expected the token to still be sent, got %q", got)
+	}
+
+	for header, want := range map[string]bool{
+		"authorization":     true,
+		"Idempotency-Key":   true,
+		hmacTimestampHeader: true,
+		defaultHMACHeader:   true,
+		"X-Tenant":          false,
+	} {
+		if got := client.reservedHeader(header); got != want {
+			t.Errorf("reservedHeader(%q) = %t, want %t", header, got, want)
+		}
+	}
+}
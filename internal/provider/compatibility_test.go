@@ -0,0 +1,126 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-bunkerweb/internal/acctest"
+)
+
+// testAPITarget returns the endpoint and token of the API the compatibility
+// tests run against: a real BunkerWeb container when acctest.EnvDocker is
+// set, the fake API otherwise.
+func testAPITarget(t *testing.T) (string, string) {
+	t.Helper()
+	if acctest.Enabled() {
+		bw := acctest.Start(t, acctest.Options{})
+		return bw.Endpoint, bw.Token
+	}
+	return newFakeBunkerWebAPI(t).URL(), "test-token"
+}
+
+// TestClientCompatibility exercises the client against the routes and
+// envelopes every resource relies on.
+func TestClientCompatibility(t *testing.T) {
+	endpoint, token := testAPITarget(t)
+	client, err := newBunkerWebClient(endpoint, nil, token, "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if _, err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if _, err := client.Health(ctx); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if _, err := client.GetGlobalConfig(ctx, true, false); err != nil {
+		t.Fatalf("GetGlobalConfig: %v", err)
+	}
+	if _, err := client.ListPlugins(ctx, "all", false); err != nil {
+		t.Fatalf("ListPlugins: %v", err)
+	}
+
+	const serverName = "compat.example.com"
+	if _, err := client.CreateService(ctx, ServiceCreateRequest{ServerName: serverName, Variables: map[string]string{"USE_GZIP": "yes"}}); err != nil {
+		t.Fatalf("CreateService: %v", err)
+	}
+	svc, err := client.GetService(ctx, serverName)
+	if err != nil {
+		t.Fatalf("GetService: %v", err)
+	}
+	if svc.Service != serverName {
+		t.Fatalf("expected service %s, got %+v", serverName, svc)
+	}
+	if err := client.DeleteService(ctx, serverName); err != nil {
+		t.Fatalf("DeleteService: %v", err)
+	}
+
+	exp := 3600
+	if err := client.BanBulk(ctx, []BanRequest{{IP: "192.0.2.10", Exp: &exp, Reason: stringPointer("compatibility")}}); err != nil {
+		t.Fatalf("BanBulk: %v", err)
+	}
+	// Bans reach the listing through the scheduler on real deployments.
+	banned := waiter{Timeout: time.Minute, Delay: time.Second}
+	err = banned.waitFor(ctx, "the ban to be listed", func(ctx context.Context) (bool, error) {
+		bans, err := client.ListBans(ctx, BanListOptions{})
+		if err != nil {
+			return false, err
+		}
+		return slices.ContainsFunc(bans, func(ban bunkerWebBan) bool { return ban.IP == "192.0.2.10" }), nil
+	})
+	if err != nil {
+		t.Fatalf("ListBans: %v", err)
+	}
+	if err := client.UnbanBulk(ctx, []UnbanRequest{{IP: "192.0.2.10"}}); err != nil {
+		t.Fatalf("UnbanBulk: %v", err)
+	}
+}
+
+func TestAccBunkerWebCompatibility(t *testing.T) {
+	endpoint, token := testAPITarget(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebCompatibilityConfig(endpoint, token, "yes"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.compat", "id", "compat.example.com"),
+					resource.TestCheckResourceAttr("bunkerweb_service.compat", "variables.USE_GZIP", "yes"),
+				),
+			},
+			{
+				Config: testAccBunkerWebCompatibilityConfig(endpoint, token, "no"),
+				Check:  resource.TestCheckResourceAttr("bunkerweb_service.compat", "variables.USE_GZIP", "no"),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebCompatibilityConfig(endpoint, token, gzip string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = %q
+  api_token    = %q
+}
+
+resource "bunkerweb_service" "compat" {
+  server_name = "compat.example.com"
+  variables = {
+    USE_GZIP = %q
+  }
+}
+`, endpoint, token, gzip)
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -16,18 +17,26 @@ import (
 )
 
 var _ ephemeral.EphemeralResource = &BunkerWebConfigBulkDeleteEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &BunkerWebConfigBulkDeleteEphemeralResource{}
 
 // BunkerWebConfigBulkDeleteEphemeralResource deletes multiple custom configs at once.
 type BunkerWebConfigBulkDeleteEphemeralResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebConfigBulkDeleteModel represents the Terraform schema.
 type BunkerWebConfigBulkDeleteModel struct {
-	Configs []BunkerWebConfigBulkDeleteItem `tfsdk:"configs"`
-	Result  types.String                    `tfsdk:"result"`
+	Configs   []BunkerWebConfigBulkDeleteItem `tfsdk:"configs"`
+	DryRun    types.Bool                      `tfsdk:"dry_run"`
+	OnMissing types.String                    `tfsdk:"on_missing"`
+	Deleted   types.List                      `tfsdk:"deleted"`
+	Missing   types.List                      `tfsdk:"missing"`
+	Result    types.String                    `tfsdk:"result"`
 }
 
+// configBulkDeleteOnMissing are the accepted on_missing values.
+var configBulkDeleteOnMissing = []string{"fail", "skip"}
+
 // BunkerWebConfigBulkDeleteItem models a single config identifier.
 type BunkerWebConfigBulkDeleteItem struct {
 	Service types.String `tfsdk:"service"`
@@ -45,7 +54,8 @@ func (r *BunkerWebConfigBulkDeleteEphemeralResource) Metadata(_ context.Context,
 
 func (r *BunkerWebConfigBulkDeleteEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Deletes multiple custom configurations in a single API call during plan/apply.",
+		MarkdownDescription: "Deletes multiple custom configurations in a single API call during plan/apply. " +
+			"The listed configs are looked up first, so a cleanup can be audited with `dry_run` before it runs.",
 		Attributes: map[string]schema.Attribute{
 			"configs": schema.ListNestedAttribute{
 				Required:            true,
@@ -67,9 +77,27 @@ func (r *BunkerWebConfigBulkDeleteEphemeralResource) Schema(_ context.Context, _
 					},
 				},
 			},
+			"dry_run": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When true, only checks which configs exist and reports them in `deleted` without deleting anything.",
+			},
+			"on_missing": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "What to do when a listed config does not exist: `fail` (the default) fails before anything is deleted, `skip` deletes the others. Missing configs are reported in `missing` either way; with `dry_run`, `fail` only warns.",
+			},
+			"deleted": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Identifiers (`service/type/name`) of the deleted configs, or of the configs that would be deleted with `dry_run`.",
+			},
+			"missing": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Identifiers of the listed configs that do not exist.",
+			},
 			"result": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "JSON-encoded payload containing the names of deleted configurations.",
+				MarkdownDescription: "JSON-encoded payload containing the deleted and missing configurations, and whether it was a dry run.",
 				Sensitive:           true,
 			},
 		},
@@ -81,16 +109,16 @@ func (r *BunkerWebConfigBulkDeleteEphemeralResource) Configure(_ context.Context
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Ephemeral Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *BunkerWebConfigBulkDeleteEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
@@ -111,30 +139,59 @@ func (r *BunkerWebConfigBulkDeleteEphemeralResource) Open(ctx context.Context, r
 		return
 	}
 
-	if err := r.client.DeleteConfigs(ctx, keys); err != nil {
-		resp.Diagnostics.AddError("Delete Configs", err.Error())
+	existing, missing, err := r.splitExistingConfigs(ctx, keys)
+	if err != nil {
+		resp.Diagnostics.AddError("List Configs", err.Error())
 		return
 	}
+	dryRun := data.DryRun.ValueBool()
+	if len(missing) > 0 && data.OnMissing.ValueString() != "skip" {
+		notFound := summarizeTargets(len(missing), "config", configKeyIDs(missing))
+		if dryRun {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("configs"),
+				"Configs Not Found",
+				fmt.Sprintf("Not found: %s. Without dry_run the deletion would fail; remove them from `configs`, or set `on_missing = \"skip\"`.", notFound),
+			)
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("configs"),
+				"Configs Not Found",
+				fmt.Sprintf("Not found: %s. Nothing was deleted; remove them from `configs`, or set `on_missing = \"skip\"` to delete the others.", notFound),
+			)
+			return
+		}
+	}
 
-	deleted := make([]map[string]string, 0, len(keys))
-	for _, key := range keys {
-		service := "global"
-		if key.Service != nil && strings.TrimSpace(*key.Service) != "" {
-			service = strings.TrimSpace(*key.Service)
+	if !dryRun && len(existing) > 0 {
+		if err := r.client.DeleteConfigs(ctx, existing); err != nil {
+			resp.Diagnostics.AddError("Delete Configs", err.Error())
+			return
 		}
+	}
+
+	deleted := make([]map[string]string, 0, len(existing))
+	for _, key := range existing {
 		deleted = append(deleted, map[string]string{
-			"service": service,
+			"service": configKeyService(key),
 			"type":    key.Type,
 			"name":    key.Name,
 		})
 	}
 
-	encoded, err := encodeResult(map[string]any{"deleted": deleted})
+	encoded, err := encodeResult(map[string]any{"deleted": deleted, "missing": configKeyIDs(missing), "dry_run": dryRun})
 	if err != nil {
 		resp.Diagnostics.AddError("Encode Result", err.Error())
 		return
 	}
 
+	data.Deleted, diags = types.ListValueFrom(ctx, types.StringType, configKeyIDs(existing))
+	resp.Diagnostics.Append(diags...)
+	data.Missing, diags = types.ListValueFrom(ctx, types.StringType, configKeyIDs(missing))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	data.Result = types.StringValue(encoded)
 	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
 }
@@ -143,6 +200,84 @@ func (r *BunkerWebConfigBulkDeleteEphemeralResource) Close(context.Context, ephe
 	// No clean-up work required.
 }
 
+// ValidateConfig summarises the configs to delete, once every value is known.
+func (r *BunkerWebConfigBulkDeleteEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data BunkerWebConfigBulkDeleteModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		return
+	}
+	keys, diags := data.toConfigKeys()
+	if diags.HasError() {
+		// Open reports these.
+		return
+	}
+
+	if !data.OnMissing.IsNull() && !slices.Contains(configBulkDeleteOnMissing, data.OnMissing.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("on_missing"),
+			"Invalid On Missing",
+			fmt.Sprintf("Expected one of %s, got %q.", strings.Join(configBulkDeleteOnMissing, ", "), data.OnMissing.ValueString()),
+		)
+		return
+	}
+
+	verb := "delete "
+	if data.DryRun.ValueBool() {
+		verb = "check (dry run, nothing is deleted) "
+	}
+	addPlanSummary(&resp.Diagnostics, "bunkerweb_config_bulk_delete", []string{verb + summarizeTargets(len(keys), "config", configKeyIDs(keys))})
+}
+
+// splitExistingConfigs separates the keys of configs that exist from the
+// others, keeping their order.
+func (r *BunkerWebConfigBulkDeleteEphemeralResource) splitExistingConfigs(ctx context.Context, keys []ConfigKey) ([]ConfigKey, []ConfigKey, error) {
+	withDrafts := true
+	configs, err := r.client.ListConfigs(ctx, ConfigListOptions{WithDrafts: &withDrafts})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	present := make(map[string]struct{}, len(configs))
+	for _, cfg := range configs {
+		service := strings.TrimSpace(cfg.Service)
+		if service == "" {
+			service = "global"
+		}
+		present[buildConfigID(service, normalizeConfigType(cfg.Type), cfg.Name)] = struct{}{}
+	}
+
+	var existing, missing []ConfigKey
+	for _, key := range keys {
+		if _, ok := present[buildConfigID(configKeyService(key), normalizeConfigType(key.Type), key.Name)]; ok {
+			existing = append(existing, key)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	return existing, missing, nil
+}
+
+// configKeyService returns the service of key, global when unset.
+func configKeyService(key ConfigKey) string {
+	if key.Service != nil && strings.TrimSpace(*key.Service) != "" {
+		return strings.TrimSpace(*key.Service)
+	}
+	return "global"
+}
+
+// configKeyIDs returns the service/type/name identifiers of keys.
+func configKeyIDs(keys []ConfigKey) []string {
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, buildConfigID(configKeyService(key), key.Type, key.Name))
+	}
+	return ids
+}
+
 func (m *BunkerWebConfigBulkDeleteModel) toConfigKeys() ([]ConfigKey, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
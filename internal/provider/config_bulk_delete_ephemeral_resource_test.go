@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -44,6 +45,80 @@ func TestAccBunkerWebConfigBulkDeleteEphemeralResource(t *testing.T) {
 	}
 }
 
+func TestAccBunkerWebConfigBulkDeleteEphemeralResourceVerification(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddConfig(bunkerWebConfig{Service: "global", Type: "http", Name: "foo", Data: "server { listen 80; }"})
+	fakeAPI.AddConfig(bunkerWebConfig{Service: "api", Type: "http", Name: "bar", Data: "server { listen 81; }"})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebConfigBulkDeleteEphemeralResourceVerification(fakeAPI.URL(), "dry_run = true"),
+			},
+			{
+				Config:      testAccBunkerWebConfigBulkDeleteEphemeralResourceVerification(fakeAPI.URL(), ""),
+				ExpectError: regexp.MustCompile(`Not found: 1 config \(global/http/missing\)`),
+			},
+			{
+				Config:      testAccBunkerWebConfigBulkDeleteEphemeralResourceVerification(fakeAPI.URL(), `on_missing = "never"`),
+				ExpectError: regexp.MustCompile("Invalid On Missing"),
+			},
+		},
+	})
+
+	if _, ok := fakeAPI.Config("global", "http", "foo"); !ok {
+		t.Fatal("expected the dry run and the failed run to keep foo")
+	}
+	if batches := fakeAPI.DeletedConfigBatches(); len(batches) != 0 {
+		t.Fatalf("expected no deletion, got %v", batches)
+	}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebConfigBulkDeleteEphemeralResourceVerification(fakeAPI.URL(), `on_missing = "skip"`),
+			},
+		},
+	})
+
+	if _, ok := fakeAPI.Config("global", "http", "foo"); ok {
+		t.Fatal("expected foo to be deleted")
+	}
+	batches := fakeAPI.DeletedConfigBatches()
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("expected one batch deleting the two existing configs, got %v", batches)
+	}
+}
+
+func testAccBunkerWebConfigBulkDeleteEphemeralResourceVerification(endpoint, options string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_config_bulk_delete" "cleanup" {
+  %s
+
+  configs = [
+    { type = "http", name = "foo" },
+    { type = "http", name = "missing" },
+    { service = "api", type = "http", name = "bar" },
+  ]
+}
+`, endpoint, options)
+}
+
 func testAccBunkerWebConfigBulkDeleteEphemeralResource(endpoint string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -5,28 +5,56 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &BunkerWebConfigResource{}
 var _ resource.ResourceWithImportState = &BunkerWebConfigResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebConfigResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebConfigResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebConfigResource{}
+var _ resource.ResourceWithUpgradeState = &BunkerWebConfigResource{}
+
+// configStateMigrations upgrade stored bunkerweb_config state; the schema
+// version is their count.
+var configStateMigrations = []stateMigration{
+	0: unchangedState,
+}
+
+// configAPIErrorAttributes scopes translated API errors; see addAPIError.
+var configAPIErrorAttributes = map[apiErrorKind]path.Path{
+	apiErrorConfigName: path.Root("name"),
+	apiErrorReadOnly:   path.Root("name"),
+}
 
 // BunkerWebConfigResource manages API-driven custom configurations.
 type BunkerWebConfigResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
+	state  *providerState
 }
 
 // BunkerWebConfigResourceModel is the Terraform state.
@@ -36,7 +64,24 @@ type BunkerWebConfigResourceModel struct {
 	Type    types.String `tfsdk:"type"`
 	Name    types.String `tfsdk:"name"`
 	Data    types.String `tfsdk:"data"`
+	DataWO  types.String `tfsdk:"data_wo"`
+	Source  types.String `tfsdk:"source"`
 	Method  types.String `tfsdk:"method"`
+
+	StoreDataInState types.Bool   `tfsdk:"store_data_in_state"`
+	DataSHA256       types.String `tfsdk:"data_sha256"`
+	AdoptExisting    types.Bool   `tfsdk:"adopt_existing"`
+	ApplyNameAffixes types.Bool   `tfsdk:"apply_name_affixes"`
+	Priority         types.Int64  `tfsdk:"priority"`
+	RecreateOnDrift  types.Bool   `tfsdk:"recreate_on_drift"`
+	Retries          types.Object `tfsdk:"retries"`
+}
+
+// configIdentityModel is the resource identity of bunkerweb_config.
+type configIdentityModel struct {
+	Service types.String `tfsdk:"service"`
+	Type    types.String `tfsdk:"type"`
+	Name    types.String `tfsdk:"name"`
 }
 
 func NewBunkerWebConfigResource() resource.Resource {
@@ -49,6 +94,7 @@ func (r *BunkerWebConfigResource) Metadata(_ context.Context, req resource.Metad
 
 func (r *BunkerWebConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             int64(len(configStateMigrations)),
 		MarkdownDescription: "Manages a BunkerWeb custom configuration snippet created via the API.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -82,13 +128,84 @@ func (r *BunkerWebConfigResource) Schema(_ context.Context, _ resource.SchemaReq
 				},
 			},
 			"data": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Configuration content as UTF-8 text.",
+				Optional:            true,
+				MarkdownDescription: "Configuration content as UTF-8 text. Stored in state; use `data_wo` instead when `store_data_in_state` is false.",
+			},
+			"data_wo": schema.StringAttribute{
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				MarkdownDescription: "Write-only configuration content, never persisted in state (requires Terraform 1.11+). Must be used when `store_data_in_state` is false.",
+			},
+			"source": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Path of a local file holding the configuration content, instead of `data` or `data_wo`. Relative paths are resolved from the working directory; " +
+					"`~` and forward slashes work on every operating system. CRLF line endings are converted to LF so checkouts on Windows, macOS and Linux plan identically. " +
+					"The content is never stored in state; `data_sha256` tracks it.",
+			},
+			"store_data_in_state": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the content is kept in state. When false, only `data_sha256` is stored and compared during refresh. Defaults to `true`.",
+				Default:             booldefault.StaticBool(true),
+			},
+			"data_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hex-encoded SHA-256 of the configuration content, used to detect drift.",
 			},
 			"method": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Source method reported by the API.",
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true and a config with the same service/type/name already exists (for example one created from the web UI), take it over and overwrite its content instead of failing. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"apply_name_affixes": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true, the provider `name_prefix` and `name_suffix` are added to `name` in BunkerWeb. `name` keeps the configured value while `id` and the resource identity use the full name. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"priority": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Inclusion order among the configs of the same service and type, from 0 to 99. BunkerWeb includes snippets in name order, " +
+					"so the provider stores the config as `<priority>-<name>` (for example `05-headers`) while `name` keeps the configured value. Lower values are included first.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"recreate_on_drift": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true, a config deleted outside Terraform stays in state with an empty `data_sha256`, and the next apply recreates it in place from the configured content instead of planning a new resource. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebConfigResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"service": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Service the config belongs to, `global` when omitted.",
+			},
+			"type": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Configuration type.",
+			},
+			"name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Configuration name.",
+			},
 		},
 	}
 }
@@ -98,16 +215,123 @@ func (r *BunkerWebConfigResource) Configure(_ context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebConfigResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.StoreDataInState.IsUnknown() || config.Data.IsUnknown() || config.DataWO.IsUnknown() || config.Source.IsUnknown() {
+		return
+	}
+
+	if !config.Priority.IsNull() && !config.Priority.IsUnknown() {
+		if p := config.Priority.ValueInt64(); p < 0 || p > maxConfigPriority {
+			resp.Diagnostics.AddAttributeError(path.Root("priority"), "Invalid Priority", fmt.Sprintf("priority must be between 0 and %d, got %d.", maxConfigPriority, p))
+		}
+	}
+
+	if !config.Source.IsNull() {
+		for attr, value := range map[string]types.String{"data": config.Data, "data_wo": config.DataWO} {
+			if !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root(attr), "Invalid Attribute Combination", fmt.Sprintf("%s cannot be used together with source.", attr))
+			}
+		}
+		return
+	}
+
+	storeData := config.StoreDataInState.IsNull() || config.StoreDataInState.ValueBool()
+	switch {
+	case storeData && !config.DataWO.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("data_wo"), "Invalid Attribute Combination", "data_wo can only be used together with store_data_in_state = false; use data instead.")
+	case storeData && config.Data.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("data"), "Missing Configuration Content", "One of data or source must be set.")
+	case !storeData && !config.Data.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("data"), "Invalid Attribute Combination", "data is persisted in state; use data_wo when store_data_in_state is false.")
+	case !storeData && config.DataWO.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("data_wo"), "Missing Configuration Content", "data_wo must be set when store_data_in_state is false.")
+	}
+}
+
+func (r *BunkerWebConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compute on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BunkerWebConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Service.IsUnknown() && normalizeTFService(plan.Service) != "global" && (req.State.Raw.IsNull() || !req.Plan.Raw.Equal(req.State.Raw)) {
+		resp.Diagnostics.Append(multisiteMismatch(ctx, r.client, path.Root("service"), true, "A service-scoped bunkerweb_config")...)
+	}
+
+	// A second resource managing the same service/type/name would overwrite
+	// this one's content on every apply. Identical duplicates cannot be told
+	// apart from a replacement planned twice; Create reports them as a
+	// conflict with the existing config.
+	if r.client != nil && !plan.Service.IsUnknown() && !plan.Type.IsUnknown() && !plan.Name.IsUnknown() &&
+		!plan.ApplyNameAffixes.IsUnknown() && !plan.Priority.IsUnknown() {
+		key := buildConfigID(normalizeTFService(plan.Service), normalizeConfigType(plan.Type.ValueString()), plan.storedName(r.state))
+		if !r.state.claimConfig(key, req.Config.Raw) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Duplicate Custom Configuration",
+				fmt.Sprintf("Another bunkerweb_config resource in this configuration also manages %s. Each custom configuration must be managed by a single resource, otherwise every apply overwrites the other one's content.", key),
+			)
+			return
+		}
+	}
+
+	// Write-only values are absent from the plan, so hash the content from the
+	// configuration: a changed hash is what schedules the update.
+	content, diags := configContent(ctx, plan, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if content.IsUnknown() {
+		plan.DataSHA256 = types.StringUnknown()
+	} else {
+		plan.DataSHA256 = types.StringValue(configDataSHA256(content.ValueString()))
+	}
+
+	// Switching apply_name_affixes or priority (for example after importing by
+	// the full name) only needs a replacement when the name in BunkerWeb
+	// changes.
+	if !req.State.Raw.IsNull() && r.client != nil {
+		var state BunkerWebConfigResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !plan.Name.IsUnknown() && !plan.ApplyNameAffixes.IsUnknown() && !plan.Priority.IsUnknown() &&
+			plan.storedName(r.state) == state.storedName(r.state) {
+			resp.RequiresReplace = slices.DeleteFunc(resp.RequiresReplace, func(p path.Path) bool {
+				return p.Equal(path.Root("name")) || p.Equal(path.Root("apply_name_affixes")) || p.Equal(path.Root("priority"))
+			})
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
 func (r *BunkerWebConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -122,36 +346,55 @@ func (r *BunkerWebConfigResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	service := normalizeTFService(plan.Service)
-	if _, err := r.client.CreateConfig(ctx, ConfigCreateRequest{
-		Service: stringPointer(service),
-		Type:    plan.Type.ValueString(),
-		Name:    plan.Name.ValueString(),
-		Data:    plan.Data.ValueString(),
-	}); err != nil {
-		resp.Diagnostics.AddError("Unable to Create Config", err.Error())
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// POST /configs returns only {"status":"success"}, so read the config back to
-	// obtain the computed `method` while keeping the planned scalar values.
-	key, diags := plan.toConfigKey()
+	content, diags := configContent(ctx, plan, req.Config)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(plan.checkSourceUnchanged(content)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	key, diags := plan.toConfigKey(r.state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := normalizeTFService(plan.Service)
+	data := content.ValueString()
+	_, err := r.client.CreateConfig(withIdempotencyKey(ctx), ConfigCreateRequest{
+		Service: stringPointer(service),
+		Type:    plan.Type.ValueString(),
+		Name:    key.Name,
+		Data:    data,
+	})
+	if isConflict(err) && plan.AdoptExisting.ValueBool() {
+		tflog.Warn(ctx, "adopting existing bunkerweb config", map[string]any{"id": buildConfigID(service, key.Type, key.Name)})
+		_, err = r.client.UpdateConfig(ctx, key, ConfigUpdateRequest{Data: &data})
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Create Config", err, configAPIErrorAttributes)
+		return
+	}
+
+	// POST /configs returns only {"status":"success"}, so read the config back to
+	// obtain the computed `method` while keeping the planned scalar values.
 	cfg, err := r.client.GetConfig(ctx, key, true)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Read Config After Create", err.Error())
 		return
 	}
 
-	plan.populateFromPlan(service, cfg)
+	plan.populateFromPlan(service, key.Name, content.ValueString(), cfg)
 
 	tflog.Info(ctx, "created bunkerweb config", map[string]any{"id": plan.ID.ValueString()})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 }
 
 func (r *BunkerWebConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -166,7 +409,12 @@ func (r *BunkerWebConfigResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	key, diags := state.toConfigKey()
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := state.toConfigKey(r.state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -176,6 +424,20 @@ func (r *BunkerWebConfigResource) Read(ctx context.Context, req resource.ReadReq
 	if err != nil {
 		var apiErr *bunkerWebAPIError
 		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			detail := fmt.Sprintf("Config %q no longer exists in BunkerWeb; it was deleted outside Terraform.", state.ID.ValueString())
+			if !state.DataSHA256.IsNull() {
+				detail += fmt.Sprintf(" The last applied content had SHA-256 %s.", state.DataSHA256.ValueString())
+			}
+			if state.RecreateOnDrift.ValueBool() {
+				// Keep the resource, and its stored content, so the next
+				// apply recreates it in place.
+				state.DataSHA256 = types.StringNull()
+				state.Method = types.StringNull()
+				resp.Diagnostics.AddWarning("Config Deleted Out-of-Band", detail+" recreate_on_drift is set, so the next apply recreates it from the configured content.")
+				resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+				return
+			}
+			resp.Diagnostics.AddWarning("Config Deleted Out-of-Band", detail+" It was removed from state and the next apply creates it again; set recreate_on_drift to keep it in state instead.")
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -188,8 +450,10 @@ func (r *BunkerWebConfigResource) Read(ctx context.Context, req resource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.Name = types.StringValue(state.configuredName(r.state, cfg.Name))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 func (r *BunkerWebConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -204,16 +468,39 @@ func (r *BunkerWebConfigResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	key, diags := plan.toConfigKey()
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := plan.toConfigKey(r.state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data := plan.Data.ValueString()
+	content, diags := configContent(ctx, plan, req.Config)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(plan.checkSourceUnchanged(content)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := content.ValueString()
 
-	if _, err := r.client.UpdateConfig(ctx, key, ConfigUpdateRequest{Data: &data}); err != nil {
-		resp.Diagnostics.AddError("Unable to Update Config", err.Error())
+	_, err := r.client.UpdateConfig(ctx, key, ConfigUpdateRequest{Data: &data})
+	var apiErr *bunkerWebAPIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound && plan.RecreateOnDrift.ValueBool() {
+		tflog.Warn(ctx, "recreating bunkerweb config deleted out-of-band", map[string]any{"id": plan.ID.ValueString()})
+		_, err = r.client.CreateConfig(withIdempotencyKey(ctx), ConfigCreateRequest{
+			Service: stringPointer(normalizeTFService(plan.Service)),
+			Type:    plan.Type.ValueString(),
+			Name:    key.Name,
+			Data:    data,
+		})
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Update Config", err, configAPIErrorAttributes)
 		return
 	}
 
@@ -224,9 +511,10 @@ func (r *BunkerWebConfigResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	plan.populateFromPlan(normalizeTFService(plan.Service), cfg)
+	plan.populateFromPlan(normalizeTFService(plan.Service), key.Name, data, cfg)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 }
 
 func (r *BunkerWebConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -241,24 +529,69 @@ func (r *BunkerWebConfigResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	key, diags := state.toConfigKey()
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := state.toConfigKey(r.state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.client.DeleteConfig(ctx, key); err != nil {
-		resp.Diagnostics.AddError("Unable to Delete Config", err.Error())
+	if err := r.client.BatchDeleteConfig(ctx, key); err != nil {
+		// A config kept in state by recreate_on_drift may already be gone.
+		var apiErr *bunkerWebAPIError
+		if state.DataSHA256.IsNull() && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Unable to Delete Config", err, configAPIErrorAttributes)
 		return
 	}
 }
 
 func (r *BunkerWebConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.Split(req.ID, "/")
-	if len(parts) != 3 {
+	id, diags := importIdentifier(ctx, req, "service", "type", "name")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An optional ?with_data=false suffix imports only data_sha256, for
+	// configs managed through data_wo.
+	id, rawQuery, _ := strings.Cut(id, "?")
+	withData := true
+	if rawQuery != "" {
+		query, err := url.ParseQuery(rawQuery)
+		if err == nil {
+			for key := range query {
+				if key != "with_data" {
+					err = fmt.Errorf("unknown option %q", key)
+				}
+			}
+		}
+		if err == nil && query.Has("with_data") {
+			withData, err = strconv.ParseBool(query.Get("with_data"))
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Expected an optional ?with_data=true|false suffix, got %q: %s", rawQuery, err),
+			)
+			return
+		}
+	}
+
+	// type/name addresses a global config.
+	parts := strings.Split(id, "/")
+	if len(parts) == 2 {
+		parts = append([]string{"global"}, parts...)
+	}
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected identifier in the form service/type/name, got %q", req.ID),
+			fmt.Sprintf("Expected identifier in the form service/type/name or type/name, got %q", id),
 		)
 		return
 	}
@@ -273,6 +606,12 @@ func (r *BunkerWebConfigResource) ImportState(ctx context.Context, req resource.
 		Service: types.StringValue(service),
 		Type:    types.StringValue(parts[1]),
 		Name:    types.StringValue(parts[2]),
+
+		StoreDataInState: types.BoolValue(withData),
+		AdoptExisting:    types.BoolValue(false),
+		ApplyNameAffixes: types.BoolValue(false),
+		RecreateOnDrift:  types.BoolValue(false),
+		Retries:          types.ObjectNull(retriesAttrTypes),
 	})...)
 }
 
@@ -298,7 +637,13 @@ func (m *BunkerWebConfigResourceModel) populateFromConfig(cfg *bunkerWebConfig)
 	m.Service = types.StringValue(service)
 	m.Type = types.StringValue(cfgType)
 	m.Name = types.StringValue(cfg.Name)
-	m.Data = types.StringValue(cfg.Data)
+	m.DataWO = types.StringNull()
+	m.DataSHA256 = types.StringValue(configDataSHA256(cfg.Data))
+	if m.Source.IsNull() && (m.StoreDataInState.IsNull() || m.StoreDataInState.ValueBool()) {
+		m.Data = types.StringValue(cfg.Data)
+	} else {
+		m.Data = types.StringNull()
+	}
 	if cfg.Method != "" {
 		m.Method = types.StringValue(cfg.Method)
 	} else {
@@ -308,13 +653,16 @@ func (m *BunkerWebConfigResourceModel) populateFromConfig(cfg *bunkerWebConfig)
 	return nil
 }
 
-// populateFromPlan finalises state after a create/update. The Required scalar
+// populateFromPlan finalises state after a create/update. The configured scalar
 // fields (type/name/data) are kept exactly as configured to avoid violating
 // Terraform's consistency check (the API normalises type, e.g. hyphen→underscore);
-// only the computed `method` is taken from the read-back config.
-func (m *BunkerWebConfigResourceModel) populateFromPlan(service string, cfg *bunkerWebConfig) {
-	m.ID = types.StringValue(buildConfigID(service, m.Type.ValueString(), m.Name.ValueString()))
+// only the computed `method` is taken from the read-back config. name is the
+// name in BunkerWeb, which includes any provider name affixes and priority prefix.
+func (m *BunkerWebConfigResourceModel) populateFromPlan(service, name, content string, cfg *bunkerWebConfig) {
+	m.ID = types.StringValue(buildConfigID(service, m.Type.ValueString(), name))
 	m.Service = types.StringValue(service)
+	m.DataWO = types.StringNull()
+	m.DataSHA256 = types.StringValue(configDataSHA256(content))
 	if cfg != nil && cfg.Method != "" {
 		m.Method = types.StringValue(cfg.Method)
 	} else {
@@ -322,7 +670,19 @@ func (m *BunkerWebConfigResourceModel) populateFromPlan(service string, cfg *bun
 	}
 }
 
-func (m *BunkerWebConfigResourceModel) toConfigKey() (ConfigKey, diag.Diagnostics) {
+// identity addresses the config by its name in BunkerWeb, taken from the ID
+// so it includes any provider name affixes.
+func (m *BunkerWebConfigResourceModel) identity() configIdentityModel {
+	name := m.Name
+	if parts := strings.SplitN(m.ID.ValueString(), "/", 3); len(parts) == 3 {
+		name = types.StringValue(parts[2])
+	}
+	return configIdentityModel{Service: m.Service, Type: m.Type, Name: name}
+}
+
+// toConfigKey addresses the config in BunkerWeb by its stored name; see
+// storedName.
+func (m *BunkerWebConfigResourceModel) toConfigKey(state *providerState) (ConfigKey, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if m.Service.IsNull() || m.Service.IsUnknown() {
@@ -344,10 +704,115 @@ func (m *BunkerWebConfigResourceModel) toConfigKey() (ConfigKey, diag.Diagnostic
 	return ConfigKey{
 		Service: stringPointer(service),
 		Type:    m.Type.ValueString(),
-		Name:    m.Name.ValueString(),
+		Name:    m.storedName(state),
 	}, diags
 }
 
+// claimConfig records that a bunkerweb_config resource with the configuration
+// owner manages key and returns false when a resource with another
+// configuration already does. Terraform plans a resource that must be
+// replaced a second time, as a create, so the same configuration may claim
+// its key again.
+func (s *providerState) claimConfig(key string, owner tftypes.Value) bool {
+	s.plannedConfigsMu.Lock()
+	defer s.plannedConfigsMu.Unlock()
+	if s.plannedConfigs == nil {
+		s.plannedConfigs = map[string]tftypes.Value{}
+	}
+	if claimed, ok := s.plannedConfigs[key]; ok {
+		return claimed.Equal(owner)
+	}
+	s.plannedConfigs[key] = owner
+	return true
+}
+
+// storedName returns the config name in BunkerWeb: the configured name with
+// the provider name affixes when apply_name_affixes is set, prefixed with the
+// zero-padded priority.
+func (m *BunkerWebConfigResourceModel) storedName(state *providerState) string {
+	name := state.remoteName(m.ApplyNameAffixes, m.Name.ValueString())
+	if m.Priority.IsNull() || m.Priority.IsUnknown() {
+		return name
+	}
+	return configPriorityPrefix(m.Priority.ValueInt64()) + name
+}
+
+// configuredName reverses storedName for a name read back from BunkerWeb.
+func (m *BunkerWebConfigResourceModel) configuredName(state *providerState, stored string) string {
+	if !m.Priority.IsNull() && !m.Priority.IsUnknown() {
+		stored = strings.TrimPrefix(stored, configPriorityPrefix(m.Priority.ValueInt64()))
+	}
+	return state.localName(m.ApplyNameAffixes, stored)
+}
+
+// maxConfigPriority keeps the priority prefix at two digits, so name order
+// matches numeric order.
+const maxConfigPriority = 99
+
+func configPriorityPrefix(priority int64) string {
+	return fmt.Sprintf("%02d-", priority)
+}
+
+// configContent returns the configuration content from `data`, the file
+// named by `source`, or the write-only `data_wo` which is only readable from
+// the configuration.
+func configContent(ctx context.Context, plan BunkerWebConfigResourceModel, config tfsdk.Config) (types.String, diag.Diagnostics) {
+	if plan.Source.IsUnknown() {
+		return types.StringUnknown(), nil
+	}
+	if !plan.Source.IsNull() {
+		var diags diag.Diagnostics
+		content, err := readSourceFile(plan.Source.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("source"), "Unable to Read Source File", err.Error())
+			return types.StringNull(), diags
+		}
+		return types.StringValue(string(content)), diags
+	}
+	if !plan.StoreDataInState.IsNull() && !plan.StoreDataInState.IsUnknown() && !plan.StoreDataInState.ValueBool() {
+		var content types.String
+		diags := config.GetAttribute(ctx, path.Root("data_wo"), &content)
+		return content, diags
+	}
+	return plan.Data, nil
+}
+
+// checkSourceUnchanged fails when the file named by `source` changed between
+// plan and apply, since applying other content than planned would leave
+// state inconsistent with the plan.
+func (m *BunkerWebConfigResourceModel) checkSourceUnchanged(content types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if m.Source.IsNull() || m.DataSHA256.IsUnknown() || m.DataSHA256.IsNull() || content.IsNull() {
+		return diags
+	}
+	if sum := configDataSHA256(content.ValueString()); sum != m.DataSHA256.ValueString() {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Source File Changed",
+			fmt.Sprintf("%s changed since the plan was made (SHA-256 %s, planned %s). Plan again to apply its current content.", m.Source.ValueString(), sum, m.DataSHA256.ValueString()),
+		)
+	}
+	return diags
+}
+
+// isConflict reports whether err is the API's 409 answer to creating an object
+// that already exists.
+func isConflict(err error) bool {
+	var apiErr *bunkerWebAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// isNotFound reports whether err is the API's 404 answer for a missing object.
+func isNotFound(err error) bool {
+	var apiErr *bunkerWebAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func configDataSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
 func normalizeTFService(v types.String) string {
 	if v.IsNull() || v.IsUnknown() {
 		return "global"
@@ -359,6 +824,10 @@ func normalizeTFService(v types.String) string {
 	return trimmed
 }
 
+func (r *BunkerWebConfigResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(configStateMigrations...)
+}
+
 func buildConfigID(service, cfgType, name string) string {
 	return fmt.Sprintf("%s/%s/%s", service, cfgType, name)
 }
@@ -5,10 +5,16 @@ package provider
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 // TestBunkerWebConfigPopulateFromConfigPreservesType locks the Read behaviour for
@@ -41,6 +47,25 @@ func TestBunkerWebConfigPopulateFromConfigPreservesType(t *testing.T) {
 	}
 }
 
+func TestBunkerWebConfigPopulateFromConfigHashOnly(t *testing.T) {
+	m := &BunkerWebConfigResourceModel{
+		Type:             types.StringValue("http"),
+		Name:             types.StringValue("secret"),
+		StoreDataInState: types.BoolValue(false),
+	}
+	cfg := &bunkerWebConfig{Service: "global", Type: "http", Name: "secret", Data: "auth_basic_user_file x;"}
+
+	if diags := m.populateFromConfig(cfg); diags.HasError() {
+		t.Fatalf("populateFromConfig: %v", diags)
+	}
+	if !m.Data.IsNull() {
+		t.Fatalf("expected data to stay out of state, got %q", m.Data.ValueString())
+	}
+	if got, want := m.DataSHA256.ValueString(), configDataSHA256(cfg.Data); got != want {
+		t.Fatalf("expected data_sha256 %q, got %q", want, got)
+	}
+}
+
 func TestAccBunkerWebConfigResource(t *testing.T) {
 	fakeAPI := newFakeBunkerWebAPI(t)
 
@@ -67,6 +92,191 @@ func TestAccBunkerWebConfigResource(t *testing.T) {
 	})
 }
 
+func TestAccBunkerWebConfigResourceHashOnly(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebConfigResourceHashOnlyConfig(fakeAPI.URL(), "set $secret one;"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("bunkerweb_config.secret", "data"),
+					resource.TestCheckNoResourceAttr("bunkerweb_config.secret", "data_wo"),
+					resource.TestCheckResourceAttr("bunkerweb_config.secret", "data_sha256", configDataSHA256("set $secret one;")),
+				),
+			},
+			{
+				Config: testAccBunkerWebConfigResourceHashOnlyConfig(fakeAPI.URL(), "set $secret two;"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_config.secret", "data_sha256", configDataSHA256("set $secret two;")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebConfigResourceSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	source := filepath.Join(t.TempDir(), "headers.conf")
+	writeSource := func(content string) func() {
+		return func() {
+			if err := os.WriteFile(source, []byte(content), 0o644); err != nil {
+				t.Fatalf("write source: %v", err)
+			}
+		}
+	}
+	writeSource("add_header X-Frame-Options DENY;\n")()
+
+	config := fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_config" "headers" {
+  type   = "server_http"
+  name   = "headers"
+  source = %q
+}
+`, fakeAPI.URL(), filepath.ToSlash(source))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("bunkerweb_config.headers", "data"),
+					resource.TestCheckResourceAttr("bunkerweb_config.headers", "data_sha256", configDataSHA256("add_header X-Frame-Options DENY;\n")),
+				),
+			},
+			{
+				// A Windows checkout of the same file plans no change.
+				PreConfig: writeSource("add_header X-Frame-Options DENY;\r\n"),
+				Config:    config,
+				PlanOnly:  true,
+			},
+			{
+				PreConfig: writeSource("add_header X-Frame-Options SAMEORIGIN;\n"),
+				Config:    config,
+				Check: func(*terraform.State) error {
+					cfg, ok := fakeAPI.Config("global", "server_http", "headers")
+					if !ok || cfg.Data != "add_header X-Frame-Options SAMEORIGIN;\n" {
+						return fmt.Errorf("expected the updated file to be applied, got %+v", cfg)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebConfigResourceAdoptExisting(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddConfig(bunkerWebConfig{Service: "global", Type: "http", Name: "legacy", Data: "# from the UI", Method: "ui"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebConfigResourceAdoptConfig(fakeAPI.URL(), false),
+				ExpectError: regexp.MustCompile(`already exists`),
+			},
+			{
+				Config: testAccBunkerWebConfigResourceAdoptConfig(fakeAPI.URL(), true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_config.legacy", "id", "global/http/legacy"),
+					func(*terraform.State) error {
+						cfg, ok := fakeAPI.Config("global", "http", "legacy")
+						if !ok || cfg.Data != "# managed by terraform" {
+							return fmt.Errorf("expected adopted config to be overwritten, got %#v", cfg)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebConfigResourceImportBlock(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebConfigResourceConfig(fakeAPI.URL(), "server_http", "access_log", "log_format combined;"),
+			},
+			{
+				ResourceName:    "bunkerweb_config.sample",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			{
+				ResourceName:    "bunkerweb_config.sample",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebConfigResourceImportShortID(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebConfigResourceConfig(fakeAPI.URL(), "server_http", "access_log", "log_format combined;"),
+			},
+			{
+				ResourceName:      "bunkerweb_config.sample",
+				ImportState:       true,
+				ImportStateId:     "server_http/access_log",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "bunkerweb_config.sample",
+				ImportState:   true,
+				ImportStateId: "server_http/access_log?with_data=false",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+					}
+					attrs := states[0].Attributes
+					if _, ok := attrs["data"]; ok {
+						return fmt.Errorf("expected data to stay out of state, got %q", attrs["data"])
+					}
+					if attrs["store_data_in_state"] != "false" || attrs["data_sha256"] != configDataSHA256("log_format combined;") {
+						return fmt.Errorf("unexpected imported attributes: %v", attrs)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:  "bunkerweb_config.sample",
+				ImportState:   true,
+				ImportStateId: "server_http/access_log?with_data=maybe",
+				ExpectError:   regexp.MustCompile(`with_data`),
+			},
+		},
+	})
+}
+
 func testAccBunkerWebConfigResourceConfig(endpoint, cfgType, name, data string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -81,3 +291,221 @@ resource "bunkerweb_config" "sample" {
 }
 `, endpoint, cfgType, name, data)
 }
+
+func testAccBunkerWebConfigResourceHashOnlyConfig(endpoint, data string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_config" "secret" {
+  type                = "http"
+  name                = "secret"
+  data_wo             = "%s"
+  store_data_in_state = false
+}
+`, endpoint, data)
+}
+
+func testAccBunkerWebConfigResourceAdoptConfig(endpoint string, adopt bool) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_config" "legacy" {
+  type           = "http"
+  name           = "legacy"
+  data           = "# managed by terraform"
+  adopt_existing = %t
+}
+`, endpoint, adopt)
+}
+
+func TestBunkerWebConfigStoredName(t *testing.T) {
+	state := &providerState{namePrefix: "dev-"}
+	m := BunkerWebConfigResourceModel{
+		Name:             types.StringValue("headers"),
+		ApplyNameAffixes: types.BoolValue(true),
+		Priority:         types.Int64Value(5),
+	}
+
+	if got := m.storedName(state); got != "05-dev-headers" {
+		t.Fatalf("expected 05-dev-headers, got %q", got)
+	}
+	if got := m.configuredName(state, "05-dev-headers"); got != "headers" {
+		t.Fatalf("expected headers, got %q", got)
+	}
+
+	m.Priority = types.Int64Null()
+	m.ApplyNameAffixes = types.BoolValue(false)
+	if got := m.storedName(state); got != "headers" {
+		t.Fatalf("expected the name unchanged without priority, got %q", got)
+	}
+}
+
+func TestAccBunkerWebConfigResourcePriority(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebConfigResourcePriorityConfig(fakeAPI.URL(), 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_config.headers", "name", "headers"),
+					resource.TestCheckResourceAttr("bunkerweb_config.headers", "id", "global/http/05-headers"),
+					func(*terraform.State) error {
+						if _, ok := fakeAPI.Config("global", "http", "05-headers"); !ok {
+							return fmt.Errorf("expected config 05-headers to exist")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   testAccBunkerWebConfigResourcePriorityConfig(fakeAPI.URL(), 5),
+				PlanOnly: true,
+			},
+			{
+				Config:      testAccBunkerWebConfigResourcePriorityConfig(fakeAPI.URL(), 100),
+				ExpectError: regexp.MustCompile(`Invalid Priority`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebConfigResourcePriorityConfig(endpoint string, priority int) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_config" "headers" {
+  type     = "http"
+  name     = "headers"
+  data     = "add_header X-Test yes;"
+  priority = %d
+}
+`, endpoint, priority)
+}
+
+func TestAccBunkerWebConfigResourceRecreateOnDrift(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebConfigResourceRecreateConfig(fakeAPI.URL()),
+			},
+			{
+				PreConfig: func() { fakeAPI.RemoveConfig("global", "http", "secret") },
+				Config:    testAccBunkerWebConfigResourceRecreateConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_config.secret", "data_sha256", configDataSHA256("set $secret one;")),
+					func(*terraform.State) error {
+						cfg, ok := fakeAPI.Config("global", "http", "secret")
+						if !ok || cfg.Data != "set $secret one;" {
+							return fmt.Errorf("expected the config to be recreated, got %+v", cfg)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebConfigResourceRecreateConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_config" "secret" {
+  type                = "http"
+  name                = "secret"
+  data_wo             = "set $secret one;"
+  store_data_in_state = false
+  recreate_on_drift   = true
+}
+`, endpoint)
+}
+
+func TestAccBunkerWebConfigResourceDuplicate(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_config" "first" {
+  type = "http"
+  name = "headers"
+  data = "add_header X-First 1;"
+}
+
+resource "bunkerweb_config" "second" {
+  type = "HTTP"
+  name = "headers"
+  data = "add_header X-Second 1;"
+}
+`, fakeAPI.URL()),
+				ExpectError: regexp.MustCompile(`Duplicate Custom Configuration`),
+			},
+		},
+	})
+}
+
+func TestProviderStateClaimConfig(t *testing.T) {
+	state := &providerState{}
+	first := tftypes.NewValue(tftypes.String, "first")
+	second := tftypes.NewValue(tftypes.String, "second")
+	if !state.claimConfig("global/http/headers", first) {
+		t.Fatalf("expected the first claim to succeed")
+	}
+	if !state.claimConfig("app.example.com/http/headers", second) {
+		t.Fatalf("expected a claim on another service to succeed")
+	}
+	if !state.claimConfig("global/http/headers", first) {
+		t.Fatalf("expected the same configuration to claim its config again")
+	}
+	if state.claimConfig("global/http/headers", second) {
+		t.Fatalf("expected a second claim on the same config to fail")
+	}
+}
+
+func TestConfigResourceReplace(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	config := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_config")
+
+	for _, configType := range []string{"http", "server_http"} {
+		if errs := config.apply(map[string]tftypes.Value{
+			"type": tftypes.NewValue(tftypes.String, configType),
+			"name": tftypes.NewValue(tftypes.String, "headers"),
+			"data": tftypes.NewValue(tftypes.String, "add_header X-Test 1;"),
+		}); len(errs) > 0 {
+			t.Fatalf("apply %s: %s: %s", configType, errs[0].Summary, errs[0].Detail)
+		}
+	}
+	if id := config.attribute("id"); id != "global/server_http/headers" {
+		t.Fatalf("expected the config to be replaced, got %q", id)
+	}
+}
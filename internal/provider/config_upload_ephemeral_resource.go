@@ -5,7 +5,10 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -16,18 +19,26 @@ import (
 )
 
 var _ ephemeral.EphemeralResource = &BunkerWebConfigUploadEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &BunkerWebConfigUploadEphemeralResource{}
+
+// configUploadPrivateKey holds the configs to delete on Close.
+const configUploadPrivateKey = "uploaded"
 
 // BunkerWebConfigUploadEphemeralResource uploads multiple custom config files.
 type BunkerWebConfigUploadEphemeralResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebConfigUploadEphemeralResourceModel captures Terraform input/result fields.
 type BunkerWebConfigUploadEphemeralResourceModel struct {
-	Service types.String                     `tfsdk:"service"`
-	Type    types.String                     `tfsdk:"type"`
-	Files   []BunkerWebConfigUploadFileModel `tfsdk:"files"`
-	Result  types.String                     `tfsdk:"result"`
+	Service         types.String                          `tfsdk:"service"`
+	Type            types.String                          `tfsdk:"type"`
+	Files           []BunkerWebConfigUploadFileModel      `tfsdk:"files"`
+	ContinueOnError types.Bool                            `tfsdk:"continue_on_error"`
+	DeleteOnClose   types.Bool                            `tfsdk:"delete_uploaded_on_close"`
+	Configs         []BunkerWebConfigUploadedModel        `tfsdk:"configs"`
+	Errors          []BunkerWebConfigUploadFileErrorModel `tfsdk:"errors"`
+	Result          types.String                          `tfsdk:"result"`
 }
 
 // BunkerWebConfigUploadFileModel represents a single upload file entry.
@@ -36,6 +47,21 @@ type BunkerWebConfigUploadFileModel struct {
 	Content types.String `tfsdk:"content"`
 }
 
+// BunkerWebConfigUploadedModel describes a config created by the upload.
+type BunkerWebConfigUploadedModel struct {
+	File    types.String `tfsdk:"file"`
+	Service types.String `tfsdk:"service"`
+	Type    types.String `tfsdk:"type"`
+	Name    types.String `tfsdk:"name"`
+	Hash    types.String `tfsdk:"hash"`
+}
+
+// BunkerWebConfigUploadFileErrorModel describes a file the API rejected.
+type BunkerWebConfigUploadFileErrorModel struct {
+	File  types.String `tfsdk:"file"`
+	Error types.String `tfsdk:"error"`
+}
+
 func NewBunkerWebConfigUploadEphemeralResource() ephemeral.EphemeralResource {
 	return &BunkerWebConfigUploadEphemeralResource{}
 }
@@ -73,9 +99,64 @@ func (r *BunkerWebConfigUploadEphemeralResource) Schema(_ context.Context, _ eph
 					},
 				},
 			},
+			"continue_on_error": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "When true, files rejected by the API (for example for an invalid name) are reported in `errors` and as a warning " +
+					"instead of failing, and the other files are still uploaded. Defaults to `false`.",
+			},
+			"delete_uploaded_on_close": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "When true, the uploaded configs are deleted again when Terraform closes the ephemeral resource at the end of " +
+					"the plan or apply, so they only exist for its duration. Defaults to `false`.",
+			},
+			"configs": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Configs created by the upload, in the order of `files`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"file": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the uploaded file.",
+						},
+						"service": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Service of the created config (`global` for global configs).",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the created config.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the created config, as sanitized by the API.",
+						},
+						"hash": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SHA-256 hex digest of the uploaded content.",
+						},
+					},
+				},
+			},
+			"errors": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Files rejected by the API. Only populated when `continue_on_error` is true; otherwise a rejected file fails the upload.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"file": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the rejected file.",
+						},
+						"error": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Reason given by the API.",
+						},
+					},
+				},
+			},
 			"result": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "JSON-encoded response payload describing the uploaded configs.",
+				MarkdownDescription: "JSON-encoded list of the created config identifiers (`service/type/name`).",
+				DeprecationMessage:  "Use the typed `configs` and `errors` attributes instead.",
 				Sensitive:           true,
 			},
 		},
@@ -87,16 +168,16 @@ func (r *BunkerWebConfigUploadEphemeralResource) Configure(_ context.Context, re
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Ephemeral Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *BunkerWebConfigUploadEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
@@ -117,24 +198,181 @@ func (r *BunkerWebConfigUploadEphemeralResource) Open(ctx context.Context, req e
 		return
 	}
 
-	configs, err := r.client.UploadConfigs(ctx, uploadReq)
+	continueOnError := data.ContinueOnError.ValueBool()
+
+	result, err := r.client.UploadConfigs(ctx, uploadReq)
+	var apiErr *bunkerWebAPIError
+	if err != nil && continueOnError && len(uploadReq.Files) > 1 && errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+		// The API rejected the whole batch; upload file by file to find
+		// out which ones it refuses.
+		result, err = uploadConfigsOneByOne(ctx, r.client, uploadReq)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Upload Configs", err.Error())
 		return
 	}
 
-	encoded, err := encodeResult(configs)
+	deleteOnClose := data.DeleteOnClose.ValueBool()
+
+	if len(result.Errors) > 0 {
+		details := make([]string, 0, len(result.Errors))
+		for _, fileErr := range result.Errors {
+			details = append(details, fmt.Sprintf("%s: %s", firstNonEmpty(fileErr.File, "(unknown file)"), fileErr.Message))
+		}
+		if !continueOnError {
+			// Close is not called when Open fails, so the accepted files
+			// are removed right away.
+			if deleteOnClose && len(result.Created) > 0 {
+				if err := deleteUploadedConfigs(ctx, r.client, result.Created); err != nil {
+					resp.Diagnostics.AddError("Unable to Delete Uploaded Configs", err.Error())
+				}
+			}
+			resp.Diagnostics.AddError(
+				"Config Files Rejected",
+				fmt.Sprintf("The API rejected %d of %d files:\n%s\n\nSet continue_on_error = true to keep the accepted files and report the rejected ones.",
+					len(result.Errors), len(uploadReq.Files), strings.Join(details, "\n")),
+			)
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"Config Files Rejected",
+			fmt.Sprintf("The API rejected %d of %d files:\n%s", len(result.Errors), len(uploadReq.Files), strings.Join(details, "\n")),
+		)
+	}
+
+	data.Configs = uploadedConfigModels(uploadReq.Files, result)
+	data.Errors = make([]BunkerWebConfigUploadFileErrorModel, 0, len(result.Errors))
+	for _, fileErr := range result.Errors {
+		data.Errors = append(data.Errors, BunkerWebConfigUploadFileErrorModel{
+			File:  types.StringValue(fileErr.File),
+			Error: types.StringValue(fileErr.Message),
+		})
+	}
+
+	encoded, err := encodeResult(result.Created)
 	if err != nil {
 		resp.Diagnostics.AddError("Encode Result", err.Error())
 		return
 	}
 
 	data.Result = types.StringValue(encoded)
+
+	if deleteOnClose && len(result.Created) > 0 {
+		uploaded, err := json.Marshal(result.Created)
+		if err != nil {
+			resp.Diagnostics.AddError("Encode Result", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, configUploadPrivateKey, uploaded)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
 }
 
-func (r *BunkerWebConfigUploadEphemeralResource) Close(context.Context, ephemeral.CloseRequest, *ephemeral.CloseResponse) {
-	// No follow-up required.
+// uploadConfigsOneByOne uploads each file on its own and collects the files
+// the API rejects.
+func uploadConfigsOneByOne(ctx context.Context, client BunkerWebAPI, input ConfigUploadRequest) (*ConfigUploadResult, error) {
+	combined := &ConfigUploadResult{}
+	for _, file := range input.Files {
+		single := input
+		single.Files = []ConfigUploadFile{file}
+
+		result, err := client.UploadConfigs(ctx, single)
+		var apiErr *bunkerWebAPIError
+		switch {
+		case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity):
+			combined.Errors = append(combined.Errors, ConfigUploadFileError{File: file.FileName, Message: apiErr.Message})
+		case err != nil:
+			return nil, err
+		default:
+			combined.Created = append(combined.Created, result.Created...)
+			combined.Errors = append(combined.Errors, result.Errors...)
+		}
+	}
+	return combined, nil
+}
+
+// uploadedConfigModels pairs the created identifiers with the uploaded files.
+// The API lists created configs in upload order, skipping rejected files.
+func uploadedConfigModels(files []ConfigUploadFile, result *ConfigUploadResult) []BunkerWebConfigUploadedModel {
+	rejected := make(map[string]bool, len(result.Errors))
+	for _, fileErr := range result.Errors {
+		rejected[fileErr.File] = true
+	}
+	accepted := make([]ConfigUploadFile, 0, len(files))
+	for _, file := range files {
+		if !rejected[file.FileName] {
+			accepted = append(accepted, file)
+		}
+	}
+
+	models := make([]BunkerWebConfigUploadedModel, 0, len(result.Created))
+	for idx, id := range result.Created {
+		model := BunkerWebConfigUploadedModel{
+			File:    types.StringNull(),
+			Service: types.StringNull(),
+			Type:    types.StringNull(),
+			Name:    types.StringValue(id),
+			Hash:    types.StringNull(),
+		}
+		if parts := strings.SplitN(id, "/", 3); len(parts) == 3 {
+			model.Service = types.StringValue(parts[0])
+			model.Type = types.StringValue(parts[1])
+			model.Name = types.StringValue(parts[2])
+		}
+		if len(accepted) == len(result.Created) {
+			model.File = types.StringValue(accepted[idx].FileName)
+			model.Hash = types.StringValue(configDataSHA256(string(accepted[idx].Content)))
+		}
+		models = append(models, model)
+	}
+	return models
+}
+
+// Close deletes the configs uploaded by Open when delete_uploaded_on_close is
+// set.
+func (r *BunkerWebConfigUploadEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	encoded, diags := req.Private.GetKey(ctx, configUploadPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(encoded) == 0 {
+		return
+	}
+
+	var created []string
+	if err := json.Unmarshal(encoded, &created); err != nil {
+		resp.Diagnostics.AddError("Unable to Delete Uploaded Configs", fmt.Sprintf("Unable to decode the uploaded configs: %v", err))
+		return
+	}
+	if len(created) == 0 {
+		return
+	}
+
+	if err := deleteUploadedConfigs(ctx, r.client, created); err != nil {
+		resp.Diagnostics.AddError("Unable to Delete Uploaded Configs", fmt.Sprintf("The configs %s were uploaded but not deleted: %s", strings.Join(created, ", "), err))
+	}
+}
+
+// deleteUploadedConfigs deletes the configs created by an upload, given their
+// service/type/name identifiers.
+func deleteUploadedConfigs(ctx context.Context, client BunkerWebAPI, created []string) error {
+	keys := make([]ConfigKey, 0, len(created))
+	for _, id := range created {
+		parts := strings.SplitN(id, "/", 3)
+		if len(parts) != 3 {
+			return fmt.Errorf("unexpected config identifier %q", id)
+		}
+		keys = append(keys, ConfigKey{Service: stringPointer(parts[0]), Type: parts[1], Name: parts[2]})
+	}
+	return client.DeleteConfigs(ctx, keys)
 }
 
 func (m *BunkerWebConfigUploadEphemeralResourceModel) toUploadRequest() (ConfigUploadRequest, diag.Diagnostics) {
@@ -4,7 +4,9 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -56,3 +58,146 @@ ephemeral "bunkerweb_config_upload" "batch" {
 }
 `, endpoint)
 }
+
+func TestAccBunkerWebConfigUploadEphemeralResourceDeleteOnClose(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebConfigUploadEphemeralResourceDeleteOnClose(fakeAPI.URL()),
+			},
+		},
+	})
+
+	deleted := false
+	for _, batch := range fakeAPI.DeletedConfigBatches() {
+		for _, key := range batch {
+			if key.Type == "http" && key.Name == "temporary.conf" {
+				deleted = true
+			}
+		}
+	}
+	if !deleted {
+		t.Fatalf("expected the uploaded config to be deleted on close, got %v", fakeAPI.DeletedConfigBatches())
+	}
+	if _, ok := fakeAPI.Config("web", "http", "temporary.conf"); ok {
+		t.Fatal("expected the uploaded config to be gone after the run")
+	}
+}
+
+func testAccBunkerWebConfigUploadEphemeralResourceDeleteOnClose(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_config_upload" "temporary" {
+  service                  = "web"
+  type                     = "http"
+  delete_uploaded_on_close = true
+
+  files = [
+    {
+      name    = "temporary.conf"
+      content = "location /maintenance { return 503; }"
+    }
+  ]
+}
+`, endpoint)
+}
+
+func TestAccBunkerWebConfigUploadEphemeralResourceRejectedFiles(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebConfigUploadEphemeralResourceRejected(fakeAPI.URL(), false),
+				ExpectError: regexp.MustCompile(`bad name\.conf: invalid config name`),
+			},
+			{
+				Config: testAccBunkerWebConfigUploadEphemeralResourceRejected(fakeAPI.URL(), true),
+			},
+		},
+	})
+}
+
+func TestUploadConfigsOneByOne(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	input := ConfigUploadRequest{Service: "web", Type: "http", Files: []ConfigUploadFile{
+		{FileName: "bad name.conf", Content: []byte("x")},
+		{FileName: "good.conf", Content: []byte("server {}")},
+	}}
+
+	result, err := uploadConfigsOneByOne(context.Background(), client, input)
+	if err != nil {
+		t.Fatalf("uploadConfigsOneByOne: %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0] != "web/http/good.conf" {
+		t.Fatalf("unexpected created configs: %#v", result.Created)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].File != "bad name.conf" {
+		t.Fatalf("unexpected errors: %#v", result.Errors)
+	}
+
+	models := uploadedConfigModels(input.Files, result)
+	if len(models) != 1 {
+		t.Fatalf("expected one uploaded config, got %d", len(models))
+	}
+	if models[0].File.ValueString() != "good.conf" || models[0].Service.ValueString() != "web" || models[0].Name.ValueString() != "good.conf" {
+		t.Fatalf("unexpected uploaded config: %#v", models[0])
+	}
+	if models[0].Hash.ValueString() != configDataSHA256("server {}") {
+		t.Fatalf("unexpected hash %q", models[0].Hash.ValueString())
+	}
+
+	if err := deleteUploadedConfigs(context.Background(), client, result.Created); err != nil {
+		t.Fatalf("deleteUploadedConfigs: %v", err)
+	}
+	if _, ok := api.Config("web", "http", "good.conf"); ok {
+		t.Fatal("expected the uploaded config to be deleted")
+	}
+}
+
+func testAccBunkerWebConfigUploadEphemeralResourceRejected(endpoint string, continueOnError bool) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_config_upload" "batch" {
+  service           = "web"
+  type              = "http"
+  continue_on_error = %t
+
+  files = [
+    {
+      name    = "good.conf"
+      content = "server { listen 80; }"
+    },
+    {
+      name    = "bad name.conf"
+      content = "server { listen 443 ssl; }"
+    }
+  ]
+}
+`, endpoint, continueOnError)
+}
@@ -19,7 +19,7 @@ var _ ephemeral.EphemeralResource = &BunkerWebConfigUploadUpdateEphemeralResourc
 
 // BunkerWebConfigUploadUpdateEphemeralResource updates an existing config with multipart upload semantics.
 type BunkerWebConfigUploadUpdateEphemeralResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebConfigUploadUpdateModel describes the Terraform schema.
@@ -94,16 +94,16 @@ func (r *BunkerWebConfigUploadUpdateEphemeralResource) Configure(_ context.Conte
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Ephemeral Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *BunkerWebConfigUploadUpdateEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
@@ -112,6 +112,10 @@ func (r *BunkerWebConfigUploadUpdateEphemeralResource) Open(ctx context.Context,
 		return
 	}
 
+	if !addFeatureCheck(&resp.Diagnostics, r.client, featureConfigUploadUpdate) {
+		return
+	}
+
 	var data BunkerWebConfigUploadUpdateModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -6,10 +6,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -17,15 +22,32 @@ var _ datasource.DataSource = &BunkerWebConfigsDataSource{}
 
 // BunkerWebConfigsDataSource lists configuration files managed by BunkerWeb.
 type BunkerWebConfigsDataSource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebConfigsDataSourceModel represents the data source configuration/state.
 type BunkerWebConfigsDataSourceModel struct {
-	Service  types.String `tfsdk:"service"`
-	Type     types.String `tfsdk:"type"`
-	WithData types.Bool   `tfsdk:"with_data"`
-	Configs  types.List   `tfsdk:"configs"`
+	Service   types.String `tfsdk:"service"`
+	Type      types.String `tfsdk:"type"`
+	WithData  types.Bool   `tfsdk:"with_data"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	NamesOnly types.Bool   `tfsdk:"names_only"`
+	Limit     types.Int64  `tfsdk:"limit"`
+	Offset    types.Int64  `tfsdk:"offset"`
+	Total     types.Int64  `tfsdk:"total_count"`
+	Configs   types.List   `tfsdk:"configs"`
+	ByType    types.Map    `tfsdk:"by_type"`
+	ByService types.Map    `tfsdk:"by_service"`
+	Names     types.List   `tfsdk:"names"`
+}
+
+// configsElemType is the object type of the configs entries.
+var configsElemType = map[string]attr.Type{
+	"service": types.StringType,
+	"type":    types.StringType,
+	"name":    types.StringType,
+	"data":    types.StringType,
+	"method":  types.StringType,
 }
 
 func NewBunkerWebConfigsDataSource() datasource.DataSource {
@@ -52,37 +74,83 @@ func (d *BunkerWebConfigsDataSource) Schema(_ context.Context, _ datasource.Sche
 				Optional:            true,
 				MarkdownDescription: "When true, includes the configuration file contents in the response.",
 			},
-			"configs": schema.ListNestedAttribute{
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Regular expression (RE2 syntax) the configuration name must match.",
+			},
+			"names_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When true, only `names` is populated and `configs` is left null, so large installations can be enumerated without storing every entry. Cannot be combined with `with_data`.",
+			},
+			"names": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the matching configurations, in API order.",
+			},
+			"configs": configsListAttribute("Configurations returned by the API."),
+			"by_type": schema.MapNestedAttribute{
 				Computed:            true,
-				MarkdownDescription: "Configurations returned by the API.",
+				MarkdownDescription: "The entries of `configs` grouped by type, for example `by_type[\"modsec\"].configs`. Null when `names_only` is set.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"service": schema.StringAttribute{
-							Computed:            true,
-							MarkdownDescription: "Service scope for the configuration entry (" + "global" + " when not bound to a specific service).",
-						},
-						"type": schema.StringAttribute{
-							Computed:            true,
-							MarkdownDescription: "Configuration type segment.",
-						},
-						"name": schema.StringAttribute{
-							Computed:            true,
-							MarkdownDescription: "Configuration file name.",
-						},
-						"data": schema.StringAttribute{
-							Computed:            true,
-							MarkdownDescription: "Configuration content when requested via `with_data`.",
-							Sensitive:           true,
-						},
-						"method": schema.StringAttribute{
+						"configs": configsListAttribute("Configurations of the type."),
+					},
+				},
+			},
+			"by_service": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The entries of `configs` grouped by service (`global` for the global scope), for example `by_service[\"app\"].by_type[\"modsec\"].configs`. Null when `names_only` is set.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"configs": configsListAttribute("Configurations of the service."),
+						"by_type": schema.MapNestedAttribute{
 							Computed:            true,
-							MarkdownDescription: "Creation method reported by the API (for example `api`).",
+							MarkdownDescription: "Configurations of the service grouped by type.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"configs": configsListAttribute("Configurations of the service and type."),
+								},
+							},
 						},
 					},
 				},
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, paginationSchemaAttributes("configurations"))
+}
+
+// configsListAttribute is the schema of the configs list and of its groups.
+func configsListAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"service": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Service scope for the configuration entry (" + "global" + " when not bound to a specific service).",
+				},
+				"type": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Configuration type segment.",
+				},
+				"name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Configuration file name.",
+				},
+				"data": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Configuration content when requested via `with_data`.",
+					Sensitive:           true,
+				},
+				"method": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Creation method reported by the API (for example `api`).",
+				},
+			},
+		},
+	}
 }
 
 func (d *BunkerWebConfigsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -90,16 +158,16 @@ func (d *BunkerWebConfigsDataSource) Configure(_ context.Context, req datasource
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *BunkerWebConfigsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -114,6 +182,22 @@ func (d *BunkerWebConfigsDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
+	namesOnly := !data.NamesOnly.IsNull() && !data.NamesOnly.IsUnknown() && data.NamesOnly.ValueBool()
+	if namesOnly && !data.WithData.IsNull() && data.WithData.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("names_only"), "Conflicting Options", "names_only cannot be combined with with_data = true.")
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() && !data.NameRegex.IsUnknown() {
+		compiled, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+		nameRegex = compiled
+	}
+
 	opts := ConfigListOptions{}
 	if !data.Service.IsNull() && !data.Service.IsUnknown() {
 		service := data.Service.ValueString()
@@ -127,6 +211,10 @@ func (d *BunkerWebConfigsDataSource) Read(ctx context.Context, req datasource.Re
 		withData := data.WithData.ValueBool()
 		opts.WithData = &withData
 	}
+	if namesOnly {
+		withData := false
+		opts.WithData = &withData
+	}
 
 	configs, err := d.client.ListConfigs(ctx, opts)
 	if err != nil {
@@ -134,27 +222,123 @@ func (d *BunkerWebConfigsDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
-	elemType := map[string]attr.Type{
-		"service": types.StringType,
-		"type":    types.StringType,
-		"name":    types.StringType,
-		"data":    types.StringType,
-		"method":  types.StringType,
+	if nameRegex != nil {
+		configs = slices.DeleteFunc(configs, func(cfg bunkerWebConfig) bool { return !nameRegex.MatchString(cfg.Name) })
 	}
+
+	start, end, diags := pageBounds(data.Limit, data.Offset, len(configs))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Total = types.Int64Value(int64(len(configs)))
+	configs = configs[start:end]
+
 	elems := make([]attr.Value, 0, len(configs))
+	names := make([]string, 0, len(configs))
+	byType := map[string][]attr.Value{}
+	byService := map[string][]attr.Value{}
+	byServiceType := map[string]map[string][]attr.Value{}
 
 	for _, cfg := range configs {
-		values := map[string]attr.Value{
+		names = append(names, cfg.Name)
+		if namesOnly {
+			continue
+		}
+
+		obj, diags := types.ObjectValue(configsElemType, map[string]attr.Value{
 			"service": types.StringValue(cfg.Service),
 			"type":    types.StringValue(cfg.Type),
 			"name":    types.StringValue(cfg.Name),
 			"data":    types.StringValue(cfg.Data),
 			"method":  types.StringValue(cfg.Method),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		elems = append(elems, obj)
+		byType[cfg.Type] = append(byType[cfg.Type], obj)
+		byService[cfg.Service] = append(byService[cfg.Service], obj)
+		if byServiceType[cfg.Service] == nil {
+			byServiceType[cfg.Service] = map[string][]attr.Value{}
 		}
-		elems = append(elems, types.ObjectValueMust(elemType, values))
+		byServiceType[cfg.Service][cfg.Type] = append(byServiceType[cfg.Service][cfg.Type], obj)
+	}
+
+	namesValue, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Names = namesValue
+	configType := types.ObjectType{AttrTypes: configsElemType}
+	groupType := configGroupType(configType)
+	serviceGroupType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"configs": types.ListType{ElemType: configType},
+		"by_type": types.MapType{ElemType: groupType},
+	}}
+	if namesOnly {
+		data.Configs = types.ListNull(configType)
+		data.ByType = types.MapNull(groupType)
+		data.ByService = types.MapNull(serviceGroupType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	data.Configs, diags = types.ListValue(configType, elems)
+	resp.Diagnostics.Append(diags...)
+	data.ByType, diags = configGroups(configType, byType)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	data.Configs = types.ListValueMust(types.ObjectType{AttrTypes: elemType}, elems)
+	services := make(map[string]attr.Value, len(byService))
+	for service, serviceConfigs := range byService {
+		list, diags := types.ListValue(configType, serviceConfigs)
+		resp.Diagnostics.Append(diags...)
+		serviceTypes, diags := configGroups(configType, byServiceType[service])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		services[service], diags = types.ObjectValue(serviceGroupType.AttrTypes, map[string]attr.Value{"configs": list, "by_type": serviceTypes})
+		resp.Diagnostics.Append(diags...)
+	}
+	data.ByService, diags = types.MapValue(serviceGroupType, services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// configGroupType is the object type of the by_type entries.
+func configGroupType(configType types.ObjectType) types.ObjectType {
+	return types.ObjectType{AttrTypes: map[string]attr.Type{"configs": types.ListType{ElemType: configType}}}
+}
+
+// configGroups builds a by_type map from config objects grouped by key.
+func configGroups(configType types.ObjectType, groups map[string][]attr.Value) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	groupType := configGroupType(configType)
+
+	values := make(map[string]attr.Value, len(groups))
+	for key, configs := range groups {
+		list, d := types.ListValue(configType, configs)
+		diags.Append(d...)
+		group, d := types.ObjectValue(groupType.AttrTypes, map[string]attr.Value{"configs": list})
+		diags.Append(d...)
+		values[key] = group
+	}
+	if diags.HasError() {
+		return types.MapNull(groupType), diags
+	}
+
+	value, d := types.MapValue(groupType, values)
+	diags.Append(d...)
+	return value, diags
+}
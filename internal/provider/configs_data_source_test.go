@@ -4,9 +4,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -21,13 +29,84 @@ func TestAccBunkerWebConfigsDataSource(t *testing.T) {
 				Config: testAccBunkerWebConfigsDataSourceConfig(fakeAPI.URL()),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.bunkerweb_configs.all", "configs.#", "2"),
+					resource.TestCheckResourceAttr("data.bunkerweb_configs.all", "by_type.http.configs.#", "2"),
+					resource.TestCheckResourceAttr("data.bunkerweb_configs.all", "by_service.app.by_type.http.configs.0.name", "app.conf"),
+					resource.TestCheckNoResourceAttr("data.bunkerweb_configs.names", "by_service.%"),
 					resource.TestCheckResourceAttr("data.bunkerweb_configs.global", "configs.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_configs.names", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_configs.names", "names.0", "app.conf"),
+					resource.TestCheckNoResourceAttr("data.bunkerweb_configs.names", "configs.#"),
+					resource.TestCheckResourceAttr("data.bunkerweb_configs.page", "configs.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_configs.page", "total_count", "2"),
 				),
 			},
 		},
 	})
 }
 
+func TestConfigsDataSourceGroups(t *testing.T) {
+	ctx := context.Background()
+	client := newMockBunkerWebAPI(t)
+	client.ListConfigsFunc = func(context.Context, ConfigListOptions) ([]bunkerWebConfig, error) {
+		return []bunkerWebConfig{
+			{Service: "app", Type: "modsec", Name: "allow-upload"},
+			{Service: "app", Type: "http", Name: "app"},
+			{Service: "app", Type: "modsec", Name: "block-admin"},
+			{Service: "global", Type: "modsec", Name: "global-rules"},
+		}, nil
+	}
+	d := &BunkerWebConfigsDataSource{client: client}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	// tfsdk.Config cannot be set directly; build its value through a state.
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	configType := types.ObjectType{AttrTypes: configsElemType}
+	if diags := config.Set(ctx, &BunkerWebConfigsDataSourceModel{
+		Total:     types.Int64Null(),
+		Configs:   types.ListNull(configType),
+		Names:     types.ListNull(types.StringType),
+		ByType:    types.MapNull(configGroupType(configType)),
+		ByService: types.MapNull(types.ObjectType{AttrTypes: map[string]attr.Type{"configs": types.ListType{ElemType: configType}, "by_type": types.MapType{ElemType: configGroupType(configType)}}}),
+	}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+
+	groupNames := func(p path.Path) []string {
+		var list types.List
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, p.AtName("configs"), &list)...)
+		var names []string
+		for _, elem := range list.Elements() {
+			names = append(names, elem.(types.Object).Attributes()["name"].(types.String).ValueString())
+		}
+		return names
+	}
+	for p, want := range map[string]struct {
+		path  path.Path
+		names []string
+	}{
+		"by_type.modsec":           {path.Root("by_type").AtMapKey("modsec"), []string{"allow-upload", "block-admin", "global-rules"}},
+		"by_type.http":             {path.Root("by_type").AtMapKey("http"), []string{"app"}},
+		"by_service.app":           {path.Root("by_service").AtMapKey("app"), []string{"allow-upload", "app", "block-admin"}},
+		"by_service.app.modsec":    {path.Root("by_service").AtMapKey("app").AtName("by_type").AtMapKey("modsec"), []string{"allow-upload", "block-admin"}},
+		"by_service.global.modsec": {path.Root("by_service").AtMapKey("global").AtName("by_type").AtMapKey("modsec"), []string{"global-rules"}},
+	} {
+		if got := groupNames(want.path); !slices.Equal(got, want.names) {
+			t.Errorf("%s: expected %v, got %v", p, want.names, got)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("GetAttribute: %v", resp.Diagnostics)
+	}
+}
+
 func testAccBunkerWebConfigsDataSourceConfig(endpoint string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -58,5 +137,17 @@ data "bunkerweb_configs" "global" {
   depends_on = [bunkerweb_config.global_conf]
 }
 
+data "bunkerweb_configs" "names" {
+  name_regex = "^app\\."
+  names_only = true
+  depends_on = [bunkerweb_config.app, bunkerweb_config.global_conf]
+}
+
+data "bunkerweb_configs" "page" {
+  limit      = 1
+  offset     = 1
+  depends_on = [bunkerweb_config.app, bunkerweb_config.global_conf]
+}
+
 `, endpoint)
 }
@@ -25,7 +25,7 @@ func NewBunkerWebDataSource() datasource.DataSource {
 
 // BunkerWebDataSource defines the data source implementation.
 type BunkerWebDataSource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebDataSourceModel describes the data source data model.
@@ -34,6 +34,9 @@ type BunkerWebDataSourceModel struct {
 	ServerName types.String `tfsdk:"server_name"`
 	IsDraft    types.Bool   `tfsdk:"is_draft"`
 	Variables  types.Map    `tfsdk:"variables"`
+	Method     types.String `tfsdk:"method"`
+	Created    types.String `tfsdk:"creation_date"`
+	Updated    types.String `tfsdk:"last_update"`
 }
 
 func (d *BunkerWebDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -62,6 +65,18 @@ func (d *BunkerWebDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Computed:            true,
 				MarkdownDescription: "Service variables as key/value pairs.",
 			},
+			"method": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "How the service was last modified, as reported by BunkerWeb (for example `ui`, `api` or `scheduler`).",
+			},
+			"creation_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the service was created, in RFC 3339 format (null when the API does not report it).",
+			},
+			"last_update": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the service was last modified, in RFC 3339 format (null when the API does not report it).",
+			},
 		},
 	}
 }
@@ -72,18 +87,18 @@ func (d *BunkerWebDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *BunkerWebDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -116,6 +131,13 @@ func (d *BunkerWebDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	summary, err := d.client.GetServiceSummary(ctx, got.Service)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Service", err.Error())
+		return
+	}
+	data.Method, data.Created, data.Updated = serviceMetadata(summary)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
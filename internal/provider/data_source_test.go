@@ -24,6 +24,8 @@ func TestAccBunkerWebDataSource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.bunkerweb_service.test", "server_name", "test.example.com"),
 					resource.TestCheckResourceAttr("data.bunkerweb_service.test", "variables.test", "one"),
+					resource.TestCheckResourceAttr("data.bunkerweb_service.test", "method", "api"),
+					resource.TestCheckResourceAttrPair("data.bunkerweb_service.test", "creation_date", "bunkerweb_service.test", "creation_date"),
 				),
 			},
 		},
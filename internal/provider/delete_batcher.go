@@ -0,0 +1,110 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// deleteBatchWindow is how long a deletion waits for others to join its
+	// batch. Terraform deletes independent resources concurrently, so
+	// deletions requested within the window have no ordering constraints.
+	deleteBatchWindow = 200 * time.Millisecond
+	// deleteBatchMax flushes a batch early once it holds this many items.
+	deleteBatchMax = 100
+)
+
+// deleteBatcher coalesces concurrent deletions into calls to a bulk
+// endpoint. A lone deletion, or every deletion of a batch whose bulk call
+// failed, goes through single so each resource reports its own error (for
+// example a 404).
+type deleteBatcher[K any] struct {
+	what   string
+	bulk   func(context.Context, []K) error
+	single func(context.Context, K) error
+
+	mu      sync.Mutex
+	pending *deleteBatch[K]
+}
+
+type deleteBatch[K any] struct {
+	items []K
+	done  chan struct{}
+	err   error
+}
+
+func newDeleteBatcher[K any](what string, bulk func(context.Context, []K) error, single func(context.Context, K) error) *deleteBatcher[K] {
+	return &deleteBatcher[K]{what: what, bulk: bulk, single: single}
+}
+
+// delete queues item and returns once its batch has been deleted.
+func (b *deleteBatcher[K]) delete(ctx context.Context, item K) error {
+	b.mu.Lock()
+	batch := b.pending
+	if batch == nil {
+		batch = &deleteBatch[K]{done: make(chan struct{})}
+		b.pending = batch
+		// The batch outlives the first caller's request if it is cancelled;
+		// the other callers still wait for it.
+		go b.flushAfter(context.WithoutCancel(ctx), batch, deleteBatchWindow)
+	}
+	batch.items = append(batch.items, item)
+	if len(batch.items) >= deleteBatchMax {
+		b.pending = nil
+		go b.flush(context.WithoutCancel(ctx), batch)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-batch.done:
+	}
+
+	if batch.err == nil {
+		return nil
+	}
+	return b.single(ctx, item)
+}
+
+func (b *deleteBatcher[K]) flushAfter(ctx context.Context, batch *deleteBatch[K], window time.Duration) {
+	time.Sleep(window)
+
+	b.mu.Lock()
+	if b.pending != batch {
+		// Already flushed because it was full.
+		b.mu.Unlock()
+		return
+	}
+	b.pending = nil
+	b.mu.Unlock()
+
+	b.flush(ctx, batch)
+}
+
+// flush deletes the batch. A failed bulk call leaves batch.err set so each
+// caller retries on its own; a lone item is left to its caller directly.
+func (b *deleteBatcher[K]) flush(ctx context.Context, batch *deleteBatch[K]) {
+	defer close(batch.done)
+
+	if len(batch.items) == 1 {
+		batch.err = errDeleteNotBatched
+		return
+	}
+
+	tflog.Debug(ctx, "deleting bunkerweb objects in bulk", map[string]any{"what": b.what, "count": len(batch.items)})
+	if err := b.bulk(ctx, batch.items); err != nil {
+		tflog.Debug(ctx, "bulk deletion failed, deleting one at a time", map[string]any{"what": b.what, "error": err.Error()})
+		batch.err = err
+	}
+}
+
+// errDeleteNotBatched sends a lone deletion to the single-item endpoint.
+var errDeleteNotBatched = errors.New("deletion not batched")
@@ -0,0 +1,88 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestBunkerWebClientBatchDeleteConfig(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	for _, name := range []string{"one", "two", "three", "four"} {
+		api.AddConfig(bunkerWebConfig{Service: "global", Type: "http", Name: name, Data: "# " + name})
+	}
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"one", "two", "three"} {
+		wg.Go(func() {
+			if err := client.BatchDeleteConfig(ctx, ConfigKey{Type: "http", Name: name}); err != nil {
+				t.Errorf("BatchDeleteConfig(%s): %v", name, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	batches := api.DeletedConfigBatches()
+	if len(batches) != 1 || len(batches[0]) != 3 {
+		t.Fatalf("expected the three deletions in one bulk call, got %v", batches)
+	}
+
+	// A lone deletion uses the single-item endpoint, so its errors (such
+	// as a 404) reach the resource unchanged.
+	if err := client.BatchDeleteConfig(ctx, ConfigKey{Type: "http", Name: "four"}); err != nil {
+		t.Fatalf("BatchDeleteConfig(four): %v", err)
+	}
+	if _, ok := api.Config("global", "http", "four"); ok {
+		t.Fatalf("expected the lone config to be deleted")
+	}
+	if got := len(api.DeletedConfigBatches()); got != 1 {
+		t.Fatalf("expected no further bulk call, got %d batches", got)
+	}
+}
+
+func TestDeleteBatcherFallsBackToSingleDeletes(t *testing.T) {
+	var mu sync.Mutex
+	var singles []string
+	b := newDeleteBatcher("items",
+		func(context.Context, []string) error { return errors.New("bulk unavailable") },
+		func(_ context.Context, item string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			singles = append(singles, item)
+			if item == "bad" {
+				return errors.New("cannot delete bad")
+			}
+			return nil
+		},
+	)
+
+	ctx := context.Background()
+	errs := make(map[string]error)
+	var wg sync.WaitGroup
+	for _, item := range []string{"good", "bad"} {
+		wg.Go(func() {
+			err := b.delete(ctx, item)
+			mu.Lock()
+			defer mu.Unlock()
+			errs[item] = err
+		})
+	}
+	wg.Wait()
+
+	if len(singles) != 2 {
+		t.Fatalf("expected each item to be deleted on its own after the bulk failure, got %v", singles)
+	}
+	if errs["good"] != nil || errs["bad"] == nil {
+		t.Fatalf("expected only the bad item to fail, got %v", errs)
+	}
+}
@@ -26,7 +26,7 @@ func NewBunkerWebEphemeralResource() ephemeral.EphemeralResource {
 
 // BunkerWebEphemeralResource defines the ephemeral resource implementation.
 type BunkerWebEphemeralResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebEphemeralResourceModel describes the ephemeral resource data model.
@@ -72,16 +72,16 @@ func (r *BunkerWebEphemeralResource) Configure(ctx context.Context, req ephemera
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Ephemeral Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *BunkerWebEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
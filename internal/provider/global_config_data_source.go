@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -20,12 +21,13 @@ func NewBunkerWebGlobalConfigDataSource() datasource.DataSource {
 }
 
 type BunkerWebGlobalConfigDataSource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 type BunkerWebGlobalConfigDataSourceModel struct {
-	Full     types.Bool `tfsdk:"full"`
-	Settings types.Map  `tfsdk:"settings"`
+	Full      types.Bool `tfsdk:"full"`
+	Settings  types.Map  `tfsdk:"settings"`
+	ImportIDs types.List `tfsdk:"import_ids"`
 }
 
 func (d *BunkerWebGlobalConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -45,6 +47,12 @@ func (d *BunkerWebGlobalConfigDataSource) Schema(_ context.Context, _ datasource
 				Computed:            true,
 				MarkdownDescription: "Key/value pairs representing the global configuration. Complex values are JSON encoded.",
 			},
+			"import_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				MarkdownDescription: "Sorted keys of the settings that differ from their default value, usable as " +
+					"`bunkerweb_global_config_setting` import IDs in a `for_each` import block to adopt an existing deployment at once.",
+			},
 		},
 	}
 }
@@ -54,16 +62,16 @@ func (d *BunkerWebGlobalConfigDataSource) Configure(_ context.Context, req datas
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *BunkerWebGlobalConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -102,6 +110,31 @@ func (d *BunkerWebGlobalConfigDataSource) Read(ctx context.Context, req datasour
 
 	data.Settings = value
 
+	// Only settings changed from their defaults are worth adopting; reuse the
+	// response when it already excludes defaults.
+	changed := settings
+	if full {
+		changed, err = d.client.GetGlobalConfig(ctx, false, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
+			return
+		}
+	}
+
+	keys := make([]string, 0, len(changed))
+	for key := range changed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	importIDs, diag := types.ListValueFrom(ctx, types.StringType, keys)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ImportIDs = importIDs
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
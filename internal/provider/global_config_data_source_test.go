@@ -30,6 +30,28 @@ func TestAccBunkerWebGlobalConfigDataSource(t *testing.T) {
 	})
 }
 
+func TestAccBunkerWebGlobalConfigDataSourceImportIDs(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddGlobalDefault("USE_GZIP", "no")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebGlobalConfigDataSourceConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config.current", "settings.%", "4"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config.current", "import_ids.#", "3"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config.current", "import_ids.0", "feature_enabled"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config.current", "import_ids.1", "retry_limit"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config.current", "import_ids.2", "some_setting"),
+				),
+			},
+		},
+	})
+}
+
 func testAccBunkerWebGlobalConfigDataSourceConfig(endpoint string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -0,0 +1,177 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BunkerWebGlobalConfigDiffDataSource{}
+
+func NewBunkerWebGlobalConfigDiffDataSource() datasource.DataSource {
+	return &BunkerWebGlobalConfigDiffDataSource{}
+}
+
+// BunkerWebGlobalConfigDiffDataSource compares proposed global settings with
+// the live configuration.
+type BunkerWebGlobalConfigDiffDataSource struct {
+	client BunkerWebAPI
+}
+
+type BunkerWebGlobalConfigDiffDataSourceModel struct {
+	Settings    types.Map  `tfsdk:"settings"`
+	Changes     types.List `tfsdk:"changes"`
+	ChangedKeys types.List `tfsdk:"changed_keys"`
+	HasChanges  types.Bool `tfsdk:"has_changes"`
+}
+
+var globalConfigChangeAttrTypes = map[string]attr.Type{
+	"key":      types.StringType,
+	"current":  types.StringType,
+	"proposed": types.StringType,
+}
+
+func (d *BunkerWebGlobalConfigDiffDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_config_diff"
+}
+
+func (d *BunkerWebGlobalConfigDiffDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compares proposed global settings with the live global configuration and reports the keys that applying them would change, " +
+			"so change reviews can show the effective delta before an apply is approved. Values read back in another spelling (`yes`/`true`, `10`/`10.0`) are not reported as changes.",
+		Attributes: map[string]schema.Attribute{
+			"settings": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Proposed global settings, as passed to `bunkerweb_global_config_setting` or `bunkerweb_global_config_patch`.",
+			},
+			"changes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Settings whose proposed value differs from the live one, sorted by key.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Setting name.",
+						},
+						"current": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Live value (null when the API does not report the setting).",
+						},
+						"proposed": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Proposed value.",
+						},
+					},
+				},
+			},
+			"changed_keys": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Sorted keys of `changes`.",
+			},
+			"has_changes": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether applying `settings` would change anything.",
+			},
+		},
+	}
+}
+
+func (d *BunkerWebGlobalConfigDiffDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebGlobalConfigDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebGlobalConfigDiffDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	proposed, diags := mapFromTerraform(ctx, data.Settings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compare against the full configuration so a setting proposed at its
+	// default value is not reported as a change.
+	live, err := d.client.GetGlobalConfig(ctx, true, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
+		return
+	}
+
+	keys := make([]string, 0, len(proposed))
+	for key := range proposed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	normalize := settingValueNormalizer(ctx, d.client)
+	changedKeys := []string{}
+	changes := []attr.Value{}
+	for _, key := range keys {
+		current := types.StringNull()
+		if value, ok := live[key]; ok {
+			stringified := stringifyValue(value)
+			if normalize(key, proposed[key], stringified) == proposed[key] {
+				continue
+			}
+			current = types.StringValue(stringified)
+		}
+
+		change, diags := types.ObjectValue(globalConfigChangeAttrTypes, map[string]attr.Value{
+			"key":      types.StringValue(key),
+			"current":  current,
+			"proposed": types.StringValue(proposed[key]),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		changes = append(changes, change)
+		changedKeys = append(changedKeys, key)
+	}
+
+	changesValue, diags := types.ListValue(types.ObjectType{AttrTypes: globalConfigChangeAttrTypes}, changes)
+	resp.Diagnostics.Append(diags...)
+	changedKeysValue, diags := types.ListValueFrom(ctx, types.StringType, changedKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Changes = changesValue
+	data.ChangedKeys = changedKeysValue
+	data.HasChanges = types.BoolValue(len(changedKeys) > 0)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,56 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebGlobalConfigDiffDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddGlobalDefault("USE_GZIP", "no")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebGlobalConfigDiffDataSourceConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "has_changes", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "changed_keys.#", "3"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "changes.0.key", "NEW_SETTING"),
+					resource.TestCheckNoResourceAttr("data.bunkerweb_global_config_diff.pending", "changes.0.current"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "changes.0.proposed", "on"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "changes.1.key", "USE_GZIP"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "changes.1.current", "no"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "changes.1.proposed", "yes"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "changes.2.key", "retry_limit"),
+					resource.TestCheckResourceAttr("data.bunkerweb_global_config_diff.pending", "changes.2.current", "5"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebGlobalConfigDiffDataSourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_global_config_diff" "pending" {
+  settings = {
+    some_setting = "value"
+    retry_limit  = "10"
+    USE_GZIP     = "yes"
+    NEW_SETTING  = "on"
+  }
+}
+`, endpoint)
+}
@@ -0,0 +1,115 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// globalConfigLockSetting is the reserved global setting holding the advisory
+// lock; its value is "owner=<id> expires=<RFC3339 time>". The API has no
+// lock endpoint, so concurrent providers coordinate through this value.
+const globalConfigLockSetting = "TERRAFORM_PROVIDER_LOCK"
+
+// globalConfigLockPoll is how often a held lock is checked again.
+var globalConfigLockPoll = 2 * time.Second
+
+// newLockOwner identifies this provider process in the lock value.
+func newLockOwner() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "terraform"
+	}
+	buf := make([]byte, 4)
+	_, _ = rand.Read(buf)
+	return strings.ReplaceAll(host, " ", "_") + "-" + hex.EncodeToString(buf)
+}
+
+// lockGlobalConfig takes or renews the advisory lease on the global config
+// before a PATCH. The lease is held for lockTTL and renewed by later writes;
+// the plugin framework has no shutdown hook, so it is never released
+// explicitly and lapses once the apply stops writing. Another holder's lease
+// is waited for until it expires.
+func (c *bunkerWebClient) lockGlobalConfig(ctx context.Context) error {
+	if c.lockTTL <= 0 {
+		return nil
+	}
+
+	c.lockMu.Lock()
+	defer c.lockMu.Unlock()
+
+	if time.Until(c.lockExpires) > c.lockTTL/2 {
+		return nil
+	}
+
+	for {
+		settings, err := c.GetGlobalConfig(ctx, true, false)
+		if err != nil {
+			return fmt.Errorf("unable to read the global config lock: %w", err)
+		}
+
+		holder, expires := parseGlobalConfigLock(stringifyValue(settings[globalConfigLockSetting]))
+		if holder == "" || holder == c.lockOwner || !time.Now().Before(expires) {
+			expiresAt := time.Now().Add(c.lockTTL).UTC().Truncate(time.Second)
+			if err := c.patchGlobalConfig(ctx, map[string]any{
+				globalConfigLockSetting: formatGlobalConfigLock(c.lockOwner, expiresAt),
+			}); err != nil {
+				return fmt.Errorf("unable to take the global config lock: %w", err)
+			}
+
+			// The API has no compare-and-swap: read back to detect a
+			// provider that took the lock at the same time.
+			settings, err = c.GetGlobalConfig(ctx, true, false)
+			if err != nil {
+				return fmt.Errorf("unable to read the global config lock: %w", err)
+			}
+			if holder, _ = parseGlobalConfigLock(stringifyValue(settings[globalConfigLockSetting])); holder == c.lockOwner {
+				c.lockExpires = expiresAt
+				tflog.Debug(ctx, "took global config lock", map[string]any{"owner": c.lockOwner, "expires": expiresAt.Format(time.RFC3339)})
+				return nil
+			}
+			continue
+		}
+
+		tflog.Info(ctx, "waiting for global config lock", map[string]any{"holder": holder, "expires": expires.Format(time.RFC3339)})
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("global config is locked by %s until %s: %w", holder, expires.Format(time.RFC3339), ctx.Err())
+		case <-time.After(globalConfigLockPoll):
+		}
+	}
+}
+
+func formatGlobalConfigLock(owner string, expires time.Time) string {
+	return fmt.Sprintf("owner=%s expires=%s", owner, expires.Format(time.RFC3339))
+}
+
+// parseGlobalConfigLock returns the holder and expiry of a lock value. An
+// empty or malformed value is not a lock.
+func parseGlobalConfigLock(value string) (owner string, expires time.Time) {
+	for _, field := range strings.Fields(value) {
+		key, v, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "owner":
+			owner = v
+		case "expires":
+			expires, _ = time.Parse(time.RFC3339, v)
+		}
+	}
+	if owner == "" || expires.IsZero() {
+		return "", time.Time{}
+	}
+	return owner, expires
+}
@@ -0,0 +1,77 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newLockingTestClient(t *testing.T, api *fakeBunkerWebAPI, owner string, ttl time.Duration) *bunkerWebClient {
+	t.Helper()
+	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.lockTTL = ttl
+	client.lockOwner = owner
+	return client
+}
+
+func TestGlobalConfigLock(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	first := newLockingTestClient(t, api, "first", time.Hour)
+	second := newLockingTestClient(t, api, "second", time.Hour)
+
+	original := globalConfigLockPoll
+	globalConfigLockPoll = 10 * time.Millisecond
+	t.Cleanup(func() { globalConfigLockPoll = original })
+
+	if _, err := first.UpdateGlobalConfig(context.Background(), map[string]any{"USE_GZIP": "yes"}); err != nil {
+		t.Fatalf("UpdateGlobalConfig: %v", err)
+	}
+	value, _ := api.GlobalSetting(globalConfigLockSetting)
+	if owner, _ := parseGlobalConfigLock(stringifyValue(value)); owner != "first" {
+		t.Fatalf("expected the lock to be held by first, got %v", value)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := second.UpdateGlobalConfig(ctx, map[string]any{"USE_GZIP": "no"})
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "locked by first") {
+		t.Fatalf("expected second to wait for the lock, got %v", err)
+	}
+	if got, _ := api.GlobalSetting("USE_GZIP"); got != "yes" {
+		t.Fatalf("expected the locked write not to be applied, got %v", got)
+	}
+
+	// An expired lease is taken over.
+	plain := newLockingTestClient(t, api, "", 0)
+	if _, err := plain.UpdateGlobalConfig(context.Background(), map[string]any{globalConfigLockSetting: formatGlobalConfigLock("first", time.Now().Add(-time.Minute))}); err != nil {
+		t.Fatalf("expiring the lock: %v", err)
+	}
+	if _, err := second.UpdateGlobalConfig(context.Background(), map[string]any{"USE_GZIP": "no"}); err != nil {
+		t.Fatalf("expected second to take the expired lock, got %v", err)
+	}
+	value, _ = api.GlobalSetting(globalConfigLockSetting)
+	if owner, _ := parseGlobalConfigLock(stringifyValue(value)); owner != "second" {
+		t.Fatalf("expected the lock to be held by second, got %v", value)
+	}
+}
+
+func TestParseGlobalConfigLock(t *testing.T) {
+	expires := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	owner, got := parseGlobalConfigLock(formatGlobalConfigLock("ci-1234", expires))
+	if owner != "ci-1234" || !got.Equal(expires) {
+		t.Fatalf("unexpected lock %q %v", owner, got)
+	}
+	for _, value := range []string{"", "owner=ci", "expires=2026-01-02T03:04:05Z", "garbage"} {
+		if owner, _ := parseGlobalConfigLock(value); owner != "" {
+			t.Fatalf("expected %q not to be a lock, got owner %q", value, owner)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &BunkerWebGlobalConfigPatchEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &BunkerWebGlobalConfigPatchEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &BunkerWebGlobalConfigPatchEphemeralResource{}
+
+// globalConfigPatchPrivateKey holds the settings to restore on Close.
+const globalConfigPatchPrivateKey = "previous"
+
+// BunkerWebGlobalConfigPatchEphemeralResource applies global settings for the
+// lifetime of a plan or apply.
+type BunkerWebGlobalConfigPatchEphemeralResource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebGlobalConfigPatchModel represents the Terraform schema.
+type BunkerWebGlobalConfigPatchModel struct {
+	Settings types.Map `tfsdk:"settings"`
+	Previous types.Map `tfsdk:"previous"`
+}
+
+func NewBunkerWebGlobalConfigPatchEphemeralResource() ephemeral.EphemeralResource {
+	return &BunkerWebGlobalConfigPatchEphemeralResource{}
+}
+
+func (r *BunkerWebGlobalConfigPatchEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_config_patch"
+}
+
+func (r *BunkerWebGlobalConfigPatchEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Temporarily applies global settings, such as a maintenance or verbose-logging toggle, and restores the previous values when Terraform closes the resource at the end of the plan or apply. Settings that were not explicitly set beforehand are reset to their defaults.",
+		Attributes: map[string]schema.Attribute{
+			"settings": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Global settings to apply while the resource is open.",
+			},
+			"previous": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Values the settings had before they were patched; null for settings that were at their default.",
+			},
+		},
+	}
+}
+
+func (r *BunkerWebGlobalConfigPatchEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+// ValidateConfig summarises the settings that will be patched, once every
+// value is known.
+func (r *BunkerWebGlobalConfigPatchEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data BunkerWebGlobalConfigPatchModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		return
+	}
+	settings, diags := mapFromTerraform(ctx, data.Settings)
+	if diags.HasError() || len(settings) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	addPlanSummary(&resp.Diagnostics, "bunkerweb_global_config_patch", []string{
+		"set " + summarizeTargets(len(keys), "global setting", keys) + " until it is closed",
+	})
+}
+
+func (r *BunkerWebGlobalConfigPatchEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebGlobalConfigPatchModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, diags := mapFromTerraform(ctx, data.Settings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(settings) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("settings"), "Missing Settings", "Provide at least one global setting to patch.")
+		return
+	}
+
+	// Only explicitly set values are restored; anything else goes back to
+	// its default.
+	current, err := r.client.GetGlobalConfig(ctx, false, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
+		return
+	}
+
+	previous := make(map[string]*string, len(settings))
+	patch := make(map[string]any, len(settings))
+	for key, value := range settings {
+		key = strings.TrimSpace(key)
+		previous[key] = nil
+		if existing, ok := current[key]; ok && existing != nil {
+			v := stringifyValue(existing)
+			previous[key] = &v
+		}
+		patch[key] = value
+	}
+
+	encoded, err := json.Marshal(previous)
+	if err != nil {
+		resp.Diagnostics.AddError("Encode Result", err.Error())
+		return
+	}
+	// Record what to restore before patching, so a partial failure is
+	// still undone on Close.
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, globalConfigPatchPrivateKey, encoded)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.UpdateGlobalConfig(ctx, patch); err != nil {
+		resp.Diagnostics.AddError("Unable to Patch Global Config", err.Error())
+		return
+	}
+
+	data.Previous, diags = types.MapValueFrom(ctx, types.StringType, previous)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *BunkerWebGlobalConfigPatchEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	encoded, diags := req.Private.GetKey(ctx, globalConfigPatchPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(encoded) == 0 {
+		return
+	}
+
+	var previous map[string]*string
+	if err := json.Unmarshal(encoded, &previous); err != nil {
+		resp.Diagnostics.AddError("Unable to Restore Global Config", fmt.Sprintf("Unable to decode the previous settings: %v", err))
+		return
+	}
+	if len(previous) == 0 {
+		return
+	}
+
+	restore := make(map[string]any, len(previous))
+	for key, value := range previous {
+		if value == nil {
+			restore[key] = nil
+			continue
+		}
+		restore[key] = *value
+	}
+
+	if _, err := r.client.UpdateGlobalConfig(ctx, restore); err != nil {
+		resp.Diagnostics.AddError("Unable to Restore Global Config", err.Error())
+	}
+}
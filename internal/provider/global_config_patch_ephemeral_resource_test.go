@@ -0,0 +1,62 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBunkerWebGlobalConfigPatchEphemeralResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebGlobalConfigPatchEphemeralResourceConfig(fakeAPI.URL()),
+			},
+		},
+	})
+
+	patched := false
+	for _, patch := range fakeAPI.GlobalPatches() {
+		if patch["some_setting"] == "maintenance" && patch["LOG_LEVEL"] == "debug" {
+			patched = true
+		}
+	}
+	if !patched {
+		t.Fatalf("expected the settings to be patched while open, got %v", fakeAPI.GlobalPatches())
+	}
+
+	if value, _ := fakeAPI.GlobalSetting("some_setting"); value != "value" {
+		t.Fatalf("expected some_setting to be restored, got %v", value)
+	}
+	if value, ok := fakeAPI.GlobalSetting("LOG_LEVEL"); ok {
+		t.Fatalf("expected LOG_LEVEL to be reset to its default, got %v", value)
+	}
+}
+
+func testAccBunkerWebGlobalConfigPatchEphemeralResourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_global_config_patch" "maintenance" {
+  settings = {
+    some_setting = "maintenance"
+    LOG_LEVEL    = "debug"
+  }
+}
+`, endpoint)
+}
@@ -7,12 +7,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -22,10 +25,13 @@ import (
 
 var _ resource.Resource = &BunkerWebGlobalConfigResource{}
 var _ resource.ResourceWithImportState = &BunkerWebGlobalConfigResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebGlobalConfigResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebGlobalConfigResource{}
 
 // BunkerWebGlobalConfigResource reconciles individual global configuration keys.
 type BunkerWebGlobalConfigResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
+	state  *providerState
 }
 
 // BunkerWebGlobalConfigResourceModel models Terraform state for a single setting.
@@ -34,6 +40,11 @@ type BunkerWebGlobalConfigResourceModel struct {
 	Key       types.String `tfsdk:"key"`
 	Value     types.String `tfsdk:"value"`
 	ValueJSON types.String `tfsdk:"value_json"`
+	Values    types.List   `tfsdk:"values"`
+
+	IgnoreUnsupported types.Bool   `tfsdk:"ignore_unsupported"`
+	Unsupported       types.Bool   `tfsdk:"unsupported"`
+	Retries           types.Object `tfsdk:"retries"`
 }
 
 func NewBunkerWebGlobalConfigResource() resource.Resource {
@@ -70,6 +81,33 @@ func (r *BunkerWebGlobalConfigResource) Schema(_ context.Context, _ resource.Sch
 				Optional:            true,
 				MarkdownDescription: "Raw JSON payload for complex values. Use `jsonencode(...)` to build this string.",
 			},
+			"values": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Values of a multiple setting such as `REVERSE_PROXY_URL`. The first element is written to `key`, the next ones " +
+					"to the numbered keys `<key>_1`, `<key>_2`, and so on; numbered keys beyond the end of the list are reset.",
+			},
+			"ignore_unsupported": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "When BunkerWeb rejects the setting because it requires a BunkerWeb Pro license, skip it with a warning instead of failing. " +
+					"The skipped setting is kept in state as configured and applied again the next time its configuration changes.",
+			},
+			"unsupported": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the setting was skipped because it requires BunkerWeb Pro (see `ignore_unsupported`).",
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebGlobalConfigResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"key": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Global configuration key.",
+			},
 		},
 	}
 }
@@ -79,16 +117,17 @@ func (r *BunkerWebGlobalConfigResource) Configure(_ context.Context, req resourc
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.state = data.state
 }
 
 func (r *BunkerWebGlobalConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -103,34 +142,61 @@ func (r *BunkerWebGlobalConfigResource) Create(ctx context.Context, req resource
 		return
 	}
 
-	key, payload, preferJSON, diags := plan.toPatchPayload()
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, payload, preferJSON, diags := plan.toPatchPayload(ctx)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if plan.usesValues() {
+		current, err := r.client.GetGlobalConfig(ctx, true, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
+			return
+		}
+		// Reset numbered keys beyond the end of the list.
+		for name, index := range multipleSettingKeys(key, current) {
+			if _, planned := payload[name]; !planned && index > 0 {
+				payload[name] = nil
+			}
+		}
+	}
+
 	updated, err := r.client.UpdateGlobalConfig(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Update Global Config", err.Error())
+		if !isProOnlyError(err) {
+			addAPIError(&resp.Diagnostics, "Unable to Update Global Config", err, map[apiErrorKind]path.Path{apiErrorSetting: path.Root("value"), apiErrorReadOnly: path.Root("key")})
+			return
+		}
+		resp.Diagnostics.Append(plan.skipUnsupported(key, err)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 		return
 	}
+	plan.Unsupported = types.BoolValue(false)
 
-	value, ok := updated[key]
-	if !ok {
-		resp.Diagnostics.AddError("Global Config Response Missing Key", fmt.Sprintf("The API response did not include key %q", key))
+	found, diags := plan.setStateFromSettings(ctx, key, updated, preferJSON)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	plan.ID = types.StringValue(key)
-	plan.Key = types.StringValue(key)
-	resp.Diagnostics.Append(plan.setStateValueFromAPI(value, preferJSON)...)
-	if resp.Diagnostics.HasError() {
+	if !found {
+		resp.Diagnostics.AddError("Global Config Response Missing Key", fmt.Sprintf("The API response did not include key %q", key))
 		return
 	}
 
 	tflog.Info(ctx, "applied bunkerweb global config setting", map[string]any{"key": key})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 }
 
 func (r *BunkerWebGlobalConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -145,6 +211,11 @@ func (r *BunkerWebGlobalConfigResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if state.Key.IsNull() || state.Key.IsUnknown() {
 		resp.Diagnostics.AddError("Missing Key", "Resource state is missing the global configuration key.")
 		return
@@ -156,28 +227,41 @@ func (r *BunkerWebGlobalConfigResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	settings, err := r.client.GetGlobalConfig(ctx, true, false)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
+	// A skipped setting keeps its configured value so it does not show drift
+	// on every plan.
+	if state.Unsupported.ValueBool() {
+		resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
 		return
 	}
 
-	value, ok := settings[key]
-	if !ok || value == nil {
-		resp.State.RemoveResource(ctx)
+	settings, err := r.client.GetGlobalConfig(ctx, true, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
 		return
 	}
 
 	preferJSON := !state.ValueJSON.IsNull() && !state.ValueJSON.IsUnknown()
+	prior := state.Value
 
-	state.ID = types.StringValue(key)
-	state.Key = types.StringValue(key)
-	resp.Diagnostics.Append(state.setStateValueFromAPI(value, preferJSON)...)
+	found, diags := state.setStateFromSettings(ctx, key, settings, preferJSON)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Unsupported = types.BoolValue(false)
+
+	// Keep the configured spelling of an unchanged value ("yes" vs "true").
+	if !prior.IsNull() && !state.Value.IsNull() {
+		normalize := settingValueNormalizer(ctx, r.client)
+		state.Value = types.StringValue(normalize(key, prior.ValueString(), state.Value.ValueString()))
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 func (r *BunkerWebGlobalConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -192,32 +276,81 @@ func (r *BunkerWebGlobalConfigResource) Update(ctx context.Context, req resource
 		return
 	}
 
-	key, payload, preferJSON, diags := plan.toPatchPayload()
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, payload, preferJSON, diags := plan.toPatchPayload(ctx)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if plan.usesValues() {
+		current, err := r.client.GetGlobalConfig(ctx, true, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
+			return
+		}
+		// Reset numbered keys beyond the end of the list.
+		for name, index := range multipleSettingKeys(key, current) {
+			if _, planned := payload[name]; !planned && index > 0 {
+				payload[name] = nil
+			}
+		}
+	}
+
 	updated, err := r.client.UpdateGlobalConfig(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Update Global Config", err.Error())
+		if !isProOnlyError(err) {
+			addAPIError(&resp.Diagnostics, "Unable to Update Global Config", err, map[apiErrorKind]path.Path{apiErrorSetting: path.Root("value"), apiErrorReadOnly: path.Root("key")})
+			return
+		}
+		resp.Diagnostics.Append(plan.skipUnsupported(key, err)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 		return
 	}
+	plan.Unsupported = types.BoolValue(false)
 
-	value, ok := updated[key]
-	if !ok {
+	found, diags := plan.setStateFromSettings(ctx, key, updated, preferJSON)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
 		resp.Diagnostics.AddError("Global Config Response Missing Key", fmt.Sprintf("The API response did not include key %q", key))
 		return
 	}
 
-	plan.ID = types.StringValue(key)
-	plan.Key = types.StringValue(key)
-	resp.Diagnostics.Append(plan.setStateValueFromAPI(value, preferJSON)...)
-	if resp.Diagnostics.HasError() {
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+}
+
+func (r *BunkerWebGlobalConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || (!req.State.Raw.IsNull() && req.Plan.Raw.Equal(req.State.Raw)) {
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	var plan BunkerWebGlobalConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Key.IsUnknown() {
+		return
+	}
+
+	// Per-service keys ("<server_name>_KEY") are ignored in single-site mode;
+	// a global SERVER_NAME is rewritten from the services in multisite mode.
+	key := strings.TrimSpace(plan.Key.ValueString())
+	switch {
+	case servicePrefixedKeyPattern.MatchString(key):
+		resp.Diagnostics.Append(multisiteMismatch(ctx, r.client, path.Root("key"), true, fmt.Sprintf("The service setting %q", key))...)
+	case key == "SERVER_NAME":
+		resp.Diagnostics.Append(multisiteMismatch(ctx, r.client, path.Root("key"), false, "The global SERVER_NAME setting")...)
+	}
 }
 
 func (r *BunkerWebGlobalConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -232,35 +365,101 @@ func (r *BunkerWebGlobalConfigResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if state.Key.IsNull() || state.Key.IsUnknown() {
 		return
 	}
 
 	key := strings.TrimSpace(state.Key.ValueString())
-	if key == "" {
+	if key == "" || state.Unsupported.ValueBool() {
 		return
 	}
 
-	if _, err := r.client.UpdateGlobalConfig(ctx, map[string]any{key: nil}); err != nil {
+	payload := map[string]any{key: nil}
+	if state.usesValues() {
+		current, err := r.client.GetGlobalConfig(ctx, true, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
+			return
+		}
+		for name := range multipleSettingKeys(key, current) {
+			payload[name] = nil
+		}
+	}
+
+	if _, err := r.client.UpdateGlobalConfig(ctx, payload); err != nil {
+		if isProOnlyError(err) && state.IgnoreUnsupported.ValueBool() {
+			resp.Diagnostics.AddWarning("Setting Requires BunkerWeb Pro", fmt.Sprintf("Setting %s requires BunkerWeb Pro and was not reset because ignore_unsupported = true: %s", key, err))
+			return
+		}
 		resp.Diagnostics.AddError("Unable to Reset Global Config", err.Error())
 		return
 	}
 }
 
 func (r *BunkerWebGlobalConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	key := strings.TrimSpace(req.ID)
+	id, diags := importIdentifier(ctx, req, "key")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := strings.TrimSpace(id)
 	if key == "" {
 		resp.Diagnostics.AddError("Invalid Import Identifier", "Expected a non-empty global configuration key.")
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &BunkerWebGlobalConfigResourceModel{
-		ID:  types.StringValue(key),
-		Key: types.StringValue(key),
+		ID:      types.StringValue(key),
+		Key:     types.StringValue(key),
+		Values:  types.ListNull(types.StringType),
+		Retries: types.ObjectNull(retriesAttrTypes),
 	})...)
 }
 
-func (m *BunkerWebGlobalConfigResourceModel) toPatchPayload() (string, map[string]any, bool, diag.Diagnostics) {
+// globalConfigIdentityModel is the resource identity of bunkerweb_global_config_setting.
+type globalConfigIdentityModel struct {
+	Key types.String `tfsdk:"key"`
+}
+
+func (m *BunkerWebGlobalConfigResourceModel) identity() globalConfigIdentityModel {
+	return globalConfigIdentityModel{Key: m.Key}
+}
+
+// skipUnsupported handles BunkerWeb rejecting key because it requires a Pro
+// license: an error naming the setting, or with ignore_unsupported a warning
+// after which m is marked as skipped.
+func (m *BunkerWebGlobalConfigResourceModel) skipUnsupported(key string, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !m.IgnoreUnsupported.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("key"),
+			"Setting Requires BunkerWeb Pro",
+			fmt.Sprintf("Setting %s requires BunkerWeb Pro: %s\n\nRemove the setting, upgrade to BunkerWeb Pro, or set ignore_unsupported = true to skip it with a warning.", key, err),
+		)
+		return diags
+	}
+
+	diags.AddWarning(
+		"Setting Requires BunkerWeb Pro",
+		fmt.Sprintf("Setting %s requires BunkerWeb Pro and was skipped because ignore_unsupported = true: %s", key, err),
+	)
+	m.ID = types.StringValue(key)
+	m.Unsupported = types.BoolValue(true)
+	return diags
+}
+
+// usesValues reports whether the setting is managed as a multiple setting.
+func (m *BunkerWebGlobalConfigResourceModel) usesValues() bool {
+	return !m.Values.IsNull() && !m.Values.IsUnknown()
+}
+
+func (m *BunkerWebGlobalConfigResourceModel) toPatchPayload(ctx context.Context) (string, map[string]any, bool, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if m.Key.IsNull() || m.Key.IsUnknown() {
@@ -276,16 +475,51 @@ func (m *BunkerWebGlobalConfigResourceModel) toPatchPayload() (string, map[strin
 
 	hasValue := !m.Value.IsNull() && !m.Value.IsUnknown()
 	hasJSON := !m.ValueJSON.IsNull() && !m.ValueJSON.IsUnknown()
+	hasValues := m.usesValues()
 
-	if hasValue && hasJSON {
-		diags.AddError("Conflicting Attributes", "Specify only one of value or value_json.")
+	set := 0
+	for _, has := range []bool{hasValue, hasJSON, hasValues} {
+		if has {
+			set++
+		}
+	}
+	if set > 1 {
+		diags.AddError("Conflicting Attributes", "Specify only one of value, value_json or values.")
 		return "", nil, false, diags
 	}
-	if !hasValue && !hasJSON {
-		diags.AddAttributeError(path.Root("value"), "Missing Value", "Provide either value or value_json to update the setting.")
+	if set == 0 {
+		diags.AddAttributeError(path.Root("value"), "Missing Value", "Provide one of value, value_json or values to update the setting.")
 		return "", nil, false, diags
 	}
 
+	if hasValues {
+		if multipleSettingSuffix.MatchString(key) {
+			diags.AddAttributeError(path.Root("key"), "Invalid Key", fmt.Sprintf("With values, key must be the base name of the multiple setting, without a numbered suffix; got %q.", key))
+			return "", nil, false, diags
+		}
+
+		var values []string
+		diags.Append(m.Values.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return "", nil, false, diags
+		}
+
+		if len(values) == 0 {
+			diags.AddAttributeError(path.Root("values"), "Missing Value", "values must contain at least one element; remove the resource to reset the setting.")
+			return "", nil, false, diags
+		}
+
+		payload := make(map[string]any, len(values))
+		for index, value := range values {
+			if strings.TrimSpace(value) == "" {
+				diags.AddAttributeError(path.Root("values").AtListIndex(index), "Invalid Value", "Elements of values may not be empty.")
+				continue
+			}
+			payload[multipleSettingKey(key, index)] = value
+		}
+		return key, payload, false, diags
+	}
+
 	if hasJSON {
 		raw := m.ValueJSON.ValueString()
 		var decoded any
@@ -300,6 +534,46 @@ func (m *BunkerWebGlobalConfigResourceModel) toPatchPayload() (string, map[strin
 	return key, map[string]any{key: parsed}, false, diags
 }
 
+// setStateFromSettings copies key from the global settings into the model and
+// reports whether it was present.
+func (m *BunkerWebGlobalConfigResourceModel) setStateFromSettings(ctx context.Context, key string, settings map[string]any, preferJSON bool) (bool, diag.Diagnostics) {
+	m.ID = types.StringValue(key)
+	m.Key = types.StringValue(key)
+
+	if !m.usesValues() {
+		value, ok := settings[key]
+		if !ok || value == nil {
+			return false, nil
+		}
+		return true, m.setStateValueFromAPI(value, preferJSON)
+	}
+
+	indexed := multipleSettingKeys(key, settings)
+	if len(indexed) == 0 {
+		return false, nil
+	}
+
+	names := make([]string, 0, len(indexed))
+	for name := range indexed {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int { return indexed[a] - indexed[b] })
+
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		// Reset numbered keys may read back as empty defaults.
+		if value := stringifyValue(settings[name]); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, values)
+	m.Values = list
+	m.Value = types.StringNull()
+	m.ValueJSON = types.StringNull()
+	return true, diags
+}
+
 func (m *BunkerWebGlobalConfigResourceModel) setStateValueFromAPI(value any, preferJSON bool) diag.Diagnostics {
 	if preferJSON {
 		encoded, err := json.Marshal(value)
@@ -316,6 +590,34 @@ func (m *BunkerWebGlobalConfigResourceModel) setStateValueFromAPI(value any, pre
 	return nil
 }
 
+// multipleSettingSuffix matches the numbered suffix of a multiple setting.
+var multipleSettingSuffix = regexp.MustCompile(`_[0-9]+$`)
+
+// multipleSettingKeys returns the keys of settings belonging to the multiple
+// setting base, mapped to their index.
+func multipleSettingKeys(base string, settings map[string]any) map[string]int {
+	keys := map[string]int{}
+	for name, value := range settings {
+		if value == nil {
+			continue
+		}
+		if name == base {
+			keys[name] = 0
+			continue
+		}
+		suffix, ok := strings.CutPrefix(name, base+"_")
+		if !ok {
+			continue
+		}
+		index, err := strconv.Atoi(suffix)
+		if err != nil || index <= 0 || strconv.Itoa(index) != suffix {
+			continue
+		}
+		keys[name] = index
+	}
+	return keys
+}
+
 func parseScalarValue(input string) any {
 	trimmed := strings.TrimSpace(input)
 	if trimmed == "" {
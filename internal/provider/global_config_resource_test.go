@@ -5,9 +5,13 @@ package provider
 
 import (
 	"fmt"
+	"maps"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccBunkerWebGlobalConfigResource(t *testing.T) {
@@ -72,3 +76,119 @@ resource "bunkerweb_global_config_setting" "retry" {
 }
 `, endpoint)
 }
+
+func TestAccBunkerWebGlobalConfigResourceValues(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebGlobalConfigResourceConfigValues(fakeAPI.URL(), "/api", "/admin", "/static"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_global_config_setting.urls", "values.#", "3"),
+					resource.TestCheckResourceAttr("bunkerweb_global_config_setting.urls", "values.2", "/static"),
+					resource.TestCheckNoResourceAttr("bunkerweb_global_config_setting.urls", "value"),
+					func(*terraform.State) error {
+						if v, _ := fakeAPI.GlobalSetting("REVERSE_PROXY_URL_2"); v != "/static" {
+							return fmt.Errorf("expected REVERSE_PROXY_URL_2=/static, got %v", v)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccBunkerWebGlobalConfigResourceConfigValues(fakeAPI.URL(), "/"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_global_config_setting.urls", "values.#", "1"),
+					resource.TestCheckResourceAttr("bunkerweb_global_config_setting.urls", "values.0", "/"),
+					func(*terraform.State) error {
+						for _, key := range []string{"REVERSE_PROXY_URL_1", "REVERSE_PROXY_URL_2"} {
+							if _, ok := fakeAPI.GlobalSetting(key); ok {
+								return fmt.Errorf("expected %s to be reset", key)
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebGlobalConfigResourceProOnly(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.SetProOnly("USE_REPORTING")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebGlobalConfigResourceConfigProOnly(fakeAPI.URL(), false),
+				ExpectError: regexp.MustCompile(`Setting USE_REPORTING requires BunkerWeb Pro`),
+			},
+			{
+				Config: testAccBunkerWebGlobalConfigResourceConfigProOnly(fakeAPI.URL(), true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_global_config_setting.reporting", "unsupported", "true"),
+					resource.TestCheckResourceAttr("bunkerweb_global_config_setting.reporting", "value", "yes"),
+					func(*terraform.State) error {
+						if _, ok := fakeAPI.GlobalSetting("USE_REPORTING"); ok {
+							return fmt.Errorf("expected USE_REPORTING not to be set")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebGlobalConfigResourceConfigProOnly(endpoint string, ignore bool) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_global_config_setting" "reporting" {
+  key                = "USE_REPORTING"
+  value              = "yes"
+  ignore_unsupported = %t
+}
+`, endpoint, ignore)
+}
+
+func TestMultipleSettingKeys(t *testing.T) {
+	settings := map[string]any{
+		"REVERSE_PROXY_URL":         "/",
+		"REVERSE_PROXY_URL_1":       "/api",
+		"REVERSE_PROXY_URL_12":      "/late",
+		"REVERSE_PROXY_URL_01":      "/padded",
+		"REVERSE_PROXY_URL_HEADERS": "x",
+		"REVERSE_PROXY_URL_3":       nil,
+		"REVERSE_PROXY_HOST":        "http://app",
+	}
+
+	got := multipleSettingKeys("REVERSE_PROXY_URL", settings)
+	want := map[string]int{"REVERSE_PROXY_URL": 0, "REVERSE_PROXY_URL_1": 1, "REVERSE_PROXY_URL_12": 12}
+	if !maps.Equal(got, want) {
+		t.Fatalf("multipleSettingKeys = %v, want %v", got, want)
+	}
+}
+
+func testAccBunkerWebGlobalConfigResourceConfigValues(endpoint string, values ...string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_global_config_setting" "urls" {
+  key    = "REVERSE_PROXY_URL"
+  values = ["%s"]
+}
+`, endpoint, strings.Join(values, `", "`))
+}
@@ -0,0 +1,458 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+var _ resource.Resource = &BunkerWebGlobalConfigSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebGlobalConfigSettingsResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebGlobalConfigSettingsResource{}
+
+// BunkerWebGlobalConfigSettingsResource manages a set of global settings
+// given as a map or as a JSON or YAML document.
+type BunkerWebGlobalConfigSettingsResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebGlobalConfigSettingsResourceModel is the Terraform state.
+type BunkerWebGlobalConfigSettingsResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Settings     types.Map    `tfsdk:"settings"`
+	SettingsJSON types.String `tfsdk:"settings_json"`
+	SettingsYAML types.String `tfsdk:"settings_yaml"`
+	Effective    types.Map    `tfsdk:"effective_settings"`
+	Retries      types.Object `tfsdk:"retries"`
+}
+
+func NewBunkerWebGlobalConfigSettingsResource() resource.Resource {
+	return &BunkerWebGlobalConfigSettingsResource{}
+}
+
+func (r *BunkerWebGlobalConfigSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_config"
+}
+
+func (r *BunkerWebGlobalConfigSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of BunkerWeb global settings, given as a map or as a JSON or YAML document such as a reviewed file of the repository " +
+			"(`file(\"global.yaml\")`). Keys are checked against the settings declared by the installed plugins before anything is applied. " +
+			"Settings removed from the set, or all of them on destroy, are reset to their defaults. Do not manage the same key with `bunkerweb_global_config_setting`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Always `global`.",
+			},
+			"settings": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Settings as key/value pairs. Exactly one of `settings`, `settings_json` and `settings_yaml` must be set.",
+			},
+			"settings_json": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "JSON object of settings. Booleans become `yes`/`no` and numbers are written as is; other values must be strings. " +
+					"Use numbered keys (`REVERSE_PROXY_URL_1`) for multiple settings.",
+			},
+			"settings_yaml": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "YAML mapping of settings, decoded like `settings_json`.",
+			},
+			"effective_settings": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Decoded settings, as applied to and read back from BunkerWeb.",
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebGlobalConfigSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebGlobalConfigSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebGlobalConfigSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := 0
+	for _, value := range []interface{ IsNull() bool }{config.Settings, config.SettingsJSON, config.SettingsYAML} {
+		if !value.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("settings"), "Invalid Settings", "Exactly one of `settings`, `settings_json` and `settings_yaml` must be set.")
+		return
+	}
+
+	if config.Settings.IsUnknown() || config.SettingsJSON.IsUnknown() || config.SettingsYAML.IsUnknown() {
+		return
+	}
+	_, diags := config.decodeSettings(ctx)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ModifyPlan plans the decoded settings and checks them against the settings
+// catalogue.
+func (r *BunkerWebGlobalConfigSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BunkerWebGlobalConfigSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Settings.IsUnknown() || plan.SettingsJSON.IsUnknown() || plan.SettingsYAML.IsUnknown() {
+		return
+	}
+
+	settings, diags := plan.decodeSettings(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client != nil {
+		resp.Diagnostics.Append(validateGlobalSettings(ctx, r.client, plan.settingsPath(), settings)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	effective, diags := mapToTerraform(ctx, settings)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_settings"), effective)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringValue("global"))...)
+}
+
+func (r *BunkerWebGlobalConfigSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebGlobalConfigSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &resp.Diagnostics, &plan, nil)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebGlobalConfigSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebGlobalConfigSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := mapFromTerraform(ctx, state.Effective)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.GetGlobalConfig(ctx, true, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Global Config", err.Error())
+		return
+	}
+
+	// Keep the configured spelling of unchanged values ("yes" vs "true");
+	// settings missing from the API show as drift.
+	normalize := settingValueNormalizer(ctx, r.client)
+	effective := make(map[string]string, len(prior))
+	for key, value := range prior {
+		if live, ok := current[key]; ok && live != nil {
+			effective[key] = normalize(key, value, stringifyValue(live))
+		}
+	}
+
+	state.Effective, diags = mapToTerraform(ctx, effective)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BunkerWebGlobalConfigSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan, state BunkerWebGlobalConfigSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := mapFromTerraform(ctx, state.Effective)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &resp.Diagnostics, &plan, slices.Collect(maps.Keys(prior)))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebGlobalConfigSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebGlobalConfigSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := mapFromTerraform(ctx, state.Effective)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(prior) == 0 {
+		return
+	}
+
+	payload := make(map[string]any, len(prior))
+	for key := range prior {
+		payload[key] = nil
+	}
+	if _, err := r.client.UpdateGlobalConfig(ctx, payload); err != nil {
+		resp.Diagnostics.AddError("Unable to Reset Global Config", err.Error())
+	}
+}
+
+// apply writes the settings of plan, resetting the keys of previous it no
+// longer sets, and records the values the API reports back.
+func (r *BunkerWebGlobalConfigSettingsResource) apply(ctx context.Context, diags *diag.Diagnostics, plan *BunkerWebGlobalConfigSettingsResourceModel, previous []string) {
+	settings, decodeDiags := plan.decodeSettings(ctx)
+	diags.Append(decodeDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	payload := make(map[string]any, len(settings)+len(previous))
+	for _, key := range previous {
+		payload[key] = nil
+	}
+	for key, value := range settings {
+		payload[key] = parseScalarValue(value)
+	}
+	if len(payload) == 0 {
+		plan.ID = types.StringValue("global")
+		plan.Effective = types.MapNull(types.StringType)
+		return
+	}
+
+	updated, err := r.client.UpdateGlobalConfig(ctx, payload)
+	if err != nil {
+		addAPIError(diags, "Unable to Update Global Config", err, map[apiErrorKind]path.Path{apiErrorSetting: plan.settingsPath()})
+		return
+	}
+
+	normalize := settingValueNormalizer(ctx, r.client)
+	effective := make(map[string]string, len(settings))
+	for key, value := range settings {
+		effective[key] = value
+		if live, ok := updated[key]; ok && live != nil {
+			effective[key] = normalize(key, value, stringifyValue(live))
+		}
+	}
+
+	var mapDiags diag.Diagnostics
+	plan.ID = types.StringValue("global")
+	plan.Effective, mapDiags = mapToTerraform(ctx, effective)
+	diags.Append(mapDiags...)
+
+	tflog.Info(ctx, "applied bunkerweb global config", map[string]any{"keys": len(settings), "reset": len(payload) - len(settings)})
+}
+
+// settingsPath returns the attribute the settings are configured with.
+func (m *BunkerWebGlobalConfigSettingsResourceModel) settingsPath() path.Path {
+	switch {
+	case !m.SettingsJSON.IsNull():
+		return path.Root("settings_json")
+	case !m.SettingsYAML.IsNull():
+		return path.Root("settings_yaml")
+	}
+	return path.Root("settings")
+}
+
+// decodeSettings returns the settings of m, decoding settings_json or
+// settings_yaml when set.
+func (m *BunkerWebGlobalConfigSettingsResourceModel) decodeSettings(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	attr := m.settingsPath()
+
+	var document map[string]any
+	switch {
+	case !m.SettingsJSON.IsNull():
+		decoder := json.NewDecoder(bytes.NewReader([]byte(m.SettingsJSON.ValueString())))
+		decoder.UseNumber()
+		if err := decoder.Decode(&document); err != nil {
+			diags.AddAttributeError(attr, "Invalid Settings JSON", fmt.Sprintf("Expected a JSON object of settings: %s", err))
+			return nil, diags
+		}
+	case !m.SettingsYAML.IsNull():
+		if err := yaml.Unmarshal([]byte(m.SettingsYAML.ValueString()), &document); err != nil {
+			diags.AddAttributeError(attr, "Invalid Settings YAML", fmt.Sprintf("Expected a YAML mapping of settings: %s", err))
+			return nil, diags
+		}
+	default:
+		settings, mapDiags := mapFromTerraform(ctx, m.Settings)
+		diags.Append(mapDiags...)
+		document = make(map[string]any, len(settings))
+		for key, value := range settings {
+			document[key] = value
+		}
+	}
+
+	settings := make(map[string]string, len(document))
+	for key, value := range document {
+		name := strings.TrimSpace(key)
+		if name == "" {
+			diags.AddAttributeError(attr, "Invalid Setting Name", "Setting names cannot be empty.")
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			settings[name] = v
+		case bool:
+			settings[name] = yesNo(v)
+		case json.Number:
+			settings[name] = v.String()
+		case int:
+			settings[name] = strconv.Itoa(v)
+		case float64:
+			settings[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case nil:
+			diags.AddAttributeError(attr, "Invalid Setting Value", fmt.Sprintf("Setting %s has no value; remove it to reset it to its default.", name))
+		default:
+			diags.AddAttributeError(attr, "Invalid Setting Value", fmt.Sprintf("Setting %s must be a string, number or boolean; use numbered keys such as %s_1 for multiple values.", name, name))
+		}
+	}
+	return settings, diags
+}
+
+// validateGlobalSettings checks settings against the catalogue declared by the
+// installed plugins: every key must exist, and values must match its regex
+// and choices. The check is skipped with a warning when the catalogue cannot
+// be read.
+func validateGlobalSettings(ctx context.Context, client BunkerWebAPI, attr path.Path, settings map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(settings) == 0 {
+		return diags
+	}
+
+	plugins, err := client.ListPlugins(ctx, "all", false)
+	if err != nil {
+		diags.AddWarning("Settings Not Validated", fmt.Sprintf("Unable to read the settings catalogue, so the settings are applied without validation: %s", err))
+		return diags
+	}
+	catalogue := map[string]bunkerWebPluginSetting{}
+	for _, plugin := range plugins {
+		maps.Copy(catalogue, plugin.Settings)
+	}
+	if len(catalogue) == 0 {
+		return diags
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		value := settings[key]
+		setting, ok := catalogue[key]
+		if !ok {
+			if loc := multipleSettingSuffix.FindStringIndex(key); loc != nil {
+				setting, ok = catalogue[key[:loc[0]]]
+			}
+		}
+		switch {
+		case !ok:
+			diags.AddAttributeError(attr, "Unknown Setting", fmt.Sprintf("No installed plugin declares the setting %s.", key))
+		case len(setting.Select) > 0 && !slices.Contains(setting.Select, value):
+			diags.AddAttributeError(attr, "Invalid Setting Value", fmt.Sprintf("Setting %s must be one of %s, got %q.", key, strings.Join(setting.Select, ", "), value))
+		case setting.Regex != "" && value != "":
+			// Catalogue patterns are written for Python; those RE2 cannot
+			// compile are not checked.
+			if pattern, err := regexp.Compile(setting.Regex); err == nil && !pattern.MatchString(value) {
+				diags.AddAttributeError(attr, "Invalid Setting Value", fmt.Sprintf("Setting %s does not match %s, got %q.", key, setting.Regex, value))
+			}
+		}
+	}
+	return diags
+}
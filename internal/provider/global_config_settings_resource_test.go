@@ -0,0 +1,144 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestGlobalConfigDecodeSettings(t *testing.T) {
+	ctx := context.Background()
+	want := map[string]string{"USE_GZIP": "yes", "LIMIT_REQ_RATE": "10r/s", "WORKER_RLIMIT_NOFILE": "2048", "RATIO": "0.5"}
+
+	for name, m := range map[string]BunkerWebGlobalConfigSettingsResourceModel{
+		"json": {SettingsJSON: types.StringValue(`{"USE_GZIP": true, "LIMIT_REQ_RATE": "10r/s", "WORKER_RLIMIT_NOFILE": 2048, " RATIO ": 0.5}`), SettingsYAML: types.StringNull()},
+		"yaml": {SettingsJSON: types.StringNull(), SettingsYAML: types.StringValue("USE_GZIP: true\nLIMIT_REQ_RATE: 10r/s\nWORKER_RLIMIT_NOFILE: 2048\nRATIO: 0.5\n")},
+	} {
+		got, diags := m.decodeSettings(ctx)
+		if diags.HasError() {
+			t.Fatalf("%s: decodeSettings: %v", name, diags)
+		}
+		if !maps.Equal(got, want) {
+			t.Errorf("%s: decodeSettings = %v, want %v", name, got, want)
+		}
+		if !m.settingsPath().Equal(path.Root("settings_" + name)) {
+			t.Errorf("%s: unexpected settings path %s", name, m.settingsPath())
+		}
+	}
+
+	for _, document := range []string{`[1, 2]`, `{"A": null}`, `{"A": ["x"]}`, `{"A": {"B": "c"}}`, `{"": "x"}`, `{`} {
+		m := BunkerWebGlobalConfigSettingsResourceModel{SettingsJSON: types.StringValue(document), SettingsYAML: types.StringNull()}
+		if _, diags := m.decodeSettings(ctx); !diags.HasError() {
+			t.Errorf("expected %s to be rejected", document)
+		}
+	}
+}
+
+func TestValidateGlobalSettings(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+	attr := path.Root("settings_yaml")
+
+	if diags := validateGlobalSettings(ctx, client, attr, map[string]string{"ANYTHING": "x"}); diags.HasError() {
+		t.Fatalf("expected no validation without a catalogue, got %v", diags)
+	}
+
+	api.AddPlugin(testGlobalConfigPlugin())
+	if diags := validateGlobalSettings(ctx, client, attr, map[string]string{"USE_GZIP": "yes", "LIMIT_REQ_RATE": "10r/s", "REVERSE_PROXY_URL_2": "/api"}); diags.HasError() {
+		t.Fatalf("expected valid settings, got %v", diags)
+	}
+	for _, settings := range []map[string]string{
+		{"USE_GIZP": "yes"},
+		{"USE_GZIP": "maybe"},
+		{"LIMIT_REQ_RATE": "fast"},
+	} {
+		if diags := validateGlobalSettings(ctx, client, attr, settings); !diags.HasError() {
+			t.Errorf("expected %v to be rejected", settings)
+		}
+	}
+}
+
+func TestAccBunkerWebGlobalConfigSettingsResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddPlugin(testGlobalConfigPlugin())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebGlobalConfigSettingsConfig(fakeAPI.URL(), "USE_GZIP: maybe\n"),
+				ExpectError: regexp.MustCompile(`Setting USE_GZIP must be one of yes, no`),
+			},
+			{
+				Config: testAccBunkerWebGlobalConfigSettingsConfig(fakeAPI.URL(), "USE_GZIP: true\nLIMIT_REQ_RATE: 10r/s\n"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_global_config.main", "id", "global"),
+					resource.TestCheckResourceAttr("bunkerweb_global_config.main", "effective_settings.USE_GZIP", "yes"),
+					resource.TestCheckResourceAttr("bunkerweb_global_config.main", "effective_settings.LIMIT_REQ_RATE", "10r/s"),
+					func(*terraform.State) error {
+						if value, ok := fakeAPI.GlobalSetting("LIMIT_REQ_RATE"); !ok || value != "10r/s" {
+							return fmt.Errorf("expected LIMIT_REQ_RATE to be applied, got %v", value)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccBunkerWebGlobalConfigSettingsConfig(fakeAPI.URL(), "USE_GZIP: false\n"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_global_config.main", "effective_settings.%", "1"),
+					resource.TestCheckResourceAttr("bunkerweb_global_config.main", "effective_settings.USE_GZIP", "no"),
+					func(*terraform.State) error {
+						if _, ok := fakeAPI.GlobalSetting("LIMIT_REQ_RATE"); ok {
+							return fmt.Errorf("expected LIMIT_REQ_RATE to be reset once removed from the document")
+						}
+						return nil
+					},
+				),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, ok := fakeAPI.GlobalSetting("USE_GZIP"); ok {
+				return fmt.Errorf("expected USE_GZIP to be reset on destroy")
+			}
+			return nil
+		},
+	})
+}
+
+func testGlobalConfigPlugin() bunkerWebPlugin {
+	return bunkerWebPlugin{ID: "general", Type: "core", Settings: map[string]bunkerWebPluginSetting{
+		"USE_GZIP":          {ID: "use-gzip", Context: "multisite", Default: "no", Type: "check", Select: []string{"yes", "no"}},
+		"LIMIT_REQ_RATE":    {ID: "limit-req-rate", Context: "multisite", Default: "2r/s", Type: "text", Regex: `^\d+r/[smhd]$`},
+		"REVERSE_PROXY_URL": {ID: "reverse-proxy-url", Context: "multisite", Type: "text", Multiple: "reverse-proxy"},
+	}}
+}
+
+func testAccBunkerWebGlobalConfigSettingsConfig(endpoint, document string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_global_config" "main" {
+  settings_yaml = <<-EOT
+%sEOT
+}
+`, endpoint, document)
+}
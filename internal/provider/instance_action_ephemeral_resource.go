@@ -7,7 +7,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
@@ -17,10 +19,12 @@ import (
 )
 
 var _ ephemeral.EphemeralResource = &BunkerWebInstanceActionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &BunkerWebInstanceActionEphemeralResource{}
 
 // BunkerWebInstanceActionEphemeralResource executes fleet or per-host instance operations.
 type BunkerWebInstanceActionEphemeralResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
+	state  *providerState
 }
 
 // BunkerWebInstanceActionModel captures Terraform configuration.
@@ -28,9 +32,16 @@ type BunkerWebInstanceActionModel struct {
 	Operation types.String `tfsdk:"operation"`
 	Hostnames types.List   `tfsdk:"hostnames"`
 	Test      types.Bool   `tfsdk:"test"`
+	OnManaged types.String `tfsdk:"on_managed_instance"`
+	BatchSize types.Int64  `tfsdk:"batch_size"`
+	Pause     types.String `tfsdk:"pause_between_batches"`
+	Verify    types.Bool   `tfsdk:"verify_health"`
 	Result    types.String `tfsdk:"result"`
 }
 
+// Values of on_managed_instance.
+var managedInstancePolicies = []string{"warn", "error", "ignore"}
+
 func NewBunkerWebInstanceActionEphemeralResource() ephemeral.EphemeralResource {
 	return &BunkerWebInstanceActionEphemeralResource{}
 }
@@ -45,17 +56,35 @@ func (r *BunkerWebInstanceActionEphemeralResource) Schema(_ context.Context, _ e
 		Attributes: map[string]schema.Attribute{
 			"operation": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Operation to execute: one of `ping`, `reload`, `stop`, or `delete`.",
+				MarkdownDescription: "Operation to execute: one of `ping`, `reload`, `stop`, `restart`, `health`, or `delete`. `restart` uses the API's restart endpoint, or stops then reloads each instance when the API has none; `health` reports whether each instance is registered and answers a ping, with the ping latency.",
 			},
 			"hostnames": schema.ListAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Target hostnames. When omitted, the action runs against all instances (for ping/reload/stop only).",
+				MarkdownDescription: "Target hostnames. When omitted, the action runs against all instances (for ping/reload/stop/restart/health only).",
 			},
 			"test": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "For reload operations, whether to run in test mode (defaults to true). Ignored for other operations.",
 			},
+			"on_managed_instance": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "What to do when `delete` targets a hostname that is a `bunkerweb_instance` resource of the same configuration: " +
+					"`warn` (default) deletes it and emits a warning, `error` refuses to delete anything, `ignore` deletes silently. Instances are " +
+					"recognized once refreshed during the current run.",
+			},
+			"batch_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "For reload operations, reload this many instances at a time instead of the whole fleet at once. Without `hostnames`, every registered instance is reloaded.",
+			},
+			"pause_between_batches": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Time to wait after each batch of a rolling reload, as a Go duration. Defaults to `0s`.",
+			},
+			"verify_health": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "For rolling reloads, ping every instance of a batch before moving to the next one and stop at the first batch that does not answer. Defaults to `true`.",
+			},
 			"result": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "JSON-encoded response payload returned by the API.",
@@ -70,16 +99,75 @@ func (r *BunkerWebInstanceActionEphemeralResource) Configure(_ context.Context,
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Ephemeral Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.state = data.state
+}
+
+// ValidateConfig checks the rolling reload settings and summarises reload,
+// stop, restart and delete operations, once every value is known. Pings and
+// health checks change nothing and are not reported.
+func (r *BunkerWebInstanceActionEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data BunkerWebInstanceActionModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() || data.Operation.IsNull() {
+		return
+	}
+
+	if !data.BatchSize.IsNull() && !data.BatchSize.IsUnknown() && data.BatchSize.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("batch_size"), "Invalid Batch Size", "`batch_size` must be at least 1.")
+	}
+	if !data.Pause.IsNull() && !data.Pause.IsUnknown() {
+		if pause, err := time.ParseDuration(data.Pause.ValueString()); err != nil || pause < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pause_between_batches"),
+				"Invalid Pause",
+				fmt.Sprintf("Expected a non-negative duration such as `30s`, got %q.", data.Pause.ValueString()),
+			)
+		}
+	}
+	if !data.Operation.IsUnknown() && !strings.EqualFold(strings.TrimSpace(data.Operation.ValueString()), "reload") &&
+		(!data.BatchSize.IsNull() || !data.Pause.IsNull() || !data.Verify.IsNull()) {
+		resp.Diagnostics.AddAttributeError(path.Root("batch_size"), "Unsupported Batching", "`batch_size`, `pause_between_batches` and `verify_health` only apply to reload operations.")
+	}
+	if resp.Diagnostics.HasError() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+	hostnames, diags := listToStrings(ctx, data.Hostnames)
+	if diags.HasError() {
+		return
+	}
+
+	op := strings.ToLower(strings.TrimSpace(data.Operation.ValueString()))
+	verb := op
+	switch op {
+	case "reload":
+		if data.Test.IsNull() || data.Test.ValueBool() {
+			verb = "test-reload"
+		}
+	case "stop", "restart", "delete":
+	default:
+		return
+	}
+
+	target := "every instance"
+	if len(hostnames) > 0 {
+		target = summarizeTargets(len(hostnames), "instance", hostnames)
+	} else if op == "delete" {
+		// Open refuses a delete without hostnames.
+		return
+	}
+	if op == "reload" && !data.BatchSize.IsNull() {
+		target += fmt.Sprintf(" in batches of %d", data.BatchSize.ValueInt64())
+	}
+	addPlanSummary(&resp.Diagnostics, "bunkerweb_instance_action", []string{verb + " " + target})
 }
 
 func (r *BunkerWebInstanceActionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
@@ -95,15 +183,15 @@ func (r *BunkerWebInstanceActionEphemeralResource) Open(ctx context.Context, req
 	}
 
 	if data.Operation.IsNull() || data.Operation.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(path.Root("operation"), "Missing Operation", "Set the `operation` attribute to one of ping, reload, stop, or delete.")
+		resp.Diagnostics.AddAttributeError(path.Root("operation"), "Missing Operation", "Set the `operation` attribute to one of ping, reload, stop, restart, health, or delete.")
 		return
 	}
 
 	op := strings.ToLower(strings.TrimSpace(data.Operation.ValueString()))
 	switch op {
-	case "ping", "reload", "stop", "delete":
+	case "ping", "reload", "stop", "restart", "health", "delete":
 	default:
-		resp.Diagnostics.AddAttributeError(path.Root("operation"), "Unsupported Operation", fmt.Sprintf("Operation %q is not supported. Use ping, reload, stop, or delete.", op))
+		resp.Diagnostics.AddAttributeError(path.Root("operation"), "Unsupported Operation", fmt.Sprintf("Operation %q is not supported. Use ping, reload, stop, restart, health, or delete.", op))
 		return
 	}
 
@@ -113,6 +201,31 @@ func (r *BunkerWebInstanceActionEphemeralResource) Open(ctx context.Context, req
 		return
 	}
 
+	if op == "delete" {
+		policy := "warn"
+		if !data.OnManaged.IsNull() && !data.OnManaged.IsUnknown() {
+			policy = strings.ToLower(strings.TrimSpace(data.OnManaged.ValueString()))
+		}
+		if !slices.Contains(managedInstancePolicies, policy) {
+			resp.Diagnostics.AddAttributeError(path.Root("on_managed_instance"), "Invalid Managed Instance Policy",
+				fmt.Sprintf("`on_managed_instance` must be one of: %s.", strings.Join(managedInstancePolicies, ", ")))
+			return
+		}
+
+		if managed := r.state.managedInstanceHostnames(hostnames); len(managed) > 0 {
+			detail := fmt.Sprintf("The delete operation targets %s, managed by bunkerweb_instance resources in this configuration. "+
+				"Deleting them here removes them from under Terraform, which recreates them on the next apply; remove the resources instead.",
+				strings.Join(managed, ", "))
+			switch policy {
+			case "error":
+				resp.Diagnostics.AddAttributeError(path.Root("hostnames"), "Managed Instances Targeted", detail)
+				return
+			case "warn":
+				resp.Diagnostics.AddAttributeWarning(path.Root("hostnames"), "Managed Instances Targeted", detail)
+			}
+		}
+	}
+
 	var result any
 	var err error
 
@@ -120,9 +233,17 @@ func (r *BunkerWebInstanceActionEphemeralResource) Open(ctx context.Context, req
 	case "ping":
 		result, err = r.handlePing(ctx, hostnames)
 	case "reload":
-		result, err = r.handleReload(ctx, hostnames, data.Test)
+		if data.BatchSize.IsNull() {
+			result, err = r.handleReload(ctx, hostnames, data.Test)
+		} else {
+			result, err = r.handleRollingReload(ctx, hostnames, data)
+		}
 	case "stop":
 		result, err = r.handleStop(ctx, hostnames)
+	case "restart":
+		result, err = r.handleRestart(ctx, hostnames)
+	case "health":
+		result, err = r.handleHealth(ctx, hostnames)
 	case "delete":
 		result, err = r.handleDelete(ctx, hostnames)
 	}
@@ -170,13 +291,77 @@ func (r *BunkerWebInstanceActionEphemeralResource) handleReload(ctx context.Cont
 		testPtr = &val
 	}
 
+	return reloadInstances(ctx, r.client, hostnames, testPtr)
+}
+
+// handleRollingReload reloads instances batch by batch, pausing and, unless
+// disabled, pinging each batch before the next one so a bad configuration
+// stops at the first batch instead of reaching the whole fleet.
+func (r *BunkerWebInstanceActionEphemeralResource) handleRollingReload(ctx context.Context, hostnames []string, data BunkerWebInstanceActionModel) (any, error) {
+	size := int(data.BatchSize.ValueInt64())
+	if size < 1 {
+		return nil, fmt.Errorf("batch_size must be at least 1")
+	}
+	pause := time.Duration(0)
+	if !data.Pause.IsNull() {
+		var err error
+		if pause, err = time.ParseDuration(data.Pause.ValueString()); err != nil {
+			return nil, fmt.Errorf("invalid pause_between_batches: %w", err)
+		}
+	}
+	verify := data.Verify.IsNull() || data.Verify.ValueBool()
+
+	hostnames, err := r.targetHostnames(ctx, hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	var test *bool
+	if !data.Test.IsNull() {
+		val := data.Test.ValueBool()
+		test = &val
+	}
+
+	responses := make(map[string]any, len(hostnames))
+	for start := 0; start < len(hostnames); start += size {
+		batch := hostnames[start:min(start+size, len(hostnames))]
+		payloads, err := reloadInstances(ctx, r.client, batch, test)
+		if err != nil {
+			return nil, fmt.Errorf("batch %d: %w", start/size+1, err)
+		}
+		for host, payload := range payloads.(map[string]any) {
+			responses[host] = payload
+		}
+
+		if start+size >= len(hostnames) {
+			break
+		}
+		if err := sleepContext(ctx, pause); err != nil {
+			return nil, fmt.Errorf("interrupted after batch %d: %w", start/size+1, err)
+		}
+		if verify {
+			for _, result := range pingInstances(ctx, r.client, batch) {
+				if !result.Success.ValueBool() {
+					return nil, fmt.Errorf("instance %s did not answer after batch %d, %d instances were not reloaded: %s",
+						result.Hostname.ValueString(), start/size+1, len(hostnames)-start-size, result.Error.ValueString())
+				}
+			}
+		}
+	}
+
+	return responses, nil
+}
+
+// reloadInstances reloads the given hosts, or the whole fleet when none are
+// provided, and returns the API payloads (keyed by host for targeted reloads).
+func reloadInstances(ctx context.Context, client BunkerWebAPI, hostnames []string, test *bool) (any, error) {
 	if len(hostnames) == 0 {
-		return r.client.ReloadInstances(ctx, testPtr)
+		return client.ReloadInstances(ctx, test)
 	}
 
 	responses := make(map[string]any, len(hostnames))
 	for _, host := range hostnames {
-		payload, err := r.client.ReloadInstance(ctx, host, testPtr)
+		payload, err := client.ReloadInstance(ctx, host, test)
 		if err != nil {
 			return nil, err
 		}
@@ -203,6 +388,64 @@ func (r *BunkerWebInstanceActionEphemeralResource) handleStop(ctx context.Contex
 	return responses, nil
 }
 
+// handleRestart restarts the given hosts, or every registered instance,
+// one at a time.
+func (r *BunkerWebInstanceActionEphemeralResource) handleRestart(ctx context.Context, hostnames []string) (any, error) {
+	hostnames, err := r.targetHostnames(ctx, hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[string]any, len(hostnames))
+	for _, host := range hostnames {
+		payload, err := r.client.RestartInstance(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		responses[host] = payload
+	}
+
+	return responses, nil
+}
+
+// handleHealth reports the health of the given hosts, or of every registered
+// instance, keyed by hostname.
+func (r *BunkerWebInstanceActionEphemeralResource) handleHealth(ctx context.Context, hostnames []string) (any, error) {
+	hostnames, err := r.targetHostnames(ctx, hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[string]any, len(hostnames))
+	for _, host := range hostnames {
+		payload, err := r.client.InstanceHealth(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		responses[host] = payload
+	}
+
+	return responses, nil
+}
+
+// targetHostnames returns hostnames, or the sorted hostnames of every
+// registered instance when none are given.
+func (r *BunkerWebInstanceActionEphemeralResource) targetHostnames(ctx context.Context, hostnames []string) ([]string, error) {
+	if len(hostnames) > 0 {
+		return hostnames, nil
+	}
+
+	instances, err := r.client.ListInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, inst := range instances {
+		hostnames = append(hostnames, inst.Hostname)
+	}
+	slices.Sort(hostnames)
+	return hostnames, nil
+}
+
 func (r *BunkerWebInstanceActionEphemeralResource) handleDelete(ctx context.Context, hostnames []string) (any, error) {
 	if len(hostnames) == 0 {
 		return nil, fmt.Errorf("provide at least one hostname when operation is delete")
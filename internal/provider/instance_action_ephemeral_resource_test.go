@@ -5,6 +5,8 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -90,3 +92,109 @@ ephemeral "bunkerweb_instance_action" "stop_all" {
 }
 `, endpoint)
 }
+
+func TestAccBunkerWebInstanceActionDeleteManagedInstance(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebInstanceActionInstanceOnlyConfig(fakeAPI.URL()),
+			},
+			{
+				Config:      testAccBunkerWebInstanceActionDeleteManagedConfig(fakeAPI.URL(), "error"),
+				ExpectError: regexp.MustCompile(`Managed Instances Targeted`),
+			},
+			{
+				Config:      testAccBunkerWebInstanceActionDeleteManagedConfig(fakeAPI.URL(), "sometimes"),
+				ExpectError: regexp.MustCompile(`Invalid Managed Instance Policy`),
+			},
+		},
+	})
+
+	for _, batch := range fakeAPI.DeletedInstanceBatches() {
+		if slices.Contains(batch, "edge-1") {
+			t.Fatalf("expected the managed instance not to be deleted, got batch %v", batch)
+		}
+	}
+}
+
+func testAccBunkerWebInstanceActionDeleteManagedConfig(endpoint, policy string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_instance" "edge" {
+  hostname = "edge-1"
+}
+
+ephemeral "bunkerweb_instance_action" "delete" {
+  operation           = "delete"
+  hostnames           = [bunkerweb_instance.edge.hostname]
+  on_managed_instance = "%s"
+}
+`, endpoint, policy)
+}
+
+func TestAccBunkerWebInstanceActionEphemeralResourceRollingReload(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	for _, host := range []string{"edge-1", "edge-2", "edge-3"} {
+		fakeAPI.AddInstance(bunkerWebInstance{Hostname: host})
+	}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebInstanceActionRollingReloadConfig(fakeAPI.URL(), `operation = "reload"`),
+			},
+			{
+				Config:      testAccBunkerWebInstanceActionRollingReloadConfig(fakeAPI.URL(), `operation = "stop"`),
+				ExpectError: regexp.MustCompile(`Unsupported Batching`),
+			},
+		},
+	})
+
+	var reloaded []string
+	for _, call := range fakeAPI.ReloadHostCalls() {
+		reloaded = append(reloaded, call.host)
+	}
+	for _, host := range []string{"edge-1", "edge-2", "edge-3"} {
+		if !slices.Contains(reloaded, host) {
+			t.Fatalf("expected %s to be reloaded, got %v", host, reloaded)
+		}
+	}
+	if len(fakeAPI.ReloadAllTests()) != 0 {
+		t.Fatalf("expected no fleet-wide reload during a rolling reload")
+	}
+	if !slices.Contains(fakeAPI.PingHosts(), "edge-1") {
+		t.Fatalf("expected the first batch to be pinged before the next one")
+	}
+}
+
+func testAccBunkerWebInstanceActionRollingReloadConfig(endpoint, operation string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_instance_action" "rolling" {
+  %s
+  test                  = false
+  batch_size            = 2
+  pause_between_batches = "0s"
+}
+`, endpoint, operation)
+}
@@ -0,0 +1,173 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BunkerWebInstanceDataSource{}
+
+// BunkerWebInstanceDataSource reads one registered instance and pings it.
+type BunkerWebInstanceDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebInstanceDataSourceModel holds state.
+type BunkerWebInstanceDataSourceModel struct {
+	Hostname    types.String `tfsdk:"hostname"`
+	Name        types.String `tfsdk:"name"`
+	Port        types.Int64  `tfsdk:"port"`
+	ListenHTTPS types.Bool   `tfsdk:"listen_https"`
+	HTTPSPort   types.Int64  `tfsdk:"https_port"`
+	ServerName  types.String `tfsdk:"server_name"`
+	Method      types.String `tfsdk:"method"`
+	Type        types.String `tfsdk:"type"`
+	Labels      types.Map    `tfsdk:"labels"`
+	Reachable   types.Bool   `tfsdk:"reachable"`
+	LatencyMs   types.Int64  `tfsdk:"latency_ms"`
+	PingError   types.String `tfsdk:"ping_error"`
+}
+
+func NewBunkerWebInstanceDataSource() datasource.DataSource {
+	return &BunkerWebInstanceDataSource{}
+}
+
+func (d *BunkerWebInstanceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance"
+}
+
+func (d *BunkerWebInstanceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a registered BunkerWeb instance by hostname and pings it, so configurations that do not manage the instance can still depend on whether it is up. " +
+			"An unreachable instance is reported in `reachable` rather than failing the read; an unregistered one is an error.",
+		Attributes: map[string]schema.Attribute{
+			"hostname": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Hostname the instance is registered under.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Friendly name of the instance.",
+			},
+			"port": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "HTTP port of the instance API.",
+			},
+			"listen_https": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the instance API listens over HTTPS.",
+			},
+			"https_port": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "HTTPS port of the instance API.",
+			},
+			"server_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Server name the instance API answers to.",
+			},
+			"method": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Method that registered the instance, such as `api`, `ui` or `autoconf`.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Integration type of the instance, when the API reports it.",
+			},
+			"labels": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels of the instance, when the API reports them.",
+			},
+			"reachable": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the instance answered a ping.",
+			},
+			"latency_ms": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Round-trip time of the ping in milliseconds.",
+			},
+			"ping_error": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Why the ping failed, when `reachable` is false.",
+			},
+		},
+	}
+}
+
+func (d *BunkerWebInstanceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebInstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebInstanceDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostname := strings.TrimSpace(data.Hostname.ValueString())
+	instance, err := d.client.GetInstance(ctx, hostname)
+	if isNotFound(err) {
+		resp.Diagnostics.AddError("Instance Not Found", fmt.Sprintf("No instance is registered with hostname %q.", hostname))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Instance", err.Error())
+		return
+	}
+
+	data.Name = types.StringPointerValue(instance.Name)
+	data.Port = intPointerValue(instance.Port)
+	data.ListenHTTPS = types.BoolPointerValue(instance.ListenHTTPS)
+	data.HTTPSPort = intPointerValue(instance.HTTPSPort)
+	data.ServerName = types.StringPointerValue(instance.ServerName)
+	data.Method = types.StringPointerValue(instance.Method)
+	data.Type = types.StringPointerValue(instance.Type)
+	labels, diags := mapToTerraform(ctx, instance.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Labels = labels
+
+	ping := pingInstances(ctx, d.client, []string{instance.Hostname})[0]
+	data.Reachable = ping.Success
+	data.LatencyMs = ping.LatencyMs
+	data.PingError = ping.Error
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// intPointerValue converts an optional API integer to a Terraform value.
+func intPointerValue(value *int) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
@@ -0,0 +1,68 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebInstanceDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	client, err := newBunkerWebClient(fakeAPI.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	port := 5000
+	for _, hostname := range []string{"edge-1", "edge-2"} {
+		if _, err := client.CreateInstance(context.Background(), InstanceCreateRequest{Hostname: hostname, Port: &port}); err != nil {
+			t.Fatalf("CreateInstance: %v", err)
+		}
+	}
+	fakeAPI.SetUnreachable("edge-2")
+
+	config := func(hostname string) string {
+		return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_instance" "edge" {
+  hostname = %q
+}
+`, fakeAPI.URL(), hostname)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("edge-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_instance.edge", "port", "5000"),
+					resource.TestCheckResourceAttr("data.bunkerweb_instance.edge", "reachable", "true"),
+					resource.TestCheckNoResourceAttr("data.bunkerweb_instance.edge", "ping_error"),
+				),
+			},
+			{
+				Config: config("edge-2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_instance.edge", "reachable", "false"),
+					resource.TestCheckResourceAttrSet("data.bunkerweb_instance.edge", "ping_error"),
+				),
+			},
+			{
+				Config:      config("edge-3"),
+				ExpectError: regexp.MustCompile(`Instance Not Found`),
+			},
+		},
+	})
+}
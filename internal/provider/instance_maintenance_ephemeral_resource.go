@@ -0,0 +1,230 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ ephemeral.EphemeralResource = &BunkerWebInstanceMaintenanceEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &BunkerWebInstanceMaintenanceEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &BunkerWebInstanceMaintenanceEphemeralResource{}
+
+// instanceMaintenancePrivateKey holds the instances to reload on Close.
+const instanceMaintenancePrivateKey = "maintenance"
+
+// defaultInstanceDrainPeriod is how long stopped instances are given to
+// finish in-flight connections.
+const defaultInstanceDrainPeriod = "30s"
+
+// BunkerWebInstanceMaintenanceEphemeralResource stops instances for the
+// lifetime of a plan or apply and reloads them afterwards.
+type BunkerWebInstanceMaintenanceEphemeralResource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebInstanceMaintenanceModel represents the Terraform schema.
+type BunkerWebInstanceMaintenanceModel struct {
+	Hostnames   types.List   `tfsdk:"hostnames"`
+	DrainPeriod types.String `tfsdk:"drain_period"`
+	Test        types.Bool   `tfsdk:"test"`
+	Stopped     types.List   `tfsdk:"stopped"`
+}
+
+// instanceMaintenanceState is what Close needs to bring instances back.
+type instanceMaintenanceState struct {
+	Hostnames []string `json:"hostnames"`
+	Test      *bool    `json:"test,omitempty"`
+}
+
+func NewBunkerWebInstanceMaintenanceEphemeralResource() ephemeral.EphemeralResource {
+	return &BunkerWebInstanceMaintenanceEphemeralResource{}
+}
+
+func (r *BunkerWebInstanceMaintenanceEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_maintenance"
+}
+
+func (r *BunkerWebInstanceMaintenanceEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Puts BunkerWeb instances in maintenance: on open they are stopped and given time to drain connections, and when Terraform closes the resource at the end of the plan or apply they are reloaded. Changes that must happen while the instances are down can reference this resource.",
+		Attributes: map[string]schema.Attribute{
+			"hostnames": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Instances to take down for maintenance.",
+			},
+			"drain_period": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Time to wait after stopping the instances, letting in-flight connections finish, as a Go duration. Defaults to `30s`.",
+			},
+			"test": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the reload on close runs in test mode (defaults to true).",
+			},
+			"stopped": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Instances stopped by this resource.",
+			},
+		},
+	}
+}
+
+func (r *BunkerWebInstanceMaintenanceEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+// ValidateConfig checks the drain period and summarises the instances that
+// will be stopped, once every value is known.
+func (r *BunkerWebInstanceMaintenanceEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data BunkerWebInstanceMaintenanceModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		return
+	}
+
+	if !data.DrainPeriod.IsNull() && !data.DrainPeriod.IsUnknown() {
+		if period, err := time.ParseDuration(data.DrainPeriod.ValueString()); err != nil || period < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("drain_period"),
+				"Invalid Drain Period",
+				fmt.Sprintf("Expected a non-negative duration such as `30s`, got %q.", data.DrainPeriod.ValueString()),
+			)
+			return
+		}
+	}
+
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+	hostnames, diags := listToStrings(ctx, data.Hostnames)
+	if diags.HasError() || len(hostnames) == 0 {
+		return
+	}
+	addPlanSummary(&resp.Diagnostics, "bunkerweb_instance_maintenance", []string{
+		"stop " + summarizeTargets(len(hostnames), "instance", hostnames) + " and reload them when closed",
+	})
+}
+
+func (r *BunkerWebInstanceMaintenanceEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebInstanceMaintenanceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostnames, diags := listToStrings(ctx, data.Hostnames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(hostnames) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("hostnames"), "Missing Hostnames", "Provide at least one instance to take down for maintenance.")
+		return
+	}
+
+	drain := defaultInstanceDrainPeriod
+	if !data.DrainPeriod.IsNull() && !data.DrainPeriod.IsUnknown() {
+		drain = data.DrainPeriod.ValueString()
+	}
+	period, err := time.ParseDuration(drain)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("drain_period"), "Invalid Drain Period", err.Error())
+		return
+	}
+
+	state := instanceMaintenanceState{Test: optionalBool(data.Test)}
+	for _, host := range hostnames {
+		host = strings.TrimSpace(host)
+		if _, err := r.client.StopInstance(ctx, host); err != nil {
+			// Bring back what was already stopped rather than leaving part
+			// of the fleet down.
+			if _, reloadErr := reloadInstances(ctx, r.client, state.Hostnames, state.Test); reloadErr != nil {
+				err = fmt.Errorf("%w; reloading the instances already stopped also failed: %s", err, reloadErr)
+			}
+			resp.Diagnostics.AddError("Unable to Stop Instance", fmt.Sprintf("Stopping %s: %s", host, err))
+			return
+		}
+		state.Hostnames = append(state.Hostnames, host)
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Encode Result", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, instanceMaintenancePrivateKey, encoded)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "stopped bunkerweb instances for maintenance", map[string]any{"hostnames": state.Hostnames, "drain_period": period.String()})
+
+	if err := sleepContext(ctx, period); err != nil {
+		resp.Diagnostics.AddError("Unable to Drain Instances", fmt.Sprintf("Interrupted while waiting for connections to drain; the instances are reloaded on close: %s", err))
+		return
+	}
+
+	data.Stopped, diags = types.ListValueFrom(ctx, types.StringType, state.Hostnames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *BunkerWebInstanceMaintenanceEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	encoded, diags := req.Private.GetKey(ctx, instanceMaintenancePrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(encoded) == 0 {
+		return
+	}
+
+	var state instanceMaintenanceState
+	if err := json.Unmarshal(encoded, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to Reload Instances", fmt.Sprintf("Unable to decode the stopped instances: %v", err))
+		return
+	}
+	if len(state.Hostnames) == 0 {
+		return
+	}
+
+	if _, err := reloadInstances(ctx, r.client, state.Hostnames, state.Test); err != nil {
+		resp.Diagnostics.AddError("Unable to Reload Instances", err.Error())
+	}
+}
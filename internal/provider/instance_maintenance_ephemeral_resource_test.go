@@ -0,0 +1,93 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBunkerWebInstanceMaintenanceEphemeralResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddInstance(bunkerWebInstance{Hostname: "edge-1"})
+	fakeAPI.AddInstance(bunkerWebInstance{Hostname: "edge-2"})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebInstanceMaintenanceEphemeralResourceConfig(fakeAPI.URL(), `"edge-1", "edge-2"`, "0s"),
+			},
+		},
+	})
+
+	stopped := fakeAPI.StopHosts()
+	reloaded := reloadedHosts(fakeAPI)
+	for _, host := range []string{"edge-1", "edge-2"} {
+		if !slices.Contains(stopped, host) {
+			t.Fatalf("expected %s to be stopped, got %v", host, stopped)
+		}
+		if !slices.Contains(reloaded, host) {
+			t.Fatalf("expected %s to be reloaded on close, got %v", host, reloaded)
+		}
+	}
+}
+
+func TestAccBunkerWebInstanceMaintenanceEphemeralResourceRollback(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddInstance(bunkerWebInstance{Hostname: "edge-1"})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebInstanceMaintenanceEphemeralResourceConfig(fakeAPI.URL(), `"edge-1", "missing"`, "0s"),
+				ExpectError: regexp.MustCompile(`Unable to Stop Instance`),
+			},
+			{
+				Config:      testAccBunkerWebInstanceMaintenanceEphemeralResourceConfig(fakeAPI.URL(), `"edge-1"`, "soon"),
+				ExpectError: regexp.MustCompile(`Invalid Drain Period`),
+			},
+		},
+	})
+
+	if !slices.Contains(reloadedHosts(fakeAPI), "edge-1") {
+		t.Fatalf("expected edge-1 to be reloaded after the failed stop")
+	}
+}
+
+func reloadedHosts(fakeAPI *fakeBunkerWebAPI) []string {
+	var hosts []string
+	for _, call := range fakeAPI.ReloadHostCalls() {
+		hosts = append(hosts, call.host)
+	}
+	return hosts
+}
+
+func testAccBunkerWebInstanceMaintenanceEphemeralResourceConfig(endpoint, hostnames, drain string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_instance_maintenance" "window" {
+  hostnames    = [%s]
+  drain_period = "%s"
+}
+`, endpoint, hostnames, drain)
+}
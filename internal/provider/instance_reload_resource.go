@@ -0,0 +1,203 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &BunkerWebInstanceReloadResource{}
+
+// BunkerWebInstanceReloadResource reloads instances whenever its triggers change.
+type BunkerWebInstanceReloadResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebInstanceReloadResourceModel is the Terraform state.
+type BunkerWebInstanceReloadResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Hostnames      types.List   `tfsdk:"hostnames"`
+	Test           types.Bool   `tfsdk:"test"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	Revision       types.Int64  `tfsdk:"revision"`
+	LastReloadedAt types.String `tfsdk:"last_reloaded_at"`
+	Result         types.String `tfsdk:"result"`
+	Retries        types.Object `tfsdk:"retries"`
+}
+
+func NewBunkerWebInstanceReloadResource() resource.Resource {
+	return &BunkerWebInstanceReloadResource{}
+}
+
+func (r *BunkerWebInstanceReloadResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_reload"
+}
+
+func (r *BunkerWebInstanceReloadResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reloads BunkerWeb instances when created and every time `triggers` (or any other argument) changes. " +
+			"Each reload bumps `revision` and records its time and API response. Destroying the resource does not contact the API.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the reload target (`all` or the comma-separated hostnames).",
+			},
+			"hostnames": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Instances to reload. When omitted, every instance is reloaded.",
+			},
+			"test": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to validate the configuration in test mode before reloading (API default when omitted).",
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that trigger a reload when they change, for example a hash of service variables.",
+			},
+			"revision": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of reloads performed by this resource.",
+			},
+			"last_reloaded_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp of the last reload.",
+			},
+			"result": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "JSON-encoded response of the last reload.",
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebInstanceReloadResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebInstanceReloadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebInstanceReloadResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reload(ctx, &plan, 0)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebInstanceReloadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Reloads leave nothing to refresh; keep the recorded state.
+	var state BunkerWebInstanceReloadResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BunkerWebInstanceReloadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan, state BunkerWebInstanceReloadResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reload(ctx, &plan, state.Revision.ValueInt64())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebInstanceReloadResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+	// Nothing to undo: removing the resource only stops future reloads.
+}
+
+// reload performs the reload described by m and records the outcome on it.
+func (r *BunkerWebInstanceReloadResource) reload(ctx context.Context, m *BunkerWebInstanceReloadResourceModel, previousRevision int64) diag.Diagnostics {
+	hostnames, diags := listToStrings(ctx, m.Hostnames)
+	if diags.HasError() {
+		return diags
+	}
+
+	result, err := reloadInstances(ctx, r.client, hostnames, optionalBool(m.Test))
+	if err != nil {
+		diags.AddError("Unable to Reload Instances", err.Error())
+		return diags
+	}
+
+	encoded, err := encodeResult(result)
+	if err != nil {
+		diags.AddError("Encode Result", err.Error())
+		return diags
+	}
+
+	id := "all"
+	if len(hostnames) > 0 {
+		id = strings.Join(hostnames, ",")
+	}
+
+	m.ID = types.StringValue(id)
+	m.Revision = types.Int64Value(previousRevision + 1)
+	m.LastReloadedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	m.Result = types.StringValue(encoded)
+
+	tflog.Info(ctx, "reloaded bunkerweb instances", map[string]any{"target": id, "revision": previousRevision + 1})
+
+	return diags
+}
@@ -0,0 +1,68 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebInstanceReloadResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebInstanceReloadResourceConfig(fakeAPI.URL(), "v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_instance_reload.edge", "id", "edge-1"),
+					resource.TestCheckResourceAttr("bunkerweb_instance_reload.edge", "revision", "1"),
+					resource.TestCheckResourceAttrSet("bunkerweb_instance_reload.edge", "last_reloaded_at"),
+					resource.TestCheckResourceAttr("bunkerweb_instance_reload.edge", "result", `{"edge-1":{"host":"edge-1","test":false}}`),
+				),
+			},
+			{
+				// Unchanged triggers must not reload again.
+				Config:   testAccBunkerWebInstanceReloadResourceConfig(fakeAPI.URL(), "v1"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccBunkerWebInstanceReloadResourceConfig(fakeAPI.URL(), "v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_instance_reload.edge", "revision", "2"),
+				),
+			},
+		},
+	})
+
+	if calls := fakeAPI.ReloadHostCalls(); len(calls) != 2 {
+		t.Fatalf("expected two reloads, got %v", calls)
+	}
+}
+
+func testAccBunkerWebInstanceReloadResourceConfig(endpoint, revision string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_instance" "edge" {
+  hostname = "edge-1"
+}
+
+resource "bunkerweb_instance_reload" "edge" {
+  hostnames = [bunkerweb_instance.edge.hostname]
+  test      = false
+
+  triggers = {
+    revision = "%s"
+  }
+}
+`, endpoint, revision)
+}
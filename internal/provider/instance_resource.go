@@ -7,13 +7,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,6 +27,12 @@ import (
 
 var _ resource.Resource = &BunkerWebInstanceResource{}
 var _ resource.ResourceWithImportState = &BunkerWebInstanceResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebInstanceResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebInstanceResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebInstanceResource{}
+
+// instanceTypes lists the integration types autoconf publishes for instances.
+var instanceTypes = []string{"docker", "swarm", "k8s", "manual"}
 
 func NewBunkerWebInstanceResource() resource.Resource {
 	return &BunkerWebInstanceResource{}
@@ -28,7 +40,8 @@ func NewBunkerWebInstanceResource() resource.Resource {
 
 // BunkerWebInstanceResource represents the bunkerweb_instance Terraform resource.
 type BunkerWebInstanceResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
+	state  *providerState
 }
 
 type BunkerWebInstanceResourceModel struct {
@@ -40,6 +53,14 @@ type BunkerWebInstanceResourceModel struct {
 	HTTPSPort   types.Int64  `tfsdk:"https_port"`
 	ServerName  types.String `tfsdk:"server_name"`
 	Method      types.String `tfsdk:"method"`
+	Type        types.String `tfsdk:"type"`
+	Labels      types.Map    `tfsdk:"labels"`
+	APIToken    types.String `tfsdk:"api_token"`
+	APICert     types.String `tfsdk:"api_cert"`
+	APIURL      types.String `tfsdk:"api_url"`
+	HTTPSAPIURL types.String `tfsdk:"https_api_url"`
+	Affixes     types.Bool   `tfsdk:"apply_name_affixes"`
+	Retries     types.Object `tfsdk:"retries"`
 }
 
 func (r *BunkerWebInstanceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,6 +86,12 @@ func (r *BunkerWebInstanceResource) Schema(_ context.Context, _ resource.SchemaR
 				Optional:            true,
 				MarkdownDescription: "Friendly display name for the instance.",
 			},
+			"apply_name_affixes": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true, the provider `name_prefix` and `name_suffix` are added to `name` in BunkerWeb while state keeps the configured value. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
 			"port": schema.Int64Attribute{
 				Optional:            true,
 				Computed:            true,
@@ -95,25 +122,136 @@ func (r *BunkerWebInstanceResource) Schema(_ context.Context, _ resource.SchemaR
 				Computed:            true,
 				MarkdownDescription: "Method tag describing how the instance was registered.",
 			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Integration type of the instance, matching what autoconf publishes: `docker`, `swarm`, `k8s` or `manual`. When unset, the type reported by BunkerWeb is kept.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseNonNullStateForUnknown(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Labels attached to the instance, in the same form autoconf publishes them. When unset, the labels reported by BunkerWeb are kept; set an empty map to remove them.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseNonNullStateForUnknown(),
+				},
+			},
+			"api_token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Token the control plane uses to call this instance's API. Write-only on the BunkerWeb side: it is sent on create and update but never read back, so out-of-band changes are not detected.",
+			},
+			"api_cert": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "PEM certificate the control plane trusts when calling this instance's API over HTTPS. Sent on create and update but never read back.",
+			},
+			"api_url": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "URL of the instance API as the control plane reaches it: `https://<hostname>:<https_port>` when `listen_https` is set, " +
+					"`http://<hostname>:<port>` otherwise. Null while the port is not known.",
+			},
+			"https_api_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`https://<hostname>:<https_port>` when the instance API listens over HTTPS, null otherwise.",
+			},
+			"retries": retriesResourceAttribute(),
 		},
 	}
 }
 
+func (r *BunkerWebInstanceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIDIdentitySchema("Hostname of the instance.")
+}
+
 func (r *BunkerWebInstanceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebInstanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebInstanceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Hostname.IsNull() && !config.Hostname.IsUnknown() && !validHostname(config.Hostname.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Invalid Hostname",
+			fmt.Sprintf("%q is neither a valid DNS name nor an IP address.", config.Hostname.ValueString()),
+		)
+	}
+
+	for _, port := range []struct {
+		name  string
+		value types.Int64
+	}{
+		{"port", config.Port},
+		{"https_port", config.HTTPSPort},
+	} {
+		if port.value.IsNull() || port.value.IsUnknown() {
+			continue
+		}
+		if v := port.value.ValueInt64(); v < 1 || v > 65535 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(port.name),
+				"Invalid Port",
+				fmt.Sprintf("`%s` must be between 1 and 65535, got %d.", port.name, v),
+			)
+		}
+	}
+
+	if !config.HTTPSPort.IsNull() && !config.ListenHTTPS.IsUnknown() && !config.ListenHTTPS.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("https_port"),
+			"HTTPS Not Enabled",
+			"`https_port` requires `listen_https = true`.",
+		)
+	}
+
+	if !config.Type.IsNull() && !config.Type.IsUnknown() && !slices.Contains(instanceTypes, strings.ToLower(strings.TrimSpace(config.Type.ValueString()))) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid Instance Type",
+			fmt.Sprintf("`type` must be one of: %s.", strings.Join(instanceTypes, ", ")),
+		)
+	}
+}
+
+// ModifyPlan derives the API URLs from the planned address, so that they are
+// known at plan time whenever the hostname and ports are.
+func (r *BunkerWebInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BunkerWebInstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.setAPIURLs()
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("api_url"), plan.APIURL)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("https_api_url"), plan.HTTPSAPIURL)...)
 }
 
 func (r *BunkerWebInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -128,29 +266,48 @@ func (r *BunkerWebInstanceResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	request := InstanceCreateRequest{
 		Hostname:    plan.Hostname.ValueString(),
-		Name:        optionalString(plan.Name),
+		Name:        r.remoteName(plan),
 		Port:        optionalInt(plan.Port),
 		ListenHTTPS: optionalBool(plan.ListenHTTPS),
 		HTTPSPort:   optionalInt(plan.HTTPSPort),
 		ServerName:  optionalString(plan.ServerName),
 		Method:      optionalString(plan.Method),
+		Type:        optionalString(plan.Type),
+		APIToken:    optionalString(plan.APIToken),
+		APICert:     optionalString(plan.APICert),
+	}
+
+	labels, diags := mapFromTerraform(ctx, plan.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	request.Labels = labels
 
-	instance, err := r.client.CreateInstance(ctx, request)
+	instance, err := r.client.CreateInstance(withIdempotencyKey(ctx), request)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Create Instance", err.Error())
 		return
 	}
 
-	diags := plan.populateFromInstance(instance)
+	diags = plan.populateFromInstance(ctx, instance)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.localizeName(r.state)
+
+	r.state.trackManagedInstance(plan.ID.ValueString())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 }
 
 func (r *BunkerWebInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -165,10 +322,16 @@ func (r *BunkerWebInstanceResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	instance, err := r.client.GetInstance(ctx, state.ID.ValueString())
 	if err != nil {
 		var apiErr *bunkerWebAPIError
 		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			r.state.untrackManagedInstance(state.ID.ValueString())
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -177,13 +340,17 @@ func (r *BunkerWebInstanceResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	diags := state.populateFromInstance(instance)
+	diags := state.populateFromInstance(ctx, instance)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.localizeName(r.state)
+
+	r.state.trackManagedInstance(state.ID.ValueString())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 func (r *BunkerWebInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -192,19 +359,51 @@ func (r *BunkerWebInstanceResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	var plan BunkerWebInstanceResourceModel
+	var plan, state BunkerWebInstanceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	request := InstanceUpdateRequest{
-		Name:        optionalString(plan.Name),
+		Name:        r.remoteName(plan),
 		Port:        optionalInt(plan.Port),
 		ListenHTTPS: optionalBool(plan.ListenHTTPS),
 		HTTPSPort:   optionalInt(plan.HTTPSPort),
 		ServerName:  optionalString(plan.ServerName),
 		Method:      optionalString(plan.Method),
+		Type:        optionalString(plan.Type),
+		APIToken:    optionalString(plan.APIToken),
+		APICert:     optionalString(plan.APICert),
+	}
+
+	// Credentials are never read back; send an empty value to clear one
+	// that was removed from the configuration.
+	if request.APIToken == nil && !state.APIToken.IsNull() {
+		request.APIToken = stringPointer("")
+	}
+	if request.APICert == nil && !state.APICert.IsNull() {
+		request.APICert = stringPointer("")
+	}
+
+	// Only touch labels when Terraform manages them, so labels published by
+	// autoconf on unmanaged fleets are left alone.
+	if !plan.Labels.IsUnknown() && (!plan.Labels.IsNull() || !state.Labels.IsNull()) {
+		labels, diags := mapFromTerraform(ctx, plan.Labels)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		request.Labels = &labels
 	}
 
 	instance, err := r.client.UpdateInstance(ctx, plan.ID.ValueString(), request)
@@ -213,13 +412,17 @@ func (r *BunkerWebInstanceResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	diags := plan.populateFromInstance(instance)
+	diags := plan.populateFromInstance(ctx, instance)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.localizeName(r.state)
+
+	r.state.trackManagedInstance(plan.ID.ValueString())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 }
 
 func (r *BunkerWebInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -234,16 +437,82 @@ func (r *BunkerWebInstanceResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	if err := r.client.DeleteInstance(ctx, state.ID.ValueString()); err != nil {
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.BatchDeleteInstance(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Unable to Delete Instance", err.Error())
+		return
+	}
+
+	r.state.untrackManagedInstance(state.ID.ValueString())
+}
+
+// trackManagedInstance records that hostname is managed by a
+// bunkerweb_instance resource, so ephemeral actions can refuse to delete it.
+// Only resources created, read or updated by this provider process are known.
+func (s *providerState) trackManagedInstance(hostname string) {
+	s.managedInstancesMu.Lock()
+	defer s.managedInstancesMu.Unlock()
+	if s.managedInstances == nil {
+		s.managedInstances = map[string]struct{}{}
+	}
+	s.managedInstances[hostname] = struct{}{}
+}
+
+func (s *providerState) untrackManagedInstance(hostname string) {
+	s.managedInstancesMu.Lock()
+	defer s.managedInstancesMu.Unlock()
+	delete(s.managedInstances, hostname)
+}
+
+// managedInstanceHostnames returns the hostnames among hostnames that are
+// managed by a bunkerweb_instance resource.
+func (s *providerState) managedInstanceHostnames(hostnames []string) []string {
+	s.managedInstancesMu.Lock()
+	defer s.managedInstancesMu.Unlock()
+	var managed []string
+	for _, hostname := range hostnames {
+		if _, ok := s.managedInstances[hostname]; ok {
+			managed = append(managed, hostname)
+		}
 	}
+	return managed
 }
 
 func (r *BunkerWebInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// remoteName returns the instance name sent to BunkerWeb, with the provider
+// name affixes when apply_name_affixes is set.
+func (r *BunkerWebInstanceResource) remoteName(m BunkerWebInstanceResourceModel) *string {
+	name := optionalString(m.Name)
+	if name == nil {
+		return nil
+	}
+	remote := r.state.remoteName(m.Affixes, *name)
+	return &remote
+}
+
+// localizeName strips the provider name affixes from the name read back from
+// BunkerWeb. Imported instances do not use the affixes.
+func (m *BunkerWebInstanceResourceModel) localizeName(state *providerState) {
+	if m.Affixes.IsNull() || m.Affixes.IsUnknown() {
+		m.Affixes = types.BoolValue(false)
+	}
+	if !m.Name.IsNull() {
+		m.Name = types.StringValue(state.localName(m.Affixes, m.Name.ValueString()))
+	}
+}
+
+func (m *BunkerWebInstanceResourceModel) identity() resourceIDIdentityModel {
+	return resourceIDIdentityModel{ID: m.ID}
 }
 
-func (m *BunkerWebInstanceResourceModel) populateFromInstance(instance *bunkerWebInstance) diag.Diagnostics {
+func (m *BunkerWebInstanceResourceModel) populateFromInstance(ctx context.Context, instance *bunkerWebInstance) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	if instance == nil {
@@ -290,9 +559,76 @@ func (m *BunkerWebInstanceResourceModel) populateFromInstance(instance *bunkerWe
 		m.Method = types.StringNull()
 	}
 
+	// Older API versions do not report type/labels (and an empty labels map
+	// reads back as absent); keep the configured values in that case.
+	if instance.Type != nil {
+		m.Type = types.StringValue(*instance.Type)
+	} else if m.Type.IsUnknown() {
+		m.Type = types.StringNull()
+	}
+
+	if instance.Labels != nil && (len(instance.Labels) > 0 || m.Labels.IsNull() || m.Labels.IsUnknown()) {
+		labels, mapDiags := mapToTerraform(ctx, instance.Labels)
+		diags.Append(mapDiags...)
+		m.Labels = labels
+	} else if m.Labels.IsUnknown() {
+		m.Labels = types.MapNull(types.StringType)
+	}
+
+	m.setAPIURLs()
+
 	return diags
 }
 
+// setAPIURLs derives api_url and https_api_url from the hostname, ports and
+// listen_https of m; they are unknown while any of those is.
+func (m *BunkerWebInstanceResourceModel) setAPIURLs() {
+	if m.Hostname.IsUnknown() || m.Port.IsUnknown() || m.ListenHTTPS.IsUnknown() || m.HTTPSPort.IsUnknown() {
+		m.APIURL = types.StringUnknown()
+		m.HTTPSAPIURL = types.StringUnknown()
+		return
+	}
+
+	instanceURL := func(scheme string, port types.Int64) types.String {
+		if m.Hostname.IsNull() || port.IsNull() {
+			return types.StringNull()
+		}
+		return types.StringValue(scheme + "://" + net.JoinHostPort(m.Hostname.ValueString(), strconv.FormatInt(port.ValueInt64(), 10)))
+	}
+
+	m.HTTPSAPIURL = types.StringNull()
+	if m.ListenHTTPS.ValueBool() {
+		m.HTTPSAPIURL = instanceURL("https", m.HTTPSPort)
+	}
+	m.APIURL = instanceURL("http", m.Port)
+	if m.ListenHTTPS.ValueBool() {
+		m.APIURL = m.HTTPSAPIURL
+	}
+}
+
+// validHostname reports whether value is an IP address or an RFC 1123 DNS name.
+func validHostname(value string) bool {
+	if net.ParseIP(value) != nil {
+		return true
+	}
+
+	name := strings.TrimSuffix(value, ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func optionalString(value types.String) *string {
 	if value.IsNull() || value.IsUnknown() {
 		return nil
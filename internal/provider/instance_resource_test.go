@@ -5,9 +5,15 @@ package provider
 
 import (
 	"fmt"
+	"maps"
+	"math/big"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccBunkerWebInstanceResource(t *testing.T) {
@@ -27,6 +33,10 @@ func TestAccBunkerWebInstanceResource(t *testing.T) {
 					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "https_port", "8443"),
 					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "server_name", "worker-1.example.internal"),
 					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "method", "api"),
+					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "type", "docker"),
+					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "labels.bunkerweb.INSTANCE", "yes"),
+					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "api_url", "https://worker-1.example.internal:8443"),
+					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "https_api_url", "https://worker-1.example.internal:8443"),
 				),
 			},
 			{
@@ -41,14 +51,140 @@ func TestAccBunkerWebInstanceResource(t *testing.T) {
 					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "name", "Worker node"),
 					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "port", "8081"),
 					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "listen_https", "false"),
-					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "https_port", "7443"),
+					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "https_port", "8443"),
 					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "server_name", "worker.internal"),
+					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "type", "swarm"),
+					resource.TestCheckNoResourceAttr("bunkerweb_instance.worker", "labels.%"),
+					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "api_url", "http://worker-1.example.internal:8081"),
+					resource.TestCheckNoResourceAttr("bunkerweb_instance.worker", "https_api_url"),
 				),
 			},
 		},
 	})
 }
 
+func TestInstanceKeepsReportedTypeAndLabels(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	const hostname = "worker-3.example.internal"
+
+	r := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_instance")
+	if errs := r.apply(map[string]tftypes.Value{
+		"hostname": tftypes.NewValue(tftypes.String, hostname),
+	}); len(errs) > 0 {
+		t.Fatalf("create: %v", errs)
+	}
+
+	// Autoconf publishes the type and labels of the instance out-of-band.
+	instance, ok := fakeAPI.Instance(hostname)
+	if !ok {
+		t.Fatal("instance was not created")
+	}
+	instanceType := "docker"
+	instance.Type = &instanceType
+	instance.Labels = map[string]string{"bunkerweb.INSTANCE": "yes"}
+	fakeAPI.AddInstance(*instance)
+
+	if errs := r.apply(map[string]tftypes.Value{
+		"hostname": tftypes.NewValue(tftypes.String, hostname),
+		"port":     tftypes.NewValue(tftypes.Number, big.NewFloat(8081)),
+	}); len(errs) > 0 {
+		t.Fatalf("update: %v", errs)
+	}
+	if got := r.attribute("type"); got != "docker" {
+		t.Fatalf("expected the reported type in state, got %q", got)
+	}
+	instance, _ = fakeAPI.Instance(hostname)
+	if want := map[string]string{"bunkerweb.INSTANCE": "yes"}; !maps.Equal(instance.Labels, want) {
+		t.Fatalf("expected the published labels to be kept, got %v", instance.Labels)
+	}
+}
+
+func TestInstanceAPIURLs(t *testing.T) {
+	m := BunkerWebInstanceResourceModel{
+		Hostname:    types.StringValue("2001:db8::1"),
+		Port:        types.Int64Value(5000),
+		ListenHTTPS: types.BoolValue(true),
+		HTTPSPort:   types.Int64Value(5443),
+	}
+	m.setAPIURLs()
+	if m.APIURL.ValueString() != "https://[2001:db8::1]:5443" || m.HTTPSAPIURL.ValueString() != "https://[2001:db8::1]:5443" {
+		t.Fatalf("unexpected HTTPS URLs: %s, %s", m.APIURL, m.HTTPSAPIURL)
+	}
+
+	m.ListenHTTPS = types.BoolNull()
+	m.setAPIURLs()
+	if m.APIURL.ValueString() != "http://[2001:db8::1]:5000" || !m.HTTPSAPIURL.IsNull() {
+		t.Fatalf("unexpected HTTP URLs: %s, %s", m.APIURL, m.HTTPSAPIURL)
+	}
+
+	m.Port = types.Int64Null()
+	m.setAPIURLs()
+	if !m.APIURL.IsNull() {
+		t.Fatalf("expected no URL without a port, got %s", m.APIURL)
+	}
+
+	m.Port = types.Int64Unknown()
+	m.setAPIURLs()
+	if !m.APIURL.IsUnknown() || !m.HTTPSAPIURL.IsUnknown() {
+		t.Fatalf("expected unknown URLs while the port is unknown, got %s, %s", m.APIURL, m.HTTPSAPIURL)
+	}
+}
+
+func TestValidHostname(t *testing.T) {
+	for value, want := range map[string]bool{
+		"worker-1.example.internal": true,
+		"bunkerweb":                 true,
+		"10.0.0.5":                  true,
+		"2001:db8::1":               true,
+		"":                          false,
+		"-worker.example.com":       false,
+		"worker_1.example.com":      false,
+		"worker..example.com":       false,
+		"worker 1":                  false,
+	} {
+		if got := validHostname(value); got != want {
+			t.Errorf("validHostname(%q) = %t, want %t", value, got, want)
+		}
+	}
+}
+
+func TestAccBunkerWebInstanceResourceValidation(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebInstanceResourceConfigInvalid(fakeAPI.URL(), "worker_1", "port = 8080"),
+				ExpectError: regexp.MustCompile(`Invalid Hostname`),
+			},
+			{
+				Config:      testAccBunkerWebInstanceResourceConfigInvalid(fakeAPI.URL(), "worker-1", "port = 70000"),
+				ExpectError: regexp.MustCompile(`Invalid Port`),
+			},
+			{
+				Config:      testAccBunkerWebInstanceResourceConfigInvalid(fakeAPI.URL(), "worker-1", "https_port = 8443"),
+				ExpectError: regexp.MustCompile(`HTTPS Not Enabled`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebInstanceResourceConfigInvalid(endpoint, hostname, extra string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_instance" "worker" {
+  hostname = "%s"
+  %s
+}
+`, endpoint, hostname, extra)
+}
+
 func testAccBunkerWebInstanceResourceConfigCreate(endpoint string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -64,6 +200,11 @@ resource "bunkerweb_instance" "worker" {
   https_port   = 8443
   server_name  = "worker-1.example.internal"
   method       = "api"
+  type         = "docker"
+
+  labels = {
+    "bunkerweb.INSTANCE" = "yes"
+  }
 }
 `, endpoint)
 }
@@ -80,9 +221,58 @@ resource "bunkerweb_instance" "worker" {
   name         = "Worker node"
   port         = 8081
   listen_https = false
-  https_port   = 7443
   server_name  = "worker.internal"
   method       = "api"
+  type         = "swarm"
+  labels       = {}
 }
 `, endpoint)
 }
+
+func TestAccBunkerWebInstanceResourceCredentials(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	const hostname = "worker-2.example.internal"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebInstanceResourceConfigInvalid(fakeAPI.URL(), hostname, `api_token = "instance-secret"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_instance.worker", "api_token", "instance-secret"),
+					func(*terraform.State) error {
+						if got := fakeAPI.InstanceAPIToken(hostname); got != "instance-secret" {
+							return fmt.Errorf("expected the API token to be sent on create, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "bunkerweb_instance.worker",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"api_token"},
+			},
+			{
+				Config: testAccBunkerWebInstanceResourceConfigInvalid(fakeAPI.URL(), hostname, `api_token = "rotated-secret"`),
+				Check: func(*terraform.State) error {
+					if got := fakeAPI.InstanceAPIToken(hostname); got != "rotated-secret" {
+						return fmt.Errorf("expected the API token to be sent on update, got %q", got)
+					}
+					return nil
+				},
+			},
+			{
+				Config: testAccBunkerWebInstanceResourceConfigInvalid(fakeAPI.URL(), hostname, ""),
+				Check: func(*terraform.State) error {
+					if got := fakeAPI.InstanceAPIToken(hostname); got != "" {
+						return fmt.Errorf("expected the API token to be cleared, got %q", got)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,231 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Command mockgen generates a mock of an interface of the package in the
+// current directory. Each method of the mock calls the function field named
+// after it (GetServiceFunc for GetService) and records the call; calling a
+// method whose field is nil fails the test.
+//
+// Usage (from go:generate):
+//
+//	go run ./internal/mockgen -interface BunkerWebAPI -type mockBunkerWebAPI -out mock_api_test.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	iface := flag.String("interface", "", "name of the interface to mock")
+	typeName := flag.String("type", "", "name of the generated mock type")
+	out := flag.String("out", "", "output file")
+	flag.Parse()
+	if *iface == "" || *typeName == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := generate(".", *iface, *typeName)
+	if err != nil {
+		log.Fatalf("mockgen: %s", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("mockgen: %s", err)
+	}
+}
+
+// generate returns the formatted source of the mock of iface, declared in
+// the package in dir.
+func generate(dir, iface, typeName string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if spec := findInterface(file, iface); spec != nil {
+			return render(fset, file.Name.Name, file, iface, typeName, spec)
+		}
+	}
+	return nil, fmt.Errorf("interface %s not found in %s", iface, dir)
+}
+
+func findInterface(file *ast.File, name string) *ast.InterfaceType {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok && ts.Name.Name == name {
+				return it
+			}
+		}
+	}
+	return nil
+}
+
+type method struct {
+	name    string
+	params  []string // "name type"
+	args    []string // names, with "..." on a variadic last one
+	results []string
+}
+
+func render(fset *token.FileSet, pkg string, file *ast.File, iface, typeName string, spec *ast.InterfaceType) ([]byte, error) {
+	expr := func(node ast.Expr) string {
+		var buf bytes.Buffer
+		_ = printer.Fprint(&buf, fset, node)
+		return buf.String()
+	}
+
+	used := map[string]bool{"sync": true, "testing": true}
+	var methods []method
+	for _, field := range spec.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return nil, fmt.Errorf("%s embeds %s; only methods are supported", iface, expr(field.Type))
+		}
+		ast.Inspect(fn, func(node ast.Node) bool {
+			if sel, ok := node.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					used[ident.Name] = true
+				}
+			}
+			return true
+		})
+
+		m := method{name: field.Names[0].Name}
+		for i, param := range fn.Params.List {
+			names := param.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent("arg" + strconv.Itoa(i))}
+			}
+			for _, n := range names {
+				m.params = append(m.params, n.Name+" "+expr(param.Type))
+				arg := n.Name
+				if _, ok := param.Type.(*ast.Ellipsis); ok {
+					arg += "..."
+				}
+				m.args = append(m.args, arg)
+			}
+		}
+		if fn.Results != nil {
+			for _, result := range fn.Results.List {
+				for range max(len(result.Names), 1) {
+					m.results = append(m.results, expr(result.Type))
+				}
+			}
+		}
+		methods = append(methods, m)
+	}
+
+	var imports []string
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if used[name] {
+			imports = append(imports, imp.Path.Value)
+			delete(used, name)
+		}
+	}
+	for name := range used {
+		imports = append(imports, strconv.Quote(name))
+	}
+	// Standard library first, as goimports groups them.
+	slices.SortFunc(imports, func(a, b string) int {
+		if aStd, bStd := !strings.Contains(a, "."), !strings.Contains(b, "."); aStd != bStd {
+			if aStd {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by mockgen from %s; DO NOT EDIT.\n\npackage %s\n\nimport (\n", iface, pkg)
+	for i, imp := range imports {
+		if i > 0 && !strings.Contains(imports[i-1], ".") && strings.Contains(imp, ".") {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\t%s\n", imp)
+	}
+	fmt.Fprintf(&b, ")\n\nvar _ %s = (*%s)(nil)\n\n", iface, typeName)
+	fmt.Fprintf(&b, "// %s is a %s whose methods call the function field named\n// after them. Calling a method whose field is nil fails the test.\n", typeName, iface)
+	fmt.Fprintf(&b, "type %s struct {\n\tt testing.TB\n\n\tmu    sync.Mutex\n\tcalls []string\n\n", typeName)
+	for _, m := range methods {
+		fmt.Fprintf(&b, "\t%sFunc func(%s)%s\n", m.name, strings.Join(m.params, ", "), resultList(m.results))
+	}
+	b.WriteString("}\n")
+
+	for _, m := range methods {
+		fmt.Fprintf(&b, "\nfunc (mock *%s) %s(%s)%s {\n", typeName, m.name, strings.Join(m.params, ", "), resultList(m.results))
+		fmt.Fprintf(&b, "\tmock.record(%q)\n\tif mock.%sFunc == nil {\n\t\tmock.unexpected(%q)\n\t}\n\t", m.name, m.name, m.name)
+		if len(m.results) > 0 {
+			b.WriteString("return ")
+		}
+		fmt.Fprintf(&b, "mock.%sFunc(%s)\n}\n", m.name, strings.Join(m.args, ", "))
+	}
+
+	fmt.Fprintf(&b, `
+// Calls returns the names of the methods called so far, in order.
+func (mock *%[1]s) Calls() []string {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return append([]string(nil), mock.calls...)
+}
+
+func (mock *%[1]s) record(name string) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	mock.calls = append(mock.calls, name)
+}
+
+func (mock *%[1]s) unexpected(name string) {
+	if mock.t != nil {
+		mock.t.Helper()
+		mock.t.Fatalf("unexpected call to %[1]s.%%s", name)
+	}
+	panic("unexpected call to %[1]s." + name)
+}
+`, typeName)
+
+	return format.Source(b.Bytes())
+}
+
+func resultList(results []string) string {
+	switch len(results) {
+	case 0:
+		return ""
+	case 1:
+		return " " + results[0]
+	}
+	return " (" + strings.Join(results, ", ") + ")"
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
@@ -18,12 +19,14 @@ var _ ephemeral.EphemeralResource = &BunkerWebRunJobsEphemeralResource{}
 
 // BunkerWebRunJobsEphemeralResource triggers scheduler jobs during plan/apply.
 type BunkerWebRunJobsEphemeralResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebRunJobsEphemeralResourceModel captures Terraform shape.
 type BunkerWebRunJobsEphemeralResourceModel struct {
-	Jobs []BunkerWebRunJobItem `tfsdk:"jobs"`
+	Jobs              []BunkerWebRunJobItem `tfsdk:"jobs"`
+	WaitForCompletion types.Bool            `tfsdk:"wait_for_completion"`
+	Timeout           types.String          `tfsdk:"timeout"`
 }
 
 // BunkerWebRunJobItem describes a single job request.
@@ -42,7 +45,7 @@ func (r *BunkerWebRunJobsEphemeralResource) Metadata(_ context.Context, req ephe
 
 func (r *BunkerWebRunJobsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Triggers one or more scheduler jobs via the BunkerWeb API during planning or apply.",
+		MarkdownDescription: "Triggers one or more scheduler jobs via the BunkerWeb API during planning or apply, optionally waiting for them to finish.",
 		Attributes: map[string]schema.Attribute{
 			"jobs": schema.ListNestedAttribute{
 				Required:            true,
@@ -60,6 +63,14 @@ func (r *BunkerWebRunJobsEphemeralResource) Schema(_ context.Context, _ ephemera
 					},
 				},
 			},
+			"wait_for_completion": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When true, wait until every triggered job has finished, and fail if one of them failed. Defaults to `false`.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum time to wait for the jobs when `wait_for_completion` is set, as a Go duration (for example `10m`). Defaults to `5m`.",
+			},
 		},
 	}
 }
@@ -69,16 +80,16 @@ func (r *BunkerWebRunJobsEphemeralResource) Configure(_ context.Context, req eph
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Ephemeral Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *BunkerWebRunJobsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
@@ -104,11 +115,34 @@ func (r *BunkerWebRunJobsEphemeralResource) Open(ctx context.Context, req epheme
 		return
 	}
 
+	wait := defaultWaiter
+	if !data.Timeout.IsNull() && !data.Timeout.IsUnknown() {
+		timeout, err := time.ParseDuration(data.Timeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid Timeout", fmt.Sprintf("Expected a positive duration such as `10m`, got %q.", data.Timeout.ValueString()))
+			return
+		}
+		wait.Timeout = timeout
+	}
+
+	// Job run dates have a one-second resolution.
+	triggered := time.Now().Truncate(time.Second)
+
 	if err := r.client.RunJobs(ctx, jobItems); err != nil {
 		resp.Diagnostics.AddError("Run Jobs", err.Error())
 		return
 	}
 
+	if data.WaitForCompletion.ValueBool() {
+		err := wait.waitFor(ctx, "triggered jobs to finish", func(ctx context.Context) (bool, error) {
+			return r.jobsFinished(ctx, jobItems, triggered)
+		})
+		if err != nil {
+			addWaitError(&resp.Diagnostics, "Run Jobs", err)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
 }
 
@@ -116,6 +150,32 @@ func (r *BunkerWebRunJobsEphemeralResource) Close(context.Context, ephemeral.Clo
 	// No follow-up action required.
 }
 
+// jobsFinished reports whether every job has a finished run started at or
+// after since, and fails as soon as one of those runs failed.
+func (r *BunkerWebRunJobsEphemeralResource) jobsFinished(ctx context.Context, jobs []JobItem, since time.Time) (bool, error) {
+	runs, err := r.client.ListJobRuns(ctx, JobRunListOptions{Since: &since})
+	if err != nil {
+		return false, err
+	}
+
+	finished := true
+	for _, job := range jobs {
+		found := false
+		for _, run := range runs {
+			if run.Plugin != job.Plugin || (job.Name != nil && run.Name != *job.Name) || run.StartDate < since.Unix() || run.EndDate == 0 {
+				continue
+			}
+			if !run.Success {
+				return false, fmt.Errorf("job %s/%s failed", run.Plugin, run.Name)
+			}
+			found = true
+		}
+		finished = finished && found
+	}
+
+	return finished, nil
+}
+
 func (m *BunkerWebRunJobsEphemeralResourceModel) toJobItems() ([]JobItem, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
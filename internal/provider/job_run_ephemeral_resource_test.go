@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -32,6 +33,46 @@ func TestAccBunkerWebRunJobsEphemeralResource(t *testing.T) {
 	}
 }
 
+func TestAccBunkerWebRunJobsEphemeralResourceWait(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.FailJobs("backup")
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebRunJobsEphemeralResourceWaitConfig(fakeAPI.URL(), "reporter"),
+			},
+			{
+				Config:      testAccBunkerWebRunJobsEphemeralResourceWaitConfig(fakeAPI.URL(), "backup"),
+				ExpectError: regexp.MustCompile(`job backup/daily failed`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebRunJobsEphemeralResourceWaitConfig(endpoint, plugin string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_run_jobs" "trigger" {
+  jobs = [{
+    plugin = %q
+    name   = "daily"
+  }]
+  wait_for_completion = true
+  timeout             = "30s"
+}
+`, endpoint, plugin)
+}
+
 func testAccBunkerWebRunJobsEphemeralResourceConfig(endpoint string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -0,0 +1,222 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BunkerWebJobRunHistoryDataSource{}
+
+// BunkerWebJobRunHistoryDataSource lists past scheduler job executions.
+type BunkerWebJobRunHistoryDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebJobRunHistoryDataSourceModel holds state.
+type BunkerWebJobRunHistoryDataSourceModel struct {
+	Plugin         types.String `tfsdk:"plugin"`
+	Name           types.String `tfsdk:"name"`
+	Since          types.String `tfsdk:"since"`
+	Limit          types.Int64  `tfsdk:"limit"`
+	Runs           types.List   `tfsdk:"runs"`
+	LastRunSuccess types.Bool   `tfsdk:"last_run_success"`
+	LastSuccess    types.String `tfsdk:"last_success"`
+}
+
+func NewBunkerWebJobRunHistoryDataSource() datasource.DataSource {
+	return &BunkerWebJobRunHistoryDataSource{}
+}
+
+func (d *BunkerWebJobRunHistoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job_run_history"
+}
+
+func (d *BunkerWebJobRunHistoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists past scheduler job executions, most recent first. Combine `last_success` with a `precondition` to assert that a job (for example `certbot-renew`) ran successfully recently.",
+		Attributes: map[string]schema.Attribute{
+			"plugin": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return runs of jobs from this plugin.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return runs of the job with this name.",
+			},
+			"since": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return runs started at or after this RFC 3339 timestamp.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of runs returned in `runs`. `last_run_success` and `last_success` consider all matching runs.",
+			},
+			"runs": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Job executions matching the filters, most recent first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"plugin": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Plugin identifier.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Job name.",
+						},
+						"success": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the run succeeded.",
+						},
+						"start_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "RFC 3339 timestamp of the run start when reported by the API.",
+						},
+						"end_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "RFC 3339 timestamp of the run end when reported by the API.",
+						},
+					},
+				},
+			},
+			"last_run_success": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the most recent matching run succeeded (null when there is none).",
+			},
+			"last_success": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp at which the most recent successful run ended, or started when no end date is reported (null when there is none).",
+			},
+		},
+	}
+}
+
+func (d *BunkerWebJobRunHistoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebJobRunHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	if !addFeatureCheck(&resp.Diagnostics, d.client, featureJobHistory) {
+		return
+	}
+
+	var data BunkerWebJobRunHistoryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := JobRunListOptions{
+		Plugin: optionalString(data.Plugin),
+		Name:   optionalString(data.Name),
+	}
+	if !data.Since.IsNull() && !data.Since.IsUnknown() {
+		since, err := time.Parse(time.RFC3339, data.Since.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("since"), "Invalid Timestamp", fmt.Sprintf("Expected an RFC 3339 timestamp: %s", err))
+			return
+		}
+		opts.Since = &since
+	}
+	if !data.Limit.IsNull() && !data.Limit.IsUnknown() && data.Limit.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("limit"), "Invalid Limit", "limit must be greater than zero.")
+		return
+	}
+
+	runs, err := d.client.ListJobRuns(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Job Runs", err.Error())
+		return
+	}
+
+	data.LastRunSuccess = types.BoolNull()
+	data.LastSuccess = types.StringNull()
+	if len(runs) > 0 {
+		data.LastRunSuccess = types.BoolValue(runs[0].Success)
+	}
+	for _, run := range runs {
+		if !run.Success {
+			continue
+		}
+		if run.EndDate != 0 {
+			data.LastSuccess = unixDate(run.EndDate)
+		} else {
+			data.LastSuccess = unixDate(run.StartDate)
+		}
+		break
+	}
+
+	if !data.Limit.IsNull() && !data.Limit.IsUnknown() && int64(len(runs)) > data.Limit.ValueInt64() {
+		runs = runs[:data.Limit.ValueInt64()]
+	}
+
+	attrTypes := map[string]attr.Type{
+		"plugin":     types.StringType,
+		"name":       types.StringType,
+		"success":    types.BoolType,
+		"start_date": types.StringType,
+		"end_date":   types.StringType,
+	}
+
+	objs := make([]attr.Value, 0, len(runs))
+	for _, run := range runs {
+		obj, diags := types.ObjectValue(attrTypes, map[string]attr.Value{
+			"plugin":     types.StringValue(run.Plugin),
+			"name":       types.StringValue(run.Name),
+			"success":    types.BoolValue(run.Success),
+			"start_date": unixDate(run.StartDate),
+			"end_date":   unixDate(run.EndDate),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		objs = append(objs, obj)
+	}
+
+	runsValue, diags := types.ListValue(types.ObjectType{AttrTypes: attrTypes}, objs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Runs = runsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// unixDate formats an API timestamp in Unix seconds as RFC 3339, null when
+// the API reported none.
+func unixDate(unix int64) types.String {
+	if unix == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(time.Unix(unix, 0).UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,56 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebJobRunHistoryDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	now := time.Now()
+	fakeAPI.AddJobRun(bunkerWebJobRun{Plugin: "letsencrypt", Name: "certbot-renew", Success: true, StartDate: now.Add(-3 * time.Hour).Unix(), EndDate: now.Add(-3*time.Hour + time.Minute).Unix()})
+	fakeAPI.AddJobRun(bunkerWebJobRun{Plugin: "letsencrypt", Name: "certbot-renew", Success: false, StartDate: now.Add(-time.Hour).Unix(), EndDate: now.Add(-time.Hour + time.Minute).Unix()})
+	fakeAPI.AddJobRun(bunkerWebJobRun{Plugin: "letsencrypt", Name: "certbot-renew", Success: true, StartDate: now.Add(-48 * time.Hour).Unix()})
+	fakeAPI.AddJobRun(bunkerWebJobRun{Plugin: "misc", Name: "download-plugins", Success: true, StartDate: now.Add(-time.Minute).Unix()})
+
+	since := now.Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	lastSuccess := time.Unix(now.Add(-3*time.Hour+time.Minute).Unix(), 0).UTC().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebJobRunHistoryDataSourceConfig(fakeAPI.URL(), since),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_job_run_history.certbot", "runs.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_run_history.certbot", "runs.0.success", "false"),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_run_history.certbot", "last_run_success", "false"),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_run_history.certbot", "last_success", lastSuccess),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebJobRunHistoryDataSourceConfig(endpoint, since string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_job_run_history" "certbot" {
+  plugin = "letsencrypt"
+  name   = "certbot-renew"
+  since  = "%s"
+  limit  = 1
+}
+`, endpoint, since)
+}
@@ -0,0 +1,232 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// jobScheduleIntervals maps the `every` values of plugin.json jobs to their
+// period; `once` jobs have no next run.
+var jobScheduleIntervals = map[string]time.Duration{
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+	"week":   7 * 24 * time.Hour,
+}
+
+var _ datasource.DataSource = &BunkerWebJobStatusDataSource{}
+
+// BunkerWebJobStatusDataSource reports the state of a single scheduler job.
+type BunkerWebJobStatusDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebJobStatusDataSourceModel holds state.
+type BunkerWebJobStatusDataSourceModel struct {
+	Plugin         types.String `tfsdk:"plugin"`
+	Name           types.String `tfsdk:"name"`
+	Status         types.String `tfsdk:"status"`
+	Every          types.String `tfsdk:"every"`
+	LastRun        types.String `tfsdk:"last_run"`
+	LastRunSuccess types.Bool   `tfsdk:"last_run_success"`
+	LastSuccess    types.String `tfsdk:"last_success"`
+	NextRun        types.String `tfsdk:"next_run"`
+	CacheFiles     types.List   `tfsdk:"cache_files"`
+}
+
+func NewBunkerWebJobStatusDataSource() datasource.DataSource {
+	return &BunkerWebJobStatusDataSource{}
+}
+
+func (d *BunkerWebJobStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job_status"
+}
+
+func (d *BunkerWebJobStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the state of a single scheduler job: its last runs, when it is next due and the cache files it produced. " +
+			"Use it in `check` blocks or preconditions to assert that a critical job, such as the Let's Encrypt renewal, keeps succeeding.",
+		Attributes: map[string]schema.Attribute{
+			"plugin": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Plugin identifier owning the job (for example `letsencrypt`).",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Job name (for example `certbot-renew`).",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Latest known status from the scheduler.",
+			},
+			"every": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Schedule declared by the plugin (`minute`, `hour`, `day`, `week` or `once`; null when the plugin does not declare the job).",
+			},
+			"last_run": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp at which the most recent run started (null when the job never ran).",
+			},
+			"last_run_success": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the most recent run succeeded (null when the job never ran or the API keeps no run history).",
+			},
+			"last_success": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp at which the most recent successful run ended, or started when no end date is reported (null when there is none).",
+			},
+			"next_run": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp at which the job is next due, from `last_run` and `every` (null for `once` jobs or when either is unknown). A time in the past means the job is overdue.",
+			},
+			"cache_files": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sorted, unique names of the cache files stored by the job.",
+			},
+		},
+	}
+}
+
+func (d *BunkerWebJobStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebJobStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebJobStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plugin := strings.TrimSpace(data.Plugin.ValueString())
+	name := strings.TrimSpace(data.Name.ValueString())
+
+	jobs, err := d.client.ListJobs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Jobs", err.Error())
+		return
+	}
+	var job *bunkerWebJob
+	for i := range jobs {
+		if jobs[i].Plugin == plugin && jobs[i].Name == name {
+			job = &jobs[i]
+			break
+		}
+	}
+	if job == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Job Not Found", fmt.Sprintf("The scheduler has no job %q in plugin %q.", name, plugin))
+		return
+	}
+
+	data.Status = types.StringValue(job.Status)
+	data.LastRun = types.StringNull()
+	data.LastRunSuccess = types.BoolNull()
+	data.LastSuccess = types.StringNull()
+	data.NextRun = types.StringNull()
+
+	var lastRun time.Time
+	if parsed, err := time.Parse(time.RFC3339, job.LastRun); err == nil {
+		lastRun = parsed
+	}
+
+	// Older APIs keep no run history; fall back to the job's last_run.
+	if d.client.CheckFeature(featureJobHistory) == nil {
+		runs, err := d.client.ListJobRuns(ctx, JobRunListOptions{Plugin: &plugin, Name: &name})
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to List Job Runs", err.Error())
+			return
+		}
+		if len(runs) > 0 {
+			data.LastRunSuccess = types.BoolValue(runs[0].Success)
+			if runs[0].StartDate != 0 {
+				lastRun = time.Unix(runs[0].StartDate, 0)
+			}
+		}
+		for _, run := range runs {
+			if !run.Success {
+				continue
+			}
+			if run.EndDate != 0 {
+				data.LastSuccess = unixDate(run.EndDate)
+			} else {
+				data.LastSuccess = unixDate(run.StartDate)
+			}
+			break
+		}
+	}
+	if !lastRun.IsZero() {
+		data.LastRun = types.StringValue(lastRun.UTC().Format(time.RFC3339))
+	}
+
+	plugins, err := d.client.ListPlugins(ctx, "all", false)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Plugins", err.Error())
+		return
+	}
+	data.Every = types.StringNull()
+	for _, p := range plugins {
+		if p.ID != plugin {
+			continue
+		}
+		for _, declared := range p.Jobs {
+			if declared.Name == name {
+				data.Every = types.StringValue(declared.Every)
+			}
+		}
+	}
+	if interval, ok := jobScheduleIntervals[data.Every.ValueString()]; ok && !lastRun.IsZero() {
+		data.NextRun = types.StringValue(lastRun.Add(interval).UTC().Format(time.RFC3339))
+	}
+
+	entries, err := d.client.ListCacheEntries(ctx, url.Values{"plugin": {plugin}, "job_name": {name}})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Cache Entries", err.Error())
+		return
+	}
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, entry.FileName)
+	}
+	// Files stored per service share their name.
+	slices.Sort(files)
+	files = slices.Compact(files)
+
+	cacheFiles, diags := types.ListValueFrom(ctx, types.StringType, files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.CacheFiles = cacheFiles
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
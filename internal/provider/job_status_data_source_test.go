@@ -0,0 +1,69 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebJobStatusDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddPlugin(bunkerWebPlugin{ID: "reporter", Type: "external", Jobs: []bunkerWebPluginJob{{Name: "daily", File: "daily.py", Every: "day"}}})
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	fakeAPI.AddJobRun(bunkerWebJobRun{Plugin: "reporter", Name: "daily", Success: true, StartDate: start.Unix(), EndDate: start.Add(time.Minute).Unix()})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebJobStatusDataSourceConfig(fakeAPI.URL(), "daily"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_job_status.daily", "status", "idle"),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_status.daily", "every", "day"),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_status.daily", "last_run", start.UTC().Format(time.RFC3339)),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_status.daily", "last_run_success", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_status.daily", "last_success", start.Add(time.Minute).UTC().Format(time.RFC3339)),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_status.daily", "next_run", start.Add(24*time.Hour).UTC().Format(time.RFC3339)),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_status.daily", "cache_files.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_job_status.daily", "cache_files.0", "summary.txt"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebJobStatusDataSourceUnknownJob(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebJobStatusDataSourceConfig(fakeAPI.URL(), "weekly"),
+				ExpectError: regexp.MustCompile(`Job Not Found`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebJobStatusDataSourceConfig(endpoint, name string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_job_status" "daily" {
+  plugin = "reporter"
+  name   = "%s"
+}
+`, endpoint, name)
+}
@@ -17,7 +17,7 @@ var _ datasource.DataSource = &BunkerWebJobsDataSource{}
 
 // BunkerWebJobsDataSource provides job metadata.
 type BunkerWebJobsDataSource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebJobsDataSourceModel holds state.
@@ -70,16 +70,16 @@ func (d *BunkerWebJobsDataSource) Configure(_ context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *BunkerWebJobsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -103,16 +103,27 @@ func (d *BunkerWebJobsDataSource) Read(ctx context.Context, _ datasource.ReadReq
 
 	objs := make([]attr.Value, 0, len(jobs))
 	for _, job := range jobs {
-		objs = append(objs, types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		obj, diags := types.ObjectValue(attrTypes, map[string]attr.Value{
 			"plugin":   types.StringValue(job.Plugin),
 			"name":     types.StringValue(job.Name),
 			"status":   types.StringValue(job.Status),
 			"last_run": types.StringValue(job.LastRun),
-		}))
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		objs = append(objs, obj)
+	}
+
+	jobsValue, diags := types.ListValue(types.ObjectType{AttrTypes: attrTypes}, objs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	data := BunkerWebJobsDataSourceModel{
-		Jobs: types.ListValueMust(types.ObjectType{AttrTypes: attrTypes}, objs),
+		Jobs: jobsValue,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,478 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Settings of the BunkerWeb letsencrypt plugin.
+const (
+	letsEncryptSetting               = "AUTO_LETS_ENCRYPT"
+	letsEncryptEmailSetting          = "EMAIL_LETS_ENCRYPT"
+	letsEncryptChallengeSetting      = "LETS_ENCRYPT_CHALLENGE"
+	letsEncryptDNSProviderSetting    = "LETS_ENCRYPT_DNS_PROVIDER"
+	letsEncryptDNSPropagationSetting = "LETS_ENCRYPT_DNS_PROPAGATION"
+	letsEncryptDNSCredentialSetting  = "LETS_ENCRYPT_DNS_CREDENTIAL_ITEM"
+	letsEncryptStagingSetting        = "USE_LETS_ENCRYPT_STAGING"
+	letsEncryptWildcardSetting       = "USE_LETS_ENCRYPT_WILDCARD"
+)
+
+// letsEncryptDNSProviders lists the DNS providers accepted by LETS_ENCRYPT_DNS_PROVIDER.
+var letsEncryptDNSProviders = []string{
+	"cloudflare", "desec", "digitalocean", "dnsimple", "dnsmadeeasy", "gehirn", "google",
+	"infomaniak", "ionos", "linode", "luadns", "njalla", "nsone", "ovh", "rfc2136",
+	"route53", "sakuracloud", "scaleway",
+}
+
+var _ resource.Resource = &BunkerWebLetsEncryptSettingsResource{}
+var _ resource.ResourceWithImportState = &BunkerWebLetsEncryptSettingsResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebLetsEncryptSettingsResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebLetsEncryptSettingsResource{}
+
+// BunkerWebLetsEncryptSettingsResource manages the Let's Encrypt settings of a service.
+type BunkerWebLetsEncryptSettingsResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebLetsEncryptSettingsResourceModel is the Terraform state.
+type BunkerWebLetsEncryptSettingsResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Service        types.String `tfsdk:"service"`
+	Email          types.String `tfsdk:"email"`
+	Challenge      types.String `tfsdk:"challenge"`
+	DNSProvider    types.String `tfsdk:"dns_provider"`
+	DNSPropagation types.String `tfsdk:"dns_propagation"`
+	DNSCredentials types.Map    `tfsdk:"dns_credentials"`
+	Staging        types.Bool   `tfsdk:"staging"`
+	Wildcard       types.Bool   `tfsdk:"wildcard"`
+	Retries        types.Object `tfsdk:"retries"`
+}
+
+func NewBunkerWebLetsEncryptSettingsResource() resource.Resource {
+	return &BunkerWebLetsEncryptSettingsResource{}
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_letsencrypt_settings"
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Enables and configures automatic Let's Encrypt certificates for a BunkerWeb service " +
+			"(HTTP-01 or DNS-01 challenge) through the `letsencrypt` plugin settings.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the settings (the service identifier).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the service to request certificates for.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Contact email registered with the ACME account.",
+			},
+			"challenge": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "ACME challenge type: `http` or `dns`. Defaults to `http`.",
+				Default:             stringdefault.StaticString("http"),
+			},
+			"dns_provider": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "DNS provider used for the `dns` challenge (for example `cloudflare` or `route53`). Required when `challenge` is `dns`.",
+			},
+			"dns_propagation": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Seconds to wait for DNS propagation; BunkerWeb uses the provider default when omitted.",
+			},
+			"dns_credentials": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Credential items passed to the DNS provider plugin (for example `dns_cloudflare_api_token`).",
+			},
+			"staging": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Use the Let's Encrypt staging environment. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"wildcard": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Request wildcard certificates (only with the `dns` challenge). Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = serviceIdentitySchema()
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebLetsEncryptSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Challenge.IsUnknown() || config.DNSProvider.IsUnknown() || config.Wildcard.IsUnknown() {
+		return
+	}
+
+	// BunkerWeb stores both values in lowercase, so any other spelling would
+	// be reported as drift after every refresh.
+	challenge := "http"
+	if !config.Challenge.IsNull() {
+		challenge = config.Challenge.ValueString()
+	}
+
+	switch challenge {
+	case "http":
+		if !config.DNSProvider.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("dns_provider"), "Unexpected DNS Provider", "`dns_provider` is only used with the `dns` challenge.")
+		}
+		if !config.DNSCredentials.IsNull() && !config.DNSCredentials.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(path.Root("dns_credentials"), "Unexpected DNS Credentials", "`dns_credentials` is only used with the `dns` challenge.")
+		}
+		if !config.Wildcard.IsNull() && config.Wildcard.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("wildcard"), "Wildcard Requires DNS Challenge", "Wildcard certificates can only be issued with the `dns` challenge.")
+		}
+	case "dns":
+		if config.DNSProvider.IsNull() || strings.TrimSpace(config.DNSProvider.ValueString()) == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("dns_provider"), "Missing DNS Provider", "Set `dns_provider` when `challenge` is `dns`.")
+			return
+		}
+		provider := config.DNSProvider.ValueString()
+		if !slices.Contains(letsEncryptDNSProviders, provider) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dns_provider"),
+				"Unsupported DNS Provider",
+				fmt.Sprintf("DNS provider %q is not supported. Use one of (lowercase): %s.", provider, strings.Join(letsEncryptDNSProviders, ", ")),
+			)
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("challenge"), "Invalid Challenge", fmt.Sprintf("`challenge` must be either `http` or `dns` (lowercase), got %q.", challenge))
+	}
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebLetsEncryptSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables, diags := plan.toVariables(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := strings.TrimSpace(plan.Service.ValueString())
+	if err := patchServiceVariables(ctx, r.client, service, variables); err != nil {
+		resp.Diagnostics.AddError("Unable to Apply Let's Encrypt Settings", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(service)
+	plan.Service = types.StringValue(service)
+
+	tflog.Info(ctx, "applied bunkerweb let's encrypt settings", map[string]any{"service": service})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebLetsEncryptSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.client.GetService(ctx, state.Service.ValueString())
+	if err != nil {
+		var apiErr *bunkerWebAPIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read Let's Encrypt Settings", err.Error())
+		return
+	}
+
+	if v, _ := lookupServiceSetting(got.Config, got.Service, letsEncryptSetting); !isAffirmative(v) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(got.Service)
+	state.Service = types.StringValue(got.Service)
+	state.populateFromConfig(got.Config, got.Service)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan, state BunkerWebLetsEncryptSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Clear credential items that are no longer configured.
+	prior, diags := mapFromTerraform(ctx, state.DNSCredentials)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables, diags := plan.toVariables(ctx, len(prior))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := strings.TrimSpace(plan.Service.ValueString())
+	if err := patchServiceVariables(ctx, r.client, service, variables); err != nil {
+		resp.Diagnostics.AddError("Unable to Apply Let's Encrypt Settings", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(service)
+	plan.Service = types.StringValue(service)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebLetsEncryptSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := mapFromTerraform(ctx, state.DNSCredentials)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables := map[string]string{letsEncryptSetting: "no"}
+	for idx := 0; idx < len(prior); idx++ {
+		variables[multipleSettingKey(letsEncryptDNSCredentialSetting, idx)] = ""
+	}
+
+	if err := patchServiceVariables(ctx, r.client, state.Service.ValueString(), variables); err != nil {
+		resp.Diagnostics.AddError("Unable to Disable Let's Encrypt", err.Error())
+	}
+}
+
+func (r *BunkerWebLetsEncryptSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := importIdentifier(ctx, req, "service")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := strings.TrimSpace(id)
+	if service == "" {
+		resp.Diagnostics.AddError("Invalid Import Identifier", "Expected a non-empty service identifier.")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &BunkerWebLetsEncryptSettingsResourceModel{
+		ID:             types.StringValue(service),
+		Service:        types.StringValue(service),
+		Email:          types.StringNull(),
+		Challenge:      types.StringValue("http"),
+		DNSProvider:    types.StringNull(),
+		DNSPropagation: types.StringNull(),
+		DNSCredentials: types.MapNull(types.StringType),
+		Staging:        types.BoolValue(false),
+		Wildcard:       types.BoolValue(false),
+		Retries:        types.ObjectNull(retriesAttrTypes),
+	})...)
+}
+
+func (m *BunkerWebLetsEncryptSettingsResourceModel) identity() serviceIdentityModel {
+	return serviceIdentityModel{Service: m.Service}
+}
+
+// toVariables renders the model as service settings. clearCredentials is the
+// number of credential items previously written, so stale ones get emptied.
+func (m *BunkerWebLetsEncryptSettingsResourceModel) toVariables(ctx context.Context, clearCredentials int) (map[string]string, diag.Diagnostics) {
+	credentials, diags := mapFromTerraform(ctx, m.DNSCredentials)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	variables := map[string]string{
+		letsEncryptSetting:               "yes",
+		letsEncryptEmailSetting:          m.Email.ValueString(),
+		letsEncryptChallengeSetting:      m.Challenge.ValueString(),
+		letsEncryptDNSProviderSetting:    m.DNSProvider.ValueString(),
+		letsEncryptDNSPropagationSetting: m.DNSPropagation.ValueString(),
+		letsEncryptStagingSetting:        yesNo(m.Staging.ValueBool()),
+		letsEncryptWildcardSetting:       yesNo(m.Wildcard.ValueBool()),
+	}
+	if variables[letsEncryptDNSPropagationSetting] == "" {
+		variables[letsEncryptDNSPropagationSetting] = "default"
+	}
+
+	for idx := 0; idx < clearCredentials; idx++ {
+		variables[multipleSettingKey(letsEncryptDNSCredentialSetting, idx)] = ""
+	}
+
+	// Credential items are "key value" lines spread across the multiple-valued
+	// setting; sort the keys so the numbering is stable across applies.
+	keys := make([]string, 0, len(credentials))
+	for k := range credentials {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for idx, k := range keys {
+		variables[multipleSettingKey(letsEncryptDNSCredentialSetting, idx)] = k + " " + credentials[k]
+	}
+
+	return variables, diags
+}
+
+func (m *BunkerWebLetsEncryptSettingsResourceModel) populateFromConfig(cfg map[string]string, service string) {
+	if v, ok := lookupServiceSetting(cfg, service, letsEncryptEmailSetting); ok && v != "" {
+		m.Email = types.StringValue(v)
+	} else if ok {
+		m.Email = types.StringNull()
+	}
+	if v, ok := lookupServiceSetting(cfg, service, letsEncryptChallengeSetting); ok && v != "" {
+		m.Challenge = types.StringValue(v)
+	}
+	if v, ok := lookupServiceSetting(cfg, service, letsEncryptDNSProviderSetting); ok && v != "" {
+		m.DNSProvider = types.StringValue(v)
+	} else if ok {
+		m.DNSProvider = types.StringNull()
+	}
+	if v, ok := lookupServiceSetting(cfg, service, letsEncryptDNSPropagationSetting); ok && v != "" && v != "default" {
+		m.DNSPropagation = types.StringValue(v)
+	} else if ok {
+		m.DNSPropagation = types.StringNull()
+	}
+	if v, ok := lookupServiceSetting(cfg, service, letsEncryptStagingSetting); ok {
+		m.Staging = types.BoolValue(isAffirmative(v))
+	}
+	if v, ok := lookupServiceSetting(cfg, service, letsEncryptWildcardSetting); ok {
+		m.Wildcard = types.BoolValue(isAffirmative(v))
+	}
+}
+
+// multipleSettingKey returns the key of the idx-th item of a multiple-valued
+// BunkerWeb setting (KEY, KEY_1, KEY_2, ...).
+func multipleSettingKey(key string, idx int) string {
+	if idx == 0 {
+		return key
+	}
+	return key + "_" + strconv.Itoa(idx)
+}
+
+// yesNo renders a boolean the way BunkerWeb stores check settings.
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,189 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBunkerWebLetsEncryptSettingsToVariables(t *testing.T) {
+	model := BunkerWebLetsEncryptSettingsResourceModel{
+		Email:          types.StringValue("admin@example.com"),
+		Challenge:      types.StringValue("dns"),
+		DNSProvider:    types.StringValue("cloudflare"),
+		DNSPropagation: types.StringNull(),
+		DNSCredentials: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"dns_cloudflare_api_token": types.StringValue("secret"),
+			"dns_cloudflare_email":     types.StringValue("admin@example.com"),
+		}),
+		Staging:  types.BoolValue(true),
+		Wildcard: types.BoolValue(false),
+	}
+
+	vars, diags := model.toVariables(context.Background(), 3)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expected := map[string]string{
+		"AUTO_LETS_ENCRYPT":                  "yes",
+		"LETS_ENCRYPT_CHALLENGE":             "dns",
+		"LETS_ENCRYPT_DNS_PROPAGATION":       "default",
+		"USE_LETS_ENCRYPT_STAGING":           "yes",
+		"USE_LETS_ENCRYPT_WILDCARD":          "no",
+		"LETS_ENCRYPT_DNS_CREDENTIAL_ITEM":   "dns_cloudflare_api_token secret",
+		"LETS_ENCRYPT_DNS_CREDENTIAL_ITEM_1": "dns_cloudflare_email admin@example.com",
+		"LETS_ENCRYPT_DNS_CREDENTIAL_ITEM_2": "",
+	}
+	for k, want := range expected {
+		if got, ok := vars[k]; !ok || got != want {
+			t.Fatalf("expected %s=%q, got %q (present=%t)", k, want, got, ok)
+		}
+	}
+}
+
+func TestBunkerWebLetsEncryptSettingsRejectsUppercase(t *testing.T) {
+	for attribute, settings := range map[string][2]string{
+		"challenge":    {"DNS", "cloudflare"},
+		"dns_provider": {"dns", "Cloudflare"},
+	} {
+		diags := protocolValidate(t, "bunkerweb_letsencrypt_settings", map[string]tftypes.Value{
+			"service":      tftypes.NewValue(tftypes.String, "app.example.com"),
+			"email":        tftypes.NewValue(tftypes.String, "admin@example.com"),
+			"challenge":    tftypes.NewValue(tftypes.String, settings[0]),
+			"dns_provider": tftypes.NewValue(tftypes.String, settings[1]),
+		})
+		if len(diags) != 1 || !diags[0].Attribute.Equal(tftypes.NewAttributePath().WithAttributeName(attribute)) {
+			t.Fatalf("expected one error on %s, got %v", attribute, diags)
+		}
+	}
+}
+
+func TestAccBunkerWebLetsEncryptSettingsResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebLetsEncryptSettingsResourceConfig(fakeAPI.URL(), `challenge = "http"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_letsencrypt_settings.app", "id", "app.example.com"),
+					resource.TestCheckResourceAttr("bunkerweb_letsencrypt_settings.app", "challenge", "http"),
+					resource.TestCheckResourceAttr("bunkerweb_letsencrypt_settings.app", "staging", "false"),
+				),
+			},
+			{
+				Config: testAccBunkerWebLetsEncryptSettingsResourceConfig(fakeAPI.URL(), `
+  challenge    = "dns"
+  dns_provider = "cloudflare"
+  wildcard     = true
+  staging      = true
+  dns_credentials = {
+    dns_cloudflare_api_token = "secret"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_letsencrypt_settings.app", "challenge", "dns"),
+					resource.TestCheckResourceAttr("bunkerweb_letsencrypt_settings.app", "dns_provider", "cloudflare"),
+					resource.TestCheckResourceAttr("bunkerweb_letsencrypt_settings.app", "wildcard", "true"),
+					resource.TestCheckResourceAttr("bunkerweb_letsencrypt_settings.app", "staging", "true"),
+				),
+			},
+			{
+				ResourceName:            "bunkerweb_letsencrypt_settings.app",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"dns_credentials"},
+			},
+		},
+	})
+}
+
+func TestLetsEncryptSettingsKeepOtherServiceVariables(t *testing.T) {
+	// Without a detected release supporting partial patches, the fake
+	// replaces the service's variables with the payload.
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddService(bunkerWebService{ID: "app.example.com", ServerName: "app.example.com", Variables: map[string]string{"USE_GZIP": "yes"}})
+	settings := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_letsencrypt_settings")
+
+	if errs := settings.apply(map[string]tftypes.Value{
+		"service": tftypes.NewValue(tftypes.String, "app.example.com"),
+		"email":   tftypes.NewValue(tftypes.String, "admin@example.com"),
+	}); len(errs) > 0 {
+		t.Fatalf("apply: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	got := fakeAPI.ServiceVariables("app.example.com")
+	if got["USE_GZIP"] != "yes" || got[letsEncryptSetting] != "yes" {
+		t.Fatalf("expected Let's Encrypt to be enabled alongside the existing variables, got %v", got)
+	}
+}
+
+func TestLetsEncryptSettingsSurviveServiceUpdate(t *testing.T) {
+	// Without a detected release supporting partial patches, the fake
+	// replaces the service's variables with the payload.
+	fakeAPI := newFakeBunkerWebAPI(t)
+	service := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_service")
+	settings := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_letsencrypt_settings")
+
+	serviceConfig := func(gzip string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"server_name": tftypes.NewValue(tftypes.String, "app.example.com"),
+			"variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"USE_GZIP": tftypes.NewValue(tftypes.String, gzip),
+			}),
+		}
+	}
+	if errs := service.apply(serviceConfig("yes")); len(errs) > 0 {
+		t.Fatalf("create service: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+	if errs := settings.apply(map[string]tftypes.Value{
+		"service": tftypes.NewValue(tftypes.String, "app.example.com"),
+		"email":   tftypes.NewValue(tftypes.String, "admin@example.com"),
+	}); len(errs) > 0 {
+		t.Fatalf("create settings: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	// A later run updates the service from a new provider process.
+	next := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_service")
+	next.state, next.identity, next.private = service.state, service.identity, service.private
+	if errs := next.apply(serviceConfig("no")); len(errs) > 0 {
+		t.Fatalf("update service: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	if got := fakeAPI.ServiceVariables("app.example.com"); got["USE_GZIP"] != "no" || got[letsEncryptSetting] != "yes" {
+		t.Fatalf("expected the service update to keep Let's Encrypt enabled, got %v", got)
+	}
+	settings.refresh()
+	if value, err := settings.state.Unmarshal(settings.objType); err != nil || value.IsNull() {
+		t.Fatalf("expected the settings to stay in state, got %v (%v)", value, err)
+	}
+}
+
+func testAccBunkerWebLetsEncryptSettingsResourceConfig(endpoint, settings string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+}
+
+resource "bunkerweb_letsencrypt_settings" "app" {
+  service = bunkerweb_service.app.id
+  email   = "admin@example.com"
+  %s
+}
+`, endpoint, settings)
+}
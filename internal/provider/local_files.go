@@ -0,0 +1,245 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// localFile is a file read for a source or source_dir attribute.
+type localFile struct {
+	// Path is relative to the source directory and always slash-separated,
+	// so hashes and archives do not depend on the operating system.
+	Path    string
+	Content []byte
+}
+
+// resolveSourcePath turns a configured path into a clean absolute one. A
+// leading ~ is the user's home directory, and forward slashes are accepted on
+// every operating system.
+func resolveSourcePath(raw string) (string, error) {
+	p := strings.TrimSpace(raw)
+	if p == "" {
+		return "", errors.New("path cannot be empty")
+	}
+	if p == "~" || strings.HasPrefix(p, "~/") || strings.HasPrefix(p, `~\`) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expand %q: %w", raw, err)
+		}
+		p = home + p[1:]
+	}
+	return filepath.Abs(filepath.Clean(filepath.FromSlash(p)))
+}
+
+// readSourceFile reads the file at raw, following symlinks like Terraform's
+// file() function.
+func readSourceFile(raw string) ([]byte, error) {
+	p, err := resolveSourcePath(raw)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", p)
+	}
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeLineEndings(content), nil
+}
+
+// readSourceDir reads the regular files below raw whose relative path matches
+// one of patterns (every file when there is none), sorted by path.
+//
+// Symlinks to files inside the directory are read as the file they point to;
+// symlinks leaving the directory, or pointing to a directory, are errors so
+// the packaged content never depends on the machine running Terraform.
+func readSourceDir(raw string, patterns []string) ([]localFile, error) {
+	for _, pattern := range patterns {
+		if err := validateSourcePattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := resolveSourcePath(raw)
+	if err != nil {
+		return nil, err
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var files []localFile
+	err = filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if len(patterns) > 0 && !slices.ContainsFunc(patterns, func(pattern string) bool { return matchSourcePattern(pattern, rel) }) {
+			return nil
+		}
+
+		target := p
+		if entry.Type()&fs.ModeSymlink != 0 {
+			if target, err = filepath.EvalSymlinks(p); err != nil {
+				return err
+			}
+			if inside, err := filepath.Rel(root, target); err != nil || inside == ".." || strings.HasPrefix(inside, ".."+string(filepath.Separator)) {
+				return fmt.Errorf("%s is a symlink to %s, outside the source directory", rel, target)
+			}
+		}
+		info, err := os.Stat(target)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			return fmt.Errorf("%s is a symlink to a directory, which is not followed", rel)
+		case !info.Mode().IsRegular():
+			return fmt.Errorf("%s is not a regular file", rel)
+		}
+
+		content, err := os.ReadFile(target)
+		if err != nil {
+			return err
+		}
+		files = append(files, localFile{Path: rel, Content: normalizeLineEndings(content)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no file in %s matches %s", dir, strings.Join(patterns, ", "))
+	}
+
+	slices.SortFunc(files, func(a, b localFile) int { return strings.Compare(a.Path, b.Path) })
+	return files, nil
+}
+
+// validateSourcePattern checks a fileset-style pattern: path.Match syntax per
+// slash-separated segment, where a ** segment matches any number of
+// directories.
+func validateSourcePattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return errors.New("patterns cannot be empty")
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchSourcePattern reports whether the slash-separated relative path name
+// matches pattern; see validateSourcePattern.
+func matchSourcePattern(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// normalizeLineEndings converts CRLF line endings to LF in text content, so a
+// checkout with Windows line endings produces the same hash and upload. Like
+// git, content with a NUL byte in its first 8000 bytes is binary and kept
+// as-is.
+func normalizeLineEndings(content []byte) []byte {
+	if bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0 {
+		return content
+	}
+	return bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+}
+
+// sourceFilesSHA256 hashes the paths and contents of files, which must be
+// sorted by path. Modification times and permissions are ignored.
+func sourceFilesSHA256(files []localFile) string {
+	h := sha256.New()
+	for _, file := range files {
+		sum := sha256.Sum256(file.Content)
+		fmt.Fprintf(h, "%s\x00%s\n", file.Path, hex.EncodeToString(sum[:]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// packSourceFiles builds a .tar.gz of files. Entries have fixed permissions
+// and timestamps, so the same files always give the same archive.
+func packSourceFiles(files []localFile) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     file.Path,
+			Mode:     0o644,
+			Size:     int64(len(file.Content)),
+			ModTime:  time.Unix(0, 0),
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(file.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,145 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestMatchSourcePattern(t *testing.T) {
+	cases := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"**", "plugin.json", true},
+		{"**", "ui/actions.py", true},
+		{"*.lua", "geo.lua", true},
+		{"*.lua", "lib/geo.lua", false},
+		{"**/*.lua", "geo.lua", true},
+		{"**/*.lua", "lib/deep/geo.lua", true},
+		{"lib/**", "lib/deep/geo.lua", true},
+		{"lib/**", "ui/geo.lua", false},
+		{"lib/*/geo.lua", "lib/deep/geo.lua", true},
+		{"confs/server-http/[a-c]*.conf", "confs/server-http/cache.conf", true},
+		{"plugin.json", "ui/plugin.json", false},
+	}
+	for _, tc := range cases {
+		if got := matchSourcePattern(tc.pattern, tc.name); got != tc.want {
+			t.Errorf("matchSourcePattern(%q, %q) = %t, want %t", tc.pattern, tc.name, got, tc.want)
+		}
+	}
+
+	if err := validateSourcePattern("lib/[a-"); err == nil {
+		t.Errorf("expected an invalid pattern to be rejected")
+	}
+}
+
+func TestNormalizeLineEndings(t *testing.T) {
+	if got := string(normalizeLineEndings([]byte("a\r\nb\r\n"))); got != "a\nb\n" {
+		t.Fatalf("normalizeLineEndings = %q", got)
+	}
+	binary := []byte("\x00\r\n")
+	if got := normalizeLineEndings(binary); !bytes.Equal(got, binary) {
+		t.Fatalf("expected binary content to be kept, got %q", got)
+	}
+}
+
+func TestReadSourceDir(t *testing.T) {
+	unix := t.TempDir()
+	windows := t.TempDir()
+	writeTestFiles(t, unix, map[string]string{"plugin.json": "{\n}\n", "lib/geo.lua": "return 1\n", "README.md": "docs\n"})
+	writeTestFiles(t, windows, map[string]string{"plugin.json": "{\r\n}\r\n", "lib/geo.lua": "return 1\r\n", "README.md": "docs\r\n"})
+
+	patterns := []string{"plugin.json", "**/*.lua"}
+	a, err := readSourceDir(unix, patterns)
+	if err != nil {
+		t.Fatalf("readSourceDir: %v", err)
+	}
+	b, err := readSourceDir(windows, patterns)
+	if err != nil {
+		t.Fatalf("readSourceDir: %v", err)
+	}
+	if len(a) != 2 || a[0].Path != "lib/geo.lua" || a[1].Path != "plugin.json" {
+		t.Fatalf("unexpected files %+v", a)
+	}
+	if sourceFilesSHA256(a) != sourceFilesSHA256(b) {
+		t.Fatalf("expected line endings not to change the hash")
+	}
+
+	archiveA, err := packSourceFiles(a)
+	if err != nil {
+		t.Fatalf("packSourceFiles: %v", err)
+	}
+	archiveB, _ := packSourceFiles(b)
+	if !bytes.Equal(archiveA, archiveB) {
+		t.Fatalf("expected identical archives")
+	}
+
+	if _, err := readSourceDir(unix, []string{"*.py"}); err == nil || !strings.Contains(err.Error(), "no file") {
+		t.Fatalf("expected an error without matching files, got %v", err)
+	}
+}
+
+func TestReadSourceDirSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires privileges on Windows")
+	}
+	dir := t.TempDir()
+	outside := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{"lib/geo.lua": "return 1\n"})
+	writeTestFiles(t, outside, map[string]string{"secret.txt": "secret\n"})
+
+	if err := os.Symlink(filepath.Join(dir, "lib", "geo.lua"), filepath.Join(dir, "alias.lua")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	files, err := readSourceDir(dir, nil)
+	if err != nil {
+		t.Fatalf("readSourceDir: %v", err)
+	}
+	if len(files) != 2 || files[0].Path != "alias.lua" || string(files[0].Content) != "return 1\n" {
+		t.Fatalf("expected the symlink to be read as its target, got %+v", files)
+	}
+
+	if err := os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(dir, "secret.txt")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	if _, err := readSourceDir(dir, nil); err == nil || !strings.Contains(err.Error(), "outside the source directory") {
+		t.Fatalf("expected a symlink leaving the directory to be rejected, got %v", err)
+	}
+}
+
+func TestResolveSourcePath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	got, err := resolveSourcePath("~/confs/./headers.conf")
+	if err != nil {
+		t.Fatalf("resolveSourcePath: %v", err)
+	}
+	if want := filepath.Join(home, "confs", "headers.conf"); got != want {
+		t.Fatalf("resolveSourcePath = %q, want %q", got, want)
+	}
+	if _, err := resolveSourcePath(" "); err == nil {
+		t.Fatalf("expected an empty path to be rejected")
+	}
+}
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultLogLines = 100
+
+var _ ephemeral.EphemeralResource = &BunkerWebLogsEphemeralResource{}
+
+// BunkerWebLogsEphemeralResource fetches recent scheduler or instance logs.
+type BunkerWebLogsEphemeralResource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebLogsEphemeralResourceModel captures Terraform shape.
+type BunkerWebLogsEphemeralResourceModel struct {
+	Instance types.String `tfsdk:"instance"`
+	Service  types.String `tfsdk:"service"`
+	Lines    types.Int64  `tfsdk:"lines"`
+	Entries  types.List   `tfsdk:"entries"`
+	Content  types.String `tfsdk:"content"`
+}
+
+func NewBunkerWebLogsEphemeralResource() ephemeral.EphemeralResource {
+	return &BunkerWebLogsEphemeralResource{}
+}
+
+func (r *BunkerWebLogsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_logs"
+}
+
+func (r *BunkerWebLogsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the last lines of the scheduler logs, or of a single instance, so failed reloads can be debugged from Terraform output.",
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Hostname of the instance whose logs are read. When omitted, the scheduler logs are returned.",
+			},
+			"service": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return lines related to this service (server name).",
+			},
+			"lines": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of lines to return, counted from the end of the log. Defaults to `100`.",
+			},
+			"entries": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Log lines, oldest first.",
+			},
+			"content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Log lines joined with newlines.",
+			},
+		},
+	}
+}
+
+func (r *BunkerWebLogsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *BunkerWebLogsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	if !addFeatureCheck(&resp.Diagnostics, r.client, featureLogs) {
+		return
+	}
+
+	var data BunkerWebLogsEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lines := defaultLogLines
+	if !data.Lines.IsNull() && !data.Lines.IsUnknown() {
+		lines = int(data.Lines.ValueInt64())
+	}
+	if lines <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("lines"), "Invalid Lines", "lines must be greater than zero.")
+		return
+	}
+
+	entries, err := r.client.GetLogs(ctx, LogsOptions{
+		Instance: data.Instance.ValueString(),
+		Service:  optionalString(data.Service),
+		Lines:    &lines,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Logs", err.Error())
+		return
+	}
+
+	// Older APIs ignore the lines parameter; keep only the tail.
+	if len(entries) > lines {
+		entries = entries[len(entries)-lines:]
+	}
+
+	entriesValue, diags := types.ListValueFrom(ctx, types.StringType, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Entries = entriesValue
+	data.Content = types.StringValue(strings.Join(entries, "\n"))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *BunkerWebLogsEphemeralResource) Close(context.Context, ephemeral.CloseRequest, *ephemeral.CloseResponse) {
+	// Reading logs has no side effects.
+}
@@ -0,0 +1,56 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBunkerWebLogsEphemeralResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.SetLogs("edge-1", []string{
+		"[app.example.com] nginx: configuration file test is successful",
+		"[other.example.com] reload failed",
+	})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebLogsEphemeralResourceConfig(fakeAPI.URL()),
+			},
+		},
+	})
+
+	queries := fakeAPI.LogQueries()
+	if len(queries) == 0 {
+		t.Fatalf("expected logs to be requested")
+	}
+	if queries[0] != "edge-1?lines=20&service=app.example.com" {
+		t.Fatalf("unexpected logs query %q", queries[0])
+	}
+}
+
+func testAccBunkerWebLogsEphemeralResourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_logs" "edge" {
+  instance = "edge-1"
+  service  = "app.example.com"
+  lines    = 20
+}
+`, endpoint)
+}
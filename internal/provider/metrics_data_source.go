@@ -0,0 +1,114 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BunkerWebMetricsDataSource{}
+
+// BunkerWebMetricsDataSource exposes the counters aggregated for a plugin.
+type BunkerWebMetricsDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebMetricsDataSourceModel holds state.
+type BunkerWebMetricsDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Plugin  types.String `tfsdk:"plugin"`
+	Metrics types.Map    `tfsdk:"metrics"`
+}
+
+func NewBunkerWebMetricsDataSource() datasource.DataSource {
+	return &BunkerWebMetricsDataSource{}
+}
+
+func (d *BunkerWebMetricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metrics"
+}
+
+func (d *BunkerWebMetricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the metrics aggregated by BunkerWeb for a plugin (for example `badbehavior`, `limit` or `country`).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the data source (the plugin).",
+			},
+			"plugin": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Plugin whose metrics are read.",
+			},
+			"metrics": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Metric values keyed by name. Nested values are JSON-encoded.",
+			},
+		},
+	}
+}
+
+func (d *BunkerWebMetricsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	if !addFeatureCheck(&resp.Diagnostics, d.client, featureMetrics) {
+		return
+	}
+
+	var data BunkerWebMetricsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plugin := strings.TrimSpace(data.Plugin.ValueString())
+	metrics, err := d.client.GetMetrics(ctx, plugin)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Metrics", err.Error())
+		return
+	}
+
+	values := make(map[string]string, len(metrics))
+	for key, value := range metrics {
+		values[key] = stringifyValue(value)
+	}
+
+	metricsValue, diags := types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(plugin)
+	data.Metrics = metricsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
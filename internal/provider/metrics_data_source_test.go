@@ -0,0 +1,47 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebMetricsDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.SetMetrics("badbehavior", map[string]any{
+		"counter_failed_url": 3,
+		"bans":               map[string]any{"203.0.113.5": 1},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebMetricsDataSourceConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_metrics.badbehavior", "id", "badbehavior"),
+					resource.TestCheckResourceAttr("data.bunkerweb_metrics.badbehavior", "metrics.counter_failed_url", "3"),
+					resource.TestCheckResourceAttr("data.bunkerweb_metrics.badbehavior", "metrics.bans", `{"203.0.113.5":1}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebMetricsDataSourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_metrics" "badbehavior" {
+  plugin = "badbehavior"
+}
+`, endpoint)
+}
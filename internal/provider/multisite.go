@@ -0,0 +1,49 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// servicePrefixedKeyPattern matches settings written as "<server_name>_KEY".
+// Setting names are upper case, server names are not.
+var servicePrefixedKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*_[A-Z0-9_]+$`)
+
+// multisiteMismatch warns at plan time when a resource only takes effect in
+// multisite mode (wantMultisite) or only outside it, and BunkerWeb runs in the
+// other mode. BunkerWeb accepts such settings but ignores them, which is
+// otherwise very hard to notice. Lookup failures are logged and ignored so a
+// plan never fails on this check.
+func multisiteMismatch(ctx context.Context, client BunkerWebAPI, attr path.Path, wantMultisite bool, what string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if client == nil {
+		return diags
+	}
+
+	enabled, known, err := client.MultisiteMode(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to determine bunkerweb multisite mode", map[string]any{"error": err.Error()})
+		return diags
+	}
+	if !known || enabled == wantMultisite {
+		return diags
+	}
+
+	if wantMultisite {
+		diags.AddAttributeWarning(attr, "Multisite Mode Disabled",
+			fmt.Sprintf("%s only takes effect when MULTISITE is enabled, but BunkerWeb runs in single-site mode (MULTISITE=no); the setting will be accepted and silently ignored. Set MULTISITE=yes in the global configuration, or ignore this warning if it is changed in the same apply.", what))
+	} else {
+		diags.AddAttributeWarning(attr, "Multisite Mode Enabled",
+			fmt.Sprintf("%s only takes effect in single-site mode, but BunkerWeb runs with MULTISITE=yes where it is overridden by the services. Manage it through bunkerweb_service instead, or ignore this warning if MULTISITE is changed in the same apply.", what))
+	}
+
+	return diags
+}
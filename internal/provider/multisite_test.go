@@ -0,0 +1,83 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestBunkerWebClientMultisiteMode(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if _, known, err := client.MultisiteMode(ctx); err != nil || known {
+		t.Fatalf("expected unknown mode without MULTISITE, got known=%t err=%v", known, err)
+	}
+
+	api.AddGlobalDefault("MULTISITE", "no")
+	enabled, known, err := client.MultisiteMode(ctx)
+	if err != nil || !known || enabled {
+		t.Fatalf("expected single-site mode, got enabled=%t known=%t err=%v", enabled, known, err)
+	}
+
+	// The first successful lookup is cached.
+	api.AddGlobalDefault("MULTISITE", "yes")
+	if enabled, _, _ := client.MultisiteMode(ctx); enabled {
+		t.Fatalf("expected cached single-site mode")
+	}
+}
+
+func TestMultisiteMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		multisite     string
+		wantMultisite bool
+		warnings      int
+	}{
+		{"no", true, 1},
+		{"yes", true, 0},
+		{"yes", false, 1},
+		{"no", false, 0},
+	}
+
+	for _, tc := range cases {
+		api := newFakeBunkerWebAPI(t)
+		api.AddGlobalDefault("MULTISITE", tc.multisite)
+		client, err := newBunkerWebClient(api.URL(), nil, "", "", "")
+		if err != nil {
+			t.Fatalf("newBunkerWebClient: %v", err)
+		}
+
+		diags := multisiteMismatch(ctx, client, path.Root("key"), tc.wantMultisite, "test")
+		if diags.HasError() {
+			t.Fatalf("unexpected errors: %v", diags)
+		}
+		if got := diags.WarningsCount(); got != tc.warnings {
+			t.Fatalf("MULTISITE=%s want=%t: expected %d warnings, got %d", tc.multisite, tc.wantMultisite, tc.warnings, got)
+		}
+	}
+}
+
+func TestServicePrefixedKeyPattern(t *testing.T) {
+	for key, want := range map[string]bool{
+		"app.example.com_USE_GZIP": true,
+		"www_SERVER_NAME":          true,
+		"USE_GZIP":                 false,
+		"SERVER_NAME":              false,
+		"MULTISITE":                false,
+	} {
+		if got := servicePrefixedKeyPattern.MatchString(key); got != want {
+			t.Fatalf("%q: expected %t, got %t", key, want, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// remoteName returns name as stored in BunkerWeb: with the provider
+// name_prefix and name_suffix when the resource sets apply_name_affixes.
+func (s *providerState) remoteName(enabled types.Bool, name string) string {
+	if !enabled.ValueBool() || name == "" {
+		return name
+	}
+	return s.namePrefix + name + s.nameSuffix
+}
+
+// localName reverses remoteName so state keeps the configured name. Names
+// lacking the affixes are returned unchanged.
+func (s *providerState) localName(enabled types.Bool, name string) string {
+	if !enabled.ValueBool() {
+		return name
+	}
+	if len(name) <= len(s.namePrefix)+len(s.nameSuffix) ||
+		!strings.HasPrefix(name, s.namePrefix) || !strings.HasSuffix(name, s.nameSuffix) {
+		return name
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(name, s.namePrefix), s.nameSuffix)
+}
+
+// remoteServerNames applies remoteName to every name of a space separated
+// server_name.
+func (s *providerState) remoteServerNames(enabled types.Bool, serverName string) string {
+	if !enabled.ValueBool() {
+		return serverName
+	}
+	names := strings.Fields(serverName)
+	for i, name := range names {
+		names[i] = s.remoteName(enabled, name)
+	}
+	return strings.Join(names, " ")
+}
+
+// localServerNames reverses remoteServerNames.
+func (s *providerState) localServerNames(enabled types.Bool, serverName string) string {
+	if !enabled.ValueBool() {
+		return serverName
+	}
+	names := strings.Fields(serverName)
+	for i, name := range names {
+		names[i] = s.localName(enabled, name)
+	}
+	return strings.Join(names, " ")
+}
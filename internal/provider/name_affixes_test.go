@@ -0,0 +1,103 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestNameAffixes(t *testing.T) {
+	state := &providerState{namePrefix: "dev-", nameSuffix: "-x"}
+	on, off := types.BoolValue(true), types.BoolValue(false)
+
+	if got := state.remoteName(on, "app"); got != "dev-app-x" {
+		t.Fatalf("expected dev-app-x, got %q", got)
+	}
+	if got := state.remoteName(off, "app"); got != "app" {
+		t.Fatalf("expected the name unchanged when not opted in, got %q", got)
+	}
+	if got := state.localName(on, "dev-app-x"); got != "app" {
+		t.Fatalf("expected app, got %q", got)
+	}
+	if got := state.localName(on, "other"); got != "other" {
+		t.Fatalf("expected a name without affixes unchanged, got %q", got)
+	}
+	if got := state.remoteServerNames(on, "a.example.com  b.example.com"); got != "dev-a.example.com-x dev-b.example.com-x" {
+		t.Fatalf("unexpected server names %q", got)
+	}
+	if got := state.localServerNames(on, "dev-a.example.com-x dev-b.example.com-x"); got != "a.example.com b.example.com" {
+		t.Fatalf("unexpected server names %q", got)
+	}
+}
+
+func TestAccBunkerWebNameAffixes(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebNameAffixesConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "server_name", "app.example.com"),
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "id", "dev-app.example.com"),
+					resource.TestCheckResourceAttr("bunkerweb_config.snippet", "name", "snippet"),
+					resource.TestCheckResourceAttr("bunkerweb_config.snippet", "id", "global/http/dev-snippet"),
+					resource.TestCheckResourceAttr("bunkerweb_instance.edge", "name", "edge"),
+					func(_ *terraform.State) error {
+						if fakeAPI.ServiceVariables("dev-app.example.com") == nil {
+							return fmt.Errorf("expected service dev-app.example.com to exist")
+						}
+						if _, ok := fakeAPI.Config("global", "http", "dev-snippet"); !ok {
+							return fmt.Errorf("expected config dev-snippet to exist")
+						}
+						inst, ok := fakeAPI.Instance("edge-1")
+						if !ok || inst.Name == nil || *inst.Name != "dev-edge" {
+							return fmt.Errorf("expected instance name dev-edge, got %+v", inst)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   testAccBunkerWebNameAffixesConfig(fakeAPI.URL()),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccBunkerWebNameAffixesConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+  name_prefix  = "dev-"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name        = "app.example.com"
+  apply_name_affixes = true
+}
+
+resource "bunkerweb_config" "snippet" {
+  type               = "http"
+  name               = "snippet"
+  data               = "# snippet"
+  apply_name_affixes = true
+}
+
+resource "bunkerweb_instance" "edge" {
+  hostname           = "edge-1"
+  name               = "edge"
+  apply_name_affixes = true
+}
+`, endpoint)
+}
@@ -0,0 +1,113 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// openAPIDocument is the part of the API's OpenAPI document used for
+// endpoint discovery.
+type openAPIDocument struct {
+	Paths map[string]map[string]any `json:"paths"`
+}
+
+// DiscoverEndpoints fetches the control plane's OpenAPI document and records
+// the endpoints it exposes. Failures are logged and leave discovery off, in
+// which case every endpoint is assumed to exist.
+func (c *bunkerWebClient) DiscoverEndpoints(ctx context.Context) int {
+	req, err := c.newRequest(ctx, http.MethodGet, "openapi.json", nil)
+	if err != nil {
+		return 0
+	}
+
+	var doc openAPIDocument
+	if err := c.do(ctx, req, &doc); err != nil || len(doc.Paths) == 0 {
+		if err != nil {
+			tflog.Debug(ctx, "unable to fetch the bunkerweb openapi document", map[string]any{"error": err.Error()})
+		}
+		return 0
+	}
+
+	basePath := strings.Trim(c.baseURL.Path, "/")
+	endpoints := make(map[string][]string, len(doc.Paths))
+	for route, operations := range doc.Paths {
+		route = strings.Trim(route, "/")
+		// Documents generated without the proxy root path list routes
+		// relative to it; others include it.
+		if basePath != "" {
+			route = strings.TrimPrefix(strings.TrimPrefix(route, basePath), "/")
+		}
+		// Tenant-scoped documents list routes under tenants/{tenant}/.
+		if segments := strings.SplitN(route, "/", 3); c.tenantInPath && len(segments) == 3 && segments[0] == "tenants" {
+			route = segments[2]
+		}
+		for method := range operations {
+			endpoints[route] = append(endpoints[route], strings.ToUpper(method))
+		}
+	}
+	c.endpoints = endpoints
+	return len(endpoints)
+}
+
+// HasEndpoint reports whether the OpenAPI document lists method on endpoint
+// (relative to the API base, e.g. "users/admin"). Path parameters such as
+// "{username}" match any segment. known is false when discovery is off.
+func (c *bunkerWebClient) HasEndpoint(method, endpoint string) (found bool, known bool) {
+	if c.endpoints == nil {
+		return false, false
+	}
+
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	for route, methods := range c.endpoints {
+		if method != "" && !containsFold(methods, method) {
+			continue
+		}
+		if routeMatches(strings.Split(route, "/"), segments) {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// hasEndpointPrefix reports whether any documented route starts with prefix.
+func (c *bunkerWebClient) hasEndpointPrefix(prefix string) (found bool, known bool) {
+	if c.endpoints == nil {
+		return false, false
+	}
+	for route := range c.endpoints {
+		if route == prefix || strings.HasPrefix(route, prefix+"/") {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+func routeMatches(route, segments []string) bool {
+	if len(route) != len(segments) {
+		return false
+	}
+	for i, part := range route {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		if part != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(values []string, want string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, want) {
+			return true
+		}
+	}
+	return false
+}
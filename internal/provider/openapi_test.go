@@ -0,0 +1,80 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBunkerWebClientDiscoverEndpoints(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	if count := client.DiscoverEndpoints(ctx); count != 0 {
+		t.Fatalf("expected no endpoints without a document, got %d", count)
+	}
+	if _, known := client.HasEndpoint("GET", "users"); known {
+		t.Fatalf("expected discovery to be off")
+	}
+	if err := client.CheckFeature(featureUsers); err != nil {
+		t.Fatalf("expected features to be assumed without a document, got %v", err)
+	}
+
+	api.SetOpenAPIPaths("/services", "/services/{service}", "/cache")
+	if count := client.DiscoverEndpoints(ctx); count != 3 {
+		t.Fatalf("expected 3 endpoints, got %d", count)
+	}
+
+	if found, known := client.HasEndpoint("GET", "services/app.example.com"); !found || !known {
+		t.Fatalf("expected services/{service} to match")
+	}
+	if found, _ := client.HasEndpoint("DELETE", "cache"); found {
+		t.Fatalf("expected DELETE /cache not to be documented")
+	}
+	if found, _ := client.HasEndpoint("GET", "users/admin"); found {
+		t.Fatalf("expected users/admin not to be documented")
+	}
+
+	err = client.CheckFeature(featureUsers)
+	if err == nil || !strings.Contains(err.Error(), "no /users endpoint") {
+		t.Fatalf("expected missing users endpoint, got %v", err)
+	}
+}
+
+func TestAccBunkerWebUserResourceUndocumentedEndpoint(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.SetOpenAPIPaths("/services", "/global_config", "/health", "/ping")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_user" "ops" {
+  username = "ops"
+  password = "s3cret-pass"
+}
+`, fakeAPI.URL()),
+				ExpectError: regexp.MustCompile(`does not expose web UI user management`),
+			},
+		},
+	})
+}
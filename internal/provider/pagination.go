@@ -0,0 +1,53 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// paginationSchemaAttributes returns the limit, offset and total_count
+// attributes of a list data source; noun names the listed items.
+func paginationSchemaAttributes(noun string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"limit": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Maximum number of %s to return. Defaults to all of them.", noun),
+		},
+		"offset": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Number of matching %s to skip before the first one returned. Defaults to `0`.", noun),
+		},
+		"total_count": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: fmt.Sprintf("Number of matching %s before `limit` and `offset` are applied.", noun),
+		},
+	}
+}
+
+// pageBounds returns the [start, end) window of total items selected by
+// limit and offset.
+func pageBounds(limit, offset types.Int64, total int) (start, end int, diags diag.Diagnostics) {
+	end = total
+	if !offset.IsNull() && !offset.IsUnknown() {
+		if offset.ValueInt64() < 0 {
+			diags.AddAttributeError(path.Root("offset"), "Invalid Offset", "offset must not be negative.")
+		} else {
+			start = int(min(offset.ValueInt64(), int64(total)))
+		}
+	}
+	if !limit.IsNull() && !limit.IsUnknown() {
+		if limit.ValueInt64() < 0 {
+			diags.AddAttributeError(path.Root("limit"), "Invalid Limit", "limit must not be negative.")
+		} else {
+			end = start + int(min(limit.ValueInt64(), int64(total-start)))
+		}
+	}
+	return start, end, diags
+}
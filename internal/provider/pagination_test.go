@@ -0,0 +1,39 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPageBounds(t *testing.T) {
+	cases := []struct {
+		limit, offset types.Int64
+		start, end    int
+	}{
+		{types.Int64Null(), types.Int64Null(), 0, 10},
+		{types.Int64Value(3), types.Int64Null(), 0, 3},
+		{types.Int64Value(3), types.Int64Value(8), 8, 10},
+		{types.Int64Null(), types.Int64Value(4), 4, 10},
+		{types.Int64Value(5), types.Int64Value(50), 10, 10},
+		{types.Int64Value(0), types.Int64Value(2), 2, 2},
+		{types.Int64Value(math.MaxInt64), types.Int64Value(1), 1, 10},
+	}
+	for _, tc := range cases {
+		start, end, diags := pageBounds(tc.limit, tc.offset, 10)
+		if diags.HasError() || start != tc.start || end != tc.end {
+			t.Errorf("pageBounds(%s, %s, 10) = [%d, %d) %v, want [%d, %d)", tc.limit, tc.offset, start, end, diags, tc.start, tc.end)
+		}
+	}
+
+	if _, _, diags := pageBounds(types.Int64Value(-1), types.Int64Null(), 10); !diags.HasError() {
+		t.Errorf("expected a negative limit to be rejected")
+	}
+	if _, _, diags := pageBounds(types.Int64Null(), types.Int64Value(-1), 10); !diags.HasError() {
+		t.Errorf("expected a negative offset to be rejected")
+	}
+}
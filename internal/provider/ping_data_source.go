@@ -0,0 +1,174 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BunkerWebPingDataSource{}
+
+// BunkerWebPingDataSource measures API and instance reachability.
+type BunkerWebPingDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebPingDataSourceModel holds state.
+type BunkerWebPingDataSourceModel struct {
+	Hostnames    types.List                   `tfsdk:"hostnames"`
+	APILatencyMs types.Int64                  `tfsdk:"api_latency_ms"`
+	Instances    []BunkerWebPingInstanceModel `tfsdk:"instances"`
+	AllHealthy   types.Bool                   `tfsdk:"all_healthy"`
+}
+
+// BunkerWebPingInstanceModel is the ping result of one instance.
+type BunkerWebPingInstanceModel struct {
+	Hostname  types.String `tfsdk:"hostname"`
+	Success   types.Bool   `tfsdk:"success"`
+	LatencyMs types.Int64  `tfsdk:"latency_ms"`
+	Error     types.String `tfsdk:"error"`
+}
+
+func NewBunkerWebPingDataSource() datasource.DataSource {
+	return &BunkerWebPingDataSource{}
+}
+
+func (d *BunkerWebPingDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ping"
+}
+
+func (d *BunkerWebPingDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Pings the BunkerWeb API and its instances, reporting round-trip latency. Unreachable instances are reported rather than failing the read.",
+		Attributes: map[string]schema.Attribute{
+			"hostnames": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Instances to ping. Defaults to every registered instance.",
+			},
+			"api_latency_ms": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Round-trip time of `GET /ping` in milliseconds.",
+			},
+			"instances": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Ping result for each instance.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hostname": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Instance hostname.",
+						},
+						"success": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the instance answered the ping.",
+						},
+						"latency_ms": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Round-trip time of the ping in milliseconds.",
+						},
+						"error": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Error returned when the ping failed.",
+						},
+					},
+				},
+			},
+			"all_healthy": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "True when every pinged instance answered.",
+			},
+		},
+	}
+}
+
+func (d *BunkerWebPingDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebPingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebPingDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	start := time.Now()
+	if _, err := d.client.Ping(ctx); err != nil {
+		resp.Diagnostics.AddError("Unable to Ping BunkerWeb API", err.Error())
+		return
+	}
+	data.APILatencyMs = types.Int64Value(time.Since(start).Milliseconds())
+
+	hostnames, diags := listToStrings(ctx, data.Hostnames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Hostnames.IsNull() {
+		instances, err := d.client.ListInstances(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to List Instances", err.Error())
+			return
+		}
+		for _, inst := range instances {
+			hostnames = append(hostnames, inst.Hostname)
+		}
+	}
+
+	data.Instances = pingInstances(ctx, d.client, hostnames)
+	healthy := true
+	for _, result := range data.Instances {
+		healthy = healthy && result.Success.ValueBool()
+	}
+	data.AllHealthy = types.BoolValue(healthy)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// pingInstances pings each hostname in turn and times the round trip.
+func pingInstances(ctx context.Context, client BunkerWebAPI, hostnames []string) []BunkerWebPingInstanceModel {
+	results := make([]BunkerWebPingInstanceModel, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		hostname = strings.TrimSpace(hostname)
+		start := time.Now()
+		_, err := client.PingInstance(ctx, hostname)
+		result := BunkerWebPingInstanceModel{
+			Hostname:  types.StringValue(hostname),
+			Success:   types.BoolValue(err == nil),
+			LatencyMs: types.Int64Value(time.Since(start).Milliseconds()),
+			Error:     types.StringNull(),
+		}
+		if err != nil {
+			result.Error = types.StringValue(err.Error())
+		}
+		results = append(results, result)
+	}
+	return results
+}
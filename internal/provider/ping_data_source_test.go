@@ -0,0 +1,75 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPingInstancesReportsFailures(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.CreateInstance(ctx, InstanceCreateRequest{Hostname: "edge-1"}); err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+
+	results := pingInstances(ctx, client, []string{"edge-1", "missing"})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if !results[0].Success.ValueBool() || !results[0].Error.IsNull() {
+		t.Fatalf("expected edge-1 to answer, got %+v", results[0])
+	}
+	if results[1].Success.ValueBool() || results[1].Error.ValueString() == "" {
+		t.Fatalf("expected missing to fail with an error, got %+v", results[1])
+	}
+}
+
+func TestAccBunkerWebPingDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebPingDataSourceConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.bunkerweb_ping.all", "api_latency_ms"),
+					resource.TestCheckResourceAttr("data.bunkerweb_ping.all", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_ping.all", "instances.0.hostname", "edge-1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_ping.all", "instances.0.success", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_ping.all", "all_healthy", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebPingDataSourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_instance" "edge" {
+  hostname = "edge-1"
+}
+
+data "bunkerweb_ping" "all" {
+  hostnames = [bunkerweb_instance.edge.hostname]
+}
+`, endpoint)
+}
@@ -0,0 +1,52 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// planSummaryTargets caps the targets named per line of a plan summary.
+const planSummaryTargets = 10
+
+// addPlanSummary reports the API calls a batch ephemeral resource makes when
+// opened. Ephemeral resources have no plan diff, so this warning is the only
+// preview of a destructive batch.
+func addPlanSummary(diags *diag.Diagnostics, typeName string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	diags.AddWarning(
+		"Planned Batch Operation",
+		fmt.Sprintf("When opened during plan and apply, %s will:\n- %s", typeName, strings.Join(lines, "\n- ")),
+	)
+}
+
+// summarizeTargets renders a count and the first targets, for example
+// "3 bans (1.2.3.4, 5.6.7.8, 9.9.9.9)".
+func summarizeTargets(count int, noun string, targets []string) string {
+	if count != 1 {
+		if strings.HasSuffix(noun, "s") {
+			noun += "es"
+		} else {
+			noun += "s"
+		}
+	}
+	line := fmt.Sprintf("%d %s", count, noun)
+	if len(targets) == 0 {
+		return line
+	}
+	shown := targets
+	if len(shown) > planSummaryTargets {
+		shown = shown[:planSummaryTargets]
+	}
+	line += " (" + strings.Join(shown, ", ")
+	if more := len(targets) - len(shown); more > 0 {
+		line += fmt.Sprintf(" and %d more", more)
+	}
+	return line + ")"
+}
@@ -0,0 +1,62 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSummarizeTargets(t *testing.T) {
+	if got := summarizeTargets(1, "config", []string{"global/http/a"}); got != "1 config (global/http/a)" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+
+	targets := make([]string, 12)
+	for i := range targets {
+		targets[i] = string(rune('a' + i))
+	}
+	if got := summarizeTargets(len(targets), "address", targets); got != "12 addresses (a, b, c, d, e, f, g, h, i, j and 2 more)" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+}
+
+func TestConfigBulkDeletePlanSummary(t *testing.T) {
+	ctx := context.Background()
+	r := &BunkerWebConfigBulkDeleteEphemeralResource{}
+
+	var schemaResp ephemeral.SchemaResponse
+	r.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+
+	// tfsdk.Config cannot be set directly; build its value through a state.
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &BunkerWebConfigBulkDeleteModel{
+		Configs: []BunkerWebConfigBulkDeleteItem{
+			{Service: types.StringNull(), Type: types.StringValue("http"), Name: types.StringValue("foo")},
+			{Service: types.StringValue("api"), Type: types.StringValue("http"), Name: types.StringValue("bar")},
+		},
+		Deleted: types.ListNull(types.StringType),
+		Missing: types.ListNull(types.StringType),
+		Result:  types.StringNull(),
+	}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var resp ephemeral.ValidateConfigResponse
+	r.ValidateConfig(ctx, ephemeral.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+
+	warnings := resp.Diagnostics.Warnings()
+	if resp.Diagnostics.HasError() || len(warnings) != 1 {
+		t.Fatalf("expected one summary warning, got %v", resp.Diagnostics)
+	}
+	if detail := warnings[0].Detail(); !strings.Contains(detail, "delete 2 configs (global/http/foo, api/http/bar)") {
+		t.Fatalf("unexpected summary %q", detail)
+	}
+}
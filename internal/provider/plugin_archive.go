@@ -0,0 +1,135 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// pluginIDPattern matches the plugin identifiers BunkerWeb accepts.
+var pluginIDPattern = regexp.MustCompile(`^[\w.-]{1,64}$`)
+
+// maxPluginManifestSize bounds the plugin.json files read from an archive.
+const maxPluginManifestSize = 1 << 20
+
+// pluginManifest is the part of plugin.json checked before an upload.
+type pluginManifest struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// validatePluginArchive checks that a .zip, .tar or .tar.gz plugin package
+// holds at least one plugin.json with a valid id and a version, so a
+// malformed archive fails before it is uploaded. Other files (single-file
+// plugins, .tar.xz archives) are left to the API.
+func validatePluginArchive(name string, content []byte) error {
+	lower := strings.ToLower(name)
+
+	var manifests map[string][]byte
+	var err error
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		manifests, err = zipPluginManifests(content)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		var gz *gzip.Reader
+		if gz, err = gzip.NewReader(bytes.NewReader(content)); err == nil {
+			manifests, err = tarPluginManifests(gz)
+		}
+	case strings.HasSuffix(lower, ".tar"):
+		manifests, err = tarPluginManifests(bytes.NewReader(content))
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s is not a readable archive: %w", name, err)
+	}
+	if len(manifests) == 0 {
+		return fmt.Errorf("%s contains no plugin.json", name)
+	}
+
+	for _, file := range slices.Sorted(maps.Keys(manifests)) {
+		var manifest pluginManifest
+		if err := json.Unmarshal(manifests[file], &manifest); err != nil {
+			return fmt.Errorf("%s: invalid JSON: %w", file, err)
+		}
+		if !pluginIDPattern.MatchString(manifest.ID) {
+			return fmt.Errorf("%s: id %q must be 1 to 64 letters, digits, dots, dashes or underscores", file, manifest.ID)
+		}
+		if strings.TrimSpace(manifest.Version) == "" {
+			return fmt.Errorf("%s: missing version", file)
+		}
+	}
+	return nil
+}
+
+func zipPluginManifests(content []byte) (map[string][]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := map[string][]byte{}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || path.Base(file.Name) != "plugin.json" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		raw, err := readPluginManifest(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		manifests[file.Name] = raw
+	}
+	return manifests, nil
+}
+
+func tarPluginManifests(r io.Reader) (map[string][]byte, error) {
+	archive := tar.NewReader(r)
+
+	manifests := map[string][]byte{}
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return manifests, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || path.Base(header.Name) != "plugin.json" {
+			continue
+		}
+		raw, err := readPluginManifest(archive)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", header.Name, err)
+		}
+		manifests[header.Name] = raw
+	}
+}
+
+func readPluginManifest(r io.Reader) ([]byte, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, maxPluginManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > maxPluginManifestSize {
+		return nil, fmt.Errorf("larger than %d bytes", maxPluginManifestSize)
+	}
+	return raw, nil
+}
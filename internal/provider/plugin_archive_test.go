@@ -0,0 +1,89 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+)
+
+func testZipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("zip create: %v", err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("zip write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func testTarGzArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("tar header: %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("tar write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("tar close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidatePluginArchive(t *testing.T) {
+	valid := `{"id": "discord", "name": "Discord", "version": "1.9"}`
+
+	cases := []struct {
+		name    string
+		file    string
+		content []byte
+		wantErr string
+	}{
+		{name: "single file plugin", file: "custom.lua", content: []byte("return 42")},
+		{name: "zip", file: "discord.zip", content: testZipArchive(t, map[string]string{"discord/plugin.json": valid, "discord/discord.lua": ""})},
+		{name: "tar.gz", file: "discord.tar.gz", content: testTarGzArchive(t, map[string]string{"discord/plugin.json": valid})},
+		{name: "corrupt zip", file: "discord.zip", content: []byte("not a zip"), wantErr: "not a readable archive"},
+		{name: "no manifest", file: "discord.zip", content: testZipArchive(t, map[string]string{"discord/discord.lua": ""}), wantErr: "contains no plugin.json"},
+		{name: "invalid json", file: "discord.tgz", content: testTarGzArchive(t, map[string]string{"discord/plugin.json": "{"}), wantErr: "discord/plugin.json: invalid JSON"},
+		{name: "bad id", file: "discord.zip", content: testZipArchive(t, map[string]string{"plugin.json": `{"id": "dis cord", "version": "1"}`}), wantErr: `id "dis cord"`},
+		{name: "missing version", file: "discord.zip", content: testZipArchive(t, map[string]string{"plugin.json": `{"id": "discord"}`}), wantErr: "plugin.json: missing version"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePluginArchive(tc.file, tc.content)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &BunkerWebPluginDownloadEphemeralResource{}
+
+// BunkerWebPluginDownloadEphemeralResource fetches the package of an installed plugin.
+type BunkerWebPluginDownloadEphemeralResource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebPluginDownloadModel captures Terraform configuration.
+type BunkerWebPluginDownloadModel struct {
+	ID            types.String `tfsdk:"id"`
+	Type          types.String `tfsdk:"type"`
+	Version       types.String `tfsdk:"version"`
+	Checksum      types.String `tfsdk:"checksum"`
+	FileName      types.String `tfsdk:"file_name"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+}
+
+func NewBunkerWebPluginDownloadEphemeralResource() ephemeral.EphemeralResource {
+	return &BunkerWebPluginDownloadEphemeralResource{}
+}
+
+func (r *BunkerWebPluginDownloadEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin_download"
+}
+
+func (r *BunkerWebPluginDownloadEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Downloads the package of an installed plugin, for example one installed from the web UI, to back it up or install it on another control plane. " +
+			"Core plugins ship with BunkerWeb and have no package to download.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the plugin to download.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Plugin type (`external`, `ui` or `pro`).",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Plugin version.",
+			},
+			"checksum": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 of the package, as reported by BunkerWeb or computed from the download.",
+			},
+			"file_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Suggested file name for the package (`<id>.tar.gz`).",
+			},
+			"content_base64": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Base64-encoded plugin package (a gzipped tarball).",
+			},
+		},
+	}
+}
+
+func (r *BunkerWebPluginDownloadEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *BunkerWebPluginDownloadEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebPluginDownloadModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := strings.TrimSpace(data.ID.ValueString())
+	if id == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Missing Plugin ID", "Set `id` to the identifier of an installed plugin.")
+		return
+	}
+
+	plugin, content, err := r.client.GetPluginPackage(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddAttributeError(path.Root("id"), "Plugin Not Found", err.Error())
+			return
+		}
+		resp.Diagnostics.AddError("Download Plugin", err.Error())
+		return
+	}
+
+	checksum := plugin.Checksum
+	if checksum == "" {
+		sum := sha256.Sum256(content)
+		checksum = hex.EncodeToString(sum[:])
+	}
+
+	data.ID = types.StringValue(id)
+	data.Type = types.StringValue(plugin.Type)
+	data.Version = types.StringValue(plugin.Version)
+	data.Checksum = types.StringValue(checksum)
+	data.FileName = types.StringValue(id + ".tar.gz")
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
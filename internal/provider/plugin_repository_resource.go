@@ -0,0 +1,299 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// externalPluginURLsSetting is the global setting listing the archives the
+// scheduler downloads external plugins from, separated by spaces.
+const externalPluginURLsSetting = "EXTERNAL_PLUGIN_URLS"
+
+// externalPluginURLsMu serialises the read-modify-write cycles on
+// EXTERNAL_PLUGIN_URLS so repositories applied in parallel do not overwrite
+// each other.
+var externalPluginURLsMu sync.Mutex
+
+var _ resource.Resource = &BunkerWebPluginRepositoryResource{}
+var _ resource.ResourceWithImportState = &BunkerWebPluginRepositoryResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebPluginRepositoryResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebPluginRepositoryResource{}
+
+// BunkerWebPluginRepositoryResource registers one external plugin source in
+// EXTERNAL_PLUGIN_URLS.
+type BunkerWebPluginRepositoryResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebPluginRepositoryResourceModel is the Terraform state.
+type BunkerWebPluginRepositoryResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	URL     types.String `tfsdk:"url"`
+	Retries types.Object `tfsdk:"retries"`
+}
+
+func NewBunkerWebPluginRepositoryResource() resource.Resource {
+	return &BunkerWebPluginRepositoryResource{}
+}
+
+func (r *BunkerWebPluginRepositoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin_repository"
+}
+
+func (r *BunkerWebPluginRepositoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Registers an external plugin source (an archive URL the scheduler downloads plugins from) in the " +
+			"`EXTERNAL_PLUGIN_URLS` global setting. Other sources in the setting are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the source (its URL).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "HTTP(S) URL of the plugin archive, for example `https://github.com/bunkerity/bunkerweb-plugins/archive/refs/tags/v1.9.zip`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebPluginRepositoryResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIDIdentitySchema("URL of the plugin archive.")
+}
+
+func (r *BunkerWebPluginRepositoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebPluginRepositoryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebPluginRepositoryResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.URL.IsNull() || config.URL.IsUnknown() {
+		return
+	}
+
+	if err := validatePluginRepositoryURL(config.URL.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("url"), "Invalid Plugin Repository URL", err.Error())
+	}
+}
+
+func (r *BunkerWebPluginRepositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebPluginRepositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := strings.TrimSpace(plan.URL.ValueString())
+	err := r.modifySources(ctx, func(sources []string) []string {
+		if slices.Contains(sources, source) {
+			return sources
+		}
+		return append(sources, source)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create Plugin Repository", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(source)
+	plan.URL = types.StringValue(source)
+
+	tflog.Info(ctx, "added bunkerweb plugin repository", map[string]any{"url": source})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+}
+
+func (r *BunkerWebPluginRepositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebPluginRepositoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sources, err := r.readSources(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Plugin Repository", err.Error())
+		return
+	}
+
+	if !slices.Contains(sources, strings.TrimSpace(state.URL.ValueString())) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
+}
+
+// Update only records a new retries attribute: url requires replacement.
+func (r *BunkerWebPluginRepositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state BunkerWebPluginRepositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Retries = plan.Retries
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
+}
+
+func (r *BunkerWebPluginRepositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebPluginRepositoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := strings.TrimSpace(state.URL.ValueString())
+	err := r.modifySources(ctx, func(sources []string) []string {
+		return slices.DeleteFunc(sources, func(entry string) bool { return entry == source })
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Delete Plugin Repository", err.Error())
+	}
+}
+
+func (r *BunkerWebPluginRepositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := importIdentifier(ctx, req, "id")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := strings.TrimSpace(id)
+	if err := validatePluginRepositoryURL(source); err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	state := BunkerWebPluginRepositoryResourceModel{
+		ID:      types.StringValue(source),
+		URL:     types.StringValue(source),
+		Retries: types.ObjectNull(retriesAttrTypes),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
+}
+
+func (m *BunkerWebPluginRepositoryResourceModel) identity() resourceIDIdentityModel {
+	return resourceIDIdentityModel{ID: m.ID}
+}
+
+// readSources returns the entries of EXTERNAL_PLUGIN_URLS.
+func (r *BunkerWebPluginRepositoryResource) readSources(ctx context.Context) ([]string, error) {
+	settings, err := r.client.GetGlobalConfig(ctx, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(stringifyValue(settings[externalPluginURLsSetting])), nil
+}
+
+// modifySources applies update to the current EXTERNAL_PLUGIN_URLS entries
+// and writes the result back.
+func (r *BunkerWebPluginRepositoryResource) modifySources(ctx context.Context, update func([]string) []string) error {
+	externalPluginURLsMu.Lock()
+	defer externalPluginURLsMu.Unlock()
+
+	sources, err := r.readSources(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.UpdateGlobalConfig(ctx, map[string]any{
+		externalPluginURLsSetting: strings.Join(update(sources), " "),
+	})
+	return err
+}
+
+// validatePluginRepositoryURL rejects values that would corrupt the
+// space-separated EXTERNAL_PLUGIN_URLS setting or that the scheduler cannot
+// download.
+func validatePluginRepositoryURL(value string) error {
+	if value != strings.TrimSpace(value) || strings.ContainsAny(value, " \t\r\n") {
+		return fmt.Errorf("%q must not contain whitespace", value)
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %w", value, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("%q must use the http or https scheme", value)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("%q has no host", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestValidatePluginRepositoryURL(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"https://github.com/bunkerity/bunkerweb-plugins/archive/refs/tags/v1.9.zip": true,
+		"http://plugins.internal/bundle.tar.gz":                                     true,
+		"ftp://plugins.internal/bundle.zip":                                         false,
+		"https://a.example/one.zip https://b.example/two.zip":                       false,
+		" https://a.example/one.zip":                                                false,
+		"https:///missing-host.zip":                                                 false,
+	} {
+		if err := validatePluginRepositoryURL(value); (err == nil) != valid {
+			t.Fatalf("validatePluginRepositoryURL(%q) = %v, want valid=%t", value, err, valid)
+		}
+	}
+}
+
+func TestPluginRepositoryResourceIdentity(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	const source = "https://example.org/official.zip"
+
+	r := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_plugin_repository")
+	if errs := r.apply(map[string]tftypes.Value{
+		"url": tftypes.NewValue(tftypes.String, source),
+	}); len(errs) > 0 {
+		t.Fatalf("create: %v", errs)
+	}
+	if r.identity == nil || r.identity.IdentityData == nil {
+		t.Fatal("expected the repository to have an identity")
+	}
+
+	identityType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String}}
+	value, err := r.identity.IdentityData.Unmarshal(identityType)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	var identity map[string]tftypes.Value
+	var id string
+	if err := value.As(&identity); err != nil {
+		t.Fatalf("As: %v", err)
+	}
+	if err := identity["id"].As(&id); err != nil || id != source {
+		t.Fatalf("expected identity id %q, got %q (%v)", source, id, err)
+	}
+}
+
+func TestAccBunkerWebPluginRepositoryResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddGlobalDefault(externalPluginURLsSetting, "https://example.org/legacy.zip")
+
+	expectSources := func(want ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			got := strings.Fields(stringifyValue(fakeAPI.LastGlobalPatch()[externalPluginURLsSetting]))
+			slices.Sort(got)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				return fmt.Errorf("expected sources %v, got %v", want, got)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             expectSources("https://example.org/legacy.zip"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebPluginRepositoryResourceConfig(fakeAPI.URL(), "https://example.org/official.zip"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_plugin_repository.official", "id", "https://example.org/official.zip"),
+					expectSources("https://example.org/legacy.zip", "https://example.org/official.zip", "https://example.org/team.zip"),
+				),
+			},
+			{
+				ResourceName:      "bunkerweb_plugin_repository.official",
+				ImportState:       true,
+				ImportStateId:     "https://example.org/official.zip",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:    "bunkerweb_plugin_repository.official",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			{
+				Config:      testAccBunkerWebPluginRepositoryResourceConfig(fakeAPI.URL(), "ftp://example.org/official.zip"),
+				ExpectError: regexp.MustCompile(`Invalid Plugin Repository URL`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebPluginRepositoryResourceConfig(endpoint, official string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_plugin_repository" "official" {
+  url = %q
+}
+
+resource "bunkerweb_plugin_repository" "team" {
+  url = "https://example.org/team.zip"
+}
+`, endpoint, official)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,10 +19,14 @@ import (
 
 var _ resource.Resource = &BunkerWebPluginResource{}
 var _ resource.ResourceWithImportState = &BunkerWebPluginResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebPluginResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebPluginResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebPluginResource{}
 
 // BunkerWebPluginResource manages lifecycle of uploaded plugins.
 type BunkerWebPluginResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
+	state  *providerState
 }
 
 // BunkerWebPluginResourceModel stores Terraform plan/state.
@@ -30,6 +35,11 @@ type BunkerWebPluginResourceModel struct {
 	Method  types.String `tfsdk:"method"`
 	Name    types.String `tfsdk:"name"`
 	Content types.String `tfsdk:"content"`
+
+	SourceDir      types.String `tfsdk:"source_dir"`
+	SourcePatterns types.List   `tfsdk:"source_patterns"`
+	SourceSHA256   types.String `tfsdk:"source_sha256"`
+	Retries        types.Object `tfsdk:"retries"`
 }
 
 func NewBunkerWebPluginResource() resource.Resource {
@@ -38,6 +48,8 @@ func NewBunkerWebPluginResource() resource.Resource {
 
 func (r *BunkerWebPluginResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_plugin"
+	// Update moves the plugin to the identifier of the uploaded package.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *BunkerWebPluginResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -60,37 +72,54 @@ func (r *BunkerWebPluginResource) Schema(_ context.Context, _ resource.SchemaReq
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "File name to associate with the uploaded plugin payload (for example `custom.lua`).",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"content": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Plugin file contents. Use functions such as `file()` to read local files.",
+				Optional:            true,
+				MarkdownDescription: "Plugin file contents. Use functions such as `file()` to read local files. `.zip`, `.tar` and `.tar.gz` archives are checked before upload: they must contain a `plugin.json` with a valid `id` and a `version`. Changes are uploaded over the existing plugin, which stays installed throughout; when the new upload has another identifier, the previous plugin is deleted afterwards.",
 				Sensitive:           true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
+			"source_dir": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Local directory packaged into a `.tar.gz` and uploaded, instead of `content`; `name` must then end with `.tar.gz` or `.tgz`. " +
+					"`~` and forward slashes work on every operating system. The archive only depends on the relative paths and contents of the files, " +
+					"with CRLF line endings of text files converted to LF, so checkouts on Windows, macOS and Linux plan identically. " +
+					"Symlinks to files inside the directory are packaged as the file they point to; other symlinks are errors.",
+			},
+			"source_patterns": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Files of `source_dir` to package, as `fileset()`-style patterns relative to it, such as `**/*.lua` or `plugin.json`. " +
+					"`*`, `?` and `[...]` match within a directory and `**` matches any number of directories. Defaults to every file.",
+			},
+			"source_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hex-encoded SHA-256 of the paths and contents of the files packaged from `source_dir`; a change schedules an upload.",
+			},
+			"retries": retriesResourceAttribute(),
 		},
 	}
 }
 
+func (r *BunkerWebPluginResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIDIdentitySchema("Identifier of the plugin.")
+}
+
 func (r *BunkerWebPluginResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.state = data.state
 }
 
 func (r *BunkerWebPluginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,33 +134,18 @@ func (r *BunkerWebPluginResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	name := strings.TrimSpace(plan.Name.ValueString())
-	if name == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid Name", "Provide a non-empty plugin file name.")
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	content := plan.Content.ValueString()
-	uploadReq := PluginUploadRequest{
-		Method: strings.TrimSpace(plan.Method.ValueString()),
-		Files: []PluginUploadFile{
-			{FileName: name, Content: []byte(content)},
-		},
-	}
-
-	created, err := r.client.UploadPlugins(ctx, uploadReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Upload Plugin", err.Error())
-		return
-	}
-	if len(created) == 0 {
-		resp.Diagnostics.AddError("Upload Plugin", "API response did not include uploaded plugin metadata")
+	resp.Diagnostics.Append(r.upload(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	plan.ID = types.StringValue(created[0])
-
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
 }
 
 func (r *BunkerWebPluginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -146,6 +160,11 @@ func (r *BunkerWebPluginResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if state.ID.IsNull() || state.ID.IsUnknown() {
 		resp.State.RemoveResource(ctx)
 		return
@@ -160,6 +179,7 @@ func (r *BunkerWebPluginResource) Read(ctx context.Context, req resource.ReadReq
 	id := state.ID.ValueString()
 	for _, plugin := range plugins {
 		if plugin.ID == id {
+			resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
 			return
 		}
 	}
@@ -167,8 +187,128 @@ func (r *BunkerWebPluginResource) Read(ctx context.Context, req resource.ReadReq
 	resp.State.RemoveResource(ctx)
 }
 
-func (r *BunkerWebPluginResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {
-	// Updates are modeled as force-new via plan modifiers on name/content.
+// Update uploads the new package over the installed one, as the API replaces
+// plugins with the same identifier, so the plugin is never missing. Only a
+// package with another identifier requires deleting the previous plugin.
+func (r *BunkerWebPluginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan, state BunkerWebPluginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upload(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+
+	if previous := state.ID.ValueString(); previous != "" && previous != plan.ID.ValueString() {
+		if err := r.client.DeletePlugin(ctx, previous); err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"Unable to Delete Previous Plugin",
+				fmt.Sprintf("Plugin %q replaces %q, which could not be deleted and is still installed: %s", plan.ID.ValueString(), previous, err),
+			)
+		}
+	}
+}
+
+func (r *BunkerWebPluginResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebPluginResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case !config.Content.IsNull() && !config.SourceDir.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("source_dir"), "Invalid Attribute Combination", "Specify only one of content or source_dir.")
+	case config.Content.IsNull() && config.SourceDir.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Missing Plugin Content", "One of content or source_dir must be set.")
+	case !config.SourcePatterns.IsNull() && config.SourceDir.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("source_patterns"), "Invalid Attribute Combination", "source_patterns requires source_dir.")
+	}
+
+	if !config.SourceDir.IsNull() && !config.Name.IsUnknown() {
+		if lower := strings.ToLower(strings.TrimSpace(config.Name.ValueString())); !strings.HasSuffix(lower, ".tar.gz") && !strings.HasSuffix(lower, ".tgz") {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid Name", fmt.Sprintf("source_dir is uploaded as a .tar.gz archive, so name must end with .tar.gz or .tgz, got %q.", config.Name.ValueString()))
+		}
+	}
+
+	if config.SourcePatterns.IsNull() || config.SourcePatterns.IsUnknown() {
+		return
+	}
+	var patterns []types.String
+	resp.Diagnostics.Append(config.SourcePatterns.ElementsAs(ctx, &patterns, false)...)
+	for idx, pattern := range patterns {
+		if pattern.IsUnknown() {
+			continue
+		}
+		if err := validateSourcePattern(pattern.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source_patterns").AtListIndex(idx), "Invalid Pattern", err.Error())
+		}
+	}
+}
+
+// ModifyPlan validates plugin archives and plans an unknown identifier when
+// the package changes, since the API derives it from the uploaded file.
+func (r *BunkerWebPluginResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BunkerWebPluginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case !plan.SourceDir.IsNull():
+		if plan.Name.IsUnknown() || plan.SourceDir.IsUnknown() || plan.SourcePatterns.IsUnknown() {
+			plan.SourceSHA256 = types.StringUnknown()
+			break
+		}
+		_, sum, diags := plan.sourceArchive(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.SourceSHA256 = types.StringValue(sum)
+	case !plan.Name.IsUnknown() && !plan.Content.IsUnknown():
+		if err := validatePluginArchive(plan.Name.ValueString(), []byte(plan.Content.ValueString())); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid Plugin Archive", err.Error())
+			return
+		}
+		plan.SourceSHA256 = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_sha256"), plan.SourceSHA256)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state BunkerWebPluginResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Name.Equal(state.Name) || !plan.Content.Equal(state.Content) || !plan.SourceSHA256.Equal(state.SourceSHA256) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
 }
 
 func (r *BunkerWebPluginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -183,6 +323,11 @@ func (r *BunkerWebPluginResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if state.ID.IsNull() || state.ID.IsUnknown() {
 		return
 	}
@@ -193,7 +338,100 @@ func (r *BunkerWebPluginResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *BunkerWebPluginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := importIdentifier(ctx, req, "id")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &BunkerWebPluginResourceModel{
-		ID: types.StringValue(strings.TrimSpace(req.ID)),
+		ID:             types.StringValue(strings.TrimSpace(id)),
+		SourcePatterns: types.ListNull(types.StringType),
+		Retries:        types.ObjectNull(retriesAttrTypes),
 	})...)
 }
+
+// upload sends the plugin package of m and sets m.ID to the identifier the
+// API assigned.
+func (r *BunkerWebPluginResource) upload(ctx context.Context, m *BunkerWebPluginResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	name := strings.TrimSpace(m.Name.ValueString())
+	if name == "" {
+		diags.AddAttributeError(path.Root("name"), "Invalid Name", "Provide a non-empty plugin file name.")
+		return diags
+	}
+
+	content := []byte(m.Content.ValueString())
+	if !m.SourceDir.IsNull() {
+		archive, sum, archiveDiags := m.sourceArchive(ctx)
+		diags.Append(archiveDiags...)
+		if diags.HasError() {
+			return diags
+		}
+		if !m.SourceSHA256.IsUnknown() && sum != m.SourceSHA256.ValueString() {
+			diags.AddAttributeError(path.Root("source_dir"), "Source Directory Changed", fmt.Sprintf("The files of %s changed since the plan was made. Plan again to upload their current content.", m.SourceDir.ValueString()))
+			return diags
+		}
+		content = archive
+		m.SourceSHA256 = types.StringValue(sum)
+	} else {
+		if err := validatePluginArchive(name, content); err != nil {
+			diags.AddAttributeError(path.Root("content"), "Invalid Plugin Archive", err.Error())
+			return diags
+		}
+		m.SourceSHA256 = types.StringNull()
+	}
+
+	created, err := r.client.UploadPlugins(ctx, PluginUploadRequest{
+		Method: strings.TrimSpace(m.Method.ValueString()),
+		Files: []PluginUploadFile{
+			{FileName: name, Content: content},
+		},
+	})
+	if err != nil {
+		diags.AddError("Upload Plugin", err.Error())
+		return diags
+	}
+	if len(created) == 0 {
+		diags.AddError("Upload Plugin", "API response did not include uploaded plugin metadata")
+		return diags
+	}
+
+	m.ID = types.StringValue(created[0])
+	return diags
+}
+
+// sourceArchive packages the files of source_dir selected by source_patterns
+// and returns the archive and the hash of the files.
+func (m *BunkerWebPluginResourceModel) sourceArchive(ctx context.Context) ([]byte, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var patterns []string
+	if !m.SourcePatterns.IsNull() {
+		diags.Append(m.SourcePatterns.ElementsAs(ctx, &patterns, false)...)
+		if diags.HasError() {
+			return nil, "", diags
+		}
+	}
+
+	files, err := readSourceDir(m.SourceDir.ValueString(), patterns)
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Unable to Read Source Directory", err.Error())
+		return nil, "", diags
+	}
+	archive, err := packSourceFiles(files)
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Unable to Package Source Directory", err.Error())
+		return nil, "", diags
+	}
+	if err := validatePluginArchive(strings.TrimSpace(m.Name.ValueString()), archive); err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Invalid Plugin Archive", err.Error())
+		return nil, "", diags
+	}
+	return archive, sourceFilesSHA256(files), diags
+}
+
+func (m *BunkerWebPluginResourceModel) identity() resourceIDIdentityModel {
+	return resourceIDIdentityModel{ID: m.ID}
+}
@@ -5,9 +5,12 @@ package provider
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccBunkerWebPluginResource(t *testing.T) {
@@ -38,6 +41,103 @@ func TestAccBunkerWebPluginResource(t *testing.T) {
 	})
 }
 
+func TestAccBunkerWebPluginResourceUpdateInPlace(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebPluginResourceConfig(fakeAPI.URL(), "custom.lua", "return 42"),
+				Check:  resource.TestCheckResourceAttr("bunkerweb_plugin.custom", "id", "custom"),
+			},
+			{
+				// New content under the same identifier is uploaded over the
+				// installed plugin without deleting it.
+				Config: testAccBunkerWebPluginResourceConfig(fakeAPI.URL(), "custom.lua", "return 43"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_plugin.custom", "id", "custom"),
+					func(*terraform.State) error {
+						if deleted := fakeAPI.DeletedPlugins(); len(deleted) != 0 {
+							return fmt.Errorf("expected no plugin deletion, got %v", deleted)
+						}
+						if batches := fakeAPI.UploadedPluginBatches(); len(batches) != 2 {
+							return fmt.Errorf("expected two uploads, got %v", batches)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// A new identifier is uploaded before the previous plugin is deleted.
+				Config: testAccBunkerWebPluginResourceConfig(fakeAPI.URL(), "renamed.lua", "return 43"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_plugin.custom", "id", "renamed"),
+					func(*terraform.State) error {
+						if _, ok := fakeAPI.Plugin("renamed"); !ok {
+							return fmt.Errorf("expected plugin renamed to be installed")
+						}
+						if deleted := fakeAPI.DeletedPlugins(); len(deleted) != 1 || deleted[0] != "custom" {
+							return fmt.Errorf("expected only custom to be deleted, got %v", deleted)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebPluginResourceSourceDir(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"plugin.json": `{"id": "geo", "version": "1.0"}`,
+		"geo.lua":     "return 1\n",
+		"notes.txt":   "not packaged\n",
+	})
+
+	config := fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_plugin" "geo" {
+  name            = "geo.tar.gz"
+  source_dir      = %q
+  source_patterns = ["plugin.json", "**/*.lua"]
+}
+`, fakeAPI.URL(), filepath.ToSlash(dir))
+
+	files, err := readSourceDir(dir, []string{"plugin.json", "**/*.lua"})
+	if err != nil {
+		t.Fatalf("readSourceDir: %v", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bunkerweb_plugin.geo", "id"),
+					resource.TestCheckResourceAttr("bunkerweb_plugin.geo", "source_sha256", sourceFilesSHA256(files)),
+					resource.TestCheckNoResourceAttr("bunkerweb_plugin.geo", "content"),
+				),
+			},
+			{
+				// Files outside source_patterns do not change the plan.
+				PreConfig: func() { writeTestFiles(t, dir, map[string]string{"notes.txt": "still not packaged\n"}) },
+				Config:    config,
+				PlanOnly:  true,
+			},
+		},
+	})
+}
+
 func testAccBunkerWebPluginResourceConfig(endpoint, name, content string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -52,3 +152,21 @@ resource "bunkerweb_plugin" "custom" {
 }
 `, endpoint, name, content)
 }
+
+func TestPluginResourceRenameKeepsIdentityConsistent(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	plugin := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_plugin")
+
+	for _, name := range []string{"custom.lua", "renamed.lua"} {
+		if errs := plugin.apply(map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, name),
+			"content": tftypes.NewValue(tftypes.String, "return 42"),
+			"method":  tftypes.NewValue(tftypes.String, "custom"),
+		}); len(errs) > 0 {
+			t.Fatalf("apply %s: %s: %s", name, errs[0].Summary, errs[0].Detail)
+		}
+	}
+	if id := plugin.attribute("id"); id != "renamed" {
+		t.Fatalf("expected the plugin to move to renamed, got %q", id)
+	}
+}
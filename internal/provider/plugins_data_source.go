@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -17,7 +18,7 @@ var _ datasource.DataSource = &BunkerWebPluginsDataSource{}
 
 // BunkerWebPluginsDataSource lists installed plugins.
 type BunkerWebPluginsDataSource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebPluginsDataSourceModel represents the data source state.
@@ -27,6 +28,13 @@ type BunkerWebPluginsDataSourceModel struct {
 	Plugins  types.List   `tfsdk:"plugins"`
 }
 
+var pluginJobAttrTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"file":   types.StringType,
+	"every":  types.StringType,
+	"reload": types.BoolType,
+}
+
 func NewBunkerWebPluginsDataSource() datasource.DataSource {
 	return &BunkerWebPluginsDataSource{}
 }
@@ -45,7 +53,7 @@ func (d *BunkerWebPluginsDataSource) Schema(_ context.Context, _ datasource.Sche
 			},
 			"with_data": schema.BoolAttribute{
 				Optional:            true,
-				MarkdownDescription: "When true, requests plugin content payloads as well.",
+				MarkdownDescription: "When true, requests plugin content payloads as well and fills `settings`, `jobs` and `page` for each plugin.",
 			},
 			"plugins": schema.ListNestedAttribute{
 				Computed:            true,
@@ -68,6 +76,41 @@ func (d *BunkerWebPluginsDataSource) Schema(_ context.Context, _ datasource.Sche
 							Computed:            true,
 							MarkdownDescription: "Short description if supplied by the API.",
 						},
+						"settings": schema.MapNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Settings declared by the plugin, keyed by name, when `with_data` is true.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: settingMetadataSchemaAttributes(),
+							},
+						},
+						"jobs": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Jobs declared by the plugin, when `with_data` is true.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Job name.",
+									},
+									"file": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Script run by the job.",
+									},
+									"every": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Schedule (`once`, `minute`, `hour`, `day`, `week`).",
+									},
+									"reload": schema.BoolAttribute{
+										Computed:            true,
+										MarkdownDescription: "Whether BunkerWeb reloads after the job succeeds.",
+									},
+								},
+							},
+						},
+						"page": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the plugin ships a web UI page, when `with_data` is true.",
+						},
 					},
 				},
 			},
@@ -80,16 +123,16 @@ func (d *BunkerWebPluginsDataSource) Configure(_ context.Context, req datasource
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *BunkerWebPluginsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -121,11 +164,16 @@ func (d *BunkerWebPluginsDataSource) Read(ctx context.Context, req datasource.Re
 	}
 
 	elems := make([]attr.Value, 0, len(plugins))
+	settingsType := types.MapType{ElemType: types.ObjectType{AttrTypes: settingMetadataAttrTypes}}
+	jobsType := types.ListType{ElemType: types.ObjectType{AttrTypes: pluginJobAttrTypes}}
 	elemType := map[string]attr.Type{
 		"id":          types.StringType,
 		"type":        types.StringType,
 		"version":     types.StringType,
 		"description": types.StringType,
+		"settings":    settingsType,
+		"jobs":        jobsType,
+		"page":        types.BoolType,
 	}
 
 	for _, plugin := range plugins {
@@ -134,11 +182,60 @@ func (d *BunkerWebPluginsDataSource) Read(ctx context.Context, req datasource.Re
 			"type":        types.StringValue(plugin.Type),
 			"version":     types.StringValue(plugin.Version),
 			"description": types.StringValue(plugin.Description),
+			"settings":    types.MapNull(settingsType.ElemType),
+			"jobs":        types.ListNull(jobsType.ElemType),
+			"page":        types.BoolNull(),
+		}
+		if withData {
+			settings := make(map[string]attr.Value, len(plugin.Settings))
+			for name, setting := range plugin.Settings {
+				value, diags := settingMetadataValue(plugin.ID, setting)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				settings[name] = value
+			}
+			jobs := make([]attr.Value, 0, len(plugin.Jobs))
+			for _, job := range plugin.Jobs {
+				jobValue, diags := types.ObjectValue(pluginJobAttrTypes, map[string]attr.Value{
+					"name":   types.StringValue(job.Name),
+					"file":   types.StringValue(job.File),
+					"every":  types.StringValue(job.Every),
+					"reload": types.BoolValue(job.Reload),
+				})
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				jobs = append(jobs, jobValue)
+			}
+
+			var diags diag.Diagnostics
+			values["settings"], diags = types.MapValue(settingsType.ElemType, settings)
+			resp.Diagnostics.Append(diags...)
+			values["jobs"], diags = types.ListValue(jobsType.ElemType, jobs)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			values["page"] = types.BoolValue(plugin.Page)
+		}
+
+		obj, diags := types.ObjectValue(elemType, values)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		elems = append(elems, types.ObjectValueMust(elemType, values))
+		elems = append(elems, obj)
 	}
 
-	data.Plugins = types.ListValueMust(types.ObjectType{AttrTypes: elemType}, elems)
+	pluginsValue, diags := types.ListValue(types.ObjectType{AttrTypes: elemType}, elems)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Plugins = pluginsValue
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
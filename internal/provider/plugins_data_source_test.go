@@ -38,3 +38,56 @@ provider "bunkerweb" {
 data "bunkerweb_plugins" "all" {}
 `, endpoint)
 }
+
+func TestAccBunkerWebPluginsDataSourceWithData(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddPlugin(bunkerWebPlugin{
+		ID:      "clamav",
+		Type:    "external",
+		Version: "1.9",
+		Settings: map[string]bunkerWebPluginSetting{
+			"USE_CLAMAV": {ID: "use-clamav", Context: "multisite", Default: "no", Type: "check", Regex: "^(yes|no)$"},
+		},
+		Jobs: []bunkerWebPluginJob{{Name: "clamav-update", File: "clamav-update.py", Every: "day", Reload: true}},
+		Page: true,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebPluginsDataSourceWithDataConfig(fakeAPI.URL(), true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_plugins.external", "plugins.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_plugins.external", "plugins.0.settings.USE_CLAMAV.default", "no"),
+					resource.TestCheckResourceAttr("data.bunkerweb_plugins.external", "plugins.0.settings.USE_CLAMAV.plugin", "clamav"),
+					resource.TestCheckResourceAttr("data.bunkerweb_plugins.external", "plugins.0.jobs.0.name", "clamav-update"),
+					resource.TestCheckResourceAttr("data.bunkerweb_plugins.external", "plugins.0.jobs.0.reload", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_plugins.external", "plugins.0.page", "true"),
+				),
+			},
+			{
+				Config: testAccBunkerWebPluginsDataSourceWithDataConfig(fakeAPI.URL(), false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.bunkerweb_plugins.external", "plugins.0.settings.%"),
+					resource.TestCheckNoResourceAttr("data.bunkerweb_plugins.external", "plugins.0.jobs.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebPluginsDataSourceWithDataConfig(endpoint string, withData bool) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_plugins" "external" {
+  type      = "external"
+  with_data = %t
+}
+`, endpoint, withData)
+}
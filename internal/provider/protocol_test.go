@@ -0,0 +1,309 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// protocolResource drives one resource through the provider's protocol
+// server the way Terraform core does (plan, then apply with the planned
+// identity and private state), so the framework's own checks run without a
+// Terraform binary.
+type protocolResource struct {
+	t        *testing.T
+	server   tfprotov6.ProviderServer
+	typeName string
+	objType  tftypes.Object
+
+	state    *tfprotov6.DynamicValue
+	identity *tfprotov6.ResourceIdentityData
+	private  []byte
+
+	// afterPlan, when set, runs between the plan and the apply.
+	afterPlan func()
+}
+
+// newProtocolResource configures the provider against endpoint and returns a
+// driver for resources of typeName.
+func newProtocolResource(t *testing.T, endpoint, typeName string) *protocolResource {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("provider server: %v", err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+
+	providerConfig := protocolValue(t, schemas.Provider.ValueType().(tftypes.Object), map[string]tftypes.Value{
+		"api_endpoint": tftypes.NewValue(tftypes.String, endpoint),
+		"api_token":    tftypes.NewValue(tftypes.String, "test-token"),
+	})
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: providerConfig})
+	if err != nil {
+		t.Fatalf("ConfigureProvider: %v", err)
+	}
+	protocolDiagnostics(t, "ConfigureProvider", configureResp.Diagnostics)
+
+	resourceSchema, ok := schemas.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no resource schema for %s", typeName)
+	}
+	r := &protocolResource{t: t, server: server, typeName: typeName, objType: resourceSchema.ValueType().(tftypes.Object)}
+	r.state = r.null()
+	return r
+}
+
+// apply plans and applies config, which sets the given attributes and leaves
+// the others null, and returns the error diagnostics of the apply. Like
+// Terraform core, a change that requires replacement is planned again as a
+// create, then applied as a delete followed by a create.
+func (r *protocolResource) apply(attributes map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	r.t.Helper()
+	return r.change(attributes, false)
+}
+
+// replace is apply with the resource forced to be replaced, as with
+// terraform apply -replace.
+func (r *protocolResource) replace(attributes map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	r.t.Helper()
+	return r.change(attributes, true)
+}
+
+func (r *protocolResource) change(attributes map[string]tftypes.Value, replace bool) []*tfprotov6.Diagnostic {
+	r.t.Helper()
+	config := protocolValue(r.t, r.objType, attributes)
+
+	plan := r.plan(r.state, config, r.private, r.identity)
+	if replace || len(plan.RequiresReplace) > 0 {
+		plan = r.plan(r.null(), config, nil, nil)
+		if errs := r.applyPlan(&tfprotov6.PlanResourceChangeResponse{PlannedState: r.null()}, r.null()); len(errs) > 0 {
+			return errs
+		}
+	}
+	if r.afterPlan != nil {
+		r.afterPlan()
+	}
+	return r.applyPlan(plan, config)
+}
+
+// refresh reads the resource into the current state, as terraform plan does
+// before planning.
+func (r *protocolResource) refresh() {
+	r.t.Helper()
+	resp, err := r.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:        r.typeName,
+		CurrentState:    r.state,
+		CurrentIdentity: r.identity,
+		Private:         r.private,
+	})
+	if err != nil {
+		r.t.Fatalf("ReadResource: %v", err)
+	}
+	protocolDiagnostics(r.t, "ReadResource", resp.Diagnostics)
+	r.state, r.identity, r.private = resp.NewState, resp.NewIdentity, resp.Private
+}
+
+func (r *protocolResource) null() *tfprotov6.DynamicValue {
+	r.t.Helper()
+	null, err := tfprotov6.NewDynamicValue(r.objType, tftypes.NewValue(r.objType, nil))
+	if err != nil {
+		r.t.Fatalf("NewDynamicValue: %v", err)
+	}
+	return &null
+}
+
+func (r *protocolResource) plan(prior, config *tfprotov6.DynamicValue, private []byte, identity *tfprotov6.ResourceIdentityData) *tfprotov6.PlanResourceChangeResponse {
+	r.t.Helper()
+	resp, err := r.server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       prior,
+		ProposedNewState: config,
+		Config:           config,
+		PriorPrivate:     private,
+		PriorIdentity:    identity,
+	})
+	if err != nil {
+		r.t.Fatalf("PlanResourceChange: %v", err)
+	}
+	protocolDiagnostics(r.t, "PlanResourceChange", resp.Diagnostics)
+	return resp
+}
+
+func (r *protocolResource) applyPlan(plan *tfprotov6.PlanResourceChangeResponse, config *tfprotov6.DynamicValue) []*tfprotov6.Diagnostic {
+	r.t.Helper()
+	resp, err := r.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:        r.typeName,
+		PriorState:      r.state,
+		PlannedState:    plan.PlannedState,
+		Config:          config,
+		PlannedPrivate:  plan.PlannedPrivate,
+		PlannedIdentity: plan.PlannedIdentity,
+	})
+	if err != nil {
+		r.t.Fatalf("ApplyResourceChange: %v", err)
+	}
+
+	errs := protocolErrors(resp.Diagnostics)
+	if len(errs) == 0 {
+		// Terraform core, not the framework, rejects applied values that
+		// differ from known planned values.
+		planned, err := plan.PlannedState.Unmarshal(r.objType)
+		if err != nil {
+			r.t.Fatalf("Unmarshal: %v", err)
+		}
+		applied, err := resp.NewState.Unmarshal(r.objType)
+		if err != nil {
+			r.t.Fatalf("Unmarshal: %v", err)
+		}
+		if path := protocolInconsistency(tftypes.NewAttributePath(), planned, applied); path != nil {
+			r.t.Fatalf("Provider produced inconsistent result after apply: %s", path)
+		}
+		r.state, r.identity, r.private = resp.NewState, resp.NewIdentity, resp.Private
+	}
+	return errs
+}
+
+// protocolInconsistency returns the path of the first known value of planned
+// that applied does not keep, or nil.
+func protocolInconsistency(path *tftypes.AttributePath, planned, applied tftypes.Value) *tftypes.AttributePath {
+	if !planned.IsKnown() {
+		return nil
+	}
+	if planned.IsFullyKnown() || planned.IsNull() || applied.IsNull() || !applied.IsKnown() {
+		if !planned.Equal(applied) {
+			return path
+		}
+		return nil
+	}
+
+	switch {
+	case planned.Type().Is(tftypes.Object{}) || planned.Type().Is(tftypes.Map{}):
+		var plannedValues, appliedValues map[string]tftypes.Value
+		if planned.As(&plannedValues) != nil || applied.As(&appliedValues) != nil || len(plannedValues) != len(appliedValues) {
+			return path
+		}
+		for name, value := range plannedValues {
+			step := path.WithElementKeyString(name)
+			if planned.Type().Is(tftypes.Object{}) {
+				step = path.WithAttributeName(name)
+			}
+			if p := protocolInconsistency(step, value, appliedValues[name]); p != nil {
+				return p
+			}
+		}
+	case planned.Type().Is(tftypes.List{}) || planned.Type().Is(tftypes.Tuple{}):
+		var plannedValues, appliedValues []tftypes.Value
+		if planned.As(&plannedValues) != nil || applied.As(&appliedValues) != nil || len(plannedValues) != len(appliedValues) {
+			return path
+		}
+		for i, value := range plannedValues {
+			if p := protocolInconsistency(path.WithElementKeyInt(i), value, appliedValues[i]); p != nil {
+				return p
+			}
+		}
+	}
+	// Sets with unknown elements cannot be matched element by element.
+	return nil
+}
+
+// attribute returns the string attribute name of the current state.
+func (r *protocolResource) attribute(name string) string {
+	r.t.Helper()
+	value, err := r.state.Unmarshal(r.objType)
+	if err != nil {
+		r.t.Fatalf("Unmarshal: %v", err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		r.t.Fatalf("As: %v", err)
+	}
+	var s string
+	if err := attributes[name].As(&s); err != nil {
+		r.t.Fatalf("%s: %v", name, err)
+	}
+	return s
+}
+
+// protocolValidate validates the configuration of a resource of typeName,
+// which sets the given attributes and leaves the others null, and returns its
+// error diagnostics.
+func protocolValidate(t *testing.T, typeName string, attributes map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("provider server: %v", err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	resourceSchema, ok := schemas.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no resource schema for %s", typeName)
+	}
+
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   protocolValue(t, resourceSchema.ValueType().(tftypes.Object), attributes),
+	})
+	if err != nil {
+		t.Fatalf("ValidateResourceConfig: %v", err)
+	}
+
+	return protocolErrors(resp.Diagnostics)
+}
+
+// protocolValue returns an object of objType with the given attributes and
+// every other attribute null.
+func protocolValue(t *testing.T, objType tftypes.Object, attributes map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	values := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+	dv, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, values))
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+	return &dv
+}
+
+// protocolErrors returns the error diagnostics of diags.
+func protocolErrors(diags []*tfprotov6.Diagnostic) []*tfprotov6.Diagnostic {
+	var errs []*tfprotov6.Diagnostic
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, d)
+		}
+	}
+	return errs
+}
+
+// protocolDiagnostics fails the test on the error diagnostics of call.
+func protocolDiagnostics(t *testing.T, call string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	var errs []string
+	for _, d := range protocolErrors(diags) {
+		errs = append(errs, d.Summary+": "+d.Detail)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("%s: %s", call, strings.Join(errs, "; "))
+	}
+}
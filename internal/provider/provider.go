@@ -7,9 +7,11 @@ package provider
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -20,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
@@ -28,7 +31,10 @@ const (
 	envAPIToken           = "BUNKERWEB_API_TOKEN"
 	envAPIUsername        = "BUNKERWEB_API_USERNAME"
 	envAPIPassword        = "BUNKERWEB_API_PASSWORD"
+	envHMACKey            = "BUNKERWEB_API_HMAC_KEY"
+	envTenant             = "BUNKERWEB_TENANT"
 	defaultRequestTimeout = 30 * time.Second
+	versionDetectTimeout  = 5 * time.Second
 )
 
 // Ensure BunkerWebProvider satisfies various provider interfaces.
@@ -51,6 +57,22 @@ type BunkerWebProviderModel struct {
 	APIUsername   types.String `tfsdk:"api_username"`
 	APIPassword   types.String `tfsdk:"api_password"`
 	SkipTLSVerify types.Bool   `tfsdk:"skip_tls_verify"`
+	HMACKey       types.String `tfsdk:"hmac_key"`
+	HMACHeader    types.String `tfsdk:"hmac_header"`
+	MinAPIVersion types.String `tfsdk:"minimum_api_version"`
+	DefaultVars   types.Map    `tfsdk:"default_service_variables"`
+	NamePrefix    types.String `tfsdk:"name_prefix"`
+	NameSuffix    types.String `tfsdk:"name_suffix"`
+	LockTTL       types.String `tfsdk:"global_config_lock_ttl"`
+	AsyncTimeout  types.String `tfsdk:"async_operation_timeout"`
+	MetricsFile   types.String `tfsdk:"metrics_file"`
+	ExtraHeaders  types.Map    `tfsdk:"extra_headers"`
+	Tenant        types.String `tfsdk:"tenant"`
+	TenantHeader  types.String `tfsdk:"tenant_header"`
+	TenantInPath  types.Bool   `tfsdk:"tenant_in_path"`
+	CBThreshold   types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CBCooldown    types.String `tfsdk:"circuit_breaker_cooldown"`
+	Retries       types.Object `tfsdk:"retries"`
 }
 
 func (p *BunkerWebProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -79,10 +101,96 @@ func (p *BunkerWebProvider) Schema(ctx context.Context, req provider.SchemaReque
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"hmac_key": schema.StringAttribute{
+				MarkdownDescription: "Secret used to sign every request for a gateway that verifies HMAC signatures in front of the API. Can also be provided via the `" + envHMACKey + "` environment variable. " +
+					"Each request carries its Unix time in `" + hmacTimestampHeader + "` and the hex-encoded HMAC-SHA256 of `<timestamp>.<body>` in `hmac_header`. " +
+					"Can be used alone when the gateway authenticates requests, or together with token or Basic authentication.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"hmac_header": schema.StringAttribute{
+				MarkdownDescription: "Header carrying the request signature when `hmac_key` is set. Defaults to `" + defaultHMACHeader + "`.",
+				Optional:            true,
+			},
+			"extra_headers": schema.MapAttribute{
+				MarkdownDescription: "Headers added to every API request, for example audit or tenant headers or routing hints required by an API gateway. " +
+					"Headers set by the provider itself (`Authorization`, `Content-Type`, `" + idempotencyKeyHeader + "` and the request signing headers) cannot be overridden.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"tenant": schema.StringAttribute{
+				MarkdownDescription: "Tenant managed by this provider configuration, for control planes serving several isolated BunkerWeb tenants. Use one provider alias per tenant. " +
+					"Sent in `tenant_header` with every request, or as a `tenants/<tenant>/` prefix of every endpoint when `tenant_in_path` is true. Can also be provided via the `" + envTenant + "` environment variable.",
+				Optional: true,
+			},
+			"tenant_header": schema.StringAttribute{
+				MarkdownDescription: "Header carrying `tenant`. Defaults to `" + defaultTenantHeader + "`.",
+				Optional:            true,
+			},
+			"tenant_in_path": schema.BoolAttribute{
+				MarkdownDescription: "Scopes endpoints to `tenant` with a `tenants/<tenant>/` path prefix instead of a header.",
+				Optional:            true,
+			},
 			"skip_tls_verify": schema.BoolAttribute{
 				MarkdownDescription: "Disables TLS certificate validation when set to true. Useful for development environments only.",
 				Optional:            true,
 			},
+			"default_service_variables": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Variables merged into every `bunkerweb_service`, for organisation-wide baselines such as security headers or " +
+					"`USE_MODSECURITY`. A service's `template` and `variables` take precedence over these defaults.",
+				Optional: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Prefix added to the server names of `bunkerweb_service`, the names of `bunkerweb_config` and the names of `bunkerweb_instance` resources that set `apply_name_affixes`, " +
+					"so the same module can target a shared control plane once per environment (for example `dev-`).",
+				Optional: true,
+			},
+			"name_suffix": schema.StringAttribute{
+				MarkdownDescription: "Suffix added to the same names as `name_prefix` (for example `-staging`).",
+				Optional:            true,
+			},
+			"global_config_lock_ttl": schema.StringAttribute{
+				MarkdownDescription: "Enables an advisory lock on the global configuration, held for this duration (for example `5m`), so two pipelines applying at the same time do not interleave global config writes. " +
+					"The lock is taken before the first write, renewed by later writes and stored in the reserved `" + globalConfigLockSetting + "` global setting; it lapses on its own after the apply. " +
+					"A provider finding the lock held waits until it expires.",
+				Optional: true,
+			},
+			"async_operation_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for an operation the API accepts asynchronously (HTTP 202 with a task reference) to complete, for example `10m`. Defaults to `5m`.",
+				Optional:            true,
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of consecutive failed requests (connection errors and HTTP 502, 503 or 504) after which the provider considers the API unavailable and fails further operations immediately with a \"BunkerWeb API unavailable\" error, instead of letting each one time out. The count covers every endpoint of the API, since these failures mean the API itself is unreachable rather than one of its routes. `0` disables the breaker. Defaults to `%d`.", defaultCircuitBreakerThreshold),
+				Optional:            true,
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long requests fail fast once the breaker has tripped, as a Go duration; a single request then probes the API and closes the breaker when it succeeds. Defaults to `%s`.", defaultCircuitBreakerCooldown),
+				Optional:            true,
+			},
+			"retries": schema.SingleNestedAttribute{
+				MarkdownDescription: "Retries API requests failing with a connection error or HTTP 429, 502, 503 or 504. Writes are retried with the same `" + idempotencyKeyHeader + "`, which API versions without idempotency support ignore, so only enable retries when repeating a write is harmless or override them per resource with its `retries` attribute. Requests are not retried by default.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"count": schema.Int64Attribute{
+						MarkdownDescription: "Number of retries after the first attempt. Defaults to `0`.",
+						Optional:            true,
+					},
+					"backoff": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Pause before the first retry as a Go duration, doubled for each later retry up to `%s`. Defaults to `%s`.", maxRetryBackoff, defaultRetryBackoff),
+						Optional:            true,
+					},
+				},
+			},
+			"metrics_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file to which the API request counters (requests and errors per endpoint, retries, total time) are appended as one JSON line when the provider process exits. " +
+					"Terraform runs a provider process per operation, so a plan and an apply each add a line, told apart by its `pid` and `started` fields. The running totals are also logged at `INFO` level after each operation.",
+				Optional: true,
+			},
+			"minimum_api_version": schema.StringAttribute{
+				MarkdownDescription: "Oldest BunkerWeb version the configuration supports, for example `1.6.5`. When set, provider configuration fails if the API reports an older version or no version at all, so plans stop before any change is made.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -111,6 +219,19 @@ func (p *BunkerWebProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	minAPIVersion := ""
+	if !data.MinAPIVersion.IsNull() && !data.MinAPIVersion.IsUnknown() {
+		minAPIVersion = data.MinAPIVersion.ValueString()
+		if _, ok := parseVersion(minAPIVersion); !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("minimum_api_version"),
+				"Invalid Minimum API Version",
+				"`minimum_api_version` must be a dotted version such as `1.6.5`.",
+			)
+			return
+		}
+	}
+
 	skipTLSVerify := false
 	if !data.SkipTLSVerify.IsNull() && !data.SkipTLSVerify.IsUnknown() {
 		skipTLSVerify = data.SkipTLSVerify.ValueBool()
@@ -138,15 +259,22 @@ func (p *BunkerWebProvider) Configure(ctx context.Context, req provider.Configur
 		apiPassword = envVal
 	}
 
+	hmacKey := ""
+	if !data.HMACKey.IsNull() && !data.HMACKey.IsUnknown() {
+		hmacKey = data.HMACKey.ValueString()
+	} else if envVal := os.Getenv(envHMACKey); envVal != "" {
+		hmacKey = envVal
+	}
+
 	// Validate authentication methods
 	hasToken := apiToken != ""
 	hasBasicAuth := apiUsername != "" && apiPassword != ""
 
-	if !hasToken && !hasBasicAuth {
+	if !hasToken && !hasBasicAuth && hmacKey == "" {
 		resp.Diagnostics.AddError(
 			"Missing Authentication Credentials",
-			"Either `api_token` (Bearer authentication) or both `api_username` and `api_password` (Basic authentication) must be provided. "+
-				"You can set these via provider attributes or environment variables ("+envAPIToken+", "+envAPIUsername+", "+envAPIPassword+").",
+			"Either `api_token` (Bearer authentication), both `api_username` and `api_password` (Basic authentication) or `hmac_key` (request signing) must be provided. "+
+				"You can set these via provider attributes or environment variables ("+envAPIToken+", "+envAPIUsername+", "+envAPIPassword+", "+envHMACKey+").",
 		)
 		return
 	}
@@ -209,19 +337,186 @@ func (p *BunkerWebProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
-	resp.EphemeralResourceData = client
+	defaultVars, diags := mapFromTerraform(ctx, data.DefaultVars)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client.hmacKey = []byte(hmacKey)
+	client.hmacHeader = strings.TrimSpace(data.HMACHeader.ValueString())
+
+	tenant := strings.TrimSpace(data.Tenant.ValueString())
+	if data.Tenant.IsNull() {
+		tenant = strings.TrimSpace(os.Getenv(envTenant))
+	}
+	if tenant == "" && (!data.TenantHeader.IsNull() || data.TenantInPath.ValueBool()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tenant"),
+			"Missing Tenant",
+			"`tenant_header` and `tenant_in_path` require `tenant` (or the "+envTenant+" environment variable).",
+		)
+		return
+	}
+	if !data.TenantHeader.IsNull() && data.TenantInPath.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tenant_header"),
+			"Conflicting Tenant Scoping",
+			"`tenant_header` is not used when `tenant_in_path` is true; set only one of them.",
+		)
+		return
+	}
+	if tenant != "" {
+		client.tenant = tenant
+		client.tenantInPath = data.TenantInPath.ValueBool()
+		if !client.tenantInPath {
+			client.tenantHeader = firstNonEmpty(strings.TrimSpace(data.TenantHeader.ValueString()), defaultTenantHeader)
+		}
+	}
+
+	client.tracer = newTracerFromEnv()
+
+	extraHeaders, diags := mapFromTerraform(ctx, data.ExtraHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for name := range extraHeaders {
+		if client.reservedHeader(name) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_headers"),
+				"Reserved Header",
+				fmt.Sprintf("The provider sets the %q header itself; remove it from extra_headers.", name),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client.extraHeaders = extraHeaders
+	if !data.LockTTL.IsNull() && !data.LockTTL.IsUnknown() {
+		ttl, err := time.ParseDuration(data.LockTTL.ValueString())
+		if err != nil || ttl <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("global_config_lock_ttl"),
+				"Invalid Lock TTL",
+				fmt.Sprintf("Expected a positive duration such as `5m`, got %q.", data.LockTTL.ValueString()),
+			)
+			return
+		}
+		client.lockTTL = ttl
+		client.lockOwner = newLockOwner()
+	}
+	if !data.AsyncTimeout.IsNull() && !data.AsyncTimeout.IsUnknown() {
+		timeout, err := time.ParseDuration(data.AsyncTimeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("async_operation_timeout"),
+				"Invalid Async Operation Timeout",
+				fmt.Sprintf("Expected a positive duration such as `10m`, got %q.", data.AsyncTimeout.ValueString()),
+			)
+			return
+		}
+		client.asyncWaiter.Timeout = timeout
+	}
+	client.stats = newRequestStats(strings.TrimSpace(data.MetricsFile.ValueString()))
+
+	threshold := int64(defaultCircuitBreakerThreshold)
+	if !data.CBThreshold.IsNull() && !data.CBThreshold.IsUnknown() {
+		threshold = data.CBThreshold.ValueInt64()
+		if threshold < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("circuit_breaker_threshold"),
+				"Invalid Circuit Breaker Threshold",
+				"`circuit_breaker_threshold` cannot be negative; use 0 to disable the breaker.",
+			)
+			return
+		}
+	}
+	cooldown := defaultCircuitBreakerCooldown
+	if !data.CBCooldown.IsNull() && !data.CBCooldown.IsUnknown() {
+		parsed, err := time.ParseDuration(data.CBCooldown.ValueString())
+		if err != nil || parsed <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("circuit_breaker_cooldown"),
+				"Invalid Circuit Breaker Cooldown",
+				fmt.Sprintf("Expected a positive duration such as `30s`, got %q.", data.CBCooldown.ValueString()),
+			)
+			return
+		}
+		cooldown = parsed
+	}
+	client.breaker = newCircuitBreaker(int(threshold), cooldown)
+
+	retries, diags := retryPolicyFromTerraform(ctx, path.Root("retries"), data.Retries, retryPolicy{Backoff: defaultRetryBackoff})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client.retries = retries
+
+	// Best effort unless minimum_api_version is set: an unreachable API must
+	// not otherwise fail configuration here.
+	versionCtx, cancel := context.WithTimeout(ctx, versionDetectTimeout)
+	version := client.DetectVersion(versionCtx)
+	cancel()
+	if version != "" {
+		tflog.Debug(ctx, "detected bunkerweb version", map[string]any{"version": version})
+	}
+
+	discoveryCtx, cancel := context.WithTimeout(ctx, versionDetectTimeout)
+	if count := client.DiscoverEndpoints(discoveryCtx); count > 0 {
+		tflog.Debug(ctx, "discovered bunkerweb api endpoints", map[string]any{"endpoints": count})
+	}
+	cancel()
+
+	if minAPIVersion != "" {
+		if _, ok := parseVersion(version); !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("minimum_api_version"),
+				"Unable to Verify BunkerWeb Version",
+				fmt.Sprintf("`minimum_api_version` is %s but the API did not report a usable version (got %q) from /health or /ping.", minAPIVersion, version),
+			)
+			return
+		}
+		if older, _ := versionLess(version, minAPIVersion); older {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("minimum_api_version"),
+				"Unsupported BunkerWeb Version",
+				fmt.Sprintf("Your BunkerWeb %s is older than the required %s. Upgrade BunkerWeb or lower `minimum_api_version`.", version, minAPIVersion),
+			)
+			return
+		}
+	}
+
+	state := newProviderState(client)
+	state.defaultServiceVariables = defaultVars
+	state.namePrefix = strings.TrimSpace(data.NamePrefix.ValueString())
+	state.nameSuffix = strings.TrimSpace(data.NameSuffix.ValueString())
+
+	shared := &providerData{client: client, state: state}
+	resp.DataSourceData = shared
+	resp.ResourceData = shared
+	resp.EphemeralResourceData = shared
 }
 
 func (p *BunkerWebProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBunkerWebResource,
+		NewBunkerWebServicesSyncResource,
 		NewBunkerWebInstanceResource,
 		NewBunkerWebGlobalConfigResource,
+		NewBunkerWebGlobalConfigSettingsResource,
 		NewBunkerWebConfigResource,
 		NewBunkerWebBanResource,
+		NewBunkerWebBanExemptionResource,
 		NewBunkerWebPluginResource,
+		NewBunkerWebCertificateResource,
+		NewBunkerWebLetsEncryptSettingsResource,
+		NewBunkerWebServiceTemplateResource,
+		NewBunkerWebInstanceReloadResource,
+		NewBunkerWebAPICredentialResource,
+		NewBunkerWebUserResource,
+		NewBunkerWebPluginRepositoryResource,
 	}
 }
 
@@ -234,7 +529,14 @@ func (p *BunkerWebProvider) EphemeralResources(ctx context.Context) []func() eph
 		NewBunkerWebConfigUploadEphemeralResource,
 		NewBunkerWebConfigUploadUpdateEphemeralResource,
 		NewBunkerWebConfigBulkDeleteEphemeralResource,
+		NewBunkerWebCachePurgeEphemeralResource,
+		NewBunkerWebAPICallEphemeralResource,
 		NewBunkerWebBanBulkEphemeralResource,
+		NewBunkerWebLogsEphemeralResource,
+		NewBunkerWebAuthTokenEphemeralResource,
+		NewBunkerWebGlobalConfigPatchEphemeralResource,
+		NewBunkerWebInstanceMaintenanceEphemeralResource,
+		NewBunkerWebPluginDownloadEphemeralResource,
 	}
 }
 
@@ -242,10 +544,21 @@ func (p *BunkerWebProvider) DataSources(ctx context.Context) []func() datasource
 	return []func() datasource.DataSource{
 		NewBunkerWebDataSource,
 		NewBunkerWebGlobalConfigDataSource,
+		NewBunkerWebGlobalConfigDiffDataSource,
 		NewBunkerWebPluginsDataSource,
+		NewBunkerWebSettingMetadataDataSource,
 		NewBunkerWebCacheDataSource,
 		NewBunkerWebJobsDataSource,
+		NewBunkerWebJobRunHistoryDataSource,
+		NewBunkerWebJobStatusDataSource,
+		NewBunkerWebReadinessDataSource,
+		NewBunkerWebBansDataSource,
+		NewBunkerWebMetricsDataSource,
+		NewBunkerWebRequestsReportDataSource,
 		NewBunkerWebConfigsDataSource,
+		NewBunkerWebPingDataSource,
+		NewBunkerWebInstanceDataSource,
+		NewBunkerWebAPIInfoDataSource,
 	}
 }
 
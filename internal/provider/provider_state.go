@@ -0,0 +1,83 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// providerData is what the provider passes to the Configure method of
+// resources, data sources and ephemeral resources.
+type providerData struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// providerState holds the provider settings and the per-process bookkeeping
+// that resources share beside the API client, none of which involves a
+// request.
+type providerState struct {
+	// endpoint, username and password are the provider api_endpoint,
+	// api_username and api_password.
+	endpoint string
+	username string
+	password string
+
+	// version is the BunkerWeb version detected during provider setup, ""
+	// when unknown.
+	version string
+
+	// endpoints maps the routes of the API's OpenAPI document to their
+	// methods; nil when discovery failed.
+	endpoints map[string][]string
+
+	// defaultServiceVariables are merged under every bunkerweb_service's
+	// variables (provider default_service_variables).
+	defaultServiceVariables map[string]string
+
+	// namePrefix and nameSuffix are added to the names of resources that opt
+	// in with apply_name_affixes; see remoteName.
+	namePrefix string
+	nameSuffix string
+
+	// retries is the provider retry policy, which resources override with
+	// withRetries.
+	retries retryPolicy
+
+	// httpClient and tracer are shared with the clients of mirrored APIs;
+	// see mirrorClient.
+	httpClient *http.Client
+	tracer     *tracer
+
+	managedInstancesMu sync.Mutex
+	managedInstances   map[string]struct{}
+
+	// plannedConfigs holds the custom configs planned by bunkerweb_config
+	// resources in this provider process; see claimConfig.
+	plannedConfigsMu sync.Mutex
+	plannedConfigs   map[string]tftypes.Value
+
+	// plannedServerNames holds the server names planned by bunkerweb_service
+	// resources in this provider process; see claimServerNames.
+	plannedServerNamesMu sync.Mutex
+	plannedServerNames   map[string]tftypes.Value
+}
+
+// newProviderState returns the state of a provider whose API client is
+// client, once the version and endpoints of the API have been detected.
+func newProviderState(client *bunkerWebClient) *providerState {
+	return &providerState{
+		endpoint:   client.baseURL.String(),
+		username:   client.apiUsername,
+		password:   client.apiPassword,
+		version:    client.version,
+		endpoints:  client.endpoints,
+		retries:    client.retries,
+		httpClient: client.httpClient,
+		tracer:     client.tracer,
+	}
+}
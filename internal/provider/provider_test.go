@@ -5,11 +5,15 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
@@ -33,3 +37,44 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestAccBunkerWebProviderExtraHeaders(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebProviderExtraHeadersConfig(fakeAPI.URL(), "X-Tenant"),
+				Check: func(*terraform.State) error {
+					if got := fakeAPI.LastHeader("X-Tenant"); got != "acme" {
+						return fmt.Errorf("expected X-Tenant: acme, got %q", got)
+					}
+					return nil
+				},
+			},
+			{
+				Config:      testAccBunkerWebProviderExtraHeadersConfig(fakeAPI.URL(), "Authorization"),
+				ExpectError: regexp.MustCompile(`Reserved Header`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebProviderExtraHeadersConfig(endpoint, header string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+
+  extra_headers = {
+    %q = "acme"
+  }
+}
+
+data "bunkerweb_ping" "api" {
+  hostnames = []
+}
+`, endpoint, header)
+}
@@ -0,0 +1,327 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BunkerWebReadinessDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &BunkerWebReadinessDataSource{}
+
+// BunkerWebReadinessDataSource summarises whether the platform is ready to
+// serve traffic, for use in check blocks.
+type BunkerWebReadinessDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebReadinessDataSourceModel holds state.
+type BunkerWebReadinessDataSourceModel struct {
+	MinInstances         types.Int64  `tfsdk:"min_instances"`
+	SchedulerStatus      types.String `tfsdk:"scheduler_status"`
+	SchedulerHealthy     types.Bool   `tfsdk:"scheduler_healthy"`
+	InstanceCount        types.Int64  `tfsdk:"instance_count"`
+	HealthyInstanceCount types.Int64  `tfsdk:"healthy_instance_count"`
+	UnreachableInstances types.List   `tfsdk:"unreachable_instances"`
+	FailedJobs           types.List   `tfsdk:"failed_jobs"`
+	PendingChanges       types.Bool   `tfsdk:"pending_changes"`
+	PendingChangeKinds   types.List   `tfsdk:"pending_change_kinds"`
+	Problems             types.List   `tfsdk:"problems"`
+	Ready                types.Bool   `tfsdk:"ready"`
+}
+
+// readinessReport is the outcome of checkReadiness.
+type readinessReport struct {
+	SchedulerStatus      string
+	InstanceCount        int
+	UnreachableInstances []string
+	FailedJobs           []string
+	// PendingChanges lists what the scheduler has yet to apply; it is nil
+	// when the API does not report it.
+	PendingChanges []string
+	Problems       []string
+}
+
+func NewBunkerWebReadinessDataSource() datasource.DataSource {
+	return &BunkerWebReadinessDataSource{}
+}
+
+func (d *BunkerWebReadinessDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_readiness"
+}
+
+func (d *BunkerWebReadinessDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks that the platform is ready: the API reports a healthy scheduler with no pending changes, at least `min_instances` instances answer a ping and no job failed its latest run. " +
+			"Failed checks are reported in `ready` and `problems` rather than failing the read, so the data source fits in a `check` block that verifies the platform after every apply.",
+		Attributes: map[string]schema.Attribute{
+			"min_instances": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Minimum number of reachable instances. Defaults to `1`.",
+			},
+			"scheduler_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status reported by `GET /health`, or `unavailable` when it answers with an error.",
+			},
+			"scheduler_healthy": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether `scheduler_status` is `ok`.",
+			},
+			"instance_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of registered instances.",
+			},
+			"healthy_instance_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of registered instances that answered a ping.",
+			},
+			"unreachable_instances": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Hostnames of the instances that did not answer a ping.",
+			},
+			"failed_jobs": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Jobs, as `plugin/name`, whose latest run failed. Without run history (BunkerWeb before 1.6.5) this relies on the status reported by the scheduler.",
+			},
+			"pending_changes": schema.BoolAttribute{
+				Computed: true,
+				MarkdownDescription: "Whether the scheduler has changes it has not applied yet, for example from a previous apply that is still converging. " +
+					"Null when the API does not report pending changes in `GET /health`.",
+			},
+			"pending_change_kinds": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "What the pending changes affect, such as `config`, `custom_configs` or `instances`.",
+			},
+			"problems": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Human-readable description of every failed check, suitable for a `check` block `error_message`.",
+			},
+			"ready": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "True when every check passed.",
+			},
+		},
+	}
+}
+
+func (d *BunkerWebReadinessDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebReadinessDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data BunkerWebReadinessDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.MinInstances.IsNull() && !data.MinInstances.IsUnknown() && data.MinInstances.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("min_instances"), "Invalid Minimum Instances", "`min_instances` cannot be negative.")
+	}
+}
+
+func (d *BunkerWebReadinessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebReadinessDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	minInstances := int64(1)
+	if !data.MinInstances.IsNull() {
+		minInstances = data.MinInstances.ValueInt64()
+	}
+
+	report, err := checkReadiness(ctx, d.client, int(minInstances))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Check BunkerWeb Readiness", err.Error())
+		return
+	}
+
+	data.SchedulerStatus = types.StringValue(report.SchedulerStatus)
+	data.SchedulerHealthy = types.BoolValue(strings.EqualFold(report.SchedulerStatus, "ok"))
+	data.InstanceCount = types.Int64Value(int64(report.InstanceCount))
+	data.HealthyInstanceCount = types.Int64Value(int64(report.InstanceCount - len(report.UnreachableInstances)))
+	data.Ready = types.BoolValue(len(report.Problems) == 0)
+	data.PendingChanges = types.BoolNull()
+	if report.PendingChanges != nil {
+		data.PendingChanges = types.BoolValue(len(report.PendingChanges) > 0)
+	}
+
+	for target, values := range map[*types.List][]string{
+		&data.UnreachableInstances: report.UnreachableInstances,
+		&data.FailedJobs:           report.FailedJobs,
+		&data.PendingChangeKinds:   report.PendingChanges,
+		&data.Problems:             report.Problems,
+	} {
+		list, diags := types.ListValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(diags...)
+		*target = list
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkReadiness runs the readiness checks. Failed checks are listed in the
+// report's Problems; only errors reaching the API itself are returned.
+func checkReadiness(ctx context.Context, client BunkerWebAPI, minInstances int) (readinessReport, error) {
+	report := readinessReport{UnreachableInstances: []string{}, FailedJobs: []string{}, Problems: []string{}}
+
+	// An unhealthy API answers /health with an error status rather than an
+	// unreachable one.
+	health, err := client.Health(ctx)
+	var apiErr *bunkerWebAPIError
+	switch {
+	case errors.As(err, &apiErr):
+		report.SchedulerStatus = "unavailable"
+		report.Problems = append(report.Problems, fmt.Sprintf("health check failed: %s", apiErr.Message))
+	case err != nil:
+		return report, fmt.Errorf("health: %w", err)
+	default:
+		report.SchedulerStatus = stringifyValue(health["status"])
+		if !strings.EqualFold(report.SchedulerStatus, "ok") {
+			report.Problems = append(report.Problems, fmt.Sprintf("scheduler status is %q, expected \"ok\"", report.SchedulerStatus))
+		}
+		report.PendingChanges = pendingChanges(health["pending_changes"])
+		if len(report.PendingChanges) > 0 {
+			report.Problems = append(report.Problems, fmt.Sprintf("the scheduler has not applied pending changes yet: %s", strings.Join(report.PendingChanges, ", ")))
+		}
+	}
+
+	instances, err := client.ListInstances(ctx)
+	if err != nil {
+		return report, fmt.Errorf("list instances: %w", err)
+	}
+	hostnames := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		hostnames = append(hostnames, inst.Hostname)
+	}
+	slices.Sort(hostnames)
+	report.InstanceCount = len(hostnames)
+	for _, result := range pingInstances(ctx, client, hostnames) {
+		if !result.Success.ValueBool() {
+			report.UnreachableInstances = append(report.UnreachableInstances, result.Hostname.ValueString())
+		}
+	}
+	if healthy := report.InstanceCount - len(report.UnreachableInstances); healthy < minInstances {
+		problem := fmt.Sprintf("%d of %d instances are reachable, expected at least %d", healthy, report.InstanceCount, minInstances)
+		if len(report.UnreachableInstances) > 0 {
+			problem += fmt.Sprintf(" (unreachable: %s)", strings.Join(report.UnreachableInstances, ", "))
+		}
+		report.Problems = append(report.Problems, problem)
+	}
+
+	failed, err := failedJobs(ctx, client)
+	if err != nil {
+		return report, err
+	}
+	report.FailedJobs = failed
+	if len(failed) > 0 {
+		report.Problems = append(report.Problems, fmt.Sprintf("jobs failed their latest run: %s", strings.Join(failed, ", ")))
+	}
+
+	return report, nil
+}
+
+// pendingChanges returns the sorted kinds of pending changes reported by
+// /health, or nil when it reports none. The API reports either a flag, a list
+// of kinds or the scheduler's change flags by kind, such as
+// {"config_changed": true}.
+func pendingChanges(value any) []string {
+	kinds := []string{}
+	switch v := value.(type) {
+	case bool:
+		if v {
+			kinds = append(kinds, "unspecified")
+		}
+	case []any:
+		for _, kind := range v {
+			if kind := strings.TrimSpace(stringifyValue(kind)); kind != "" {
+				kinds = append(kinds, strings.TrimSuffix(kind, "_changed"))
+			}
+		}
+	case map[string]any:
+		for kind, changed := range v {
+			if flag, _ := changed.(bool); flag {
+				kinds = append(kinds, strings.TrimSuffix(kind, "_changed"))
+			}
+		}
+	default:
+		return nil
+	}
+	slices.Sort(kinds)
+	return slices.Compact(kinds)
+}
+
+// failedJobs returns the sorted plugin/name of the jobs whose latest run
+// failed, falling back to the scheduler status without run history.
+func failedJobs(ctx context.Context, client BunkerWebAPI) ([]string, error) {
+	failed := []string{}
+	if client.CheckFeature(featureJobHistory) != nil {
+		jobs, err := client.ListJobs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list jobs: %w", err)
+		}
+		for _, job := range jobs {
+			if strings.EqualFold(job.Status, "failed") || strings.EqualFold(job.Status, "error") {
+				failed = append(failed, job.Plugin+"/"+job.Name)
+			}
+		}
+		slices.Sort(failed)
+		return failed, nil
+	}
+
+	runs, err := client.ListJobRuns(ctx, JobRunListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list job runs: %w", err)
+	}
+	// Runs come newest first, so the first one seen per job is its latest.
+	seen := map[string]bool{}
+	for _, run := range runs {
+		key := run.Plugin + "/" + run.Name
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if !run.Success {
+			failed = append(failed, key)
+		}
+	}
+	slices.Sort(failed)
+	return failed, nil
+}
@@ -0,0 +1,143 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCheckReadiness(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	api.AddInstance(bunkerWebInstance{Hostname: "bw-1"})
+	api.AddInstance(bunkerWebInstance{Hostname: "bw-2"})
+	api.AddJobRun(bunkerWebJobRun{Plugin: "reporter", Name: "daily", Success: true, StartDate: 200})
+	api.AddJobRun(bunkerWebJobRun{Plugin: "reporter", Name: "daily", Success: false, StartDate: 100})
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+
+	report, err := checkReadiness(ctx, client, 2)
+	if err != nil {
+		t.Fatalf("checkReadiness: %v", err)
+	}
+	if report.SchedulerStatus != "ok" || report.InstanceCount != 2 || len(report.Problems) != 0 {
+		t.Fatalf("expected a ready platform, got %+v", report)
+	}
+	if report.PendingChanges != nil {
+		t.Fatalf("expected pending changes to be unknown when /health does not report them, got %v", report.PendingChanges)
+	}
+
+	api.SetPendingChanges(map[string]any{"config_changed": true, "custom_configs_changed": false, "instances_changed": true})
+	report, err = checkReadiness(ctx, client, 2)
+	if err != nil {
+		t.Fatalf("checkReadiness: %v", err)
+	}
+	if !slices.Equal(report.PendingChanges, []string{"config", "instances"}) {
+		t.Fatalf("unexpected pending changes: %v", report.PendingChanges)
+	}
+	if len(report.Problems) != 1 || !strings.Contains(report.Problems[0], "pending changes yet: config, instances") {
+		t.Fatalf("expected the pending changes to be reported, got %v", report.Problems)
+	}
+
+	api.SetPendingChanges(nil)
+	api.SetHealthStatus("degraded")
+	api.SetUnreachable("bw-2")
+	api.AddJobRun(bunkerWebJobRun{Plugin: "letsencrypt", Name: "certbot-renew", Success: false, StartDate: 300})
+
+	report, err = checkReadiness(ctx, client, 2)
+	if err != nil {
+		t.Fatalf("checkReadiness: %v", err)
+	}
+	if !slices.Equal(report.UnreachableInstances, []string{"bw-2"}) {
+		t.Fatalf("unexpected unreachable instances: %v", report.UnreachableInstances)
+	}
+	if !slices.Equal(report.FailedJobs, []string{"letsencrypt/certbot-renew"}) {
+		t.Fatalf("unexpected failed jobs: %v", report.FailedJobs)
+	}
+	problems := strings.Join(report.Problems, "\n")
+	for _, want := range []string{`health check failed: {"status":"degraded"}`, "1 of 2 instances are reachable, expected at least 2 (unreachable: bw-2)", "letsencrypt/certbot-renew"} {
+		if !strings.Contains(problems, want) {
+			t.Errorf("expected problems to mention %q, got:\n%s", want, problems)
+		}
+	}
+}
+
+func TestPendingChanges(t *testing.T) {
+	for _, tc := range []struct {
+		value any
+		want  []string
+	}{
+		{nil, nil},
+		{false, []string{}},
+		{true, []string{"unspecified"}},
+		{[]any{"plugins_config_changed", "config", "config"}, []string{"config", "plugins_config"}},
+		{map[string]any{"config_changed": false}, []string{}},
+	} {
+		got := pendingChanges(tc.value)
+		if !slices.Equal(got, tc.want) || (got == nil) != (tc.want == nil) {
+			t.Errorf("pendingChanges(%v) = %#v, want %#v", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestAccBunkerWebReadinessDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddInstance(bunkerWebInstance{Hostname: "bw-1"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebReadinessDataSourceConfig(fakeAPI.URL(), 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "ready", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "scheduler_healthy", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "healthy_instance_count", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "problems.#", "0"),
+					resource.TestCheckNoResourceAttr("data.bunkerweb_readiness.platform", "pending_changes"),
+				),
+			},
+			{
+				PreConfig: func() { fakeAPI.SetPendingChanges(map[string]any{"config_changed": true}) },
+				Config:    testAccBunkerWebReadinessDataSourceConfig(fakeAPI.URL(), 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "ready", "false"),
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "pending_changes", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "pending_change_kinds.0", "config"),
+				),
+			},
+			{
+				PreConfig: func() { fakeAPI.SetPendingChanges(map[string]any{"config_changed": false}) },
+				Config:    testAccBunkerWebReadinessDataSourceConfig(fakeAPI.URL(), 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "ready", "false"),
+					resource.TestCheckResourceAttr("data.bunkerweb_readiness.platform", "problems.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebReadinessDataSourceConfig(endpoint string, minInstances int) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_readiness" "platform" {
+  min_instances = %d
+}
+`, endpoint, minInstances)
+}
@@ -0,0 +1,45 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultHMACHeader   = "X-Signature"
+	hmacTimestampHeader = "X-Timestamp"
+)
+
+// requestSignature returns the hex-encoded HMAC-SHA256 of "<timestamp>.<body>",
+// the value an HMAC-signing gateway in front of the API verifies.
+func requestSignature(key []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signRequest adds the timestamp and signature headers when hmac_key is set.
+// body is the complete request body (nil for requests without one).
+func (c *bunkerWebClient) signRequest(req *http.Request, body []byte) {
+	if len(c.hmacKey) == 0 {
+		return
+	}
+
+	header := c.hmacHeader
+	if header == "" {
+		header = defaultHMACHeader
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(hmacTimestampHeader, timestamp)
+	req.Header.Set(header, requestSignature(c.hmacKey, timestamp, body))
+}
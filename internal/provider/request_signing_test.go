@@ -0,0 +1,80 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBunkerWebClientSignsRequests(t *testing.T) {
+	client, err := newBunkerWebClient("https://bunkerweb.example.com/api", nil, "", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.hmacKey = []byte("gateway-secret")
+	client.hmacHeader = "X-Gateway-Signature"
+
+	req, err := client.newRequest(context.Background(), http.MethodPost, "services", map[string]string{"server_name": "app.example.com"})
+	if err != nil {
+		t.Fatalf("newRequest: %v", err)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if string(body) != "{\"server_name\":\"app.example.com\"}\n" {
+		t.Fatalf("expected the body to be preserved, got %q", body)
+	}
+
+	timestamp := req.Header.Get(hmacTimestampHeader)
+	if timestamp == "" {
+		t.Fatalf("expected a timestamp header")
+	}
+	if got, want := req.Header.Get("X-Gateway-Signature"), requestSignature([]byte("gateway-secret"), timestamp, body); got != want {
+		t.Fatalf("expected signature %q, got %q", want, got)
+	}
+	if req.Header.Get(defaultHMACHeader) != "" {
+		t.Fatalf("expected the configured header to replace the default one")
+	}
+
+	req, err = client.newRequest(context.Background(), http.MethodGet, "services", nil)
+	if err != nil {
+		t.Fatalf("newRequest: %v", err)
+	}
+	if got, want := req.Header.Get("X-Gateway-Signature"), requestSignature([]byte("gateway-secret"), req.Header.Get(hmacTimestampHeader), nil); got != want {
+		t.Fatalf("expected signature %q for an empty body, got %q", want, got)
+	}
+}
+
+func TestAccBunkerWebProviderHMACAuth(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.RequireHMAC("gateway-secret")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  hmac_key     = "gateway-secret"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+}
+`, fakeAPI.URL()),
+				Check: resource.TestCheckResourceAttr("bunkerweb_service.app", "id", "app.example.com"),
+			},
+		},
+	})
+}
@@ -0,0 +1,278 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// requestStats counts the API requests of one configured client, to quantify
+// the load Terraform puts on the API.
+type requestStats struct {
+	// file receives the summary as a JSON line at shutdown (provider
+	// metrics_file).
+	file string
+
+	mu        sync.Mutex
+	started   time.Time
+	logged    int
+	requests  int
+	errors    int
+	retries   int
+	duration  time.Duration
+	endpoints map[string]*endpointStats
+}
+
+type endpointStats struct {
+	Requests   int   `json:"requests"`
+	Errors     int   `json:"errors"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+// statsEndpoint names the endpoint of req for the counters: its method and
+// path relative to the API base, using the OpenAPI route template (such as
+// "services/{service}") when discovery matched one.
+func (c *bunkerWebClient) statsEndpoint(req *http.Request) string {
+	endpoint := strings.Trim(c.withoutTenant(strings.TrimPrefix(req.URL.Path, c.baseURL.Path)), "/")
+	segments := strings.Split(endpoint, "/")
+	for route, methods := range c.endpoints {
+		if strings.Contains(route, "{") && containsFold(methods, req.Method) && routeMatches(strings.Split(route, "/"), segments) {
+			endpoint = route
+			break
+		}
+	}
+	return req.Method + " /" + endpoint
+}
+
+// requestStatsSummary is the JSON line appended to metrics_file.
+type requestStatsSummary struct {
+	PID        int                      `json:"pid"`
+	Started    time.Time                `json:"started"`
+	Requests   int                      `json:"requests"`
+	Errors     int                      `json:"errors"`
+	Retries    int                      `json:"retries"`
+	DurationMs int64                    `json:"duration_ms"`
+	Endpoints  map[string]endpointStats `json:"endpoints"`
+}
+
+var (
+	liveRequestStatsMu sync.Mutex
+	liveRequestStats   []*requestStats
+)
+
+// newRequestStats returns the counters of a new client and registers them
+// for ReportRequestStats.
+func newRequestStats(file string) *requestStats {
+	stats := &requestStats{file: file, started: time.Now(), endpoints: map[string]*endpointStats{}}
+
+	liveRequestStatsMu.Lock()
+	liveRequestStats = append(liveRequestStats, stats)
+	liveRequestStatsMu.Unlock()
+
+	return stats
+}
+
+// record counts one request. endpoint is "METHOD route"; failed covers
+// transport errors and non-2xx responses.
+func (s *requestStats) record(endpoint string, duration time.Duration, failed bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	s.duration += duration
+	e := s.endpoints[endpoint]
+	if e == nil {
+		e = &endpointStats{}
+		s.endpoints[endpoint] = e
+	}
+	e.Requests++
+	e.DurationMs += duration.Milliseconds()
+	if failed {
+		s.errors++
+		e.Errors++
+	}
+}
+
+// recordRetry counts a request repeating an earlier one, such as a
+// follow-up poll of an asynchronous task.
+func (s *requestStats) recordRetry() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retries++
+}
+
+func (s *requestStats) summary() requestStatsSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoints := make(map[string]endpointStats, len(s.endpoints))
+	for name, e := range s.endpoints {
+		endpoints[name] = *e
+	}
+	return requestStatsSummary{
+		PID:        os.Getpid(),
+		Started:    s.started,
+		Requests:   s.requests,
+		Errors:     s.errors,
+		Retries:    s.retries,
+		DurationMs: s.duration.Milliseconds(),
+		Endpoints:  endpoints,
+	}
+}
+
+// String renders the summary for the log, busiest endpoints first.
+func (s requestStatsSummary) String() string {
+	names := make([]string, 0, len(s.Endpoints))
+	for name := range s.Endpoints {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if d := s.Endpoints[b].Requests - s.Endpoints[a].Requests; d != 0 {
+			return d
+		}
+		return strings.Compare(a, b)
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d requests (%d errors, %d retries) in %s", s.Requests, s.Errors, s.Retries, time.Duration(s.DurationMs)*time.Millisecond)
+	for _, name := range names {
+		e := s.Endpoints[name]
+		fmt.Fprintf(&b, "\n  %s: %d requests, %d errors, %dms", name, e.Requests, e.Errors, e.DurationMs)
+	}
+	return b.String()
+}
+
+// appendFile adds the summary to file as one JSON line, so the processes
+// Terraform starts for a run (plan, apply) each leave their own line.
+func (s requestStatsSummary) appendFile(file string) error {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(content, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// logRequestStats logs the request counters of every client configured by
+// this provider process that sent requests since they were last logged.
+// Providers have no teardown hook in which the Terraform logger is still
+// available, so the running totals are logged after each operation instead
+// and the last line of a process is its summary.
+func logRequestStats(ctx context.Context) {
+	liveRequestStatsMu.Lock()
+	all := slices.Clone(liveRequestStats)
+	liveRequestStatsMu.Unlock()
+
+	for _, stats := range all {
+		summary := stats.summary()
+		stats.mu.Lock()
+		fresh := summary.Requests > stats.logged
+		stats.logged = summary.Requests
+		stats.mu.Unlock()
+		if fresh {
+			tflog.Info(ctx, "bunkerweb api requests: "+summary.String())
+		}
+	}
+}
+
+// ReportRequestStats appends the request counters of every client configured
+// with a metrics_file by this provider process to that file. It is called
+// once the plugin server stops, since providers have no teardown hook.
+func ReportRequestStats() {
+	liveRequestStatsMu.Lock()
+	all := liveRequestStats
+	liveRequestStats = nil
+	liveRequestStatsMu.Unlock()
+
+	for _, stats := range all {
+		summary := stats.summary()
+		if summary.Requests == 0 || stats.file == "" {
+			continue
+		}
+		if err := summary.appendFile(stats.file); err != nil {
+			// The Terraform logger is gone once the server stopped.
+			log.Printf("[WARN] unable to write bunkerweb request metrics to %s: %s", stats.file, err)
+		}
+	}
+}
+
+// requestStatsServer logs the request counters after each call of the
+// protocol server that may send requests; see logRequestStats.
+type requestStatsServer struct {
+	tfprotov6.ProviderServer
+}
+
+// NewProtocol6Server returns the protocol server of the provider.
+func NewProtocol6Server(version string) func() tfprotov6.ProviderServer {
+	return func() tfprotov6.ProviderServer {
+		return requestStatsServer{providerserver.NewProtocol6(New(version)())()}
+	}
+}
+
+func (s requestStatsServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	defer logRequestStats(ctx)
+	return s.ProviderServer.ConfigureProvider(ctx, req)
+}
+
+func (s requestStatsServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	defer logRequestStats(ctx)
+	return s.ProviderServer.PlanResourceChange(ctx, req)
+}
+
+func (s requestStatsServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	defer logRequestStats(ctx)
+	return s.ProviderServer.ApplyResourceChange(ctx, req)
+}
+
+func (s requestStatsServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	defer logRequestStats(ctx)
+	return s.ProviderServer.ReadResource(ctx, req)
+}
+
+func (s requestStatsServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	defer logRequestStats(ctx)
+	return s.ProviderServer.ImportResourceState(ctx, req)
+}
+
+func (s requestStatsServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	defer logRequestStats(ctx)
+	return s.ProviderServer.ReadDataSource(ctx, req)
+}
+
+func (s requestStatsServer) OpenEphemeralResource(ctx context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	defer logRequestStats(ctx)
+	return s.ProviderServer.OpenEphemeralResource(ctx, req)
+}
+
+func (s requestStatsServer) CloseEphemeralResource(ctx context.Context, req *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+	defer logRequestStats(ctx)
+	return s.ProviderServer.CloseEphemeralResource(ctx, req)
+}
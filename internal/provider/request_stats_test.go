@@ -0,0 +1,111 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestBunkerWebClientRequestStats(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	api.SetOpenAPIPaths("/services", "/services/{service}", "/ping")
+
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	ctx := context.Background()
+	client.DiscoverEndpoints(ctx)
+	client.stats = &requestStats{endpoints: map[string]*endpointStats{}}
+
+	if _, err := client.CreateService(ctx, ServiceCreateRequest{ServerName: "app.example.com"}); err != nil {
+		t.Fatalf("CreateService: %v", err)
+	}
+	if _, err := client.GetService(ctx, "app.example.com"); err != nil {
+		t.Fatalf("GetService: %v", err)
+	}
+	if _, err := client.GetService(ctx, "missing.example.com"); err == nil {
+		t.Fatalf("expected GetService to fail for a missing service")
+	}
+
+	summary := client.stats.summary()
+	if summary.Requests != 3 || summary.Errors != 1 {
+		t.Fatalf("expected 3 requests and 1 error, got %+v", summary)
+	}
+	if got := summary.Endpoints["GET /services/{service}"]; got.Requests != 2 || got.Errors != 1 {
+		t.Fatalf("expected service reads grouped under their route, got %+v", summary.Endpoints)
+	}
+	if got := summary.Endpoints["POST /services"]; got.Requests != 1 {
+		t.Fatalf("expected one service creation, got %+v", summary.Endpoints)
+	}
+	if text := summary.String(); !strings.HasPrefix(text, "3 requests (1 errors, 0 retries)") || !strings.Contains(text, "GET /services/{service}: 2 requests") {
+		t.Fatalf("unexpected summary text %q", text)
+	}
+
+	file := filepath.Join(t.TempDir(), "metrics.json")
+	for range 2 {
+		if err := summary.appendFile(file); err != nil {
+			t.Fatalf("appendFile: %v", err)
+		}
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a line per process, got %q", content)
+	}
+	var written requestStatsSummary
+	if err := json.Unmarshal([]byte(lines[1]), &written); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", lines[1], err)
+	}
+	if written.Requests != 3 || len(written.Endpoints) != 2 || written.PID != os.Getpid() {
+		t.Fatalf("unexpected metrics file content %+v", written)
+	}
+}
+
+func TestLogRequestStats(t *testing.T) {
+	// Leave out the clients of other tests.
+	liveRequestStatsMu.Lock()
+	others := liveRequestStats
+	liveRequestStats = nil
+	liveRequestStatsMu.Unlock()
+	t.Cleanup(func() {
+		liveRequestStatsMu.Lock()
+		liveRequestStats = others
+		liveRequestStatsMu.Unlock()
+	})
+
+	stats := newRequestStats("")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	stats.record("GET /services", time.Millisecond, false)
+	logRequestStats(ctx)
+	logRequestStats(ctx)
+	stats.record("GET /services", time.Millisecond, true)
+	logRequestStats(ctx)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("MultilineJSONDecode: %v", err)
+	}
+	var messages []string
+	for _, entry := range entries {
+		messages = append(messages, entry["@message"].(string))
+	}
+	if len(messages) != 2 || !strings.HasPrefix(messages[0], "bunkerweb api requests: 1 requests") || !strings.HasPrefix(messages[1], "bunkerweb api requests: 2 requests (1 errors") {
+		t.Fatalf("expected the totals to be logged once per change, got %q", messages)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultReportWindowSeconds = 86400
+	defaultReportTop           = 10
+)
+
+var _ datasource.DataSource = &BunkerWebRequestsReportDataSource{}
+
+// BunkerWebRequestsReportDataSource summarises the blocked requests over a window.
+type BunkerWebRequestsReportDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebRequestsReportDataSourceModel holds state.
+type BunkerWebRequestsReportDataSourceModel struct {
+	WindowSeconds     types.Int64 `tfsdk:"window_seconds"`
+	Top               types.Int64 `tfsdk:"top"`
+	TotalBlocked      types.Int64 `tfsdk:"total_blocked"`
+	BlockedPerService types.Map   `tfsdk:"blocked_per_service"`
+	TopCountries      types.List  `tfsdk:"top_countries"`
+}
+
+func NewBunkerWebRequestsReportDataSource() datasource.DataSource {
+	return &BunkerWebRequestsReportDataSource{}
+}
+
+func (d *BunkerWebRequestsReportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_requests_report"
+}
+
+func (d *BunkerWebRequestsReportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Summarises the requests blocked by BunkerWeb over a time window: totals per service and the top attacking countries.",
+		Attributes: map[string]schema.Attribute{
+			"window_seconds": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only requests recorded within this many seconds are counted. Defaults to `86400` (24 hours).",
+			},
+			"top": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of countries reported in `top_countries`. Defaults to `10`.",
+			},
+			"total_blocked": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of blocked requests in the window.",
+			},
+			"blocked_per_service": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Blocked requests keyed by server name (`global` when the request matched no service).",
+			},
+			"top_countries": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Countries with the most blocked requests, in descending order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"country": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ISO 3166-1 alpha-2 country code (`local` for private addresses).",
+						},
+						"count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Blocked requests from the country.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BunkerWebRequestsReportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebRequestsReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebRequestsReportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	window := int64(defaultReportWindowSeconds)
+	if !data.WindowSeconds.IsNull() {
+		window = data.WindowSeconds.ValueInt64()
+	}
+	top := int64(defaultReportTop)
+	if !data.Top.IsNull() {
+		top = data.Top.ValueInt64()
+	}
+	if window <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("window_seconds"), "Invalid Window", "window_seconds must be greater than zero.")
+	}
+	if top < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("top"), "Invalid Top", "top cannot be negative.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.ListRequests(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Requests", err.Error())
+		return
+	}
+
+	since := float64(time.Now().Unix() - window)
+	var total int64
+	perService := map[string]int64{}
+	perCountry := map[string]int64{}
+	for _, record := range records {
+		if record.Date < since {
+			continue
+		}
+		total++
+
+		service := record.ServerName
+		if service == "" || service == "_" {
+			service = "global"
+		}
+		perService[service]++
+
+		country := record.Country
+		if country == "" {
+			country = "unknown"
+		}
+		perCountry[country]++
+	}
+
+	countries := make([]string, 0, len(perCountry))
+	for country := range perCountry {
+		countries = append(countries, country)
+	}
+	sort.Slice(countries, func(i, j int) bool {
+		if perCountry[countries[i]] != perCountry[countries[j]] {
+			return perCountry[countries[i]] > perCountry[countries[j]]
+		}
+		return countries[i] < countries[j]
+	})
+	if int64(len(countries)) > top {
+		countries = countries[:top]
+	}
+
+	attrTypes := map[string]attr.Type{
+		"country": types.StringType,
+		"count":   types.Int64Type,
+	}
+
+	objs := make([]attr.Value, 0, len(countries))
+	for _, country := range countries {
+		obj, diags := types.ObjectValue(attrTypes, map[string]attr.Value{
+			"country": types.StringValue(country),
+			"count":   types.Int64Value(perCountry[country]),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		objs = append(objs, obj)
+	}
+
+	topCountries, diags := types.ListValue(types.ObjectType{AttrTypes: attrTypes}, objs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	perServiceValue, diags := types.MapValueFrom(ctx, types.Int64Type, perService)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.TotalBlocked = types.Int64Value(total)
+	data.BlockedPerService = perServiceValue
+	data.TopCountries = topCountries
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,53 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebRequestsReportDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	now := float64(time.Now().Unix())
+	fakeAPI.AddRequest(bunkerWebRequestRecord{ID: "1", Date: now - 60, Country: "FR", ServerName: "app.example.com"})
+	fakeAPI.AddRequest(bunkerWebRequestRecord{ID: "2", Date: now - 120, Country: "FR", ServerName: "app.example.com"})
+	fakeAPI.AddRequest(bunkerWebRequestRecord{ID: "3", Date: now - 180, Country: "US", ServerName: "_"})
+	fakeAPI.AddRequest(bunkerWebRequestRecord{ID: "4", Date: now - 7200, Country: "CN", ServerName: "app.example.com"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebRequestsReportDataSourceConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_requests_report.last_hour", "total_blocked", "3"),
+					resource.TestCheckResourceAttr("data.bunkerweb_requests_report.last_hour", "blocked_per_service.app.example.com", "2"),
+					resource.TestCheckResourceAttr("data.bunkerweb_requests_report.last_hour", "blocked_per_service.global", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_requests_report.last_hour", "top_countries.#", "1"),
+					resource.TestCheckResourceAttr("data.bunkerweb_requests_report.last_hour", "top_countries.0.country", "FR"),
+					resource.TestCheckResourceAttr("data.bunkerweb_requests_report.last_hour", "top_countries.0.count", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebRequestsReportDataSourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_requests_report" "last_hour" {
+  window_seconds = 3600
+  top            = 1
+}
+`, endpoint)
+}
@@ -8,7 +8,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -16,13 +19,31 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &BunkerWebResource{}
 var _ resource.ResourceWithImportState = &BunkerWebResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebResource{}
+var _ resource.ResourceWithUpgradeState = &BunkerWebResource{}
+
+// serviceStateMigrations upgrade stored bunkerweb_service state; the schema
+// version is their count.
+var serviceStateMigrations = []stateMigration{
+	0: unchangedState,
+}
+
+// serviceAPIErrorAttributes scopes translated API errors; see addAPIError.
+var serviceAPIErrorAttributes = map[apiErrorKind]path.Path{
+	apiErrorSetting:   path.Root("variables"),
+	apiErrorDraftOnly: path.Root("is_draft"),
+}
 
 func NewBunkerWebResource() resource.Resource {
 	return &BunkerWebResource{}
@@ -30,7 +51,8 @@ func NewBunkerWebResource() resource.Resource {
 
 // BunkerWebResource represents the bunkerweb_service Terraform resource.
 type BunkerWebResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
+	state  *providerState
 }
 
 // BunkerWebResourceModel mirrors the Terraform state for bunkerweb_service.
@@ -39,14 +61,36 @@ type BunkerWebResourceModel struct {
 	ServerName types.String `tfsdk:"server_name"`
 	IsDraft    types.Bool   `tfsdk:"is_draft"`
 	Variables  types.Map    `tfsdk:"variables"`
+	Template   types.Map    `tfsdk:"template"`
+	Preset     types.String `tfsdk:"security_preset"`
+	Effective  types.Map    `tfsdk:"effective_variables"`
+	Adopt      types.Bool   `tfsdk:"adopt_existing"`
+	Configs    types.List   `tfsdk:"custom_configs"`
+	Protect    types.Bool   `tfsdk:"deletion_protection"`
+	Drain      types.Bool   `tfsdk:"drain_on_destroy"`
+	DrainGrace types.String `tfsdk:"drain_grace_period"`
+	Affixes    types.Bool   `tfsdk:"apply_name_affixes"`
+	Method     types.String `tfsdk:"method"`
+	Created    types.String `tfsdk:"creation_date"`
+	Updated    types.String `tfsdk:"last_update"`
+
+	ListenStream   types.Bool   `tfsdk:"listen_stream"`
+	StreamPort     types.Int64  `tfsdk:"stream_port"`
+	StreamSSLPort  types.Int64  `tfsdk:"stream_ssl_port"`
+	StreamProtocol types.String `tfsdk:"stream_protocol"`
+	Retries        types.Object `tfsdk:"retries"`
+	HealthCheck    types.Object `tfsdk:"health_check"`
 }
 
 func (r *BunkerWebResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service"
+	// Changing the first server_name renames the service in place.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *BunkerWebResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             int64(len(serviceStateMigrations)),
 		MarkdownDescription: "Manages a BunkerWeb service via the BunkerWeb API.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -60,6 +104,12 @@ func (r *BunkerWebResource) Schema(ctx context.Context, req resource.SchemaReque
 				Required:            true,
 				MarkdownDescription: "Server name of the service (first label used as identifier).",
 			},
+			"apply_name_affixes": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true, the provider `name_prefix` and `name_suffix` are added to every name in `server_name`. `server_name` keeps the configured value while `id` is the identifier in BunkerWeb. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
 			"is_draft": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -70,27 +120,151 @@ func (r *BunkerWebResource) Schema(ctx context.Context, req resource.SchemaReque
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Additional service variables as key/value pairs.",
+				MarkdownDescription: "Additional service variables as key/value pairs. Check and number settings read back in another spelling (`yes`/`true`, `10`/`10.0`) are not reported as drift.",
+			},
+			"template": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Variables inherited from a template, usually `bunkerweb_service_template.<name>.variables`. Keys set in `variables` take precedence.",
+			},
+			"security_preset": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: securityPresetDescription(),
+			},
+			"effective_variables": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Variables applied to the service after merging `security_preset`, `template` and `variables`.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true and a service with the same identifier already exists (for example one created from the web UI), take it over and apply this configuration instead of failing. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true, destroying the service fails unless it is a draft (`is_draft = true`) or this flag is first set back to `false`. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"drain_on_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true, destroying the service first converts it to draft so BunkerWeb stops routing to it, then waits `drain_grace_period` before deleting it, letting in-flight connections finish. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"drain_grace_period": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Time to wait between draining and deleting the service when `drain_on_destroy` is set, as a Go duration. Defaults to `30s`.",
+				Default:             stringdefault.StaticString("30s"),
+			},
+			"method": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "How the service was last modified, as reported by BunkerWeb (for example `ui`, `api` or `scheduler`).",
+			},
+			"creation_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the service was created, in RFC 3339 format (null when the API does not report it).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_update": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the service was last modified, in RFC 3339 format (null when the API does not report it).",
+			},
+			"listen_stream": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "When true, the service proxies plain TCP or UDP traffic instead of HTTP (`SERVER_TYPE = stream`, `LISTEN_STREAM = yes`); when false, it is an HTTP service. " +
+					"Stream services cannot enable HTTP-only features such as `USE_ANTIBOT`, `USE_MODSECURITY` or `USE_GZIP` in `variables`. Leave unset to manage the server type through `variables`.",
+			},
+			"stream_port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Port the stream service listens on (`LISTEN_STREAM_PORT`). Requires `listen_stream = true`.",
+			},
+			"stream_ssl_port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Port the stream service listens on for TLS traffic (`LISTEN_STREAM_PORT_SSL`). Requires `listen_stream = true`.",
+			},
+			"stream_protocol": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Transport of the stream service, `tcp` or `udp` (`USE_TCP` / `USE_UDP`). Requires `listen_stream = true`.",
+			},
+			"custom_configs": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Custom configs scoped to this service, created, updated and deleted together with it. Use this instead of separate `bunkerweb_config` resources to avoid wiring dependencies between them; do not manage the same config from both.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Config type (for example `http`, `server_http`, `modsec`).",
+						},
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Config name.",
+						},
+						"data": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Config content.",
+						},
+					},
+				},
+			},
+			"health_check": serviceHealthCheckAttribute(),
+			"retries":      retriesResourceAttribute(),
 		},
 	}
 }
 
+func (r *BunkerWebResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIDIdentitySchema("Identifier of the service inside BunkerWeb.")
+}
+
 func (r *BunkerWebResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(config.validateStream()...)
+	_, presetDiags := config.securityPresetVariables()
+	resp.Diagnostics.Append(presetDiags...)
+	if !config.ServerName.IsUnknown() {
+		_, diags := config.healthCheck(ctx, firstToken(config.ServerName.ValueString()))
+		resp.Diagnostics.Append(diags...)
+	}
+	if config.DrainGrace.IsNull() || config.DrainGrace.IsUnknown() {
+		return
+	}
+
+	if grace, err := time.ParseDuration(config.DrainGrace.ValueString()); err != nil || grace < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("drain_grace_period"),
+			"Invalid Grace Period",
+			fmt.Sprintf("Expected a non-negative duration such as `30s`, got %q.", config.DrainGrace.ValueString()),
+		)
+	}
 }
 
 func (r *BunkerWebResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,31 +279,87 @@ func (r *BunkerWebResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	variables, diags := mapFromTerraform(ctx, plan.Variables)
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables, diags := r.mergedVariables(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	service, err := r.client.CreateService(ctx, ServiceCreateRequest{
-		ServerName: plan.ServerName.ValueString(),
+	configs, diags := customConfigsFromList(ctx, plan.Configs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adopted := false
+	serverName := r.state.remoteServerNames(plan.Affixes, plan.ServerName.ValueString())
+	service, err := r.client.CreateService(withIdempotencyKey(ctx), ServiceCreateRequest{
+		ServerName: serverName,
 		IsDraft:    plan.IsDraft.ValueBool(),
 		Variables:  variables,
 	})
+	if isConflict(err) && plan.Adopt.ValueBool() {
+		isDraft := plan.IsDraft.ValueBool()
+		tflog.Warn(ctx, "adopting existing bunkerweb service", map[string]any{"id": firstToken(serverName)})
+		adopted = true
+
+		service, err = r.client.UpdateService(ctx, firstToken(serverName), ServiceUpdateRequest{
+			ServerName: &serverName,
+			IsDraft:    &isDraft,
+			Variables:  variables,
+		})
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Create Service", err.Error())
+		addAPIError(&resp.Diagnostics, "Unable to Create Service", err, serviceAPIErrorAttributes)
 		return
 	}
 
-	populateDiags := plan.populateFromService(ctx, service)
+	if created, err := applyCustomConfigs(ctx, r.client, service.ID, nil, configs); err != nil {
+		// Roll back so a failed config does not leave a half-configured
+		// service behind; an adopted service is left in place, as are the
+		// configs that already existed and were overwritten.
+		if !adopted {
+			for _, cfg := range created {
+				_ = deleteCustomConfig(ctx, r.client, service.ID, cfg)
+			}
+			if delErr := r.client.DeleteService(ctx, service.ID); delErr != nil {
+				tflog.Warn(ctx, "unable to roll back bunkerweb service", map[string]any{"id": service.ID, "error": delErr.Error()})
+			}
+		}
+		resp.Diagnostics.AddError("Unable to Create Service Custom Configs", err.Error())
+		return
+	}
+
+	configuredServerName := plan.ServerName
+	populateDiags := plan.populateFromService(ctx, service, r.inheritsVariables(plan))
 	resp.Diagnostics.Append(populateDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if plan.Affixes.ValueBool() {
+		plan.ServerName = configuredServerName
+	}
+
+	resp.Diagnostics.Append(plan.refreshMetadata(ctx, r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Info(ctx, "created bunkerweb service", map[string]any{"id": service.ID})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The service is saved first, so a failed check taints it.
+	r.verifyHealth(ctx, &resp.Diagnostics, &plan)
 }
 
 func (r *BunkerWebResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -144,6 +374,11 @@ func (r *BunkerWebResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	got, err := r.client.GetService(ctx, state.ID.ValueString())
 	if err != nil {
 		var apiErr *bunkerWebAPIError
@@ -159,21 +394,38 @@ func (r *BunkerWebResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	state.ID = types.StringValue(got.Service)
+	// Imported services have no prior value.
+	if state.Adopt.IsNull() {
+		state.Adopt = types.BoolValue(false)
+	}
+	if state.Protect.IsNull() {
+		state.Protect = types.BoolValue(false)
+	}
+	if state.Drain.IsNull() {
+		state.Drain = types.BoolValue(false)
+	}
+	if state.DrainGrace.IsNull() {
+		state.DrainGrace = types.StringValue("30s")
+	}
 
 	// The API persists only the first token of server_name (unless overridden via
 	// variables), so GET does not round-trip a multi-domain server_name. Preserve
 	// the configured value and only adopt the API's when the identity (the id /
 	// first token) actually changed out-of-band.
-	if firstToken(state.ServerName.ValueString()) != got.Service {
+	if state.Affixes.IsNull() {
+		state.Affixes = types.BoolValue(false)
+	}
+	if firstToken(r.state.remoteServerNames(state.Affixes, state.ServerName.ValueString())) != got.Service {
 		if v, ok := lookupServiceSetting(got.Config, got.Service, "SERVER_NAME"); ok && v != "" {
-			state.ServerName = types.StringValue(v)
+			state.ServerName = types.StringValue(r.state.localServerNames(state.Affixes, v))
 		} else {
-			state.ServerName = types.StringValue(got.Service)
+			state.ServerName = types.StringValue(r.state.localName(state.Affixes, got.Service))
 		}
 	}
 	if v, ok := lookupServiceSetting(got.Config, got.Service, "IS_DRAFT"); ok {
 		state.IsDraft = types.BoolValue(isAffirmative(v))
 	}
+	state.refreshStream(got.Config, got.Service)
 
 	// Refresh only the variables already managed in state. GET /services/{id}
 	// returns the full non-default settings set (including inherited multisite
@@ -183,11 +435,12 @@ func (r *BunkerWebResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	normalize := settingValueNormalizer(ctx, r.client)
 	if len(prior) > 0 {
 		merged := make(map[string]string, len(prior))
 		for k, v := range prior {
 			if apiV, ok := lookupServiceSetting(got.Config, got.Service, k); ok {
-				merged[k] = apiV
+				merged[k] = normalize(k, v, apiV)
 			} else {
 				merged[k] = v
 			}
@@ -200,7 +453,39 @@ func (r *BunkerWebResource) Read(ctx context.Context, req resource.ReadRequest,
 		state.Variables = vars
 	}
 
+	effective, diags := mapFromTerraform(ctx, state.Effective)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(effective) > 0 {
+		for k, v := range effective {
+			if apiV, ok := lookupServiceSetting(got.Config, got.Service, k); ok {
+				effective[k] = normalize(k, v, apiV)
+			}
+		}
+		vars, mapDiags := mapToTerraform(ctx, effective)
+		resp.Diagnostics.Append(mapDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Effective = vars
+	}
+
+	configs, diags := refreshCustomConfigs(ctx, r.client, got.Service, state.Configs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Configs = configs
+
+	resp.Diagnostics.Append(state.refreshMetadata(ctx, r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 func (r *BunkerWebResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -209,40 +494,188 @@ func (r *BunkerWebResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	var plan BunkerWebResourceModel
+	var plan, state BunkerWebResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	variables, diags := mapFromTerraform(ctx, plan.Variables)
+	merged, diags := r.mergedVariables(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	serverName := plan.ServerName.ValueString()
+	// Reset settings that a template provided previously but no longer does.
+	prior, diags := mapFromTerraform(ctx, state.Template)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	priorEffective, diags := mapFromTerraform(ctx, state.Effective)
+	resp.Diagnostics.Append(diags...)
+	priorOwn, diags := mapFromTerraform(ctx, state.Variables)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Likewise for settings removed from variables or from the provider
+	// defaults: a partial patch would otherwise leave them on the service.
+	variables := make(map[string]string, len(merged)+len(prior))
+	for _, previous := range []map[string]string{prior, priorEffective, priorOwn} {
+		for k := range previous {
+			if _, ok := merged[k]; !ok {
+				variables[k] = ""
+			}
+		}
+	}
+	for k, v := range merged {
+		variables[k] = v
+	}
+	// Send only the settings that changed, so large services patch quickly
+	// and settings changed concurrently elsewhere are left alone. Older or
+	// undetected releases replace the variables with the payload, so they get
+	// the full map along with the service's other settings, such as those of
+	// bunkerweb_certificate or bunkerweb_letsencrypt_settings.
+	if r.client.SupportsFeature(featureServiceVariablePatch) {
+		if len(priorEffective) > 0 {
+			variables = changedVariables(priorEffective, variables)
+		}
+	} else {
+		got, err := r.client.GetService(ctx, state.ID.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Unable to Read Service", err, serviceAPIErrorAttributes)
+			return
+		}
+		for k, v := range serviceFromConfig(got.Service, got.Config).Variables {
+			if _, ok := variables[k]; !ok {
+				variables[k] = v
+			}
+		}
+	}
+
+	priorConfigs, diags := customConfigsFromList(ctx, state.Configs)
+	resp.Diagnostics.Append(diags...)
+	plannedConfigs, diags := customConfigsFromList(ctx, plan.Configs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverName := r.state.remoteServerNames(plan.Affixes, plan.ServerName.ValueString())
 	isDraft := plan.IsDraft.ValueBool()
 
-	service, err := r.client.UpdateService(ctx, plan.ID.ValueString(), ServiceUpdateRequest{
+	service, err := r.client.UpdateService(ctx, state.ID.ValueString(), ServiceUpdateRequest{
 		ServerName: &serverName,
 		IsDraft:    &isDraft,
 		Variables:  variables,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Update Service", err.Error())
+		addAPIError(&resp.Diagnostics, "Unable to Update Service", err, serviceAPIErrorAttributes)
+		return
+	}
+	service.Variables = merged
+
+	// A rename moves the service to a new identifier; write every config
+	// under it rather than diffing against the old one.
+	if service.ID != state.ID.ValueString() {
+		priorConfigs = nil
+	}
+	if _, err := applyCustomConfigs(ctx, r.client, service.ID, priorConfigs, plannedConfigs); err != nil {
+		resp.Diagnostics.AddError("Unable to Update Service Custom Configs", err.Error())
 		return
 	}
 
-	populateDiags := plan.populateFromService(ctx, service)
+	configuredServerName := plan.ServerName
+	populateDiags := plan.populateFromService(ctx, service, r.inheritsVariables(plan))
 	resp.Diagnostics.Append(populateDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if plan.Affixes.ValueBool() {
+		plan.ServerName = configuredServerName
+	}
+
+	resp.Diagnostics.Append(plan.refreshMetadata(ctx, r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Info(ctx, "updated bunkerweb service", map[string]any{"id": service.ID})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.verifyHealth(ctx, &resp.Diagnostics, &plan)
+}
+
+func (r *BunkerWebResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BunkerWebResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() || !req.Plan.Raw.Equal(req.State.Raw) {
+		resp.Diagnostics.Append(multisiteMismatch(ctx, r.client, path.Root("server_name"), true, "bunkerweb_service")...)
+	}
+
+	// A server name declared by two resources makes BunkerWeb route requests
+	// unpredictably. Identical duplicates cannot be told apart from a
+	// replacement planned twice; Create reports them as a conflict with the
+	// existing service.
+	if r.client != nil && !plan.ServerName.IsUnknown() && !plan.Affixes.IsUnknown() {
+		if taken := r.state.claimServerNames(strings.Fields(r.state.remoteServerNames(plan.Affixes, plan.ServerName.ValueString())), req.Config.Raw); taken != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("server_name"),
+				"Conflicting Server Name",
+				fmt.Sprintf("Another bunkerweb_service resource in this configuration also declares %s in its server_name. BunkerWeb resolves such conflicts unpredictably, so each server name must belong to a single service.", taken),
+			)
+			return
+		}
+	}
+
+	// A new first server name (or switching apply_name_affixes) renames the
+	// service, so plan the identifier it moves to.
+	if !req.State.Raw.IsNull() && r.client != nil && !plan.ServerName.IsUnknown() && !plan.Affixes.IsUnknown() {
+		id := firstToken(r.state.remoteServerNames(plan.Affixes, plan.ServerName.ValueString()))
+		if id != "" && id != plan.ID.ValueString() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringValue(id))...)
+		}
+	}
+
+	if plan.Template.IsUnknown() || plan.Variables.IsUnknown() || plan.Preset.IsUnknown() {
+		return
+	}
+
+	// Plan the merged variables so template changes (or out-of-band drift on
+	// inherited settings) surface as an update of the dependent service.
+	merged, diags := r.mergedVariables(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	effective, diags := mapToTerraform(ctx, merged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_variables"), effective)...)
 }
 
 func (r *BunkerWebResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -257,16 +690,177 @@ func (r *BunkerWebResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Protect.ValueBool() && !state.IsDraft.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Service Deletion Protected",
+			fmt.Sprintf("Service %q is online and has deletion_protection enabled. Set is_draft = true or deletion_protection = false and apply before destroying it.", state.ID.ValueString()),
+		)
+		return
+	}
+
+	if state.Drain.ValueBool() && !state.IsDraft.ValueBool() {
+		grace, err := time.ParseDuration(state.DrainGrace.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("drain_grace_period"), "Invalid Grace Period", err.Error())
+			return
+		}
+
+		if _, err := r.client.ConvertService(ctx, state.ID.ValueString(), "draft"); err != nil {
+			resp.Diagnostics.AddError("Unable to Drain Service", err.Error())
+			return
+		}
+
+		tflog.Info(ctx, "drained bunkerweb service before deletion", map[string]any{"id": state.ID.ValueString(), "grace_period": grace.String()})
+
+		if err := sleepContext(ctx, grace); err != nil {
+			resp.Diagnostics.AddError("Unable to Drain Service", fmt.Sprintf("Interrupted while waiting for connections to drain; the service is left as a draft: %s", err))
+			return
+		}
+	}
+
+	configs, diags := customConfigsFromList(ctx, state.Configs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, cfg := range configs {
+		if err := deleteCustomConfig(ctx, r.client, state.ID.ValueString(), cfg); err != nil {
+			resp.Diagnostics.AddError("Unable to Delete Service Custom Configs", err.Error())
+			return
+		}
+	}
+
 	if err := r.client.DeleteService(ctx, state.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Unable to Delete Service", err.Error())
+		addAPIError(&resp.Diagnostics, "Unable to Delete Service", err, serviceAPIErrorAttributes)
 	}
 }
 
+func (r *BunkerWebResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(serviceStateMigrations...)
+}
+
 func (r *BunkerWebResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// claimServerNames records that a bunkerweb_service resource with the
+// configuration owner declares names and returns the first one a resource
+// with another configuration already declared, if any, in which case none
+// are recorded. The same configuration may claim its names again, as
+// Terraform plans a resource that must be replaced a second time.
+func (s *providerState) claimServerNames(names []string, owner tftypes.Value) string {
+	s.plannedServerNamesMu.Lock()
+	defer s.plannedServerNamesMu.Unlock()
+	if s.plannedServerNames == nil {
+		s.plannedServerNames = map[string]tftypes.Value{}
+	}
+	for _, name := range names {
+		if claimed, ok := s.plannedServerNames[strings.ToLower(name)]; ok && !claimed.Equal(owner) {
+			return name
+		}
+	}
+	for _, name := range names {
+		s.plannedServerNames[strings.ToLower(name)] = owner
+	}
+	return ""
 }
 
-func (m *BunkerWebResourceModel) populateFromService(ctx context.Context, svc *bunkerWebService) diag.Diagnostics {
+// refreshMetadata sets the read-only method and dates of the service from its
+// GET /services entry.
+func (m *BunkerWebResourceModel) refreshMetadata(ctx context.Context, client BunkerWebAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	summary, err := client.GetServiceSummary(ctx, m.ID.ValueString())
+	if err != nil {
+		diags.AddError("Unable to Read Service", err.Error())
+		return diags
+	}
+	m.Method, m.Created, m.Updated = serviceMetadata(summary)
+	return diags
+}
+
+// serviceMetadata returns the method, creation date and last update of a
+// GET /services entry, null when summary is nil or lacks them.
+func serviceMetadata(summary *bunkerWebService) (method, created, updated types.String) {
+	if summary == nil {
+		return types.StringNull(), types.StringNull(), types.StringNull()
+	}
+	method = types.StringNull()
+	if summary.Method != "" {
+		method = types.StringValue(summary.Method)
+	}
+	return method, unixDate(summary.CreationDate), unixDate(summary.LastUpdate)
+}
+
+func (m *BunkerWebResourceModel) identity() resourceIDIdentityModel {
+	return resourceIDIdentityModel{ID: m.ID}
+}
+
+// mergedVariables layers the provider's default_service_variables, the
+// security preset, the template, the service's own variables and its stream
+// attributes; later layers win.
+func (r *BunkerWebResource) mergedVariables(ctx context.Context, m BunkerWebResourceModel) (map[string]string, diag.Diagnostics) {
+	merged, diags := mergeTemplateVariables(ctx, m.Template, m.Variables)
+	preset, presetDiags := m.securityPresetVariables()
+	diags.Append(presetDiags...)
+	stream := m.streamVariables()
+	if diags.HasError() || ((r.state == nil || len(r.state.defaultServiceVariables) == 0) && preset == nil && stream == nil) {
+		return merged, diags
+	}
+
+	layered := map[string]string{}
+	if r.state != nil {
+		maps.Copy(layered, r.state.defaultServiceVariables)
+	}
+	maps.Copy(layered, preset)
+	maps.Copy(layered, merged)
+	maps.Copy(layered, stream)
+	return layered, diags
+}
+
+// changedVariables returns the planned variables whose value differs from
+// prior, including the resets ("") of removed ones.
+func changedVariables(prior, planned map[string]string) map[string]string {
+	changed := make(map[string]string, len(planned))
+	for k, v := range planned {
+		if old, ok := prior[k]; !ok || old != v {
+			changed[k] = v
+		}
+	}
+	return changed
+}
+
+// patchServiceVariables sets variables on the service, leaving its other
+// variables alone. Releases without partial variable patches replace the
+// service's variables with the payload, so its current ones are read and sent
+// along.
+func patchServiceVariables(ctx context.Context, client BunkerWebAPI, service string, variables map[string]string) error {
+	if !client.SupportsFeature(featureServiceVariablePatch) {
+		got, err := client.GetService(ctx, service)
+		if err != nil {
+			return err
+		}
+		merged := serviceFromConfig(got.Service, got.Config).Variables
+		maps.Copy(merged, variables)
+		variables = merged
+	}
+
+	_, err := client.UpdateService(ctx, service, ServiceUpdateRequest{Variables: variables})
+	return err
+}
+
+// inheritsVariables reports whether the applied variables include keys that
+// do not come from the service's own variables.
+func (r *BunkerWebResource) inheritsVariables(m BunkerWebResourceModel) bool {
+	return !m.Template.IsNull() || !m.Preset.IsNull() || !m.ListenStream.IsNull() || (r.state != nil && len(r.state.defaultServiceVariables) > 0)
+}
+
+func (m *BunkerWebResourceModel) populateFromService(ctx context.Context, svc *bunkerWebService, inherited bool) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	if svc == nil {
@@ -284,7 +878,16 @@ func (m *BunkerWebResourceModel) populateFromService(ctx context.Context, svc *b
 		return diags
 	}
 
-	m.Variables = variables
+	m.Effective = variables
+
+	// With a template or provider defaults, svc.Variables holds the merged
+	// set; keep the configured variables as-is so they do not absorb
+	// inherited keys.
+	if !inherited {
+		m.Variables = variables
+	} else if m.Variables.IsUnknown() {
+		m.Variables = types.MapNull(types.StringType)
+	}
 
 	return diags
 }
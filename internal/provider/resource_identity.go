@@ -0,0 +1,78 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resourceIDIdentityModel is the identity of resources addressed by a single id.
+type resourceIDIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// serviceIdentityModel is the identity of per-service settings resources.
+type serviceIdentityModel struct {
+	Service types.String `tfsdk:"service"`
+}
+
+func resourceIDIdentitySchema(description string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       description,
+			},
+		},
+	}
+}
+
+func serviceIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"service": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Identifier of the service.",
+			},
+		},
+	}
+}
+
+// setResourceIdentity stores the identity when the Terraform client supports
+// resource identities (Terraform 1.12+); older clients pass a nil identity.
+func setResourceIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, value any) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, value)
+}
+
+// importIdentifier returns the import ID, rebuilding the legacy slash-separated
+// form from the identity attributes when importing by identity.
+func importIdentifier(ctx context.Context, req resource.ImportStateRequest, attributes ...string) (string, diag.Diagnostics) {
+	if req.ID != "" || req.Identity == nil {
+		return req.ID, nil
+	}
+
+	var diags diag.Diagnostics
+	parts := make([]string, 0, len(attributes))
+	for _, name := range attributes {
+		var value types.String
+		diags.Append(req.Identity.GetAttribute(ctx, path.Root(name), &value)...)
+		if diags.HasError() {
+			return "", diags
+		}
+		parts = append(parts, value.ValueString())
+	}
+
+	return strings.Join(parts, "/"), diags
+}
@@ -0,0 +1,46 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImportIdentifierFromIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.IdentitySchemaResponse
+	(&BunkerWebConfigResource{}).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &schemaResp)
+
+	identity := &tfsdk.ResourceIdentity{
+		Schema: schemaResp.IdentitySchema,
+		Raw:    tftypes.NewValue(schemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+	if diags := identity.Set(ctx, configIdentityModel{
+		Service: types.StringValue("app.example.com"),
+		Type:    types.StringValue("server_http"),
+		Name:    types.StringValue("access_log"),
+	}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	id, diags := importIdentifier(ctx, resource.ImportStateRequest{Identity: identity}, "service", "type", "name")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if id != "app.example.com/server_http/access_log" {
+		t.Fatalf("unexpected import identifier %q", id)
+	}
+
+	id, diags = importIdentifier(ctx, resource.ImportStateRequest{ID: "global/http/gzip", Identity: identity}, "service", "type", "name")
+	if diags.HasError() || id != "global/http/gzip" {
+		t.Fatalf("expected explicit import ID to take precedence, got %q (%v)", id, diags)
+	}
+}
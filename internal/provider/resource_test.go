@@ -6,9 +6,15 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccBunkerWebResource(t *testing.T) {
@@ -24,6 +30,9 @@ func TestAccBunkerWebResource(t *testing.T) {
 					resource.TestCheckResourceAttr("bunkerweb_service.test", "server_name", "test.example.com"),
 					resource.TestCheckResourceAttr("bunkerweb_service.test", "is_draft", "false"),
 					resource.TestCheckResourceAttr("bunkerweb_service.test", "variables.test", "one"),
+					resource.TestCheckResourceAttr("bunkerweb_service.test", "method", "api"),
+					resource.TestCheckResourceAttrSet("bunkerweb_service.test", "creation_date"),
+					resource.TestCheckResourceAttrSet("bunkerweb_service.test", "last_update"),
 				),
 			},
 			{
@@ -42,6 +51,75 @@ func TestAccBunkerWebResource(t *testing.T) {
 	})
 }
 
+func TestAccBunkerWebResourceRename(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebResourceRenameConfig(fakeAPI.URL(), "old.example.com"),
+				Check:  resource.TestCheckResourceAttr("bunkerweb_service.app", "id", "old.example.com"),
+			},
+			{
+				// The first server_name changes: the service is renamed in
+				// place and its identity follows.
+				Config: testAccBunkerWebResourceRenameConfig(fakeAPI.URL(), "new.example.com"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bunkerweb_service.app", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "id", "new.example.com"),
+					func(*terraform.State) error {
+						if vars := fakeAPI.ServiceVariables("new.example.com"); vars["USE_GZIP"] != "yes" {
+							return fmt.Errorf("expected the renamed service to keep its variables, got %v", vars)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestServiceRenameKeepsIdentityConsistent(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	service := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_service")
+
+	for _, name := range []string{"old.example.com", "new.example.com"} {
+		if errs := service.apply(map[string]tftypes.Value{
+			"server_name": tftypes.NewValue(tftypes.String, name),
+			"variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"USE_GZIP": tftypes.NewValue(tftypes.String, "yes"),
+			}),
+		}); len(errs) > 0 {
+			t.Fatalf("apply %s: %s: %s", name, errs[0].Summary, errs[0].Detail)
+		}
+	}
+	if id := service.attribute("id"); id != "new.example.com" {
+		t.Fatalf("expected the service to be renamed, got %q", id)
+	}
+}
+
+func testAccBunkerWebResourceRenameConfig(endpoint, serverName string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "%s"
+  variables = {
+    USE_GZIP = "yes"
+  }
+}
+`, endpoint, serverName)
+}
+
 // TestAccBunkerWebResourceMultiDomain is a regression test ensuring a multi-domain
 // server_name does not drift on refresh. The API persists only the first token of
 // server_name, so Read must preserve the configured value (issue #19 follow-up).
@@ -69,6 +147,344 @@ func TestAccBunkerWebResourceMultiDomain(t *testing.T) {
 	})
 }
 
+func TestAccBunkerWebResourceAdoptExisting(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddService(bunkerWebService{
+		ID:         "legacy.example.com",
+		ServerName: "legacy.example.com",
+		Variables:  map[string]string{"USE_GZIP": "no"},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebResourceAdoptConfig(fakeAPI.URL(), false),
+				ExpectError: regexp.MustCompile(`already exists`),
+			},
+			{
+				Config: testAccBunkerWebResourceAdoptConfig(fakeAPI.URL(), true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.legacy", "id", "legacy.example.com"),
+					resource.TestCheckResourceAttr("bunkerweb_service.legacy", "variables.USE_GZIP", "yes"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebResourceCustomConfigs(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	expectConfig := func(name, data string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			cfg, ok := fakeAPI.Config("app.example.com", "server_http", name)
+			if !ok {
+				return fmt.Errorf("expected config %s to exist", name)
+			}
+			if cfg.Data != data {
+				return fmt.Errorf("expected config %s data %q, got %q", name, data, cfg.Data)
+			}
+			return nil
+		}
+	}
+	expectNoConfig := func(name string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if _, ok := fakeAPI.Config("app.example.com", "server_http", name); ok {
+				return fmt.Errorf("expected config %s to be deleted", name)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			expectNoConfig("headers"),
+			expectNoConfig("robots"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebResourceCustomConfigsConfig(fakeAPI.URL(), map[string]string{
+					"headers": "add_header X-Test one;",
+					"cache":   "expires 1h;",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "custom_configs.#", "2"),
+					expectConfig("headers", "add_header X-Test one;"),
+					expectConfig("cache", "expires 1h;"),
+				),
+			},
+			{
+				Config: testAccBunkerWebResourceCustomConfigsConfig(fakeAPI.URL(), map[string]string{
+					"headers": "add_header X-Test two;",
+					"robots":  "location = /robots.txt { return 200; }",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "custom_configs.#", "2"),
+					expectConfig("headers", "add_header X-Test two;"),
+					expectConfig("robots", "location = /robots.txt { return 200; }"),
+					expectNoConfig("cache"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebResourceCustomConfigsConfig(endpoint string, configs map[string]string) string {
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var blocks strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&blocks, `
+    {
+      type = "server_http"
+      name = %q
+      data = %q
+    },`, name, configs[name])
+	}
+
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name    = "app.example.com"
+  custom_configs = [%s
+  ]
+}
+`, endpoint, blocks.String())
+}
+
+func TestAccBunkerWebResourceDeletionProtection(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebResourceProtectedConfig(fakeAPI.URL(), true),
+				Check:  resource.TestCheckResourceAttr("bunkerweb_service.prod", "deletion_protection", "true"),
+			},
+			{
+				Config:      testAccBunkerWebResourceProtectedConfig(fakeAPI.URL(), true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Service Deletion Protected`),
+			},
+			{
+				// Lifting the protection makes the final destroy succeed.
+				Config: testAccBunkerWebResourceProtectedConfig(fakeAPI.URL(), false),
+				Check:  resource.TestCheckResourceAttr("bunkerweb_service.prod", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebResourceProtectedConfig(endpoint string, protect bool) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "prod" {
+  server_name         = "prod.example.com"
+  deletion_protection = %t
+}
+`, endpoint, protect)
+}
+
+func TestAccBunkerWebResourceDrainOnDestroy(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			calls := fakeAPI.ConvertCalls()
+			if len(calls) != 1 || calls[0].serviceID != "drain.example.com" || calls[0].target != "draft" {
+				return fmt.Errorf("expected the service to be drained before deletion, got %#v", calls)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "drain" {
+  server_name        = "drain.example.com"
+  drain_on_destroy   = true
+  drain_grace_period = "10ms"
+}
+`, fakeAPI.URL()),
+				Check: resource.TestCheckResourceAttr("bunkerweb_service.drain", "drain_grace_period", "10ms"),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebResourceEquivalentValues(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddPlugin(bunkerWebPlugin{
+		ID:   "gzip",
+		Type: "core",
+		Settings: map[string]bunkerWebPluginSetting{
+			"USE_GZIP":        {ID: "use-gzip", Context: "multisite", Default: "no", Type: "check"},
+			"GZIP_COMP_LEVEL": {ID: "gzip-comp-level", Context: "multisite", Default: "5", Type: "number"},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebResourceEquivalentValuesConfig(fakeAPI.URL()),
+			},
+			{
+				PreConfig: func() {
+					fakeAPI.SetServiceVariable("app.example.com", "USE_GZIP", "yes")
+					fakeAPI.SetServiceVariable("app.example.com", "GZIP_COMP_LEVEL", "6.0")
+				},
+				Config:   testAccBunkerWebResourceEquivalentValuesConfig(fakeAPI.URL()),
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					fakeAPI.SetServiceVariable("app.example.com", "USE_GZIP", "no")
+				},
+				Config:             testAccBunkerWebResourceEquivalentValuesConfig(fakeAPI.URL()),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebResourceProviderDefaultVariables(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebResourceDefaultVariablesConfig(fakeAPI.URL(), `{
+    USE_MODSECURITY = "yes"
+    USE_GZIP        = "no"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "variables.%", "1"),
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "effective_variables.USE_MODSECURITY", "yes"),
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "effective_variables.USE_GZIP", "yes"),
+					func(*terraform.State) error {
+						if got := fakeAPI.ServiceVariables("app.example.com")["USE_MODSECURITY"]; got != "yes" {
+							return fmt.Errorf("expected USE_MODSECURITY=yes on the service, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccBunkerWebResourceDefaultVariablesConfig(fakeAPI.URL(), "{}"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("bunkerweb_service.app", "effective_variables.USE_MODSECURITY"),
+					func(*terraform.State) error {
+						if got, ok := fakeAPI.ServiceVariables("app.example.com")["USE_MODSECURITY"]; !ok || got != "" {
+							return fmt.Errorf("expected USE_MODSECURITY to be reset, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebResourceStream(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebResourceStreamConfig(fakeAPI.URL(), `USE_REVERSE_PROXY = "yes"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.db", "listen_stream", "true"),
+					resource.TestCheckResourceAttr("bunkerweb_service.db", "stream_port", "5432"),
+					resource.TestCheckResourceAttr("bunkerweb_service.db", "variables.%", "1"),
+					resource.TestCheckResourceAttr("bunkerweb_service.db", "effective_variables.SERVER_TYPE", "stream"),
+					func(*terraform.State) error {
+						vars := fakeAPI.ServiceVariables("db.example.com")
+						if vars["LISTEN_STREAM_PORT"] != "5432" || vars["USE_UDP"] != "yes" || vars["USE_TCP"] != "no" {
+							return fmt.Errorf("unexpected stream settings: %v", vars)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccBunkerWebResourceStreamConfig(fakeAPI.URL(), `USE_MODSECURITY = "yes"`),
+				ExpectError: regexp.MustCompile(`HTTP-Only Setting on Stream Service`),
+			},
+			{
+				Config:      testAccBunkerWebResourceStreamConfig(fakeAPI.URL(), `LISTEN_STREAM_PORT = "1337"`),
+				ExpectError: regexp.MustCompile(`Conflicting Stream Setting`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebResourceStreamConfig(endpoint, variable string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "db" {
+  server_name     = "db.example.com"
+  listen_stream   = true
+  stream_port     = 5432
+  stream_protocol = "udp"
+  variables = {
+    %s
+  }
+}
+`, endpoint, variable)
+}
+
+func testAccBunkerWebResourceAdoptConfig(endpoint string, adopt bool) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "legacy" {
+  server_name    = "legacy.example.com"
+  adopt_existing = %t
+  variables = {
+    USE_GZIP = "yes"
+  }
+}
+`, endpoint, adopt)
+}
+
 func testAccBunkerWebResourceMultiDomainConfig(endpoint string) string {
 	return fmt.Sprintf(`
 provider "bunkerweb" {
@@ -97,3 +513,224 @@ resource "bunkerweb_service" "test" {
 }
 `, endpoint, value)
 }
+
+func testAccBunkerWebResourceEquivalentValuesConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+  variables = {
+    USE_GZIP        = "true"
+    GZIP_COMP_LEVEL = "6"
+  }
+}
+`, endpoint)
+}
+
+func testAccBunkerWebResourceDefaultVariablesConfig(endpoint, defaults string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint              = "%s"
+  api_token                 = "test-token"
+  default_service_variables = %s
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+  variables = {
+    USE_GZIP = "yes"
+  }
+}
+`, endpoint, defaults)
+}
+
+func TestAccBunkerWebResourceConflictingServerNames(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "shop" {
+  server_name = "shop.example.com www.example.com"
+}
+
+resource "bunkerweb_service" "www" {
+  server_name = "www.example.com"
+}
+`, fakeAPI.URL()),
+				ExpectError: regexp.MustCompile(`Conflicting Server Name`),
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebResourceVariablePatch(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.SetVersion("1.6.5")
+
+	config := func(antibot string) string {
+		return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "patch" {
+  server_name = "patch.example.com"
+  variables = {
+    USE_GZIP        = "yes"
+    USE_ANTIBOT     = "%s"
+    MAX_CLIENT_SIZE = "10m"
+  }
+}
+`, fakeAPI.URL(), antibot)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{Config: config("no")},
+			{
+				Config: config("captcha"),
+				Check: func(*terraform.State) error {
+					if got := fakeAPI.LastServicePatch(); len(got) != 1 || got["USE_ANTIBOT"] != "captcha" {
+						return fmt.Errorf("expected only USE_ANTIBOT to be sent, got %v", got)
+					}
+					return nil
+				},
+			},
+			{
+				// A removed variable is reset rather than left on the service.
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "patch" {
+  server_name = "patch.example.com"
+  variables = {
+    USE_GZIP    = "yes"
+    USE_ANTIBOT = "captcha"
+  }
+}
+`, fakeAPI.URL()),
+				Check: func(*terraform.State) error {
+					if got := fakeAPI.LastServicePatch(); len(got) != 1 || got["MAX_CLIENT_SIZE"] != "" {
+						return fmt.Errorf("expected only MAX_CLIENT_SIZE to be reset, got %v", got)
+					}
+					if _, ok := fakeAPI.ServiceVariables("patch.example.com")["MAX_CLIENT_SIZE"]; ok {
+						return fmt.Errorf("expected MAX_CLIENT_SIZE to be removed from the service")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccBunkerWebResourceVariablePatchUnknownVersion(t *testing.T) {
+	// Without a detected version the API may replace the variables with
+	// the payload, so the full map is sent.
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	config := func(antibot string) string {
+		return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "patch" {
+  server_name = "patch.example.com"
+  variables = {
+    USE_GZIP        = "yes"
+    USE_ANTIBOT     = "%s"
+    MAX_CLIENT_SIZE = "10m"
+  }
+}
+`, fakeAPI.URL(), antibot)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{Config: config("no")},
+			{
+				Config: config("captcha"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(*terraform.State) error {
+						want := map[string]string{"USE_GZIP": "yes", "USE_ANTIBOT": "captcha", "MAX_CLIENT_SIZE": "10m"}
+						if got := fakeAPI.LastServicePatch(); fmt.Sprint(got) != fmt.Sprint(want) {
+							return fmt.Errorf("expected the full variable map to be sent, got %v", got)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr("bunkerweb_service.patch", "variables.USE_GZIP", "yes"),
+					resource.TestCheckResourceAttr("bunkerweb_service.patch", "variables.MAX_CLIENT_SIZE", "10m"),
+				),
+			},
+		},
+	})
+}
+
+func TestChangedVariables(t *testing.T) {
+	prior := map[string]string{"USE_GZIP": "yes", "USE_ANTIBOT": "no", "OLD": "x"}
+	planned := map[string]string{"USE_GZIP": "yes", "USE_ANTIBOT": "captcha", "OLD": "", "NEW": "1"}
+
+	got := changedVariables(prior, planned)
+	want := map[string]string{"USE_ANTIBOT": "captcha", "OLD": "", "NEW": "1"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("changedVariables = %v, want %v", got, want)
+	}
+}
+
+func TestProviderStateClaimServerNames(t *testing.T) {
+	state := &providerState{}
+	shop := tftypes.NewValue(tftypes.String, "shop")
+	api := tftypes.NewValue(tftypes.String, "api")
+	if taken := state.claimServerNames([]string{"shop.example.com", "www.example.com"}, shop); taken != "" {
+		t.Fatalf("expected the first claim to succeed, got conflict on %s", taken)
+	}
+	if taken := state.claimServerNames([]string{"shop.example.com", "www.example.com"}, shop); taken != "" {
+		t.Fatalf("expected the same configuration to claim its names again, got conflict on %s", taken)
+	}
+	if taken := state.claimServerNames([]string{"api.example.com", "WWW.example.com"}, api); taken != "WWW.example.com" {
+		t.Fatalf("expected a conflict on WWW.example.com, got %q", taken)
+	}
+	if taken := state.claimServerNames([]string{"api.example.com"}, api); taken != "" {
+		t.Fatalf("expected a rejected claim to record nothing, got conflict on %s", taken)
+	}
+}
+
+func TestServiceResourceReplace(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	service := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_service")
+
+	config := map[string]tftypes.Value{
+		"server_name": tftypes.NewValue(tftypes.String, "app.example.com www.example.com"),
+	}
+	if errs := service.apply(config); len(errs) > 0 {
+		t.Fatalf("create: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+	if errs := service.replace(config); len(errs) > 0 {
+		t.Fatalf("replace: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+	if id := service.attribute("id"); id != "app.example.com" {
+		t.Fatalf("expected the service to be recreated, got %q", id)
+	}
+}
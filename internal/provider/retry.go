@@ -0,0 +1,195 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultRetryBackoff = time.Second
+	maxRetryBackoff     = 30 * time.Second
+)
+
+// retryPolicy controls how often a request failing with a transient error is
+// sent again. The zero value never retries.
+type retryPolicy struct {
+	// Count is the number of retries after the first attempt.
+	Count int
+	// Backoff is the pause before the first retry; it doubles for each later
+	// one, up to maxRetryBackoff.
+	Backoff time.Duration
+}
+
+// retriesModel is the retries attribute of the provider and of resources.
+type retriesModel struct {
+	Count   types.Int64  `tfsdk:"count"`
+	Backoff types.String `tfsdk:"backoff"`
+}
+
+// retriesAttrTypes are the attribute types of retriesModel, for the null
+// retries of imported resources.
+var retriesAttrTypes = map[string]attr.Type{
+	"count":   types.Int64Type,
+	"backoff": types.StringType,
+}
+
+// retriesResourceAttribute is the retries attribute shared by resources.
+func retriesResourceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Overrides the provider `retries` for the API requests of this resource; unset fields keep the provider's value. For example, retry reloads aggressively but never retry a write that is not safe to repeat.",
+		Attributes: map[string]schema.Attribute{
+			"count": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of retries after the first attempt. `0` disables retries.",
+			},
+			"backoff": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Pause before the first retry as a Go duration, doubled for each later retry.",
+			},
+		},
+	}
+}
+
+type retryPolicyContextKey struct{}
+
+// retryPolicyFromTerraform overrides base with the fields set in value, a
+// retries object configured at attr.
+func retryPolicyFromTerraform(ctx context.Context, attr path.Path, value types.Object, base retryPolicy) (retryPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return base, diags
+	}
+
+	var m retriesModel
+	diags.Append(value.As(ctx, &m, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return base, diags
+	}
+
+	policy := base
+	if !m.Count.IsNull() && !m.Count.IsUnknown() {
+		if m.Count.ValueInt64() < 0 {
+			diags.AddAttributeError(attr.AtName("count"), "Invalid Retry Count", "`count` cannot be negative; use 0 to disable retries.")
+		}
+		policy.Count = int(m.Count.ValueInt64())
+	}
+	if !m.Backoff.IsNull() && !m.Backoff.IsUnknown() {
+		backoff, err := time.ParseDuration(m.Backoff.ValueString())
+		if err != nil || backoff <= 0 {
+			diags.AddAttributeError(attr.AtName("backoff"), "Invalid Retry Backoff", fmt.Sprintf("Expected a positive duration such as `2s`, got %q.", m.Backoff.ValueString()))
+		}
+		policy.Backoff = backoff
+	}
+	return policy, diags
+}
+
+// withRetries returns a context whose requests follow the retries attribute of
+// a resource instead of the provider's retry policy.
+func (s *providerState) withRetries(ctx context.Context, diags *diag.Diagnostics, value types.Object) context.Context {
+	if value.IsNull() || value.IsUnknown() {
+		return ctx
+	}
+	policy, policyDiags := retryPolicyFromTerraform(ctx, path.Root("retries"), value, s.retries)
+	diags.Append(policyDiags...)
+	if policyDiags.HasError() {
+		return ctx
+	}
+	return context.WithValue(ctx, retryPolicyContextKey{}, policy)
+}
+
+// retryPolicy returns the retry policy for requests made with ctx.
+func (c *bunkerWebClient) retryPolicy(ctx context.Context) retryPolicy {
+	if policy, ok := ctx.Value(retryPolicyContextKey{}).(retryPolicy); ok {
+		return policy
+	}
+	return c.retries
+}
+
+// delay returns the pause before retry number attempt, counted from 1.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	delay := p.Backoff
+	if delay <= 0 {
+		delay = defaultRetryBackoff
+	}
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
+
+// isRetryableResponse reports whether a request failing with err, or answered
+// with resp, may succeed if sent again: connection errors, rate limiting and
+// the gateway errors the circuit breaker counts. Requests refused by an open
+// breaker are not retried.
+func isRetryableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, errAPIUnavailable) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// replayRequest returns a copy of req to send again, or nil when its body
+// cannot be replayed.
+func replayRequest(req *http.Request) *http.Request {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil
+		}
+		retry.Body = body
+	}
+	return retry
+}
+
+// send executes req, retrying transient failures according to the retry
+// policy of ctx. Writes keep their idempotency key across attempts, so API
+// versions that support it apply them once.
+func (c *bunkerWebClient) send(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	policy := c.retryPolicy(ctx)
+	for attempt := 1; ; attempt++ {
+		resp, body, err := c.sendOnce(ctx, req)
+		if attempt > policy.Count || !isRetryableResponse(resp, err) {
+			return resp, body, err
+		}
+		retry := replayRequest(req)
+		if retry == nil {
+			return resp, body, err
+		}
+
+		delay := policy.delay(attempt)
+		tflog.Debug(ctx, "retrying bunkerweb api request", map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt,
+			"delay":   delay.String(),
+		})
+		if sleepContext(ctx, delay) != nil {
+			return resp, body, err
+		}
+		c.stats.recordRetry()
+		req = retry
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBunkerWebClientRetries(t *testing.T) {
+	var mu sync.Mutex
+	var statuses []int
+	var bodies, keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		status := http.StatusOK
+		if len(statuses) > 0 {
+			status, statuses = statuses[0], statuses[1:]
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"status":"success","message":"ok"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newBunkerWebClient(server.URL, nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.retries = retryPolicy{Count: 2, Backoff: time.Millisecond}
+	ctx := context.Background()
+
+	reset := func(next ...int) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses, bodies, keys = next, nil, nil
+	}
+
+	reset(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	if err := client.Ban(ctx, BanRequest{IP: "192.0.2.1"}); err != nil {
+		t.Fatalf("expected the ban to succeed on the third attempt, got %v", err)
+	}
+	if len(bodies) != 3 || bodies[0] != bodies[2] || bodies[2] == "" || keys[0] != keys[2] {
+		t.Fatalf("expected three identical attempts with one idempotency key, got bodies %q and keys %q", bodies, keys)
+	}
+
+	reset(http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	var apiErr *bunkerWebAPIError
+	if err := client.Ban(ctx, BanRequest{IP: "192.0.2.1"}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected the last error once retries are exhausted, got %v", err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("expected three attempts, got %d", len(bodies))
+	}
+
+	reset(http.StatusBadRequest)
+	if err := client.Ban(ctx, BanRequest{IP: "192.0.2.1"}); err == nil {
+		t.Fatal("expected the client error")
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("expected client errors not to be retried, got %d attempts", len(bodies))
+	}
+
+	var diags diag.Diagnostics
+	noRetries := newProviderState(client).withRetries(ctx, &diags, retriesObject(t, types.Int64Value(0), types.StringNull()))
+	if diags.HasError() {
+		t.Fatalf("withRetries: %v", diags)
+	}
+	reset(http.StatusServiceUnavailable)
+	if err := client.Ban(noRetries, BanRequest{IP: "192.0.2.1"}); err == nil {
+		t.Fatal("expected the resource override to disable retries")
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("expected a single attempt, got %d", len(bodies))
+	}
+}
+
+func TestRetryPolicyFromTerraform(t *testing.T) {
+	base := retryPolicy{Count: 1, Backoff: time.Second}
+	ctx := context.Background()
+
+	policy, diags := retryPolicyFromTerraform(ctx, path.Root("retries"), retriesObject(t, types.Int64Null(), types.StringValue("250ms")), base)
+	if diags.HasError() || policy.Count != 1 || policy.Backoff != 250*time.Millisecond {
+		t.Fatalf("expected count to be inherited, got %+v (%v)", policy, diags)
+	}
+
+	for _, value := range []types.Object{
+		retriesObject(t, types.Int64Value(-1), types.StringNull()),
+		retriesObject(t, types.Int64Null(), types.StringValue("soon")),
+		retriesObject(t, types.Int64Null(), types.StringValue("0s")),
+	} {
+		if _, diags := retryPolicyFromTerraform(ctx, path.Root("retries"), value, base); !diags.HasError() {
+			t.Errorf("expected %s to be rejected", value)
+		}
+	}
+
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: maxRetryBackoff} {
+		if got := base.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
+
+func TestImportedResourcesHaveNullRetries(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddBan(bunkerWebBan{IP: "192.0.2.1", Reason: "manual", Exp: 3600})
+
+	for typeName, id := range map[string]string{
+		"bunkerweb_ban":                   "192.0.2.1",
+		"bunkerweb_ban_exemption":         "192.0.2.0/24",
+		"bunkerweb_certificate":           "app.example.com",
+		"bunkerweb_config":                "http/foo",
+		"bunkerweb_letsencrypt_settings":  "app.example.com",
+		"bunkerweb_plugin":                "demo",
+		"bunkerweb_plugin_repository":     "https://example.com/plugins.zip",
+		"bunkerweb_global_config_setting": "USE_GZIP",
+	} {
+		r := newProtocolResource(t, fakeAPI.URL(), typeName)
+		resp, err := r.server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{TypeName: typeName, ID: id})
+		if err != nil {
+			t.Fatalf("%s: ImportResourceState: %v", typeName, err)
+		}
+		protocolDiagnostics(t, typeName+" ImportResourceState", resp.Diagnostics)
+
+		value, err := resp.ImportedResources[0].State.Unmarshal(r.objType)
+		if err != nil {
+			t.Fatalf("%s: Unmarshal: %v", typeName, err)
+		}
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil || !attributes["retries"].IsNull() {
+			t.Errorf("%s: expected null retries after import, got %v", typeName, attributes["retries"])
+		}
+	}
+}
+
+func TestAccBunkerWebBanResourceRetries(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebBanResourceRetriesConfig(fakeAPI.URL(), 0),
+				Check:  resource.TestCheckResourceAttr("bunkerweb_ban.block", "retries.count", "0"),
+			},
+			{
+				// Only retries changes, so the ban is updated in place.
+				Config: testAccBunkerWebBanResourceRetriesConfig(fakeAPI.URL(), 3),
+				Check:  resource.TestCheckResourceAttr("bunkerweb_ban.block", "retries.count", "3"),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebBanResourceRetriesConfig(endpoint string, count int) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+
+  retries = {
+    count   = 2
+    backoff = "10ms"
+  }
+}
+
+resource "bunkerweb_ban" "block" {
+  ip = "192.0.2.20"
+
+  retries = {
+    count = %d
+  }
+}
+`, endpoint, count)
+}
+
+func retriesObject(t *testing.T, count types.Int64, backoff types.String) types.Object {
+	t.Helper()
+	value, diags := types.ObjectValue(map[string]attr.Type{"count": types.Int64Type, "backoff": types.StringType}, map[string]attr.Value{"count": count, "backoff": backoff})
+	if diags.HasError() {
+		t.Fatalf("ObjectValue: %v", diags)
+	}
+	return value
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -15,17 +17,25 @@ import (
 )
 
 var _ ephemeral.EphemeralResource = &BunkerWebServiceConvertEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &BunkerWebServiceConvertEphemeralResource{}
 
-// BunkerWebServiceConvertEphemeralResource switches a service between draft and online states.
+// BunkerWebServiceConvertEphemeralResource switches services between draft and online states.
 type BunkerWebServiceConvertEphemeralResource struct {
-	client *bunkerWebClient
+	client BunkerWebAPI
 }
 
 // BunkerWebServiceConvertModel captures Terraform-side shape.
 type BunkerWebServiceConvertModel struct {
-	ServiceID types.String `tfsdk:"service_id"`
-	ConvertTo types.String `tfsdk:"convert_to"`
-	IsDraft   types.Bool   `tfsdk:"is_draft"`
+	ServiceID  types.String `tfsdk:"service_id"`
+	ServiceIDs types.List   `tfsdk:"service_ids"`
+	ConvertTo  types.String `tfsdk:"convert_to"`
+	IsDraft    types.Bool   `tfsdk:"is_draft"`
+	Results    types.List   `tfsdk:"results"`
+}
+
+var serviceConvertResultAttrTypes = map[string]attr.Type{
+	"service_id": types.StringType,
+	"is_draft":   types.BoolType,
 }
 
 func NewBunkerWebServiceConvertEphemeralResource() ephemeral.EphemeralResource {
@@ -38,11 +48,16 @@ func (r *BunkerWebServiceConvertEphemeralResource) Metadata(_ context.Context, r
 
 func (r *BunkerWebServiceConvertEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Converts a BunkerWeb service between online and draft states using the `/services/{service}/convert` endpoint.",
+		MarkdownDescription: "Converts BunkerWeb services between online and draft states using the `/services/{service}/convert` endpoint.",
 		Attributes: map[string]schema.Attribute{
 			"service_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Identifier of the service to convert.",
+				Optional:            true,
+				MarkdownDescription: "Identifier of the service to convert. Conflicts with `service_ids`.",
+			},
+			"service_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Identifiers of several services to convert to the same state. Every service is attempted; the open fails afterwards if any conversion failed. Conflicts with `service_id`.",
 			},
 			"convert_to": schema.StringAttribute{
 				Required:            true,
@@ -50,7 +65,23 @@ func (r *BunkerWebServiceConvertEphemeralResource) Schema(_ context.Context, _ e
 			},
 			"is_draft": schema.BoolAttribute{
 				Computed:            true,
-				MarkdownDescription: "Draft flag returned by the API after conversion.",
+				MarkdownDescription: "Draft flag returned by the API after conversion; with `service_ids`, true when every service is now a draft.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Per-service outcome, in the order the services were given.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"service_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Identifier of the converted service.",
+						},
+						"is_draft": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Draft flag returned by the API for this service.",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -61,16 +92,16 @@ func (r *BunkerWebServiceConvertEphemeralResource) Configure(_ context.Context,
 		return
 	}
 
-	client, ok := req.ProviderData.(*bunkerWebClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Ephemeral Resource Configure Type",
-			fmt.Sprintf("Expected *bunkerWebClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *BunkerWebServiceConvertEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
@@ -85,8 +116,9 @@ func (r *BunkerWebServiceConvertEphemeralResource) Open(ctx context.Context, req
 		return
 	}
 
-	if data.ServiceID.IsNull() || data.ServiceID.IsUnknown() || strings.TrimSpace(data.ServiceID.ValueString()) == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("service_id"), "Missing Service ID", "Provide a valid service identifier to convert.")
+	serviceIDs, diags := data.serviceIDs(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -101,16 +133,94 @@ func (r *BunkerWebServiceConvertEphemeralResource) Open(ctx context.Context, req
 		return
 	}
 
-	service, err := r.client.ConvertService(ctx, data.ServiceID.ValueString(), target)
-	if err != nil {
-		resp.Diagnostics.AddError("Convert Service", err.Error())
+	// Convert every service before reporting failures, so one bad vhost
+	// does not leave the rest of a publish or rollback undone.
+	allDraft := true
+	results := make([]attr.Value, 0, len(serviceIDs))
+	var failures []string
+	for _, id := range serviceIDs {
+		service, err := r.client.ConvertService(ctx, id, target)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", id, err))
+			continue
+		}
+		allDraft = allDraft && service.IsDraft
+		result, diags := types.ObjectValue(serviceConvertResultAttrTypes, map[string]attr.Value{
+			"service_id": types.StringValue(id),
+			"is_draft":   types.BoolValue(service.IsDraft),
+		})
+		resp.Diagnostics.Append(diags...)
+		results = append(results, result)
+	}
+	if len(failures) > 0 {
+		resp.Diagnostics.AddError(
+			"Convert Service",
+			fmt.Sprintf("Converted %d of %d services to %s. Failed:\n- %s", len(results), len(serviceIDs), target, strings.Join(failures, "\n- ")),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resultsValue, diags := types.ListValue(types.ObjectType{AttrTypes: serviceConvertResultAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.IsDraft = types.BoolValue(service.IsDraft)
+	data.IsDraft = types.BoolValue(allDraft)
+	data.Results = resultsValue
 	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
 }
 
+// ValidateConfig requires exactly one of service_id and service_ids.
+func (r *BunkerWebServiceConvertEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data BunkerWebServiceConvertModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ServiceID.IsNull() && !data.ServiceIDs.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("service_ids"), "Conflicting Service IDs", "Set either `service_id` or `service_ids`, not both.")
+		return
+	}
+	if data.ServiceID.IsNull() && data.ServiceIDs.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("service_id"), "Missing Service ID", "Set `service_id` or `service_ids` to the services to convert.")
+	}
+}
+
+// serviceIDs returns the services to convert, rejecting empty identifiers.
+func (m BunkerWebServiceConvertModel) serviceIDs(ctx context.Context) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !m.ServiceIDs.IsNull() {
+		ids, listDiags := listToStrings(ctx, m.ServiceIDs)
+		diags.Append(listDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if len(ids) == 0 {
+			diags.AddAttributeError(path.Root("service_ids"), "Missing Service ID", "Provide at least one service identifier to convert.")
+			return nil, diags
+		}
+		for i, id := range ids {
+			if strings.TrimSpace(id) == "" {
+				diags.AddAttributeError(path.Root("service_ids").AtListIndex(i), "Missing Service ID", "Service identifiers must not be empty.")
+			}
+		}
+		return ids, diags
+	}
+
+	if m.ServiceID.IsNull() || m.ServiceID.IsUnknown() || strings.TrimSpace(m.ServiceID.ValueString()) == "" {
+		diags.AddAttributeError(path.Root("service_id"), "Missing Service ID", "Provide a valid service identifier to convert.")
+		return nil, diags
+	}
+	return []string{m.ServiceID.ValueString()}, diags
+}
+
 func (r *BunkerWebServiceConvertEphemeralResource) Close(context.Context, ephemeral.CloseRequest, *ephemeral.CloseResponse) {
 	// No clean-up.
 }
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -57,3 +58,72 @@ ephemeral "bunkerweb_service_convert" "convert" {
 }
 `, endpoint)
 }
+
+func TestAccBunkerWebServiceConvertEphemeralResourceMultiple(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccBunkerWebServiceConvertEphemeralResourceMultipleConfig(fakeAPI.URL(), "bunkerweb_service.app.id, bunkerweb_service.api.id"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testAccBunkerWebServiceConvertEphemeralResourceMultipleConfig(fakeAPI.URL(), `bunkerweb_service.app.id, "missing.example.com"`),
+				ExpectError: regexp.MustCompile(`Converted 1 of 2 services to draft`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+ephemeral "bunkerweb_service_convert" "convert" {
+  service_id  = "app.example.com"
+  service_ids = ["api.example.com"]
+  convert_to  = "draft"
+}
+`, fakeAPI.URL()),
+				ExpectError: regexp.MustCompile(`Conflicting Service IDs`),
+			},
+		},
+	})
+
+	converted := map[string]bool{}
+	for _, call := range fakeAPI.ConvertCalls() {
+		converted[call.serviceID] = true
+	}
+	for _, id := range []string{"app.example.com", "api.example.com"} {
+		if !converted[id] {
+			t.Fatalf("expected %s to be converted, got %v", id, converted)
+		}
+	}
+}
+
+func testAccBunkerWebServiceConvertEphemeralResourceMultipleConfig(endpoint, ids string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+}
+
+resource "bunkerweb_service" "api" {
+  server_name = "api.example.com"
+}
+
+ephemeral "bunkerweb_service_convert" "convert" {
+  service_ids = [%s]
+  convert_to  = "draft"
+}
+`, endpoint, ids)
+}
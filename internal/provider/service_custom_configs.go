@@ -0,0 +1,155 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// serviceCustomConfigModel is one entry of bunkerweb_service.custom_configs.
+type serviceCustomConfigModel struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+	Data types.String `tfsdk:"data"`
+}
+
+var serviceCustomConfigAttrTypes = map[string]attr.Type{
+	"type": types.StringType,
+	"name": types.StringType,
+	"data": types.StringType,
+}
+
+func (m serviceCustomConfigModel) key() string {
+	return normalizeConfigType(m.Type.ValueString()) + "/" + m.Name.ValueString()
+}
+
+func (m serviceCustomConfigModel) configKey(service string) ConfigKey {
+	return ConfigKey{Service: stringPointer(service), Type: m.Type.ValueString(), Name: m.Name.ValueString()}
+}
+
+// customConfigsFromList decodes custom_configs, rejecting entries that target
+// the same type/name twice.
+func customConfigsFromList(ctx context.Context, list types.List) ([]serviceCustomConfigModel, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	var configs []serviceCustomConfigModel
+	diags := list.ElementsAs(ctx, &configs, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	seen := make(map[string]struct{}, len(configs))
+	for idx, cfg := range configs {
+		if _, ok := seen[cfg.key()]; ok {
+			diags.AddAttributeError(
+				path.Root("custom_configs").AtListIndex(idx),
+				"Duplicate Custom Config",
+				fmt.Sprintf("A custom config of type %q named %q is declared more than once.", cfg.Type.ValueString(), cfg.Name.ValueString()),
+			)
+		}
+		seen[cfg.key()] = struct{}{}
+	}
+
+	return configs, diags
+}
+
+// applyCustomConfigs converges the service's custom configs from prior to
+// planned: new entries are created (or overwritten when they already exist),
+// changed ones updated and removed ones deleted. It returns the configs it
+// created, even on failure, so a caller rolling back leaves the overwritten
+// ones in place.
+func applyCustomConfigs(ctx context.Context, client BunkerWebAPI, service string, prior, planned []serviceCustomConfigModel) ([]serviceCustomConfigModel, error) {
+	var created []serviceCustomConfigModel
+	previous := make(map[string]serviceCustomConfigModel, len(prior))
+	for _, cfg := range prior {
+		previous[cfg.key()] = cfg
+	}
+
+	for _, cfg := range planned {
+		data := cfg.Data.ValueString()
+		old, existed := previous[cfg.key()]
+		delete(previous, cfg.key())
+
+		switch {
+		case !existed:
+			_, err := client.CreateConfig(withIdempotencyKey(ctx), ConfigCreateRequest{
+				Service: stringPointer(service),
+				Type:    cfg.Type.ValueString(),
+				Name:    cfg.Name.ValueString(),
+				Data:    data,
+			})
+			if isConflict(err) {
+				_, err = client.UpdateConfig(ctx, cfg.configKey(service), ConfigUpdateRequest{Data: &data})
+			} else if err == nil {
+				created = append(created, cfg)
+			}
+			if err != nil {
+				return created, fmt.Errorf("create custom config %s: %w", cfg.key(), err)
+			}
+		case old.Data.ValueString() != data:
+			if _, err := client.UpdateConfig(ctx, cfg.configKey(service), ConfigUpdateRequest{Data: &data}); err != nil {
+				return created, fmt.Errorf("update custom config %s: %w", cfg.key(), err)
+			}
+		}
+	}
+
+	for _, cfg := range previous {
+		if err := deleteCustomConfig(ctx, client, service, cfg); err != nil {
+			return created, err
+		}
+	}
+
+	tflog.Debug(ctx, "applied bunkerweb service custom configs", map[string]any{"service": service, "count": len(planned)})
+
+	return created, nil
+}
+
+func deleteCustomConfig(ctx context.Context, client BunkerWebAPI, service string, cfg serviceCustomConfigModel) error {
+	err := client.DeleteConfig(ctx, cfg.configKey(service))
+	var apiErr *bunkerWebAPIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		return fmt.Errorf("delete custom config %s: %w", cfg.key(), err)
+	}
+	return nil
+}
+
+// refreshCustomConfigs re-reads the content of the managed custom configs,
+// dropping those deleted out-of-band so the next plan recreates them.
+func refreshCustomConfigs(ctx context.Context, client BunkerWebAPI, service string, list types.List) (types.List, diag.Diagnostics) {
+	configs, diags := customConfigsFromList(ctx, list)
+	if diags.HasError() || configs == nil {
+		return list, diags
+	}
+
+	refreshed := make([]serviceCustomConfigModel, 0, len(configs))
+	for _, cfg := range configs {
+		got, err := client.GetConfig(ctx, cfg.configKey(service), true)
+		if err != nil {
+			var apiErr *bunkerWebAPIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			diags.AddError("Unable to Read Custom Config", err.Error())
+			return list, diags
+		}
+
+		cfg.Data = types.StringValue(got.Data)
+		refreshed = append(refreshed, cfg)
+	}
+
+	value, listDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: serviceCustomConfigAttrTypes}, refreshed)
+	diags.Append(listDiags...)
+	return value, diags
+}
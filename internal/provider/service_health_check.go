@@ -0,0 +1,207 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultHealthCheckTimeout = 2 * time.Minute
+	// healthProbeTimeout bounds a single probe; failed probes are repeated
+	// until the health check timeout.
+	healthProbeTimeout = 10 * time.Second
+)
+
+// serviceHealthCheckModel is the health_check attribute of bunkerweb_service.
+type serviceHealthCheckModel struct {
+	Instance       types.String `tfsdk:"instance"`
+	URL            types.String `tfsdk:"url"`
+	Port           types.Int64  `tfsdk:"port"`
+	ExpectedStatus types.Int64  `tfsdk:"expected_status"`
+	Timeout        types.String `tfsdk:"timeout"`
+}
+
+// serviceHealthCheck is a parsed health_check attribute.
+type serviceHealthCheck struct {
+	instance       string
+	url            *url.URL
+	port           string
+	expectedStatus int
+	timeout        time.Duration
+}
+
+func serviceHealthCheckAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Verifies the service after each create and update: the designated instance is reloaded, then `url` is probed through it " +
+			"until it answers with `expected_status`. The apply fails when it does not within `timeout`; a new service is then tainted. Skipped for drafts.",
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Hostname of the BunkerWeb instance to reload and send the probes to.",
+			},
+			"url": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "URL to probe. Its host is sent as the `Host` header and TLS server name while the connection goes to `instance`. " +
+					"Defaults to `http://<first server name>/`.",
+			},
+			"port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Port of `instance` to connect to. Defaults to the port of `url`.",
+			},
+			"expected_status": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "HTTP status a healthy service answers with. Redirects are not followed. Defaults to `200`.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to keep probing before failing, as a Go duration. Defaults to `2m`.",
+			},
+		},
+	}
+}
+
+// healthCheck parses the health_check attribute of m, or returns nil when it
+// is not set. serverName is the first server name of the service in BunkerWeb.
+func (m *BunkerWebResourceModel) healthCheck(ctx context.Context, serverName string) (*serviceHealthCheck, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m.HealthCheck.IsNull() || m.HealthCheck.IsUnknown() {
+		return nil, diags
+	}
+
+	var hc serviceHealthCheckModel
+	diags.Append(m.HealthCheck.As(ctx, &hc, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	attr := path.Root("health_check")
+	check := &serviceHealthCheck{
+		instance:       hc.Instance.ValueString(),
+		expectedStatus: http.StatusOK,
+		timeout:        defaultHealthCheckTimeout,
+	}
+	if !hc.Instance.IsUnknown() && check.instance == "" {
+		diags.AddAttributeError(attr.AtName("instance"), "Invalid Health Check Instance", "`instance` cannot be empty.")
+	}
+
+	rawURL := "http://" + serverName + "/"
+	if !hc.URL.IsNull() && !hc.URL.IsUnknown() {
+		rawURL = hc.URL.ValueString()
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		diags.AddAttributeError(attr.AtName("url"), "Invalid Health Check URL", fmt.Sprintf("Expected an absolute http or https URL, got %q.", rawURL))
+	} else {
+		check.url = parsed
+		check.port = parsed.Port()
+		if check.port == "" {
+			check.port = map[string]string{"http": "80", "https": "443"}[parsed.Scheme]
+		}
+	}
+
+	if !hc.Port.IsNull() && !hc.Port.IsUnknown() {
+		if port := hc.Port.ValueInt64(); port < 1 || port > 65535 {
+			diags.AddAttributeError(attr.AtName("port"), "Invalid Health Check Port", fmt.Sprintf("Expected a port between 1 and 65535, got %d.", port))
+		}
+		check.port = strconv.FormatInt(hc.Port.ValueInt64(), 10)
+	}
+
+	if !hc.ExpectedStatus.IsNull() && !hc.ExpectedStatus.IsUnknown() {
+		check.expectedStatus = int(hc.ExpectedStatus.ValueInt64())
+		if check.expectedStatus < 100 || check.expectedStatus > 599 {
+			diags.AddAttributeError(attr.AtName("expected_status"), "Invalid Expected Status", fmt.Sprintf("Expected an HTTP status code, got %d.", check.expectedStatus))
+		}
+	}
+
+	if !hc.Timeout.IsNull() && !hc.Timeout.IsUnknown() {
+		check.timeout, err = time.ParseDuration(hc.Timeout.ValueString())
+		if err != nil || check.timeout <= 0 {
+			diags.AddAttributeError(attr.AtName("timeout"), "Invalid Health Check Timeout", fmt.Sprintf("Expected a positive duration such as `2m`, got %q.", hc.Timeout.ValueString()))
+		}
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+	return check, diags
+}
+
+// verifyHealth runs the health check of m, if any, against the service
+// described by m as it was just written, and reports a failure in diags.
+func (r *BunkerWebResource) verifyHealth(ctx context.Context, diags *diag.Diagnostics, m *BunkerWebResourceModel) {
+	if m.IsDraft.ValueBool() {
+		return
+	}
+	check, checkDiags := m.healthCheck(ctx, firstToken(r.state.remoteServerNames(m.Affixes, m.ServerName.ValueString())))
+	diags.Append(checkDiags...)
+	if check == nil {
+		return
+	}
+
+	if _, err := r.client.ReloadInstance(ctx, check.instance, nil); err != nil {
+		diags.AddError("Service Health Check Failed", fmt.Sprintf("Unable to reload instance %s before probing the service: %s", check.instance, err))
+		return
+	}
+
+	if err := check.wait(ctx); err != nil {
+		diags.AddError("Service Health Check Failed", fmt.Sprintf("Service %q does not serve %s through instance %s: %s", m.ID.ValueString(), check.url, check.instance, err))
+	}
+}
+
+// wait probes the service until it answers with the expected status or the
+// timeout elapses.
+func (c *serviceHealthCheck) wait(ctx context.Context) error {
+	address := net.JoinHostPort(c.instance, c.port)
+	dialer := &net.Dialer{Timeout: healthProbeTimeout}
+	client := &http.Client{
+		Timeout: healthProbeTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+			TLSClientConfig: &tls.Config{ServerName: c.url.Hostname(), MinVersion: tls.VersionTLS12},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	probes := waiter{Timeout: c.timeout, Delay: time.Second, MaxDelay: 10 * time.Second, ContinueOnError: func(error) bool { return true }}
+	return probes.waitFor(ctx, fmt.Sprintf("%s to answer %d", c.url, c.expectedStatus), func(ctx context.Context) (bool, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url.String(), nil)
+		if err != nil {
+			return false, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return false, err
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		tflog.Debug(ctx, "probed bunkerweb service", map[string]any{"url": c.url.String(), "instance": address, "status": resp.StatusCode})
+		if resp.StatusCode != c.expectedStatus {
+			return false, fmt.Errorf("got status %d", resp.StatusCode)
+		}
+		return true, nil
+	})
+}
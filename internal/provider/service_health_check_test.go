@@ -0,0 +1,169 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestServiceHealthCheck(t *testing.T) {
+	ctx := context.Background()
+
+	m := BunkerWebResourceModel{HealthCheck: healthCheckObject(t, "bw-1", types.StringNull(), types.Int64Null(), types.StringNull())}
+	check, diags := m.healthCheck(ctx, "app.example.com")
+	if diags.HasError() {
+		t.Fatalf("healthCheck: %v", diags)
+	}
+	if check.url.String() != "http://app.example.com/" || check.port != "80" || check.expectedStatus != http.StatusOK || check.timeout != defaultHealthCheckTimeout {
+		t.Fatalf("unexpected defaults: %+v", check)
+	}
+
+	m.HealthCheck = healthCheckObject(t, "bw-1", types.StringValue("https://app.example.com/healthz"), types.Int64Null(), types.StringValue("5s"))
+	if check, _ = m.healthCheck(ctx, "app.example.com"); check.port != "443" || check.timeout != 5*time.Second {
+		t.Fatalf("expected the https port and the configured timeout, got %+v", check)
+	}
+
+	for _, value := range []types.Object{
+		healthCheckObject(t, "", types.StringNull(), types.Int64Null(), types.StringNull()),
+		healthCheckObject(t, "bw-1", types.StringValue("ftp://app.example.com/"), types.Int64Null(), types.StringNull()),
+		healthCheckObject(t, "bw-1", types.StringNull(), types.Int64Value(70000), types.StringNull()),
+		healthCheckObject(t, "bw-1", types.StringNull(), types.Int64Null(), types.StringValue("soon")),
+	} {
+		m.HealthCheck = value
+		if _, diags := m.healthCheck(ctx, "app.example.com"); !diags.HasError() {
+			t.Errorf("expected %s to be rejected", value)
+		}
+	}
+
+	m.HealthCheck = types.ObjectNull(healthCheckAttrTypes)
+	if check, diags := m.healthCheck(ctx, "app.example.com"); check != nil || diags.HasError() {
+		t.Fatalf("expected no health check when unset, got %+v (%v)", check, diags)
+	}
+}
+
+func TestServiceHealthCheckWait(t *testing.T) {
+	var hosts []string
+	probes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.Host)
+		probes++
+		if probes < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	host, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	target, _ := url.Parse("http://app.example.com/healthz")
+	check := &serviceHealthCheck{instance: host, url: target, port: port, expectedStatus: http.StatusNoContent, timeout: 10 * time.Second}
+	if err := check.wait(context.Background()); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if probes != 2 || hosts[0] != "app.example.com" {
+		t.Fatalf("expected two probes for app.example.com, got %v", hosts)
+	}
+
+	check.expectedStatus = http.StatusOK
+	check.timeout = 100 * time.Millisecond
+	if err := check.wait(context.Background()); err == nil || !strings.Contains(err.Error(), "got status 204") {
+		t.Fatalf("expected a timeout reporting the last status, got %v", err)
+	}
+}
+
+func TestAccBunkerWebResourceHealthCheck(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddInstance(bunkerWebInstance{Hostname: "127.0.0.1"})
+
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	instance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "app.example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(instance.Close)
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(instance.URL, "http://"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBunkerWebResourceHealthCheckConfig(fakeAPI.URL(), port),
+				ExpectError: regexp.MustCompile(`Service Health Check Failed`),
+			},
+			{
+				PreConfig: func() { status.Store(http.StatusOK) },
+				Config:    testAccBunkerWebResourceHealthCheckConfig(fakeAPI.URL(), port),
+				Check: func(*terraform.State) error {
+					if calls := fakeAPI.ReloadHostCalls(); len(calls) == 0 || calls[len(calls)-1].host != "127.0.0.1" {
+						return fmt.Errorf("expected the instance to be reloaded before probing, got %v", calls)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccBunkerWebResourceHealthCheckConfig(endpoint, port string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+
+  health_check = {
+    instance = "127.0.0.1"
+    port     = %s
+    timeout  = "2s"
+  }
+}
+`, endpoint, port)
+}
+
+var healthCheckAttrTypes = map[string]attr.Type{
+	"instance":        types.StringType,
+	"url":             types.StringType,
+	"port":            types.Int64Type,
+	"expected_status": types.Int64Type,
+	"timeout":         types.StringType,
+}
+
+func healthCheckObject(t *testing.T, instance string, url types.String, port types.Int64, timeout types.String) types.Object {
+	t.Helper()
+	value, diags := types.ObjectValue(healthCheckAttrTypes, map[string]attr.Value{
+		"instance":        types.StringValue(instance),
+		"url":             url,
+		"port":            port,
+		"expected_status": types.Int64Null(),
+		"timeout":         timeout,
+	})
+	if diags.HasError() {
+		t.Fatalf("ObjectValue: %v", diags)
+	}
+	return value
+}
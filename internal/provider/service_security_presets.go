@@ -0,0 +1,101 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// securityPresets are the variable bundles of the security_preset attribute
+// of bunkerweb_service, from the most permissive to the strictest. They only
+// touch settings whose meaning is stable across BunkerWeb 1.5 and 1.6.
+var securityPresets = map[string]map[string]string{
+	"low": {
+		"USE_MODSECURITY":        "yes",
+		"USE_MODSECURITY_CRS":    "yes",
+		"USE_BAD_BEHAVIOR":       "yes",
+		"BAD_BEHAVIOR_THRESHOLD": "30",
+		"USE_LIMIT_REQ":          "no",
+		"USE_LIMIT_CONN":         "no",
+		"USE_DNSBL":              "no",
+		"USE_BLACKLIST":          "yes",
+		"USE_ANTIBOT":            "no",
+	},
+	"medium": {
+		"USE_MODSECURITY":        "yes",
+		"USE_MODSECURITY_CRS":    "yes",
+		"USE_BAD_BEHAVIOR":       "yes",
+		"BAD_BEHAVIOR_THRESHOLD": "10",
+		"BAD_BEHAVIOR_BAN_TIME":  "86400",
+		"USE_LIMIT_REQ":          "yes",
+		"LIMIT_REQ_RATE":         "2r/s",
+		"USE_LIMIT_CONN":         "yes",
+		"USE_DNSBL":              "yes",
+		"USE_BLACKLIST":          "yes",
+		"USE_ANTIBOT":            "no",
+	},
+	"high": {
+		"USE_MODSECURITY":        "yes",
+		"USE_MODSECURITY_CRS":    "yes",
+		"USE_BAD_BEHAVIOR":       "yes",
+		"BAD_BEHAVIOR_THRESHOLD": "5",
+		"BAD_BEHAVIOR_BAN_TIME":  "604800",
+		"USE_LIMIT_REQ":          "yes",
+		"LIMIT_REQ_RATE":         "1r/s",
+		"USE_LIMIT_CONN":         "yes",
+		"USE_DNSBL":              "yes",
+		"USE_BLACKLIST":          "yes",
+		"USE_ANTIBOT":            "javascript",
+	},
+}
+
+// securityPresetNames lists the presets from the most permissive to the
+// strictest.
+var securityPresetNames = []string{"low", "medium", "high"}
+
+// securityPresetDescription documents the security_preset attribute,
+// including the variables of every preset.
+func securityPresetDescription() string {
+	var b strings.Builder
+	b.WriteString("Hardening level applied as a bundle of variables, under `template` and `variables` which override individual settings: ")
+	for i, name := range securityPresetNames {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		preset := securityPresets[name]
+		settings := make([]string, 0, len(preset))
+		for _, key := range slices.Sorted(maps.Keys(preset)) {
+			settings = append(settings, fmt.Sprintf("`%s=%s`", key, preset[key]))
+		}
+		fmt.Fprintf(&b, "`%s` (%s)", name, strings.Join(settings, ", "))
+	}
+	b.WriteString(". Settings of a preset that is removed or replaced are reset to their defaults.")
+	return b.String()
+}
+
+// securityPresetVariables returns the variables of the security_preset of m,
+// or nil when it is not set.
+func (m *BunkerWebResourceModel) securityPresetVariables() (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m.Preset.IsNull() || m.Preset.IsUnknown() {
+		return nil, diags
+	}
+
+	preset, ok := securityPresets[m.Preset.ValueString()]
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("security_preset"),
+			"Invalid Security Preset",
+			fmt.Sprintf("Expected one of %s, got %q.", strings.Join(securityPresetNames, ", "), m.Preset.ValueString()),
+		)
+		return nil, diags
+	}
+	return preset, diags
+}
@@ -0,0 +1,119 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestSecurityPresetLayering(t *testing.T) {
+	r := &BunkerWebResource{}
+	m := BunkerWebResourceModel{
+		Preset: types.StringValue("high"),
+		Template: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"USE_ANTIBOT": types.StringValue("captcha"),
+		}),
+		Variables: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"LIMIT_REQ_RATE": types.StringValue("5r/s"),
+		}),
+		ListenStream: types.BoolNull(),
+	}
+
+	merged, diags := r.mergedVariables(context.Background(), m)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if merged["USE_ANTIBOT"] != "captcha" || merged["LIMIT_REQ_RATE"] != "5r/s" || merged["BAD_BEHAVIOR_THRESHOLD"] != "5" {
+		t.Fatalf("expected template and variables to override the preset, got %#v", merged)
+	}
+	if securityPresets["high"]["USE_ANTIBOT"] != "javascript" {
+		t.Fatal("merging modified the preset bundle")
+	}
+	if !r.inheritsVariables(m) {
+		t.Fatal("expected a preset to count as inherited variables")
+	}
+
+	m.Preset = types.StringValue("paranoid")
+	if _, diags := r.mergedVariables(context.Background(), m); !diags.HasError() {
+		t.Fatal("expected an unknown preset to be rejected")
+	}
+}
+
+func TestSecurityPresetNames(t *testing.T) {
+	if len(securityPresetNames) != len(securityPresets) {
+		t.Fatalf("securityPresetNames lists %d presets, securityPresets has %d", len(securityPresetNames), len(securityPresets))
+	}
+	for _, name := range securityPresetNames {
+		if _, ok := securityPresets[name]; !ok {
+			t.Errorf("preset %q has no variables", name)
+		}
+	}
+}
+
+func TestAccBunkerWebResourceSecurityPreset(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebResourceSecurityPresetConfig(fakeAPI.URL(), `security_preset = "high"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "variables.%", "1"),
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "effective_variables.USE_ANTIBOT", "javascript"),
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "effective_variables.LIMIT_REQ_RATE", "10r/s"),
+					func(*terraform.State) error {
+						if got := fakeAPI.ServiceVariables("app.example.com")["BAD_BEHAVIOR_BAN_TIME"]; got != "604800" {
+							return fmt.Errorf("expected BAD_BEHAVIOR_BAN_TIME=604800 on the service, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccBunkerWebResourceSecurityPresetConfig(fakeAPI.URL(), `security_preset = "low"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "effective_variables.USE_ANTIBOT", "no"),
+					resource.TestCheckNoResourceAttr("bunkerweb_service.app", "effective_variables.BAD_BEHAVIOR_BAN_TIME"),
+					func(*terraform.State) error {
+						if got, ok := fakeAPI.ServiceVariables("app.example.com")["BAD_BEHAVIOR_BAN_TIME"]; !ok || got != "" {
+							return fmt.Errorf("expected BAD_BEHAVIOR_BAN_TIME to be reset, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccBunkerWebResourceSecurityPresetConfig(fakeAPI.URL(), `security_preset = "paranoid"`),
+				ExpectError: regexp.MustCompile(`Invalid Security Preset`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebResourceSecurityPresetConfig(endpoint, preset string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+  %s
+  variables = {
+    LIMIT_REQ_RATE = "10r/s"
+  }
+}
+`, endpoint, preset)
+}
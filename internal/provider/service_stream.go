@@ -0,0 +1,135 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// streamSettings are the settings bunkerweb_service derives from its stream
+// attributes; they cannot also be set through variables.
+var streamSettings = []string{"SERVER_TYPE", "LISTEN_STREAM", "LISTEN_STREAM_PORT", "LISTEN_STREAM_PORT_SSL", "USE_TCP", "USE_UDP"}
+
+// httpOnlySettings are features nginx only provides in HTTP servers; enabling
+// them on a stream service has no effect.
+var httpOnlySettings = []string{"USE_ANTIBOT", "USE_AUTH_BASIC", "USE_BROTLI", "USE_CLIENT_CACHE", "USE_CORS", "USE_GZIP", "USE_LIMIT_REQ", "USE_MODSECURITY", "SERVE_FILES"}
+
+// streamVariables returns the settings for the stream attributes of m, or nil
+// when listen_stream is not set.
+func (m *BunkerWebResourceModel) streamVariables() map[string]string {
+	if m.ListenStream.IsNull() || m.ListenStream.IsUnknown() {
+		return nil
+	}
+	if !m.ListenStream.ValueBool() {
+		return map[string]string{"SERVER_TYPE": "http", "LISTEN_STREAM": "no"}
+	}
+
+	variables := map[string]string{"SERVER_TYPE": "stream", "LISTEN_STREAM": "yes"}
+	if !m.StreamPort.IsNull() && !m.StreamPort.IsUnknown() {
+		variables["LISTEN_STREAM_PORT"] = strconv.FormatInt(m.StreamPort.ValueInt64(), 10)
+	}
+	if !m.StreamSSLPort.IsNull() && !m.StreamSSLPort.IsUnknown() {
+		variables["LISTEN_STREAM_PORT_SSL"] = strconv.FormatInt(m.StreamSSLPort.ValueInt64(), 10)
+	}
+	if !m.StreamProtocol.IsNull() && !m.StreamProtocol.IsUnknown() {
+		udp := strings.EqualFold(m.StreamProtocol.ValueString(), "udp")
+		variables["USE_UDP"] = yesNo(udp)
+		variables["USE_TCP"] = yesNo(!udp)
+	}
+	return variables
+}
+
+// refreshStream updates the configured stream attributes from the settings
+// of a GET /services/{id} response.
+func (m *BunkerWebResourceModel) refreshStream(config map[string]string, service string) {
+	if m.ListenStream.IsNull() {
+		return
+	}
+	if v, ok := lookupServiceSetting(config, service, "SERVER_TYPE"); ok {
+		m.ListenStream = types.BoolValue(strings.EqualFold(v, "stream"))
+	}
+	for _, port := range []struct {
+		value   *types.Int64
+		setting string
+	}{
+		{&m.StreamPort, "LISTEN_STREAM_PORT"},
+		{&m.StreamSSLPort, "LISTEN_STREAM_PORT_SSL"},
+	} {
+		if port.value.IsNull() {
+			continue
+		}
+		if v, ok := lookupServiceSetting(config, service, port.setting); ok {
+			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+				*port.value = types.Int64Value(parsed)
+			}
+		}
+	}
+	if !m.StreamProtocol.IsNull() {
+		if v, ok := lookupServiceSetting(config, service, "USE_UDP"); ok {
+			m.StreamProtocol = types.StringValue("tcp")
+			if isAffirmative(v) {
+				m.StreamProtocol = types.StringValue("udp")
+			}
+		}
+	}
+}
+
+// validateStream checks the stream attributes and that the variables neither
+// duplicate them nor enable HTTP-only features on a stream service.
+func (m *BunkerWebResourceModel) validateStream() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	streaming := !m.ListenStream.IsNull() && !m.ListenStream.IsUnknown() && m.ListenStream.ValueBool()
+	for attr, value := range map[string]types.Int64{"stream_port": m.StreamPort, "stream_ssl_port": m.StreamSSLPort} {
+		if value.IsNull() {
+			continue
+		}
+		if !m.ListenStream.IsUnknown() && !streaming {
+			diags.AddAttributeError(path.Root(attr), "Stream Setting Without Stream Mode", fmt.Sprintf("`%s` requires `listen_stream = true`.", attr))
+		}
+		if !value.IsUnknown() && (value.ValueInt64() < 1 || value.ValueInt64() > 65535) {
+			diags.AddAttributeError(path.Root(attr), "Invalid Stream Port", fmt.Sprintf("`%s` must be between 1 and 65535, got %d.", attr, value.ValueInt64()))
+		}
+	}
+	if !m.StreamProtocol.IsNull() && !m.StreamProtocol.IsUnknown() {
+		if !m.ListenStream.IsUnknown() && !streaming {
+			diags.AddAttributeError(path.Root("stream_protocol"), "Stream Setting Without Stream Mode", "`stream_protocol` requires `listen_stream = true`.")
+		}
+		if protocol := m.StreamProtocol.ValueString(); protocol != "tcp" && protocol != "udp" {
+			diags.AddAttributeError(path.Root("stream_protocol"), "Invalid Stream Protocol", fmt.Sprintf("Expected `tcp` or `udp`, got %q.", protocol))
+		}
+	}
+
+	if m.ListenStream.IsNull() || m.Variables.IsNull() || m.Variables.IsUnknown() {
+		return diags
+	}
+	for key, value := range m.Variables.Elements() {
+		str, ok := value.(types.String)
+		if !ok || str.IsUnknown() {
+			continue
+		}
+		switch {
+		case slices.Contains(streamSettings, strings.ToUpper(key)):
+			diags.AddAttributeError(
+				path.Root("variables").AtMapKey(key),
+				"Conflicting Stream Setting",
+				fmt.Sprintf("%s is managed by `listen_stream` and the other stream attributes; remove it from `variables`.", key),
+			)
+		case streaming && slices.Contains(httpOnlySettings, strings.ToUpper(key)) && isAffirmative(str.ValueString()):
+			diags.AddAttributeError(
+				path.Root("variables").AtMapKey(key),
+				"HTTP-Only Setting on Stream Service",
+				fmt.Sprintf("%s only applies to HTTP services and cannot be enabled with `listen_stream = true`.", key),
+			)
+		}
+	}
+	return diags
+}
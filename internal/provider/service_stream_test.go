@@ -0,0 +1,84 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testStreamModel(listen types.Bool, variables map[string]string) BunkerWebResourceModel {
+	elements := make(map[string]attr.Value, len(variables))
+	for k, v := range variables {
+		elements[k] = types.StringValue(v)
+	}
+	return BunkerWebResourceModel{
+		ListenStream:   listen,
+		StreamPort:     types.Int64Value(1337),
+		StreamSSLPort:  types.Int64Null(),
+		StreamProtocol: types.StringValue("tcp"),
+		Variables:      types.MapValueMust(types.StringType, elements),
+	}
+}
+
+func TestServiceStreamVariables(t *testing.T) {
+	m := testStreamModel(types.BoolValue(true), nil)
+	want := map[string]string{"SERVER_TYPE": "stream", "LISTEN_STREAM": "yes", "LISTEN_STREAM_PORT": "1337", "USE_TCP": "yes", "USE_UDP": "no"}
+	if got := m.streamVariables(); !maps.Equal(got, want) {
+		t.Fatalf("streamVariables = %v, want %v", got, want)
+	}
+
+	m.ListenStream = types.BoolNull()
+	if got := m.streamVariables(); got != nil {
+		t.Fatalf("expected no stream settings when listen_stream is unset, got %v", got)
+	}
+
+	m.ListenStream = types.BoolValue(true)
+	m.refreshStream(map[string]string{"SERVER_TYPE": "stream", "LISTEN_STREAM_PORT": "4242", "USE_UDP": "yes"}, "app.example.com")
+	if m.StreamPort.ValueInt64() != 4242 || m.StreamProtocol.ValueString() != "udp" || !m.StreamSSLPort.IsNull() {
+		t.Fatalf("unexpected refreshed stream attributes: %+v", m)
+	}
+}
+
+func TestServiceStreamValidation(t *testing.T) {
+	invalid := testStreamModel(types.BoolValue(true), nil)
+	invalid.StreamPort = types.Int64Value(70000)
+	invalid.StreamProtocol = types.StringValue("sctp")
+
+	cases := []struct {
+		name    string
+		model   BunkerWebResourceModel
+		wantErr string
+	}{
+		{name: "valid", model: testStreamModel(types.BoolValue(true), map[string]string{"USE_REVERSE_PROXY": "yes", "USE_MODSECURITY": "no"})},
+		{name: "http only", model: testStreamModel(types.BoolValue(true), map[string]string{"USE_ANTIBOT": "yes"}), wantErr: "HTTP-Only Setting on Stream Service"},
+		{name: "duplicate", model: testStreamModel(types.BoolValue(true), map[string]string{"use_udp": "yes"}), wantErr: "Conflicting Stream Setting"},
+		{name: "port without stream", model: testStreamModel(types.BoolValue(false), nil), wantErr: "Stream Setting Without Stream Mode"},
+		{name: "unknown listen_stream", model: testStreamModel(types.BoolUnknown(), map[string]string{"USE_ANTIBOT": "yes"})},
+		{name: "invalid values", model: invalid, wantErr: "Invalid Stream Protocol"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := tc.model.validateStream()
+			if tc.wantErr == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected errors: %v", diags)
+				}
+				return
+			}
+			var summaries []string
+			for _, d := range diags.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			if !strings.Contains(strings.Join(summaries, "\n"), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, summaries)
+			}
+		})
+	}
+}
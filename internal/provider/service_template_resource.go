@@ -0,0 +1,137 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &BunkerWebServiceTemplateResource{}
+
+func NewBunkerWebServiceTemplateResource() resource.Resource {
+	return &BunkerWebServiceTemplateResource{}
+}
+
+// BunkerWebServiceTemplateResource is a provider-side bundle of service
+// variables, similar to the templates of the BunkerWeb web UI. It does not
+// call the API: services consume it through their `template` attribute.
+type BunkerWebServiceTemplateResource struct{}
+
+// BunkerWebServiceTemplateResourceModel mirrors the Terraform state for bunkerweb_service_template.
+type BunkerWebServiceTemplateResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Variables   types.Map    `tfsdk:"variables"`
+}
+
+func (r *BunkerWebServiceTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_template"
+}
+
+func (r *BunkerWebServiceTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Defines a reusable bundle of service variables. Templates live only in Terraform state; " +
+			"pass `variables` to the `template` attribute of `bunkerweb_service` to apply them. Services referencing " +
+			"a template are updated whenever it changes, and their own `variables` take precedence.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the template (same as `name`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the template.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Free-form description of the template.",
+			},
+			"variables": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Service variables provided by the template.",
+			},
+		},
+	}
+}
+
+func (r *BunkerWebServiceTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BunkerWebServiceTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(strings.TrimSpace(plan.Name.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebServiceTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BunkerWebServiceTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BunkerWebServiceTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BunkerWebServiceTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(strings.TrimSpace(plan.Name.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebServiceTemplateResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Nothing to clean up remotely; services keep the variables they were given.
+}
+
+// mergeTemplateVariables layers the service variables over the template ones.
+func mergeTemplateVariables(ctx context.Context, template, variables types.Map) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	base, d := mapFromTerraform(ctx, template)
+	diags.Append(d...)
+	own, d := mapFromTerraform(ctx, variables)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if len(base) == 0 {
+		return own, diags
+	}
+
+	merged := make(map[string]string, len(base)+len(own))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range own {
+		merged[k] = v
+	}
+
+	return merged, diags
+}
@@ -0,0 +1,83 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMergeTemplateVariables(t *testing.T) {
+	template := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"USE_ANTIBOT": types.StringValue("captcha"),
+		"USE_GZIP":    types.StringValue("yes"),
+	})
+	variables := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"USE_GZIP": types.StringValue("no"),
+	})
+
+	merged, diags := mergeTemplateVariables(context.Background(), template, variables)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(merged) != 2 || merged["USE_ANTIBOT"] != "captcha" || merged["USE_GZIP"] != "no" {
+		t.Fatalf("unexpected merged variables: %#v", merged)
+	}
+}
+
+func TestAccBunkerWebServiceTemplateResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebServiceTemplateResourceConfig(fakeAPI.URL(), "captcha"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service_template.hardened", "id", "hardened"),
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "effective_variables.USE_ANTIBOT", "captcha"),
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "effective_variables.USE_GZIP", "no"),
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "variables.%", "1"),
+				),
+			},
+			{
+				Config: testAccBunkerWebServiceTemplateResourceConfig(fakeAPI.URL(), "javascript"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_service.app", "effective_variables.USE_ANTIBOT", "javascript"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebServiceTemplateResourceConfig(endpoint, antibot string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_service_template" "hardened" {
+  name = "hardened"
+  variables = {
+    USE_ANTIBOT = "%s"
+    USE_GZIP    = "yes"
+  }
+}
+
+resource "bunkerweb_service" "app" {
+  server_name = "app.example.com"
+  template    = bunkerweb_service_template.hardened.variables
+  variables = {
+    USE_GZIP = "no"
+  }
+}
+`, endpoint, antibot)
+}
@@ -0,0 +1,595 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const servicesSyncID = "services_sync"
+
+var _ resource.Resource = &BunkerWebServicesSyncResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebServicesSyncResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebServicesSyncResource{}
+
+// BunkerWebServicesSyncResource manages the whole set of services on the
+// control plane: services missing from its map are deleted.
+type BunkerWebServicesSyncResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebServicesSyncResourceModel is the Terraform state.
+type BunkerWebServicesSyncResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Services  types.Map    `tfsdk:"services"`
+	Exclude   types.List   `tfsdk:"exclude"`
+	Unmanaged types.List   `tfsdk:"unmanaged_services"`
+	Pending   types.List   `tfsdk:"pending_deletions"`
+	Retries   types.Object `tfsdk:"retries"`
+}
+
+// servicesSyncServiceModel is one entry of bunkerweb_services_sync.services.
+type servicesSyncServiceModel struct {
+	ServerName types.String `tfsdk:"server_name"`
+	IsDraft    types.Bool   `tfsdk:"is_draft"`
+	Variables  types.Map    `tfsdk:"variables"`
+}
+
+var servicesSyncServiceAttrTypes = map[string]attr.Type{
+	"server_name": types.StringType,
+	"is_draft":    types.BoolType,
+	"variables":   types.MapType{ElemType: types.StringType},
+}
+
+func (m servicesSyncServiceModel) serverName(id string) string {
+	if m.ServerName.IsNull() || m.ServerName.IsUnknown() || strings.TrimSpace(m.ServerName.ValueString()) == "" {
+		return id
+	}
+	return m.ServerName.ValueString()
+}
+
+func NewBunkerWebServicesSyncResource() resource.Resource {
+	return &BunkerWebServicesSyncResource{}
+}
+
+func (r *BunkerWebServicesSyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_services_sync"
+}
+
+func (r *BunkerWebServicesSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritatively manages the services of the control plane: the services in `services` are created or updated, " +
+			"and every other service not listed in `exclude` is deleted, so BunkerWeb exactly mirrors the configuration. " +
+			"Do not combine it with `bunkerweb_service` resources unless their identifiers are excluded. " +
+			"Destroying this resource deletes the services it defines.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Constant identifier of the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"services": schema.MapNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Services keyed by their identifier (the first name of `server_name`).",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"server_name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Space separated server names. Defaults to the map key; its first name must be the key.",
+						},
+						"is_draft": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "When true, the service stays in draft mode.",
+						},
+						"variables": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "Service settings, merged over the provider `default_service_variables`.",
+						},
+					},
+				},
+			},
+			"exclude": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Identifiers of services that are never deleted, for example services managed by another team or by `bunkerweb_service`.",
+			},
+			"unmanaged_services": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Services found on the control plane that are neither defined nor excluded. They are deleted at the next apply.",
+			},
+			"pending_deletions": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Unmanaged services the planned apply deletes, listed when planning from the services seen by the last refresh. A service created since is left for the next plan. Applying fails when `services` or `exclude` is unknown at plan time, as the deletions cannot be shown. Empty after a refresh.",
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebServicesSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebServicesSyncResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebServicesSyncResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Services.IsUnknown() {
+		return
+	}
+
+	services, diags := servicesSyncServicesFromMap(ctx, config.Services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for id, svc := range services {
+		if strings.TrimSpace(id) == "" || strings.ContainsAny(id, " \t") {
+			resp.Diagnostics.AddAttributeError(path.Root("services").AtMapKey(id), "Invalid Service Identifier", fmt.Sprintf("Service keys must be a single server name, got %q.", id))
+			continue
+		}
+		if svc.ServerName.IsUnknown() {
+			continue
+		}
+		if first := firstToken(svc.serverName(id)); first != id {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("services").AtMapKey(id).AtName("server_name"),
+				"Mismatched Server Name",
+				fmt.Sprintf("The first server name %q must match the service key %q.", first, id),
+			)
+		}
+	}
+
+	if config.Exclude.IsUnknown() {
+		return
+	}
+	exclude, diags := listToStrings(ctx, config.Exclude)
+	resp.Diagnostics.Append(diags...)
+	for idx, id := range exclude {
+		if _, ok := services[id]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("exclude").AtListIndex(idx),
+				"Conflicting Exclusion",
+				fmt.Sprintf("Service %q is both defined in `services` and excluded.", id),
+			)
+		}
+	}
+}
+
+// ModifyPlan lists the unmanaged services in pending_deletions, so the apply
+// deletes exactly the services shown in the plan, and in a warning. Once the
+// resource exists they come from the services seen by the last refresh rather
+// than a new listing: Terraform plans again during the apply and fails when
+// the list changed, so a service created since is left for the next plan.
+func (r *BunkerWebServicesSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	none, diags := types.ListValueFrom(ctx, types.StringType, []string{})
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_services"), none)...)
+
+	var plan BunkerWebServicesSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without the planned services, the deletions cannot be shown and the
+	// apply refuses to run.
+	if r.client == nil || plan.Services.IsUnknown() || plan.Exclude.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pending_deletions"), types.ListUnknown(types.StringType))...)
+		if r.client != nil {
+			resp.Diagnostics.AddWarning(
+				"Unknown Pending Deletions",
+				"`services` or `exclude` is only known after apply, so bunkerweb_services_sync cannot list the services it would delete and its apply will fail.",
+			)
+		}
+		return
+	}
+
+	services, diags := servicesSyncServicesFromMap(ctx, plan.Services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var pending types.List
+	if req.State.Raw.IsNull() {
+		pending, diags = r.unmanagedServices(ctx, services, plan.Exclude)
+	} else {
+		var state BunkerWebServicesSyncResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		pending, diags = refreshedUnmanagedServices(ctx, state, services, plan.Exclude)
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pending_deletions"), pending)...)
+
+	if ids, _ := listToStrings(ctx, pending); len(ids) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Unmanaged Services Will Be Deleted",
+			fmt.Sprintf("bunkerweb_services_sync will delete services that are neither defined nor excluded: %s.", strings.Join(ids, ", ")),
+		)
+	}
+}
+
+func (r *BunkerWebServicesSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebServicesSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, nil, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebServicesSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebServicesSyncResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	services, diags := servicesSyncServicesFromMap(ctx, state.Services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	normalize := settingValueNormalizer(ctx, r.client)
+	for id, svc := range services {
+		got, err := r.client.GetService(ctx, id)
+		if err != nil {
+			var apiErr *bunkerWebAPIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				delete(services, id)
+				continue
+			}
+			resp.Diagnostics.AddError("Unable to Read Service", fmt.Sprintf("Service %q: %s", id, err))
+			return
+		}
+
+		if v, ok := lookupServiceSetting(got.Config, got.Service, "IS_DRAFT"); ok {
+			if draft := isAffirmative(v); !svc.IsDraft.IsNull() || draft {
+				svc.IsDraft = types.BoolValue(draft)
+			}
+		}
+
+		// Refresh only the variables already managed in state; GET returns
+		// every non-default setting.
+		prior, diags := mapFromTerraform(ctx, svc.Variables)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(prior) > 0 {
+			for k, v := range prior {
+				if apiV, ok := lookupServiceSetting(got.Config, got.Service, k); ok {
+					prior[k] = normalize(k, v, apiV)
+				}
+			}
+			vars, diags := mapToTerraform(ctx, prior)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			svc.Variables = vars
+		}
+		services[id] = svc
+	}
+
+	servicesValue, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: servicesSyncServiceAttrTypes}, services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Services = servicesValue
+
+	unmanaged, diags := r.unmanagedServices(ctx, services, state.Exclude)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Unmanaged = unmanaged
+	state.Pending, diags = types.ListValueFrom(ctx, types.StringType, []string{})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BunkerWebServicesSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan, state BunkerWebServicesSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := servicesSyncServicesFromMap(ctx, state.Services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, prior, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BunkerWebServicesSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebServicesSyncResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	services, diags := servicesSyncServicesFromMap(ctx, state.Services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(services)) {
+		if err := r.client.DeleteService(ctx, id); err != nil {
+			var apiErr *bunkerWebAPIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			resp.Diagnostics.AddError("Unable to Delete Service", fmt.Sprintf("Service %q: %s", id, err))
+			return
+		}
+	}
+}
+
+// sync creates or updates the planned services, then deletes the services of
+// pending_deletions. prior holds the services of the previous state, whose
+// removed variables are reset.
+func (r *BunkerWebServicesSyncResource) sync(ctx context.Context, prior map[string]servicesSyncServiceModel, plan *BunkerWebServicesSyncResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Deleting services nobody saw in the plan is refused.
+	if plan.Pending.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("pending_deletions"),
+			"Unknown Pending Deletions",
+			"The services to delete were unknown when planning, because `services` or `exclude` depended on values known only after apply. "+
+				"Set them from values known at plan time, or apply the resources they depend on first with -target.",
+		)
+		return diags
+	}
+
+	services, d := servicesSyncServicesFromMap(ctx, plan.Services)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	existing, err := r.client.ListServices(ctx, true)
+	if err != nil {
+		diags.AddError("Unable to List Services", err.Error())
+		return diags
+	}
+	present := make(map[string]struct{}, len(existing))
+	for _, svc := range existing {
+		present[svc.ID] = struct{}{}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(services)) {
+		svc := services[id]
+		own, d := mapFromTerraform(ctx, svc.Variables)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+
+		variables := map[string]string{}
+		if old, ok := prior[id]; ok {
+			previous, d := mapFromTerraform(ctx, old.Variables)
+			diags.Append(d...)
+			for k := range previous {
+				variables[k] = ""
+			}
+		}
+		maps.Copy(variables, r.state.defaultServiceVariables)
+		maps.Copy(variables, own)
+
+		serverName := svc.serverName(id)
+		isDraft := svc.IsDraft.ValueBool()
+		if _, ok := present[id]; ok {
+			_, err = r.client.UpdateService(ctx, id, ServiceUpdateRequest{
+				ServerName: &serverName,
+				IsDraft:    &isDraft,
+				Variables:  variables,
+			})
+		} else {
+			_, err = r.client.CreateService(withIdempotencyKey(ctx), ServiceCreateRequest{
+				ServerName: serverName,
+				IsDraft:    isDraft,
+				Variables:  variables,
+			})
+		}
+		if err != nil {
+			diags.AddError("Unable to Sync Service", fmt.Sprintf("Service %q: %s", id, err))
+			return diags
+		}
+	}
+
+	pending, d := listToStrings(ctx, plan.Pending)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, id := range pending {
+		if _, ok := present[id]; !ok {
+			continue
+		}
+		if err := r.client.DeleteService(ctx, id); err != nil {
+			diags.AddError("Unable to Delete Unmanaged Service", fmt.Sprintf("Service %q: %s", id, err))
+			return diags
+		}
+		tflog.Info(ctx, "deleted unmanaged bunkerweb service", map[string]any{"id": id})
+	}
+
+	plan.ID = types.StringValue(servicesSyncID)
+	unmanaged, d := types.ListValueFrom(ctx, types.StringType, []string{})
+	diags.Append(d...)
+	plan.Unmanaged = unmanaged
+
+	return diags
+}
+
+// unmanagedServices lists the services on the control plane that are neither
+// defined nor excluded.
+func (r *BunkerWebServicesSyncResource) unmanagedServices(ctx context.Context, services map[string]servicesSyncServiceModel, excludeList types.List) (types.List, diag.Diagnostics) {
+	exclude, diags := listToStrings(ctx, excludeList)
+	if diags.HasError() {
+		return types.ListNull(types.StringType), diags
+	}
+
+	existing, err := r.client.ListServices(ctx, true)
+	if err != nil {
+		diags.AddError("Unable to List Services", err.Error())
+		return types.ListNull(types.StringType), diags
+	}
+
+	unmanaged := []string{}
+	for _, svc := range existing {
+		if _, defined := services[svc.ID]; !defined && !slices.Contains(exclude, svc.ID) {
+			unmanaged = append(unmanaged, svc.ID)
+		}
+	}
+	slices.Sort(unmanaged)
+
+	value, d := types.ListValueFrom(ctx, types.StringType, unmanaged)
+	diags.Append(d...)
+	return value, diags
+}
+
+// refreshedUnmanagedServices lists the services of state, as seen by the last
+// refresh, that are neither defined nor excluded by the plan.
+func refreshedUnmanagedServices(ctx context.Context, state BunkerWebServicesSyncResourceModel, services map[string]servicesSyncServiceModel, excludeList types.List) (types.List, diag.Diagnostics) {
+	exclude, diags := listToStrings(ctx, excludeList)
+	seen, d := listToStrings(ctx, state.Unmanaged)
+	diags.Append(d...)
+	managed, d := servicesSyncServicesFromMap(ctx, state.Services)
+	diags.Append(d...)
+	if diags.HasError() {
+		return types.ListNull(types.StringType), diags
+	}
+
+	unmanaged := []string{}
+	for _, id := range append(seen, slices.Collect(maps.Keys(managed))...) {
+		if _, defined := services[id]; !defined && !slices.Contains(exclude, id) && !slices.Contains(unmanaged, id) {
+			unmanaged = append(unmanaged, id)
+		}
+	}
+	slices.Sort(unmanaged)
+
+	value, d := types.ListValueFrom(ctx, types.StringType, unmanaged)
+	diags.Append(d...)
+	return value, diags
+}
+
+func servicesSyncServicesFromMap(ctx context.Context, value types.Map) (map[string]servicesSyncServiceModel, diag.Diagnostics) {
+	services := map[string]servicesSyncServiceModel{}
+	if value.IsNull() || value.IsUnknown() {
+		return services, nil
+	}
+	diags := value.ElementsAs(ctx, &services, false)
+	return services, diags
+}
@@ -0,0 +1,197 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccBunkerWebServicesSyncResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddService(bunkerWebService{ID: "stray.example.com", ServerName: "stray.example.com"})
+	fakeAPI.AddService(bunkerWebService{ID: "legacy.example.com", ServerName: "legacy.example.com"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebServicesSyncConfig(fakeAPI.URL(), "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_services_sync.fleet", "unmanaged_services.#", "0"),
+					resource.TestCheckResourceAttr("bunkerweb_services_sync.fleet", "pending_deletions.#", "1"),
+					resource.TestCheckResourceAttr("bunkerweb_services_sync.fleet", "pending_deletions.0", "stray.example.com"),
+					func(_ *terraform.State) error {
+						if fakeAPI.HasService("stray.example.com") {
+							return fmt.Errorf("expected stray.example.com to be deleted")
+						}
+						if !fakeAPI.HasService("legacy.example.com") {
+							return fmt.Errorf("expected excluded legacy.example.com to be kept")
+						}
+						if got := fakeAPI.ServiceVariables("app.example.com")["TEST"]; got != "one" {
+							return fmt.Errorf("expected TEST=one on app.example.com, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					fakeAPI.AddService(bunkerWebService{ID: "manual.example.com", ServerName: "manual.example.com"})
+				},
+				Config: testAccBunkerWebServicesSyncConfig(fakeAPI.URL(), "two"),
+				Check: func(_ *terraform.State) error {
+					if fakeAPI.HasService("manual.example.com") {
+						return fmt.Errorf("expected manual.example.com to be deleted")
+					}
+					if got := fakeAPI.ServiceVariables("app.example.com")["TEST"]; got != "two" {
+						return fmt.Errorf("expected TEST=two on app.example.com, got %q", got)
+					}
+					return nil
+				},
+			},
+			{
+				// Nothing left to delete: the refreshed state plans no change.
+				Config:   testAccBunkerWebServicesSyncConfig(fakeAPI.URL(), "two"),
+				PlanOnly: true,
+			},
+			{
+				Config:      testAccBunkerWebServicesSyncMismatchConfig(fakeAPI.URL()),
+				ExpectError: regexp.MustCompile(`Mismatched Server Name`),
+			},
+		},
+	})
+}
+
+func TestServicesSyncDeletesOnlyPlannedServices(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddService(bunkerWebService{ID: "stray.example.com", ServerName: "stray.example.com"})
+	sync := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_services_sync")
+	sync.afterPlan = func() {
+		fakeAPI.AddService(bunkerWebService{ID: "late.example.com", ServerName: "late.example.com"})
+	}
+
+	if errs := sync.apply(map[string]tftypes.Value{
+		"services": tftypes.NewValue(servicesSyncServicesType, map[string]tftypes.Value{}),
+	}); len(errs) > 0 {
+		t.Fatalf("apply: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	if fakeAPI.HasService("stray.example.com") {
+		t.Fatal("expected the planned deletion of stray.example.com to be applied")
+	}
+	if !fakeAPI.HasService("late.example.com") {
+		t.Fatal("expected late.example.com, created after the plan, to be left for the next plan")
+	}
+}
+
+func TestServicesSyncKeepsPlannedDeletionsDuringApply(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	sync := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_services_sync")
+	attributes := map[string]tftypes.Value{
+		"services": tftypes.NewValue(servicesSyncServicesType, map[string]tftypes.Value{}),
+	}
+	if errs := sync.apply(attributes); len(errs) > 0 {
+		t.Fatalf("apply: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+
+	fakeAPI.AddService(bunkerWebService{ID: "manual.example.com", ServerName: "manual.example.com"})
+	sync.refresh()
+	config := protocolValue(t, sync.objType, attributes)
+	plan := sync.plan(sync.state, config, sync.private, sync.identity)
+
+	// Terraform plans again during the apply, after another resource of the
+	// same apply may have created a service.
+	fakeAPI.AddService(bunkerWebService{ID: "late.example.com", ServerName: "late.example.com"})
+	final := sync.plan(sync.state, config, sync.private, sync.identity)
+	planned, err := plan.PlannedState.Unmarshal(sync.objType)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	replanned, err := final.PlannedState.Unmarshal(sync.objType)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if path := protocolInconsistency(tftypes.NewAttributePath(), planned, replanned); path != nil {
+		t.Fatalf("Provider produced inconsistent final plan: %s", path)
+	}
+
+	if errs := sync.applyPlan(final, config); len(errs) > 0 {
+		t.Fatalf("apply: %s: %s", errs[0].Summary, errs[0].Detail)
+	}
+	if fakeAPI.HasService("manual.example.com") {
+		t.Fatal("expected the planned deletion of manual.example.com to be applied")
+	}
+	if !fakeAPI.HasService("late.example.com") {
+		t.Fatal("expected late.example.com, created after the plan, to be left for the next plan")
+	}
+}
+
+func TestServicesSyncRefusesUnknownDeletions(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddService(bunkerWebService{ID: "stray.example.com", ServerName: "stray.example.com"})
+	sync := newProtocolResource(t, fakeAPI.URL(), "bunkerweb_services_sync")
+
+	errs := sync.apply(map[string]tftypes.Value{
+		"services": tftypes.NewValue(servicesSyncServicesType, tftypes.UnknownValue),
+	})
+	if len(errs) == 0 || errs[0].Summary != "Unknown Pending Deletions" {
+		t.Fatalf("expected the apply to be refused, got %v", errs)
+	}
+	if !fakeAPI.HasService("stray.example.com") {
+		t.Fatal("expected stray.example.com, never shown in a plan, to be kept")
+	}
+}
+
+var servicesSyncServicesType = tftypes.Map{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"server_name": tftypes.String,
+	"is_draft":    tftypes.Bool,
+	"variables":   tftypes.Map{ElementType: tftypes.String},
+}}}
+
+func testAccBunkerWebServicesSyncConfig(endpoint, value string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_services_sync" "fleet" {
+  services = {
+    "app.example.com" = {
+      server_name = "app.example.com www.app.example.com"
+      variables = {
+        TEST = "%s"
+      }
+    }
+    "api.example.com" = {}
+  }
+
+  exclude = ["legacy.example.com"]
+}
+`, endpoint, value)
+}
+
+func testAccBunkerWebServicesSyncMismatchConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_services_sync" "fleet" {
+  services = {
+    "app.example.com" = {
+      server_name = "www.app.example.com"
+    }
+  }
+}
+`, endpoint)
+}
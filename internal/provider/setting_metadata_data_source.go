@@ -0,0 +1,223 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BunkerWebSettingMetadataDataSource{}
+
+// BunkerWebSettingMetadataDataSource exposes the settings catalogue declared by plugins.
+type BunkerWebSettingMetadataDataSource struct {
+	client BunkerWebAPI
+}
+
+// BunkerWebSettingMetadataDataSourceModel represents the data source state.
+type BunkerWebSettingMetadataDataSourceModel struct {
+	Plugin   types.String `tfsdk:"plugin"`
+	Context  types.String `tfsdk:"context"`
+	Settings types.Map    `tfsdk:"settings"`
+}
+
+var settingMetadataAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"plugin":         types.StringType,
+	"context":        types.StringType,
+	"type":           types.StringType,
+	"regex":          types.StringType,
+	"default":        types.StringType,
+	"label":          types.StringType,
+	"help":           types.StringType,
+	"multiple":       types.BoolType,
+	"multiple_group": types.StringType,
+	"select":         types.ListType{ElemType: types.StringType},
+}
+
+func NewBunkerWebSettingMetadataDataSource() datasource.DataSource {
+	return &BunkerWebSettingMetadataDataSource{}
+}
+
+func (d *BunkerWebSettingMetadataDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_setting_metadata"
+}
+
+func (d *BunkerWebSettingMetadataDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exposes the catalogue of BunkerWeb settings declared by the installed plugins, keyed by setting name.",
+		Attributes: map[string]schema.Attribute{
+			"plugin": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return settings declared by this plugin.",
+			},
+			"context": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return settings of this context (`global` or `multisite`).",
+			},
+			"settings": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Settings keyed by their name (for example `USE_GZIP`).",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: settingMetadataSchemaAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// settingMetadataSchemaAttributes describes one setting of a plugin; it is
+// shared with bunkerweb_plugins.
+func settingMetadataSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Setting identifier inside the plugin definition.",
+		},
+		"plugin": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Plugin declaring the setting.",
+		},
+		"context": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Either `global` or `multisite`.",
+		},
+		"type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Input type (`text`, `check`, `select`, `password`, ...).",
+		},
+		"regex": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Regular expression values must match.",
+		},
+		"default": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Default value.",
+		},
+		"label": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Human-readable label.",
+		},
+		"help": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Help text.",
+		},
+		"multiple": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Whether the setting accepts numbered suffixes (`NAME_1`, `NAME_2`, ...).",
+		},
+		"multiple_group": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Group shared by related multiple settings, empty when `multiple` is false.",
+		},
+		"select": schema.ListAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "Allowed values for `select` settings.",
+		},
+	}
+}
+
+func (d *BunkerWebSettingMetadataDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *BunkerWebSettingMetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var data BunkerWebSettingMetadataDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pluginFilter := strings.TrimSpace(data.Plugin.ValueString())
+	contextFilter := strings.ToLower(strings.TrimSpace(data.Context.ValueString()))
+
+	plugins, err := d.client.ListPlugins(ctx, "all", false)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to List Plugins", err.Error())
+		return
+	}
+
+	elems := make(map[string]attr.Value)
+	for _, plugin := range plugins {
+		if pluginFilter != "" && plugin.ID != pluginFilter {
+			continue
+		}
+		for name, setting := range plugin.Settings {
+			if contextFilter != "" && setting.Context != contextFilter {
+				continue
+			}
+
+			value, diags := settingMetadataValue(plugin.ID, setting)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			elems[name] = value
+		}
+	}
+
+	settings, diags := types.MapValue(types.ObjectType{AttrTypes: settingMetadataAttrTypes}, elems)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Settings = settings
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// settingMetadataValue converts a plugin setting to settingMetadataAttrTypes.
+func settingMetadataValue(pluginID string, setting bunkerWebPluginSetting) (attr.Value, diag.Diagnostics) {
+	selectValues := make([]attr.Value, 0, len(setting.Select))
+	for _, v := range setting.Select {
+		selectValues = append(selectValues, types.StringValue(v))
+	}
+
+	selectValue, diags := types.ListValue(types.StringType, selectValues)
+	if diags.HasError() {
+		return types.ObjectNull(settingMetadataAttrTypes), diags
+	}
+
+	obj, objDiags := types.ObjectValue(settingMetadataAttrTypes, map[string]attr.Value{
+		"id":             types.StringValue(setting.ID),
+		"plugin":         types.StringValue(pluginID),
+		"context":        types.StringValue(setting.Context),
+		"type":           types.StringValue(setting.Type),
+		"regex":          types.StringValue(setting.Regex),
+		"default":        types.StringValue(setting.Default),
+		"label":          types.StringValue(setting.Label),
+		"help":           types.StringValue(setting.Help),
+		"multiple":       types.BoolValue(setting.Multiple != ""),
+		"multiple_group": types.StringValue(setting.Multiple),
+		"select":         selectValue,
+	})
+	diags.Append(objDiags...)
+	return obj, diags
+}
@@ -0,0 +1,87 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBunkerWebSettingMetadataDataSource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+	fakeAPI.AddPlugin(bunkerWebPlugin{
+		ID:   "gzip",
+		Type: "core",
+		Settings: map[string]bunkerWebPluginSetting{
+			"USE_GZIP":        {ID: "use-gzip", Context: "multisite", Default: "no", Regex: "^(yes|no)$", Type: "check"},
+			"GZIP_COMP_LEVEL": {ID: "gzip-comp-level", Context: "multisite", Default: "5", Type: "select", Select: []string{"1", "5", "9"}},
+			"GZIP_TYPES":      {ID: "gzip-types", Context: "global", Default: "text/html", Type: "text", Multiple: "gzip-types"},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebSettingMetadataDataSourceConfig(fakeAPI.URL()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bunkerweb_setting_metadata.all", "settings.%", "3"),
+					resource.TestCheckResourceAttr("data.bunkerweb_setting_metadata.all", "settings.USE_GZIP.plugin", "gzip"),
+					resource.TestCheckResourceAttr("data.bunkerweb_setting_metadata.all", "settings.USE_GZIP.regex", "^(yes|no)$"),
+					resource.TestCheckResourceAttr("data.bunkerweb_setting_metadata.all", "settings.GZIP_COMP_LEVEL.select.#", "3"),
+					resource.TestCheckResourceAttr("data.bunkerweb_setting_metadata.all", "settings.GZIP_TYPES.multiple", "true"),
+					resource.TestCheckResourceAttr("data.bunkerweb_setting_metadata.multisite", "settings.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebSettingMetadataDataSourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+data "bunkerweb_setting_metadata" "all" {}
+
+data "bunkerweb_setting_metadata" "multisite" {
+  plugin  = "gzip"
+  context = "multisite"
+}
+`, endpoint)
+}
+
+func TestSettingMetadataValue(t *testing.T) {
+	value, diags := settingMetadataValue("antibot", bunkerWebPluginSetting{
+		ID:      "use-antibot",
+		Context: "multisite",
+		Type:    "select",
+		Default: "no",
+		Select:  []string{"no", "captcha"},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	obj, ok := value.(types.Object)
+	if !ok {
+		t.Fatalf("expected an object, got %T", value)
+	}
+	if got := obj.Attributes()["select"].(types.List).Elements(); len(got) != 2 {
+		t.Errorf("expected 2 select values, got %d", len(got))
+	}
+
+	value, diags = settingMetadataValue("antibot", bunkerWebPluginSetting{ID: "antibot-uri"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := value.(types.Object).Attributes()["select"]; got.IsNull() {
+		t.Errorf("expected an empty select list rather than null")
+	}
+}
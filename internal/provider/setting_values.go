@@ -0,0 +1,85 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// settingValuesEquivalent reports whether a and b are the same logical value
+// for a setting of the given catalogue type, for example "yes" and "true" for
+// a check, or "10" and "10.0" for a number.
+func settingValuesEquivalent(settingType, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	switch settingType {
+	case "check":
+		av, aok := parseSettingBool(a)
+		bv, bok := parseSettingBool(b)
+		return aok && bok && av == bv
+	case "number":
+		av, aerr := strconv.ParseFloat(strings.TrimSpace(a), 64)
+		bv, berr := strconv.ParseFloat(strings.TrimSpace(b), 64)
+		return aerr == nil && berr == nil && av == bv
+	default:
+		return false
+	}
+}
+
+func parseSettingBool(v string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "yes", "true", "on", "1":
+		return true, true
+	case "no", "false", "off", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// settingValueNormalizer returns a function that keeps the prior (configured)
+// representation of a setting when the API reports the same logical value in
+// another form, so it does not show as drift. The settings catalogue is only
+// fetched when a value differs; without it, values are compared verbatim.
+func settingValueNormalizer(ctx context.Context, client BunkerWebAPI) func(key, prior, current string) string {
+	var settingTypes map[string]string
+	loaded := false
+
+	return func(key, prior, current string) string {
+		if prior == current {
+			return current
+		}
+
+		if !loaded {
+			loaded = true
+			var err error
+			if settingTypes, err = client.SettingTypes(ctx); err != nil {
+				tflog.Debug(ctx, "unable to read the bunkerweb settings catalogue", map[string]any{"error": err.Error()})
+			}
+		}
+
+		if settingValuesEquivalent(settingTypeOf(settingTypes, key), prior, current) {
+			return prior
+		}
+		return current
+	}
+}
+
+// settingTypeOf looks key up in the catalogue, falling back to the base name
+// of numbered multiple settings (REVERSE_PROXY_URL_1 -> REVERSE_PROXY_URL).
+func settingTypeOf(settingTypes map[string]string, key string) string {
+	if t, ok := settingTypes[key]; ok {
+		return t
+	}
+	if loc := multipleSettingSuffix.FindStringIndex(key); loc != nil {
+		return settingTypes[key[:loc[0]]]
+	}
+	return ""
+}
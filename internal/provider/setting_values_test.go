@@ -0,0 +1,44 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "testing"
+
+func TestSettingValuesEquivalent(t *testing.T) {
+	cases := []struct {
+		settingType, a, b string
+		want              bool
+	}{
+		{settingType: "check", a: "yes", b: "true", want: true},
+		{settingType: "check", a: "no", b: "off", want: true},
+		{settingType: "check", a: "yes", b: "no", want: false},
+		{settingType: "check", a: "yes", b: "maybe", want: false},
+		{settingType: "number", a: "10", b: "10.0", want: true},
+		{settingType: "number", a: " 5", b: "5", want: true},
+		{settingType: "number", a: "5", b: "6", want: false},
+		{settingType: "text", a: "yes", b: "true", want: false},
+		{settingType: "", a: "10", b: "10.0", want: false},
+		{settingType: "text", a: "same", b: "same", want: true},
+	}
+
+	for _, tc := range cases {
+		if got := settingValuesEquivalent(tc.settingType, tc.a, tc.b); got != tc.want {
+			t.Errorf("settingValuesEquivalent(%q, %q, %q) = %t, want %t", tc.settingType, tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestSettingTypeOf(t *testing.T) {
+	settingTypes := map[string]string{"USE_GZIP": "check", "REVERSE_PROXY_KEEPALIVE": "check"}
+
+	if got := settingTypeOf(settingTypes, "USE_GZIP"); got != "check" {
+		t.Fatalf("expected check, got %q", got)
+	}
+	if got := settingTypeOf(settingTypes, "REVERSE_PROXY_KEEPALIVE_2"); got != "check" {
+		t.Fatalf("expected numbered key to use its base type, got %q", got)
+	}
+	if got := settingTypeOf(settingTypes, "UNKNOWN"); got != "" {
+		t.Fatalf("expected no type, got %q", got)
+	}
+}
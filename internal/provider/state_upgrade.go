@@ -0,0 +1,89 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stateMigration rewrites the stored state of one schema version, decoded
+// from its JSON form, into the next version. Attributes it removes become
+// null, and attributes the current schema no longer has are dropped when the
+// result is decoded.
+type stateMigration func(ctx context.Context, state map[string]any) error
+
+// stateUpgraders returns the UpgradeState implementation of a resource whose
+// schema version is len(migrations): migrations[v] turns version v into
+// v+1, and state stored at any prior version goes through every later
+// migration in order. A breaking schema change is then a new migration
+// appended to the resource's list and a schema version bump.
+func stateUpgraders(migrations ...stateMigration) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(migrations))
+	for version := range migrations {
+		pending := migrations[version:]
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeState(ctx, req.RawState, pending, resp)
+			},
+		}
+	}
+	return upgraders
+}
+
+func upgradeState(ctx context.Context, raw *tfprotov6.RawState, migrations []stateMigration, resp *resource.UpgradeStateResponse) {
+	if raw == nil || raw.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The stored state has no JSON representation; refresh it with an earlier provider version first.")
+		return
+	}
+
+	// UseNumber keeps large numbers exact through the round trip.
+	var state map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw.JSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to decode the stored state: %s", err))
+		return
+	}
+
+	for _, migrate := range migrations {
+		if err := migrate(ctx, state); err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+			return
+		}
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to encode the upgraded state: %s", err))
+		return
+	}
+	value, err := (&tfprotov6.RawState{JSON: upgraded}).UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("The upgraded state does not match the current schema: %s", err))
+		return
+	}
+	resp.State.Raw = value
+}
+
+// unchangedState is the migration of a schema version bump that only
+// introduces versioning, or adds optional attributes.
+func unchangedState(context.Context, map[string]any) error {
+	return nil
+}
+
+// stateString returns the string attribute name of state, or "" when it is
+// null or missing.
+func stateString(state map[string]any, name string) string {
+	value, _ := state[name].(string)
+	return value
+}
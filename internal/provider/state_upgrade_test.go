@@ -0,0 +1,95 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestStateUpgraders(t *testing.T) {
+	ctx := context.Background()
+	for name, r := range map[string]resource.Resource{
+		"service": NewBunkerWebResource(),
+		"config":  NewBunkerWebConfigResource(),
+		"ban":     NewBunkerWebBanResource(),
+	} {
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		upgraders := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)
+		for version := range schemaResp.Schema.Version {
+			if _, ok := upgraders[version]; !ok {
+				t.Errorf("%s: no state upgrader for version %d", name, version)
+			}
+		}
+		if _, ok := upgraders[schemaResp.Schema.Version]; ok {
+			t.Errorf("%s: state upgrader registered for the current version %d", name, schemaResp.Schema.Version)
+		}
+	}
+}
+
+func TestUpgradeBanState(t *testing.T) {
+	ctx := context.Background()
+	r := NewBunkerWebBanResource()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// Version 0 state with a hand-written ID and an attribute since removed.
+	raw := &tfprotov6.RawState{JSON: []byte(`{"id":"legacy","ip":"192.0.2.1","service":"app.example.com","reason":"manual","expiration_seconds":3600,"removed_attribute":"x"}`)}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[0].StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: raw}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState: %v", resp.Diagnostics)
+	}
+
+	var id, reason types.String
+	var exp types.Int64
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("reason"), &reason)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("expiration_seconds"), &exp)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("GetAttribute: %v", resp.Diagnostics)
+	}
+	if id.ValueString() != "192.0.2.1/app.example.com" || reason.ValueString() != "manual" || exp.ValueInt64() != 3600 {
+		t.Fatalf("unexpected upgraded state: id=%s reason=%s exp=%s", id, reason, exp)
+	}
+}
+
+func TestUpgradeStateMigrationsRunInOrder(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewBunkerWebBanResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	rename := func(_ context.Context, state map[string]any) error {
+		state["reason"], state["why"] = state["why"], nil
+		return nil
+	}
+	suffix := func(_ context.Context, state map[string]any) error {
+		state["reason"] = stateString(state, "reason") + " (migrated)"
+		return nil
+	}
+	upgraders := stateUpgraders(rename, suffix)
+
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgraders[0].StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{"ip":"192.0.2.1","why":"abuse"}`)}}, &resp)
+	var reason types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("reason"), &reason)...)
+	if resp.Diagnostics.HasError() || reason.ValueString() != "abuse (migrated)" {
+		t.Fatalf("expected both migrations to apply, got %s (%v)", reason, resp.Diagnostics)
+	}
+
+	failing := stateUpgraders(func(context.Context, map[string]any) error { return errors.New("unsupported layout") })
+	resp = resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	failing[0].StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{}`)}}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected a failing migration to be reported")
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/url"
+	"strings"
+)
+
+// defaultTenantHeader carries the provider tenant unless tenant_header or
+// tenant_in_path is set.
+const defaultTenantHeader = "X-Tenant-ID"
+
+// tenantPathPrefix returns the prefix scoping endpoints to the provider
+// tenant when tenant_in_path is set, else "".
+func (c *bunkerWebClient) tenantPathPrefix() string {
+	if c.tenant == "" || !c.tenantInPath {
+		return ""
+	}
+	return "tenants/" + url.PathEscape(c.tenant) + "/"
+}
+
+// withTenant scopes a relative endpoint to the provider tenant. Absolute URLs,
+// such as task locations returned by the API, are already scoped.
+func (c *bunkerWebClient) withTenant(endpoint string) string {
+	prefix := c.tenantPathPrefix()
+	if prefix == "" || strings.Contains(endpoint, "://") {
+		return endpoint
+	}
+	return prefix + strings.TrimPrefix(endpoint, "/")
+}
+
+// withoutTenant strips the tenant prefix from an endpoint relative to the API
+// base.
+func (c *bunkerWebClient) withoutTenant(endpoint string) string {
+	if prefix := c.tenantPathPrefix(); prefix != "" {
+		return strings.TrimPrefix(endpoint, prefix)
+	}
+	return endpoint
+}
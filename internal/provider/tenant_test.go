@@ -0,0 +1,95 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestBunkerWebClientTenant(t *testing.T) {
+	api := newFakeBunkerWebAPI(t)
+	client, err := newBunkerWebClient(api.URL(), nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.tenant = "acme"
+	client.tenantHeader = defaultTenantHeader
+
+	if _, err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if got := api.LastHeader(defaultTenantHeader); got != "acme" {
+		t.Fatalf("expected the tenant header, got %q", got)
+	}
+	if !client.reservedHeader("x-tenant-id") {
+		t.Fatalf("expected the tenant header to be reserved")
+	}
+
+	client.tenantHeader = ""
+	client.tenantInPath = true
+	for endpoint, want := range map[string]string{
+		"services/app":  api.URL() + "/tenants/acme/services/app",
+		"/ping":         api.URL() + "/tenants/acme/ping",
+		api.URL() + "/": api.URL() + "/",
+	} {
+		got, err := client.withEndpoint(endpoint)
+		if err != nil {
+			t.Fatalf("withEndpoint(%q): %v", endpoint, err)
+		}
+		if got != want {
+			t.Errorf("withEndpoint(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+	if got := client.withoutTenant("tenants/acme/services/app"); got != "services/app" {
+		t.Errorf("withoutTenant() = %q, want services/app", got)
+	}
+}
+
+func TestAccBunkerWebProviderTenant(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebProviderTenantConfig(fakeAPI.URL(), `tenant = "acme"`),
+				Check: func(*terraform.State) error {
+					if got := fakeAPI.LastHeader(defaultTenantHeader); got != "acme" {
+						return fmt.Errorf("expected %s: acme, got %q", defaultTenantHeader, got)
+					}
+					return nil
+				},
+			},
+			{
+				Config:      testAccBunkerWebProviderTenantConfig(fakeAPI.URL(), `tenant_in_path = true`),
+				ExpectError: regexp.MustCompile(`Missing Tenant`),
+			},
+			{
+				Config:      testAccBunkerWebProviderTenantConfig(fakeAPI.URL(), "tenant = \"acme\"\n  tenant_header = \"X-Org\"\n  tenant_in_path = true"),
+				ExpectError: regexp.MustCompile(`Conflicting Tenant Scoping`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebProviderTenantConfig(endpoint, tenant string) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+  %s
+}
+
+data "bunkerweb_ping" "api" {
+  hostnames = []
+}
+`, endpoint, tenant)
+}
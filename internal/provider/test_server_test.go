@@ -4,18 +4,27 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 	"unicode"
 )
 
@@ -25,34 +34,72 @@ type fakeBunkerWebAPI struct {
 	mu                     sync.Mutex
 	services               map[string]*bunkerWebService
 	instances              map[string]*bunkerWebInstance
+	instanceTokens         map[string]string
 	globalConfig           map[string]any
+	globalDefaults         map[string]any
 	configs                map[string]*bunkerWebConfig
 	bans                   map[string]*bunkerWebBan
 	plugins                map[string]*bunkerWebPlugin
 	cache                  map[string]*bunkerWebCacheEntry
 	jobs                   []bunkerWebJob
+	jobRuns                []bunkerWebJobRun
+	failJobs               map[string]bool
+	apiCredentials         map[string]*bunkerWebAPICredential
+	apiCredentialSeq       int
+	users                  map[string]*bunkerWebUser
+	userPasswords          map[string]string
+	requests               []bunkerWebRequestRecord
+	logs                   map[string][]string
+	logQueries             []string
+	banQueries             []string
+	idempotencyKeys        []string
+	metrics                map[string]map[string]any
 	runJobs                []RunJobsRequest
 	pingPayload            map[string]any
 	healthStatus           map[string]any
+	openAPIPaths           []string
+	hmacKey                []byte
 	authCreds              map[string]string
 	authTokens             map[string]string
 	lastAuth               string
+	lastHeaders            http.Header
 	deletedInstanceBatches [][]string
 	pingAllCount           int
 	pingHosts              []string
+	unreachableHosts       map[string]bool
+	proOnlySettings        map[string]bool
+	lastServicePatch       map[string]string
 	reloadAllTests         []bool
 	reloadHostCalls        []instanceActionCall
 	stopAllCount           int
 	stopHosts              []string
+	restartEndpoint        bool
+	restartHosts           []string
 	convertCalls           []serviceConvertCall
 	lastGlobalPatch        map[string]any
+	globalPatches          []map[string]any
 	deletedConfigBatches   [][]ConfigKey
 	createdBanBatches      [][]BanRequest
 	deletedBanBatches      [][]UnbanRequest
 	uploadedPluginBatches  [][]string
 	deletedPlugins         []string
+	asyncPolls             int
+	asyncTasks             map[string]*fakeAsyncTask
+	asyncTaskPolls         int
 }
 
+// fakeAsyncTask is a write answered with 202 Accepted, completed after
+// pending more polls of /tasks/{id}.
+type fakeAsyncTask struct {
+	pending int
+	code    int
+	body    []byte
+}
+
+// fakeSyncRequest marks a write replayed synchronously to produce the result
+// of an asynchronous task.
+type fakeSyncRequest struct{}
+
 type instanceActionCall struct {
 	host string
 	test bool
@@ -65,12 +112,13 @@ type serviceConvertCall struct {
 
 func newFakeBunkerWebAPI(t *testing.T) *fakeBunkerWebAPI {
 	api := &fakeBunkerWebAPI{
-		t:            t,
-		services:     make(map[string]*bunkerWebService),
-		instances:    make(map[string]*bunkerWebInstance),
-		globalConfig: map[string]any{"some_setting": "value", "feature_enabled": true, "retry_limit": 5},
-		configs:      make(map[string]*bunkerWebConfig),
-		bans:         make(map[string]*bunkerWebBan),
+		t:              t,
+		services:       make(map[string]*bunkerWebService),
+		instances:      make(map[string]*bunkerWebInstance),
+		instanceTokens: make(map[string]string),
+		globalConfig:   map[string]any{"some_setting": "value", "feature_enabled": true, "retry_limit": 5},
+		configs:        make(map[string]*bunkerWebConfig),
+		bans:           make(map[string]*bunkerWebBan),
 		plugins: map[string]*bunkerWebPlugin{
 			"ui-dashboard": {ID: "ui-dashboard", Type: "ui", Version: "1.0.0", Description: "Dashboard"},
 		},
@@ -107,9 +155,46 @@ func (f *fakeBunkerWebAPI) URL() string {
 func (f *fakeBunkerWebAPI) handle(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
+	f.mu.Lock()
+	f.lastHeaders = r.Header.Clone()
+	f.lastHeaders.Set("Host", r.Host)
+	f.mu.Unlock()
+
+	if !f.verifySignature(r) {
+		f.writeDetailError(w, http.StatusUnauthorized, "invalid request signature")
+		return
+	}
+
+	if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/tasks/") {
+		f.handleTask(w, r)
+		return
+	}
+	f.mu.Lock()
+	async := f.asyncTasks != nil && r.Method != http.MethodGet && r.Context().Value(fakeSyncRequest{}) == nil
+	f.mu.Unlock()
+	if async {
+		f.handleAsyncWrite(w, r)
+		return
+	}
+
+	// Like idempotency-aware API versions, acknowledge a repeated POST key
+	// without applying the write again.
+	if key := r.Header.Get(idempotencyKeyHeader); key != "" {
+		f.mu.Lock()
+		replay := r.Method == http.MethodPost && slices.Contains(f.idempotencyKeys, key)
+		f.idempotencyKeys = append(f.idempotencyKeys, key)
+		f.mu.Unlock()
+		if replay {
+			f.writeSuccess(w, struct{}{})
+			return
+		}
+	}
+
 	switch {
 	case r.Method == http.MethodGet && r.URL.Path == "/ping":
 		f.handlePing(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/openapi.json":
+		f.handleOpenAPI(w, r)
 	case r.Method == http.MethodGet && r.URL.Path == "/health":
 		f.handleHealth(w, r)
 	case r.Method == http.MethodPost && r.URL.Path == "/auth":
@@ -187,10 +272,30 @@ func (f *fakeBunkerWebAPI) handle(w http.ResponseWriter, r *http.Request) {
 		f.handleDeletePlugin(w, r)
 	case r.Method == http.MethodGet && r.URL.Path == "/cache":
 		f.handleListCache(w, r)
+	case r.Method == http.MethodDelete && r.URL.Path == "/cache":
+		f.handleDeleteCache(w, r)
 	case r.Method == http.MethodGet && r.URL.Path == "/jobs":
 		f.handleListJobs(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/users":
+		f.handleCreateUser(w, r)
+	case strings.HasPrefix(r.URL.Path, "/users/"):
+		f.handleUser(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/api_credentials":
+		f.handleCreateAPICredential(w, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api_credentials/"):
+		f.handleGetAPICredential(w, r)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api_credentials/"):
+		f.handleDeleteAPICredential(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/jobs/history":
+		f.handleListJobRuns(w, r)
 	case r.Method == http.MethodPost && r.URL.Path == "/jobs/run":
 		f.handleRunJobs(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/logs/scheduler":
+		f.handleGetLogs(w, r, "scheduler")
+	case r.Method == http.MethodGet && r.URL.Path == "/metrics/requests":
+		f.handleListRequests(w, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/metrics/"):
+		f.handleGetMetrics(w, r)
 	default:
 		f.writeDetailError(w, http.StatusNotFound, "Not Found")
 	}
@@ -203,6 +308,66 @@ func (f *fakeBunkerWebAPI) handlePing(w http.ResponseWriter, _ *http.Request) {
 	f.writeSuccess(w, payload)
 }
 
+// handleOpenAPI serves the routes set with SetOpenAPIPaths, or 404 like API
+// versions with docs disabled.
+func (f *fakeBunkerWebAPI) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	routes := slices.Clone(f.openAPIPaths)
+	f.mu.Unlock()
+
+	if routes == nil {
+		f.writeDetailError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	paths := make(map[string]any, len(routes))
+	for _, route := range routes {
+		paths[route] = map[string]any{"get": map[string]any{}}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"openapi": "3.1.0", "paths": paths})
+}
+
+// handleAsyncWrite applies the write, then answers 202 with a task whose
+// status reports the result once it has been polled SetAsyncWrites times.
+func (f *fakeBunkerWebAPI) handleAsyncWrite(w http.ResponseWriter, r *http.Request) {
+	rec := httptest.NewRecorder()
+	f.handle(rec, r.WithContext(context.WithValue(r.Context(), fakeSyncRequest{}, true)))
+
+	f.mu.Lock()
+	id := strconv.Itoa(len(f.asyncTasks) + 1)
+	f.asyncTasks[id] = &fakeAsyncTask{pending: f.asyncPolls, code: rec.Code, body: rec.Body.Bytes()}
+	f.mu.Unlock()
+
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]any{"status": "accepted", "task_id": id})
+}
+
+func (f *fakeBunkerWebAPI) handleTask(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/tasks/")
+
+	f.mu.Lock()
+	f.asyncTaskPolls++
+	task, ok := f.asyncTasks[id]
+	pending := ok && task.pending > 0
+	if pending {
+		task.pending--
+	}
+	f.mu.Unlock()
+
+	switch {
+	case !ok:
+		f.writeDetailError(w, http.StatusNotFound, "Task not found")
+	case pending:
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "pending", "task_id": id})
+	case task.code >= 300:
+		var payload map[string]any
+		_ = json.Unmarshal(task.body, &payload)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "failed", "task_id": id, "message": firstNonEmpty(stringifyValue(payload["message"]), stringifyValue(payload["detail"]))})
+	default:
+		_, _ = w.Write(task.body)
+	}
+}
+
 func (f *fakeBunkerWebAPI) handleHealth(w http.ResponseWriter, _ *http.Request) {
 	f.mu.Lock()
 	payload := cloneAnyMap(f.healthStatus)
@@ -265,6 +430,13 @@ func (f *fakeBunkerWebAPI) extractCredentials(r *http.Request, authHeader string
 	return strings.TrimSpace(body.Username), strings.TrimSpace(body.Password), nil
 }
 
+// LastHeader returns a header of the last request received.
+func (f *fakeBunkerWebAPI) LastHeader(name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lastHeaders.Get(name)
+}
+
 func (f *fakeBunkerWebAPI) LastAuthorization() string {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -283,14 +455,23 @@ func (f *fakeBunkerWebAPI) handleCreateService(w http.ResponseWriter, r *http.Re
 	}
 
 	id := firstToken(req.ServerName)
+	now := time.Now().Unix()
 	svc := &bunkerWebService{
-		ID:         id,
-		ServerName: req.ServerName,
-		IsDraft:    req.IsDraft,
-		Variables:  cloneStringMap(req.Variables),
+		ID:           id,
+		ServerName:   req.ServerName,
+		IsDraft:      req.IsDraft,
+		Variables:    cloneStringMap(req.Variables),
+		Method:       "api",
+		CreationDate: now,
+		LastUpdate:   now,
 	}
 
 	f.mu.Lock()
+	if _, exists := f.services[id]; exists {
+		f.mu.Unlock()
+		f.writeError(w, http.StatusConflict, fmt.Sprintf("Service %s already exists", id))
+		return
+	}
 	f.services[id] = svc
 	f.mu.Unlock()
 
@@ -375,9 +556,25 @@ func (f *fakeBunkerWebAPI) handleUpdateService(w http.ResponseWriter, r *http.Re
 	if req.IsDraft != nil {
 		svc.IsDraft = *req.IsDraft
 	}
-	if req.Variables != nil {
+	// Releases supporting partial variable patches merge the variables into
+	// the service's, an empty value resetting one; older ones replace them.
+	if f.reportsVersionFrom(featureServiceVariablePatch.MinVersion) {
+		if len(req.Variables) > 0 && svc.Variables == nil {
+			svc.Variables = map[string]string{}
+		}
+		for k, v := range req.Variables {
+			if v == "" {
+				delete(svc.Variables, k)
+			} else {
+				svc.Variables[k] = v
+			}
+		}
+	} else if req.Variables != nil {
 		svc.Variables = cloneStringMap(req.Variables)
 	}
+	f.lastServicePatch = cloneStringMap(req.Variables)
+	svc.Method = "api"
+	svc.LastUpdate = time.Now().Unix()
 
 	if req.ServerName != nil {
 		newID := firstToken(*req.ServerName)
@@ -471,9 +668,17 @@ func (f *fakeBunkerWebAPI) handleCreateInstance(w http.ResponseWriter, r *http.R
 		method := *req.Method
 		inst.Method = &method
 	}
+	if req.Type != nil {
+		instType := *req.Type
+		inst.Type = &instType
+	}
+	inst.Labels = cloneStringMap(req.Labels)
 
 	f.mu.Lock()
 	f.instances[inst.Hostname] = inst
+	if req.APIToken != nil {
+		f.instanceTokens[inst.Hostname] = *req.APIToken
+	}
 	f.mu.Unlock()
 
 	f.writeSuccess(w, bunkerWebInstancePayload{Instance: *inst})
@@ -540,6 +745,8 @@ func (f *fakeBunkerWebAPI) routeInstanceGet(w http.ResponseWriter, r *http.Reque
 		f.handleReloadInstance(w, r)
 	case strings.HasSuffix(r.URL.Path, "/stop"):
 		f.handleStopInstance(w, r)
+	case strings.HasSuffix(r.URL.Path, "/logs"):
+		f.handleGetLogs(w, r, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/instances/"), "/logs"))
 	default:
 		f.handleGetInstance(w, r)
 	}
@@ -551,6 +758,8 @@ func (f *fakeBunkerWebAPI) routeInstancePost(w http.ResponseWriter, r *http.Requ
 		f.handleReloadInstance(w, r)
 	case strings.HasSuffix(r.URL.Path, "/stop"):
 		f.handleStopInstance(w, r)
+	case strings.HasSuffix(r.URL.Path, "/restart") && f.hasRestartEndpoint():
+		f.handleRestartInstance(w, r)
 	default:
 		f.writeError(w, http.StatusNotFound, "not found")
 	}
@@ -565,12 +774,17 @@ func (f *fakeBunkerWebAPI) handlePingInstance(w http.ResponseWriter, r *http.Req
 	if ok {
 		f.pingHosts = append(f.pingHosts, hostname)
 	}
+	unreachable := f.unreachableHosts[hostname]
 	f.mu.Unlock()
 
 	if !ok {
 		f.writeError(w, http.StatusNotFound, "instance not found")
 		return
 	}
+	if unreachable {
+		f.writeError(w, http.StatusBadGateway, "instance unreachable")
+		return
+	}
 
 	f.writeSuccess(w, map[string]any{"host": hostname, "pong": true})
 }
@@ -644,6 +858,31 @@ func (f *fakeBunkerWebAPI) handleStopInstance(w http.ResponseWriter, r *http.Req
 	f.writeSuccess(w, bunkerWebInstancePayload{Instance: *inst})
 }
 
+func (f *fakeBunkerWebAPI) hasRestartEndpoint() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.restartEndpoint
+}
+
+func (f *fakeBunkerWebAPI) handleRestartInstance(w http.ResponseWriter, r *http.Request) {
+	hostname := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/instances/"), "/restart")
+	hostname = strings.Trim(hostname, "/")
+
+	f.mu.Lock()
+	_, ok := f.instances[hostname]
+	if ok {
+		f.restartHosts = append(f.restartHosts, hostname)
+	}
+	f.mu.Unlock()
+
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "instance not found")
+		return
+	}
+
+	f.writeSuccess(w, map[string]any{"host": hostname, "restarted": true})
+}
+
 func (f *fakeBunkerWebAPI) handleGetInstance(w http.ResponseWriter, r *http.Request) {
 	hostname := strings.TrimPrefix(r.URL.Path, "/instances/")
 	hostname = strings.Trim(hostname, "/")
@@ -702,6 +941,16 @@ func (f *fakeBunkerWebAPI) handleUpdateInstance(w http.ResponseWriter, r *http.R
 		method := *req.Method
 		inst.Method = &method
 	}
+	if req.Type != nil {
+		instType := *req.Type
+		inst.Type = &instType
+	}
+	if req.Labels != nil {
+		inst.Labels = cloneStringMap(*req.Labels)
+	}
+	if req.APIToken != nil {
+		f.instanceTokens[hostname] = *req.APIToken
+	}
 
 	updated := *inst
 	f.mu.Unlock()
@@ -722,9 +971,15 @@ func (f *fakeBunkerWebAPI) handleDeleteInstance(w http.ResponseWriter, r *http.R
 
 func (f *fakeBunkerWebAPI) handleGetGlobalConfig(w http.ResponseWriter, r *http.Request) {
 	includeMethods := r.URL.Query().Get("methods") == "true"
+	full := r.URL.Query().Get("full") == "true"
 
 	f.mu.Lock()
-	configCopy := make(map[string]any, len(f.globalConfig))
+	configCopy := make(map[string]any, len(f.globalConfig)+len(f.globalDefaults))
+	if full {
+		for k, v := range f.globalDefaults {
+			configCopy[k] = v
+		}
+	}
 	for k, v := range f.globalConfig {
 		configCopy[k] = v
 	}
@@ -750,6 +1005,13 @@ func (f *fakeBunkerWebAPI) handlePatchGlobalConfig(w http.ResponseWriter, r *htt
 	}
 
 	f.mu.Lock()
+	for k := range payload {
+		if f.proOnlySettings[k] {
+			f.mu.Unlock()
+			f.writeError(w, http.StatusForbidden, fmt.Sprintf("Setting %s requires a BunkerWeb PRO license", k))
+			return
+		}
+	}
 	for k, v := range payload {
 		if v == nil {
 			delete(f.globalConfig, k)
@@ -758,6 +1020,7 @@ func (f *fakeBunkerWebAPI) handlePatchGlobalConfig(w http.ResponseWriter, r *htt
 		}
 	}
 	f.lastGlobalPatch = cloneAnyMap(payload)
+	f.globalPatches = append(f.globalPatches, cloneAnyMap(payload))
 	f.mu.Unlock()
 
 	// Real API returns only {"status":"success"}; clients read settings back via GET.
@@ -779,6 +1042,11 @@ func (f *fakeBunkerWebAPI) handleCreateConfig(w http.ResponseWriter, r *http.Req
 	key := configStorageKey(service, req.Type, req.Name)
 
 	f.mu.Lock()
+	if _, exists := f.configs[key]; exists {
+		f.mu.Unlock()
+		f.writeError(w, http.StatusConflict, "Config already exists")
+		return
+	}
 	cfg := &bunkerWebConfig{Service: service, Type: req.Type, Name: req.Name, Data: req.Data, Method: "api"}
 	f.configs[key] = cfg
 	f.mu.Unlock()
@@ -959,9 +1227,14 @@ func (f *fakeBunkerWebAPI) handleUploadConfigs(w http.ResponseWriter, r *http.Re
 	service := normalizeConfigService(optionalStringPointer(r.FormValue("service")))
 
 	created := make([]string, 0, len(files))
+	rejected := make([]map[string]any, 0)
 
 	f.mu.Lock()
 	for _, fh := range files {
+		if !validUploadConfigName.MatchString(fh.Filename) {
+			rejected = append(rejected, map[string]any{"file": fh.Filename, "error": "invalid config name"})
+			continue
+		}
 		file, err := fh.Open()
 		if err != nil {
 			f.mu.Unlock()
@@ -984,8 +1257,13 @@ func (f *fakeBunkerWebAPI) handleUploadConfigs(w http.ResponseWriter, r *http.Re
 	}
 	f.mu.Unlock()
 
+	if len(created) == 0 {
+		f.writeError(w, http.StatusBadRequest, "no valid config files")
+		return
+	}
+
 	// Real API returns only the created identifiers, not the config objects.
-	f.writeSuccessCode(w, http.StatusCreated, map[string]any{"created": created})
+	f.writeSuccessCode(w, http.StatusCreated, map[string]any{"created": created, "errors": rejected})
 }
 
 func (f *fakeBunkerWebAPI) handleUploadConfigUpdate(w http.ResponseWriter, r *http.Request) {
@@ -1056,8 +1334,9 @@ func (f *fakeBunkerWebAPI) handleUploadConfigUpdate(w http.ResponseWriter, r *ht
 	f.writeSuccess(w, nil)
 }
 
-func (f *fakeBunkerWebAPI) handleListBans(w http.ResponseWriter, _ *http.Request) {
+func (f *fakeBunkerWebAPI) handleListBans(w http.ResponseWriter, r *http.Request) {
 	f.mu.Lock()
+	f.banQueries = append(f.banQueries, r.URL.RawQuery)
 	bans := make([]bunkerWebBan, 0, len(f.bans))
 	for _, ban := range f.bans {
 		bans = append(bans, *ban)
@@ -1099,7 +1378,7 @@ func (f *fakeBunkerWebAPI) handleCreateBan(w http.ResponseWriter, r *http.Reques
 		if service == "" {
 			storedService = nil
 		}
-		f.bans[banStorageKey(ip, optionalStringPointer(service))] = &bunkerWebBan{IP: ip, Reason: reason, Exp: exp, Service: storedService}
+		f.bans[banStorageKey(ip, optionalStringPointer(service))] = &bunkerWebBan{IP: ip, Reason: reason, Date: time.Now().Unix(), Exp: exp, Service: storedService}
 
 		expCopy := exp
 		reasonCopy := reason
@@ -1166,6 +1445,7 @@ func (f *fakeBunkerWebAPI) processUnbanRequests(w http.ResponseWriter, req []Unb
 
 func (f *fakeBunkerWebAPI) handleListPlugins(w http.ResponseWriter, r *http.Request) {
 	filterType := strings.TrimSpace(r.URL.Query().Get("type"))
+	withData := r.URL.Query().Get("with_data") == "true"
 
 	f.mu.Lock()
 	plugins := make([]bunkerWebPlugin, 0, len(f.plugins))
@@ -1173,7 +1453,11 @@ func (f *fakeBunkerWebAPI) handleListPlugins(w http.ResponseWriter, r *http.Requ
 		if filterType != "" && filterType != "all" && plugin.Type != filterType {
 			continue
 		}
-		plugins = append(plugins, *plugin)
+		listed := *plugin
+		if !withData {
+			listed.Data, listed.Checksum = "", ""
+		}
+		plugins = append(plugins, listed)
 	}
 	f.mu.Unlock()
 
@@ -1206,8 +1490,9 @@ func (f *fakeBunkerWebAPI) handleUploadPlugins(w http.ResponseWriter, r *http.Re
 			f.writeError(w, http.StatusBadRequest, "unable to read uploaded file")
 			return
 		}
-		_, _ = io.Copy(io.Discard, file)
+		content, _ := io.ReadAll(file)
 		_ = file.Close()
+		sum := sha256.Sum256(content)
 
 		base := filepath.Base(fh.Filename)
 		id := strings.TrimSuffix(base, filepath.Ext(base))
@@ -1219,6 +1504,8 @@ func (f *fakeBunkerWebAPI) handleUploadPlugins(w http.ResponseWriter, r *http.Re
 			Type:        method,
 			Version:     "uploaded",
 			Description: fmt.Sprintf("uploaded from %s", fh.Filename),
+			Data:        base64.StdEncoding.EncodeToString(content),
+			Checksum:    hex.EncodeToString(sum[:]),
 		}
 		f.plugins[id] = plugin
 		ids = append(ids, id)
@@ -1285,6 +1572,57 @@ func (f *fakeBunkerWebAPI) handleListCache(w http.ResponseWriter, r *http.Reques
 	f.writeSuccess(w, bunkerWebCacheEntriesPayload{Cache: cacheEntries})
 }
 
+func (f *fakeBunkerWebAPI) handleDeleteCache(w http.ResponseWriter, r *http.Request) {
+	var payload CacheFilesDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if len(payload.CacheFiles) == 0 {
+		f.writeError(w, http.StatusBadRequest, "cache_files is required")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	storageKeys := make([]string, 0, len(payload.CacheFiles))
+	for _, key := range payload.CacheFiles {
+		service := "global"
+		if key.Service != nil && *key.Service != "" {
+			service = *key.Service
+		}
+		storageKey := cacheStorageKey(service, key.Plugin, key.JobName, key.FileName)
+		if _, ok := f.cache[storageKey]; !ok {
+			f.writeError(w, http.StatusNotFound, "cache file not found")
+			return
+		}
+		storageKeys = append(storageKeys, storageKey)
+	}
+	for _, storageKey := range storageKeys {
+		delete(f.cache, storageKey)
+	}
+
+	f.writeSuccess(w, struct{}{})
+}
+
+// CacheFiles returns the stored cache files as service|plugin|job|file keys.
+func (f *fakeBunkerWebAPI) CacheFiles() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Sorted(maps.Keys(f.cache))
+}
+
+// AddCacheEntry stores a job cache file out-of-band.
+func (f *fakeBunkerWebAPI) AddCacheEntry(entry bunkerWebCacheEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cache[cacheStorageKey(entry.Service, entry.Plugin, entry.JobName, entry.FileName)] = &entry
+}
+
+func cacheStorageKey(service, plugin, job, file string) string {
+	return strings.Join([]string{service, plugin, job, file}, "|")
+}
+
 func (f *fakeBunkerWebAPI) handleListJobs(w http.ResponseWriter, _ *http.Request) {
 	f.mu.Lock()
 	jobs := make([]bunkerWebJob, len(f.jobs))
@@ -1294,6 +1632,206 @@ func (f *fakeBunkerWebAPI) handleListJobs(w http.ResponseWriter, _ *http.Request
 	f.writeSuccess(w, bunkerWebJobsPayload{Jobs: jobs})
 }
 
+func (f *fakeBunkerWebAPI) handleCreateAPICredential(w http.ResponseWriter, r *http.Request) {
+	var req APICredentialCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		f.writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	f.mu.Lock()
+	if f.apiCredentials == nil {
+		f.apiCredentials = make(map[string]*bunkerWebAPICredential)
+	}
+	f.apiCredentialSeq++
+	id := strconv.Itoa(f.apiCredentialSeq)
+	credential := &bunkerWebAPICredential{ID: id, Name: req.Name, Instance: req.Instance, CreatedAt: time.Now().Unix()}
+	f.apiCredentials[id] = credential
+	created := *credential
+	f.mu.Unlock()
+
+	created.Token = "credential-token-" + id
+	f.writeSuccess(w, bunkerWebAPICredentialPayload{Credential: created})
+}
+
+func (f *fakeBunkerWebAPI) handleGetAPICredential(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api_credentials/"), "/")
+
+	f.mu.Lock()
+	credential, ok := f.apiCredentials[id]
+	f.mu.Unlock()
+
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "credential not found")
+		return
+	}
+
+	f.writeSuccess(w, bunkerWebAPICredentialPayload{Credential: *credential})
+}
+
+func (f *fakeBunkerWebAPI) handleDeleteAPICredential(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api_credentials/"), "/")
+
+	f.mu.Lock()
+	_, ok := f.apiCredentials[id]
+	delete(f.apiCredentials, id)
+	f.mu.Unlock()
+
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "credential not found")
+		return
+	}
+
+	f.writeSuccess(w, struct{}{})
+}
+
+func (f *fakeBunkerWebAPI) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req UserCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Username == "" || req.Password == "" {
+		f.writeError(w, http.StatusBadRequest, "username and password are required")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.users == nil {
+		f.users = make(map[string]*bunkerWebUser)
+		f.userPasswords = make(map[string]string)
+	}
+	if _, exists := f.users[req.Username]; exists {
+		f.writeError(w, http.StatusConflict, "user already exists")
+		return
+	}
+
+	user := &bunkerWebUser{Username: req.Username, Role: req.Role, Email: req.Email, TOTPRequired: req.TOTPRequired}
+	f.users[req.Username] = user
+	f.userPasswords[req.Username] = req.Password
+	f.writeSuccess(w, bunkerWebUserPayload{User: *user})
+}
+
+func (f *fakeBunkerWebAPI) handleUser(w http.ResponseWriter, r *http.Request) {
+	username := strings.Trim(strings.TrimPrefix(r.URL.Path, "/users/"), "/")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, ok := f.users[username]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		f.writeSuccess(w, bunkerWebUserPayload{User: *user})
+	case http.MethodPatch:
+		var req UserUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			f.writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		if req.Password != nil {
+			f.userPasswords[username] = *req.Password
+		}
+		if req.Role != nil {
+			user.Role = *req.Role
+		}
+		if req.Email != nil {
+			user.Email = req.Email
+		}
+		if req.TOTPRequired != nil {
+			user.TOTPRequired = *req.TOTPRequired
+		}
+		f.writeSuccess(w, bunkerWebUserPayload{User: *user})
+	case http.MethodDelete:
+		delete(f.users, username)
+		delete(f.userPasswords, username)
+		f.writeSuccess(w, struct{}{})
+	default:
+		f.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// User returns a stored account and its password.
+func (f *fakeBunkerWebAPI) User(username string) (*bunkerWebUser, string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, ok := f.users[username]
+	if !ok {
+		return nil, "", false
+	}
+	copyUser := *user
+	return &copyUser, f.userPasswords[username], true
+}
+
+// APICredentialIDs returns the identifiers of the credentials that exist.
+func (f *fakeBunkerWebAPI) APICredentialIDs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ids := make([]string, 0, len(f.apiCredentials))
+	for id := range f.apiCredentials {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// handleListJobRuns ignores the query filters, like older API versions, so
+// client-side filtering is exercised.
+func (f *fakeBunkerWebAPI) handleListJobRuns(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	runs := make([]bunkerWebJobRun, len(f.jobRuns))
+	copy(runs, f.jobRuns)
+	f.mu.Unlock()
+
+	f.writeSuccess(w, bunkerWebJobRunsPayload{Runs: runs})
+}
+
+func (f *fakeBunkerWebAPI) handleListRequests(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	requests := make([]bunkerWebRequestRecord, len(f.requests))
+	copy(requests, f.requests)
+	f.mu.Unlock()
+
+	f.writeSuccess(w, bunkerWebRequestsPayload{Requests: requests})
+}
+
+func (f *fakeBunkerWebAPI) handleGetLogs(w http.ResponseWriter, r *http.Request, source string) {
+	service := r.URL.Query().Get("service")
+	lines, _ := strconv.Atoi(r.URL.Query().Get("lines"))
+
+	f.mu.Lock()
+	f.logQueries = append(f.logQueries, source+"?"+r.URL.RawQuery)
+	all := f.logs[source]
+	f.mu.Unlock()
+
+	logs := make([]string, 0, len(all))
+	for _, line := range all {
+		if service == "" || strings.Contains(line, service) {
+			logs = append(logs, line)
+		}
+	}
+	if lines > 0 && len(logs) > lines {
+		logs = logs[len(logs)-lines:]
+	}
+
+	f.writeSuccess(w, bunkerWebLogsPayload{Logs: logs})
+}
+
+func (f *fakeBunkerWebAPI) handleGetMetrics(w http.ResponseWriter, r *http.Request) {
+	plugin := strings.TrimPrefix(r.URL.Path, "/metrics/")
+
+	f.mu.Lock()
+	metrics, ok := f.metrics[plugin]
+	f.mu.Unlock()
+
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "plugin not found")
+		return
+	}
+
+	f.writeSuccess(w, bunkerWebMetricsPayload{Metrics: metrics})
+}
+
 func (f *fakeBunkerWebAPI) handleRunJobs(w http.ResponseWriter, r *http.Request) {
 	var req RunJobsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1305,8 +1843,16 @@ func (f *fakeBunkerWebAPI) handleRunJobs(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	now := time.Now().Unix()
 	f.mu.Lock()
 	f.runJobs = append(f.runJobs, req)
+	for _, job := range req.Jobs {
+		name := ""
+		if job.Name != nil {
+			name = *job.Name
+		}
+		f.jobRuns = append(f.jobRuns, bunkerWebJobRun{Plugin: job.Plugin, Name: name, Success: !f.failJobs[job.Plugin], StartDate: now, EndDate: now})
+	}
 	f.mu.Unlock()
 
 	f.writeSuccess(w, struct{}{})
@@ -1362,6 +1908,22 @@ func (f *fakeBunkerWebAPI) StopHosts() []string {
 	return result
 }
 
+// SetRestartEndpoint toggles POST /instances/{hostname}/restart, which
+// returns 404 like an older API when disabled (the default).
+func (f *fakeBunkerWebAPI) SetRestartEndpoint(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.restartEndpoint = enabled
+}
+
+func (f *fakeBunkerWebAPI) RestartHosts() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := make([]string, len(f.restartHosts))
+	copy(result, f.restartHosts)
+	return result
+}
+
 func (f *fakeBunkerWebAPI) ConvertCalls() []serviceConvertCall {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -1370,12 +1932,31 @@ func (f *fakeBunkerWebAPI) ConvertCalls() []serviceConvertCall {
 	return result
 }
 
+// GlobalSetting returns the explicitly set value of a global setting.
+func (f *fakeBunkerWebAPI) GlobalSetting(key string) (any, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.globalConfig[key]
+	return value, ok
+}
+
 func (f *fakeBunkerWebAPI) LastGlobalPatch() map[string]any {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	return cloneAnyMap(f.lastGlobalPatch)
 }
 
+// GlobalPatches returns every global config PATCH payload in order.
+func (f *fakeBunkerWebAPI) GlobalPatches() []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := make([]map[string]any, 0, len(f.globalPatches))
+	for _, patch := range f.globalPatches {
+		result = append(result, cloneAnyMap(patch))
+	}
+	return result
+}
+
 func (f *fakeBunkerWebAPI) DeletedConfigBatches() [][]ConfigKey {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -1453,6 +2034,14 @@ func (f *fakeBunkerWebAPI) Config(service, cfgType, name string) (*bunkerWebConf
 	return &copyCfg, true
 }
 
+// RemoveConfig deletes a config out-of-band.
+func (f *fakeBunkerWebAPI) RemoveConfig(service, cfgType, name string) {
+	key := configStorageKey(normalizeConfigService(optionalStringPointer(service)), cfgType, name)
+	f.mu.Lock()
+	delete(f.configs, key)
+	f.mu.Unlock()
+}
+
 func (f *fakeBunkerWebAPI) Ban(ip, service string) (*bunkerWebBan, bool) {
 	key := banStorageKey(strings.TrimSpace(ip), optionalStringPointer(strings.TrimSpace(service)))
 	f.mu.Lock()
@@ -1476,6 +2065,271 @@ func (f *fakeBunkerWebAPI) Plugin(id string) (*bunkerWebPlugin, bool) {
 	return &copyPlugin, true
 }
 
+// AddGlobalDefault registers a setting only returned by GET /global_config?full=true.
+func (f *fakeBunkerWebAPI) AddGlobalDefault(key string, value any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.globalDefaults == nil {
+		f.globalDefaults = make(map[string]any)
+	}
+	f.globalDefaults[key] = value
+}
+
+func (f *fakeBunkerWebAPI) AddPlugin(plugin bunkerWebPlugin) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.plugins[plugin.ID] = &plugin
+}
+
+func (f *fakeBunkerWebAPI) AddRequest(record bunkerWebRequestRecord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, record)
+}
+
+func (f *fakeBunkerWebAPI) AddService(service bunkerWebService) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.services[service.ID] = &service
+}
+
+// AddInstance registers an instance out-of-band.
+func (f *fakeBunkerWebAPI) AddInstance(instance bunkerWebInstance) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.instances[instance.Hostname] = &instance
+}
+
+// Instance returns a copy of the instance registered under hostname.
+func (f *fakeBunkerWebAPI) Instance(hostname string) (*bunkerWebInstance, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	inst, ok := f.instances[hostname]
+	if !ok {
+		return nil, false
+	}
+	clone := *inst
+	return &clone, true
+}
+
+// InstanceAPIToken returns the API token last sent for an instance.
+func (f *fakeBunkerWebAPI) InstanceAPIToken(hostname string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.instanceTokens[hostname]
+}
+
+// HasService reports whether a service exists.
+func (f *fakeBunkerWebAPI) HasService(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.services[id]
+	return ok
+}
+
+// ServiceVariables returns a copy of the variables stored for a service.
+func (f *fakeBunkerWebAPI) ServiceVariables(id string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	svc, ok := f.services[id]
+	if !ok {
+		return nil
+	}
+	return cloneStringMap(svc.Variables)
+}
+
+// SetServiceVariable changes a service setting out-of-band.
+func (f *fakeBunkerWebAPI) SetServiceVariable(id, key, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	svc := f.services[id]
+	if svc.Variables == nil {
+		svc.Variables = map[string]string{}
+	}
+	svc.Variables[key] = value
+}
+
+func (f *fakeBunkerWebAPI) AddConfig(cfg bunkerWebConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.configs[configStorageKey(cfg.Service, cfg.Type, cfg.Name)] = &cfg
+}
+
+func (f *fakeBunkerWebAPI) IdempotencyKeys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]string, len(f.idempotencyKeys))
+	copy(out, f.idempotencyKeys)
+	return out
+}
+
+func (f *fakeBunkerWebAPI) AddBan(ban bunkerWebBan) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bans[banStorageKey(ban.IP, ban.Service)] = &ban
+}
+
+// FailJobs makes the runs of the plugin's jobs triggered afterwards fail.
+func (f *fakeBunkerWebAPI) FailJobs(plugin string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failJobs == nil {
+		f.failJobs = make(map[string]bool)
+	}
+	f.failJobs[plugin] = true
+}
+
+func (f *fakeBunkerWebAPI) AddJobRun(run bunkerWebJobRun) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobRuns = append(f.jobRuns, run)
+}
+
+func (f *fakeBunkerWebAPI) BanQueries() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]string, len(f.banQueries))
+	copy(out, f.banQueries)
+	return out
+}
+
+// RequireHMAC makes every request need a valid signature made with key, like
+// an HMAC-verifying gateway in front of the API.
+func (f *fakeBunkerWebAPI) RequireHMAC(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hmacKey = []byte(key)
+}
+
+func (f *fakeBunkerWebAPI) verifySignature(r *http.Request) bool {
+	f.mu.Lock()
+	key := f.hmacKey
+	f.mu.Unlock()
+	if len(key) == 0 {
+		return true
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	want := requestSignature(key, r.Header.Get(hmacTimestampHeader), body)
+	return r.Header.Get(hmacTimestampHeader) != "" && hmac.Equal([]byte(r.Header.Get(defaultHMACHeader)), []byte(want))
+}
+
+// SetOpenAPIPaths makes /openapi.json list the given routes (as GET).
+func (f *fakeBunkerWebAPI) SetOpenAPIPaths(routes ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.openAPIPaths = append([]string{}, routes...)
+}
+
+// SetAsyncWrites makes every write answer 202 Accepted with a task that
+// stays pending for the given number of polls.
+func (f *fakeBunkerWebAPI) SetAsyncWrites(polls int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.asyncPolls = polls
+	if f.asyncTasks == nil {
+		f.asyncTasks = make(map[string]*fakeAsyncTask)
+	}
+}
+
+// AsyncTaskPolls returns the number of task status requests received.
+func (f *fakeBunkerWebAPI) AsyncTaskPolls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.asyncTaskPolls
+}
+
+// SetUnreachable makes pings of the instance fail.
+func (f *fakeBunkerWebAPI) SetUnreachable(hostname string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unreachableHosts == nil {
+		f.unreachableHosts = make(map[string]bool)
+	}
+	f.unreachableHosts[hostname] = true
+}
+
+// LastServicePatch returns the variables sent by the latest PATCH
+// /services/{id}.
+func (f *fakeBunkerWebAPI) LastServicePatch() map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return cloneStringMap(f.lastServicePatch)
+}
+
+// SetProOnly makes PATCH /global_config reject the setting as requiring a
+// Pro license.
+func (f *fakeBunkerWebAPI) SetProOnly(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.proOnlySettings == nil {
+		f.proOnlySettings = make(map[string]bool)
+	}
+	f.proOnlySettings[key] = true
+}
+
+// SetHealthStatus makes /health report the given status.
+func (f *fakeBunkerWebAPI) SetHealthStatus(status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.healthStatus["status"] = status
+}
+
+// reportsVersionFrom reports whether /health advertises a BunkerWeb version
+// at least minVersion. The caller holds f.mu.
+func (f *fakeBunkerWebAPI) reportsVersionFrom(minVersion string) bool {
+	version, _ := f.healthStatus["version"].(string)
+	older, ok := versionLess(version, minVersion)
+	return ok && !older
+}
+
+// SetVersion makes /health report the given BunkerWeb version.
+func (f *fakeBunkerWebAPI) SetVersion(version string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.healthStatus["version"] = version
+}
+
+// SetPendingChanges makes /health report the scheduler's change flags, or
+// none when flags is nil.
+func (f *fakeBunkerWebAPI) SetPendingChanges(flags map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if flags == nil {
+		delete(f.healthStatus, "pending_changes")
+		return
+	}
+	f.healthStatus["pending_changes"] = flags
+}
+
+func (f *fakeBunkerWebAPI) SetLogs(source string, lines []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.logs == nil {
+		f.logs = map[string][]string{}
+	}
+	f.logs[source] = lines
+}
+
+func (f *fakeBunkerWebAPI) LogQueries() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]string, len(f.logQueries))
+	copy(out, f.logQueries)
+	return out
+}
+
+func (f *fakeBunkerWebAPI) SetMetrics(plugin string, metrics map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.metrics == nil {
+		f.metrics = map[string]map[string]any{}
+	}
+	f.metrics[plugin] = metrics
+}
+
 // writeSuccess mirrors the real BunkerWeb API: the payload's fields are merged at
 // the TOP LEVEL of the body next to "status":"success" (there is no "data" wrapper).
 func (f *fakeBunkerWebAPI) writeSuccess(w http.ResponseWriter, payload any) {
@@ -1600,6 +2454,9 @@ func parseConfigPathParts(fullPath string) (service string, cfgType string, name
 	return service, cfgType, name, nil
 }
 
+// validUploadConfigName mirrors the API's naming rule for uploaded configs.
+var validUploadConfigName = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
 func sanitizeConfigFileName(raw string) string {
 	base := path.Base(raw)
 	if base == "." || base == "/" {
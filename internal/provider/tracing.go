@@ -0,0 +1,138 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// W3C Trace Context headers; see https://www.w3.org/TR/trace-context/.
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+)
+
+// Standard OpenTelemetry environment variables. TRACEPARENT and TRACESTATE
+// carry the caller's trace context into the process, as set by CI systems and
+// wrappers such as otel-cli.
+const (
+	envOTELSDKDisabled = "OTEL_SDK_DISABLED"
+	envOTELPropagators = "OTEL_PROPAGATORS"
+	envTraceparent     = "TRACEPARENT"
+	envTracestate      = "TRACESTATE"
+)
+
+var traceparentPattern = regexp.MustCompile(`^([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+
+// tracer propagates a W3C trace context to the API: every request is a span
+// of the trace the provider runs in, and carries a traceparent header naming
+// it, so the control plane's own spans are recorded as its children. Spans
+// are logged rather than exported.
+type tracer struct {
+	traceID  string
+	parentID string
+	flags    string
+	state    string
+}
+
+// newTracerFromEnv returns the tracer configured by the OpenTelemetry
+// environment variables, or nil when tracing is disabled with
+// OTEL_SDK_DISABLED or an OTEL_PROPAGATORS list without tracecontext. Requests
+// continue the trace in TRACEPARENT when it is valid; otherwise the provider
+// process starts a new sampled trace.
+func newTracerFromEnv() *tracer {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv(envOTELSDKDisabled)), "true") {
+		return nil
+	}
+	if propagators := strings.TrimSpace(os.Getenv(envOTELPropagators)); propagators != "" {
+		enabled := false
+		for _, name := range strings.Split(propagators, ",") {
+			if strings.TrimSpace(name) == "tracecontext" {
+				enabled = true
+			}
+		}
+		if !enabled {
+			return nil
+		}
+	}
+
+	if m := traceparentPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(os.Getenv(envTraceparent)))); m != nil &&
+		m[1] != "ff" && strings.Trim(m[2], "0") != "" && strings.Trim(m[3], "0") != "" {
+		return &tracer{traceID: m[2], parentID: m[3], flags: m[4], state: strings.TrimSpace(os.Getenv(envTracestate))}
+	}
+	return &tracer{traceID: randomHex(16), flags: "01"}
+}
+
+// startSpan starts the span of req and sets its trace context headers.
+func (c *bunkerWebClient) startSpan(req *http.Request) apiSpan {
+	if c.tracer == nil {
+		return apiSpan{}
+	}
+	span, headers := c.tracer.startSpan(c.statsEndpoint(req))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return span
+}
+
+// apiSpan is the span of one API request.
+type apiSpan struct {
+	tracer *tracer
+	id     string
+	name   string
+	start  time.Time
+}
+
+// startSpan starts the span of a request to the route named name (such as
+// "GET /services/{id}") and returns the headers propagating it.
+func (t *tracer) startSpan(name string) (apiSpan, map[string]string) {
+	if t == nil {
+		return apiSpan{}, nil
+	}
+	span := apiSpan{tracer: t, id: randomHex(8), name: name, start: time.Now()}
+	headers := map[string]string{traceparentHeader: "00-" + t.traceID + "-" + span.id + "-" + t.flags}
+	if t.state != "" {
+		headers[tracestateHeader] = t.state
+	}
+	return span, headers
+}
+
+// end logs the span with the response status, zero when the request failed
+// before any response, and err.
+func (s apiSpan) end(ctx context.Context, status int, err error) {
+	if s.tracer == nil {
+		return
+	}
+	fields := map[string]any{
+		"trace_id":    s.tracer.traceID,
+		"span_id":     s.id,
+		"span_name":   s.name,
+		"duration_ms": time.Since(s.start).Milliseconds(),
+	}
+	if s.tracer.parentID != "" {
+		fields["parent_span_id"] = s.tracer.parentID
+	}
+	if status != 0 {
+		fields["http_status_code"] = status
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.Debug(ctx, "bunkerweb api span", fields)
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,76 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewTracerFromEnv(t *testing.T) {
+	t.Setenv(envTraceparent, "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01")
+	t.Setenv(envTracestate, "vendor=value")
+	tr := newTracerFromEnv()
+	if tr == nil || tr.traceID != "4bf92f3577b34da6a3ce929d0e0e4736" || tr.parentID != "00f067aa0ba902b7" || tr.flags != "01" || tr.state != "vendor=value" {
+		t.Fatalf("expected the TRACEPARENT context to be continued, got %+v", tr)
+	}
+
+	for _, invalid := range []string{"garbage", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"} {
+		t.Setenv(envTraceparent, invalid)
+		if tr := newTracerFromEnv(); tr == nil || tr.parentID != "" || len(tr.traceID) != 32 || tr.state != "" {
+			t.Errorf("TRACEPARENT %q: expected a new root trace, got %+v", invalid, tr)
+		}
+	}
+
+	t.Setenv(envOTELPropagators, "baggage, tracecontext")
+	if newTracerFromEnv() == nil {
+		t.Error("expected tracecontext in OTEL_PROPAGATORS to enable tracing")
+	}
+	t.Setenv(envOTELPropagators, "b3")
+	if tr := newTracerFromEnv(); tr != nil {
+		t.Errorf("expected OTEL_PROPAGATORS without tracecontext to disable tracing, got %+v", tr)
+	}
+	t.Setenv(envOTELPropagators, "")
+	t.Setenv(envOTELSDKDisabled, "true")
+	if tr := newTracerFromEnv(); tr != nil {
+		t.Errorf("expected OTEL_SDK_DISABLED to disable tracing, got %+v", tr)
+	}
+}
+
+func TestBunkerWebClientTraceparent(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get(traceparentHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","message":"ok"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newBunkerWebClient(server.URL, nil, "test-token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+	client.tracer = &tracer{traceID: "4bf92f3577b34da6a3ce929d0e0e4736", parentID: "00f067aa0ba902b7", flags: "01"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Ping(context.Background()); err != nil {
+			t.Fatalf("Ping: %v", err)
+		}
+	}
+	if len(headers) != 2 || headers[0] == headers[1] {
+		t.Fatalf("expected a distinct span per request, got %q", headers)
+	}
+	for _, header := range headers {
+		parts := strings.Split(header, "-")
+		if len(parts) != 4 || parts[0] != "00" || parts[1] != client.tracer.traceID || len(parts[2]) != 16 || parts[2] == client.tracer.parentID || parts[3] != "01" {
+			t.Errorf("unexpected traceparent %q", header)
+		}
+	}
+	if !client.reservedHeader("Traceparent") {
+		t.Error("expected traceparent to be reserved while tracing")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// unmodeledKeys returns the sorted keys of the JSON object data that no field
+// of the struct model decodes, so that fields added by newer BunkerWeb
+// versions can be reported instead of silently dropped.
+func unmodeledKeys(data []byte, model any) []string {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || len(object) == 0 {
+		return nil
+	}
+
+	t := reflect.TypeOf(model)
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		// encoding/json matches keys case-insensitively.
+		for key := range object {
+			if strings.EqualFold(key, name) {
+				delete(object, key)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (s *bunkerWebService) UnmarshalJSON(data []byte) error {
+	type plain bunkerWebService
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	s.unmodeled = unmodeledKeys(data, plain{})
+	return nil
+}
+
+func (i *bunkerWebInstance) UnmarshalJSON(data []byte) error {
+	type plain bunkerWebInstance
+	if err := json.Unmarshal(data, (*plain)(i)); err != nil {
+		return err
+	}
+	i.unmodeled = unmodeledKeys(data, plain{})
+	return nil
+}
+
+// reportUnmodeledFields logs a warning listing the fields of a kind of API
+// object ("service", "instance") that the provider does not model. Each
+// field is reported once per provider process, that is once per plan or
+// apply.
+func (c *bunkerWebClient) reportUnmodeledFields(ctx context.Context, kind string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	c.unmodeledMu.Lock()
+	defer c.unmodeledMu.Unlock()
+
+	var fresh []string
+	for _, key := range keys {
+		id := kind + "." + key
+		if _, seen := c.unmodeledReported[id]; seen {
+			continue
+		}
+		if c.unmodeledReported == nil {
+			c.unmodeledReported = map[string]struct{}{}
+		}
+		c.unmodeledReported[id] = struct{}{}
+		fresh = append(fresh, key)
+	}
+	if len(fresh) == 0 {
+		return
+	}
+
+	tflog.Warn(ctx, "bunkerweb api returned fields the provider does not model; they are ignored, upgrading the provider may expose them", map[string]any{
+		"object":            kind,
+		"unknown_fields":    fresh,
+		"bunkerweb_version": c.version,
+	})
+}
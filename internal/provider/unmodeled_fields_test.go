@@ -0,0 +1,77 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestUnmodeledKeys(t *testing.T) {
+	var instance bunkerWebInstance
+	if err := instance.UnmarshalJSON([]byte(`{"hostname":"bw-1","Port":8080,"status":"up","last_seen":"2026-01-01T00:00:00"}`)); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if instance.Hostname != "bw-1" || instance.Port == nil || *instance.Port != 8080 {
+		t.Fatalf("unexpected decoded instance: %+v", instance)
+	}
+	if want := []string{"last_seen", "status"}; !slices.Equal(instance.unmodeled, want) {
+		t.Fatalf("expected unmodeled keys %v, got %v", want, instance.unmodeled)
+	}
+
+	var service bunkerWebService
+	if err := service.UnmarshalJSON([]byte(`{"id":"app","server_name":"app","is_draft":false,"variables":{},"method":"ui"}`)); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if len(service.unmodeled) != 0 {
+		t.Fatalf("expected every service key to be modeled, got %v", service.unmodeled)
+	}
+}
+
+func TestReportUnmodeledFieldsOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","instances":[{"hostname":"bw-1","status":"up"},{"hostname":"bw-2","status":"down","health":"ok"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newBunkerWebClient(server.URL, server.Client(), "token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	for range 2 {
+		if _, err := client.ListInstances(ctx); err != nil {
+			t.Fatalf("ListInstances: %v", err)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("MultilineJSONDecode: %v", err)
+	}
+	var reported []string
+	for _, entry := range entries {
+		if entry["@level"] != "warn" {
+			continue
+		}
+		if entry["object"] != "instance" {
+			t.Errorf("unexpected warning: %v", entry)
+		}
+		for _, field := range entry["unknown_fields"].([]any) {
+			reported = append(reported, field.(string))
+		}
+	}
+	if want := []string{"status", "health"}; !slices.Equal(reported, want) {
+		t.Fatalf("expected %v to be reported once each, got %v", want, reported)
+	}
+}
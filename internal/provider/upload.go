@@ -0,0 +1,111 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// minUploadThroughput is the slowest transfer rate, in bytes per second, an
+// upload is given time for on top of the request timeout: a 200MB plugin
+// archive gets about 53 minutes.
+const minUploadThroughput = 64 << 10
+
+// uploadProgressSteps is how many progress lines an upload logs at most.
+const uploadProgressSteps = 10
+
+type uploadContextKey struct{}
+
+// uploadContext bounds a multipart upload of size bytes by a deadline that
+// grows with its size, instead of the HTTP client timeout meant for regular
+// requests. An earlier deadline of ctx still applies, and cancelling ctx
+// aborts the transfer. The returned cancel must be called.
+func (c *bunkerWebClient) uploadContext(ctx context.Context, size int) (context.Context, context.CancelFunc) {
+	timeout := defaultRequestTimeout
+	if c.httpClient.Timeout > 0 {
+		timeout = c.httpClient.Timeout
+	}
+	timeout += time.Duration(size) * time.Second / minUploadThroughput
+
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, uploadContextKey{}, true), timeout)
+	tflog.Debug(ctx, "starting bunkerweb upload", map[string]any{"bytes": size, "timeout": timeout.String()})
+	return ctx, cancel
+}
+
+// httpClientFor returns the HTTP client sending a request with ctx: uploads
+// are bounded by their uploadContext deadline alone.
+func (c *bunkerWebClient) httpClientFor(ctx context.Context) *http.Client {
+	if upload, _ := ctx.Value(uploadContextKey{}).(bool); !upload || c.httpClient.Timeout == 0 {
+		return c.httpClient
+	}
+	transfer := *c.httpClient
+	transfer.Timeout = 0
+	return &transfer
+}
+
+// trackUploadProgress logs the transfer of the body of req at debug level,
+// including on retries.
+func trackUploadProgress(ctx context.Context, req *http.Request) {
+	if req.Body == nil || req.Body == http.NoBody || req.ContentLength <= 0 {
+		return
+	}
+	wrap := func(body io.ReadCloser) io.ReadCloser {
+		return &progressReader{ReadCloser: body, ctx: ctx, endpoint: req.URL.Path, total: req.ContentLength, start: time.Now()}
+	}
+	req.Body = wrap(req.Body)
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return wrap(body), nil
+		}
+	}
+}
+
+// progressReader logs every uploadProgressSteps-th of the body read by the
+// HTTP transport.
+type progressReader struct {
+	io.ReadCloser
+	ctx      context.Context
+	endpoint string
+	total    int64
+	start    time.Time
+
+	mu     sync.Mutex
+	sent   int64
+	logged int64
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent += int64(n)
+	step := r.sent * uploadProgressSteps / r.total
+	if step > r.logged {
+		r.logged = step
+		elapsed := time.Since(r.start)
+		fields := map[string]any{
+			"endpoint": r.endpoint,
+			"sent":     r.sent,
+			"total":    r.total,
+			"percent":  r.sent * 100 / r.total,
+			"elapsed":  elapsed.Round(time.Millisecond).String(),
+		}
+		if seconds := elapsed.Seconds(); seconds > 0 {
+			fields["bytes_per_second"] = int64(float64(r.sent) / seconds)
+		}
+		tflog.Debug(r.ctx, "bunkerweb upload progress", fields)
+	}
+	return n, err
+}
@@ -0,0 +1,105 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUploadContext(t *testing.T) {
+	client, err := newBunkerWebClient("http://bunkerweb.invalid", &http.Client{Timeout: 10 * time.Second}, "token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	ctx, cancel := client.uploadContext(context.Background(), 200<<20)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if want := 10*time.Second + 3200*time.Second; !ok || time.Until(deadline) < want-time.Minute || time.Until(deadline) > want {
+		t.Fatalf("expected a deadline of about %s for 200MB, got %s", want, time.Until(deadline))
+	}
+	if transfer := client.httpClientFor(ctx); transfer.Timeout != 0 || client.httpClient.Timeout != 10*time.Second {
+		t.Fatalf("expected uploads to drop the client timeout without changing it, got %s", transfer.Timeout)
+	}
+	if client.httpClientFor(context.Background()) != client.httpClient {
+		t.Fatal("expected regular requests to keep the shared client")
+	}
+
+	// An earlier deadline of the caller wins.
+	short, cancelShort := context.WithTimeout(context.Background(), time.Second)
+	defer cancelShort()
+	ctx, cancel = client.uploadContext(short, 200<<20)
+	defer cancel()
+	if deadline, _ := ctx.Deadline(); time.Until(deadline) > time.Second {
+		t.Fatalf("expected the caller's deadline to apply, got %s", time.Until(deadline))
+	}
+}
+
+func TestUploadOutlivesClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A slow link: the request takes longer than the client timeout.
+		time.Sleep(300 * time.Millisecond)
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","created":["global/http/big.conf"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newBunkerWebClient(server.URL, &http.Client{Timeout: 100 * time.Millisecond}, "token", "", "")
+	if err != nil {
+		t.Fatalf("newBunkerWebClient: %v", err)
+	}
+
+	if _, err := client.Ping(context.Background()); err == nil {
+		t.Fatal("expected regular requests to keep the client timeout")
+	}
+
+	input := ConfigUploadRequest{Type: "http", Files: []ConfigUploadFile{{FileName: "big.conf", Content: bytes.Repeat([]byte("# padding\n"), 1<<15)}}}
+	result, err := client.UploadConfigs(context.Background(), input)
+	if err != nil {
+		t.Fatalf("UploadConfigs: %v", err)
+	}
+	if len(result.Created) != 1 {
+		t.Fatalf("unexpected upload result: %+v", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.UploadConfigs(ctx, input); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancelled upload to stop, got %v", err)
+	}
+}
+
+func TestTrackUploadProgress(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 1000)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://bunkerweb.invalid/plugins/upload", bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	trackUploadProgress(context.Background(), req)
+
+	for _, body := range []func() (io.ReadCloser, error){
+		func() (io.ReadCloser, error) { return req.Body, nil },
+		req.GetBody,
+	} {
+		reader, err := body()
+		if err != nil {
+			t.Fatalf("body: %v", err)
+		}
+		sent, err := io.Copy(io.Discard, reader)
+		if err != nil || sent != int64(len(content)) {
+			t.Fatalf("expected the whole body to pass through, got %d bytes (%v)", sent, err)
+		}
+		if progress := reader.(*progressReader); progress.logged != uploadProgressSteps {
+			t.Fatalf("expected the transfer to reach the last progress step, got %d", progress.logged)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &BunkerWebUserResource{}
+var _ resource.ResourceWithImportState = &BunkerWebUserResource{}
+var _ resource.ResourceWithIdentity = &BunkerWebUserResource{}
+var _ resource.ResourceWithValidateConfig = &BunkerWebUserResource{}
+var _ resource.ResourceWithModifyPlan = &BunkerWebUserResource{}
+
+// userRoles lists the roles the web UI assigns to operators.
+var userRoles = []string{"admin", "writer", "reader"}
+
+// BunkerWebUserResource manages a web UI operator account.
+type BunkerWebUserResource struct {
+	client BunkerWebAPI
+	state  *providerState
+}
+
+// BunkerWebUserResourceModel is the Terraform state.
+type BunkerWebUserResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	Role         types.String `tfsdk:"role"`
+	Email        types.String `tfsdk:"email"`
+	TOTPRequired types.Bool   `tfsdk:"totp_required"`
+	TOTPEnabled  types.Bool   `tfsdk:"totp_enabled"`
+	Retries      types.Object `tfsdk:"retries"`
+}
+
+func NewBunkerWebUserResource() resource.Resource {
+	return &BunkerWebUserResource{}
+}
+
+func (r *BunkerWebUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *BunkerWebUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a BunkerWeb web UI operator account. The password is stored in state; the API never returns it, so password changes made from the UI are not detected.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Username of the account.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Login of the account.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password of the account.",
+			},
+			"role": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Role of the account: `admin`, `writer` or `reader`. Defaults to `reader`.",
+				Default:             stringdefault.StaticString("reader"),
+			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Contact email of the account.",
+			},
+			"totp_required": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When true, the user must enrol a TOTP device at next login. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"totp_enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the user has enrolled a TOTP device.",
+			},
+			"retries": retriesResourceAttribute(),
+		},
+	}
+}
+
+func (r *BunkerWebUserResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIDIdentitySchema("Username of the account.")
+}
+
+func (r *BunkerWebUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.state = data.state
+}
+
+func (r *BunkerWebUserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BunkerWebUserResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Username.IsNull() && !config.Username.IsUnknown() && strings.TrimSpace(config.Username.ValueString()) == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("username"), "Invalid Username", "`username` may not be empty.")
+	}
+
+	if !config.Password.IsNull() && !config.Password.IsUnknown() && config.Password.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("password"), "Invalid Password", "`password` may not be empty.")
+	}
+
+	if !config.Role.IsNull() && !config.Role.IsUnknown() && !slices.Contains(userRoles, config.Role.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role"),
+			"Invalid Role",
+			fmt.Sprintf("`role` must be one of: %s.", strings.Join(userRoles, ", ")),
+		)
+	}
+}
+
+// ModifyPlan fails the plan when the BunkerWeb version predates the endpoint.
+func (r *BunkerWebUserResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	addFeatureCheck(&resp.Diagnostics, r.client, featureUsers)
+}
+
+func (r *BunkerWebUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan BunkerWebUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.CreateUser(withIdempotencyKey(ctx), UserCreateRequest{
+		Username:     plan.Username.ValueString(),
+		Password:     plan.Password.ValueString(),
+		Role:         plan.Role.ValueString(),
+		Email:        optionalString(plan.Email),
+		TOTPRequired: plan.TOTPRequired.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create User", err.Error())
+		return
+	}
+
+	plan.populateFromUser(user)
+
+	tflog.Info(ctx, "created bunkerweb user", map[string]any{"username": user.Username})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+}
+
+func (r *BunkerWebUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.GetUser(ctx, state.ID.ValueString())
+	if err != nil {
+		var apiErr *bunkerWebAPIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read User", err.Error())
+		return
+	}
+
+	state.populateFromUser(user)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.identity())...)
+}
+
+func (r *BunkerWebUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var plan, state BunkerWebUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, plan.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := plan.Role.ValueString()
+	totpRequired := plan.TOTPRequired.ValueBool()
+	update := UserUpdateRequest{
+		Role:         &role,
+		Email:        optionalString(plan.Email),
+		TOTPRequired: &totpRequired,
+	}
+	if !plan.Password.Equal(state.Password) {
+		password := plan.Password.ValueString()
+		update.Password = &password
+	}
+	if update.Email == nil && !state.Email.IsNull() {
+		// Clear an email removed from the configuration.
+		update.Email = stringPointer("")
+	}
+
+	user, err := r.client.UpdateUser(ctx, state.ID.ValueString(), update)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Update User", err.Error())
+		return
+	}
+
+	plan.populateFromUser(user)
+
+	tflog.Info(ctx, "updated bunkerweb user", map[string]any{"username": user.Username})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.identity())...)
+}
+
+func (r *BunkerWebUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "Expected BunkerWeb client to be configured during provider setup.")
+		return
+	}
+
+	var state BunkerWebUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.state.withRetries(ctx, &resp.Diagnostics, state.Retries)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteUser(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Unable to Delete User", err.Error())
+	}
+}
+
+func (r *BunkerWebUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+func (m *BunkerWebUserResourceModel) identity() resourceIDIdentityModel {
+	return resourceIDIdentityModel{ID: m.ID}
+}
+
+// populateFromUser copies the API view of the account; the password is never
+// returned and is kept as configured.
+func (m *BunkerWebUserResourceModel) populateFromUser(user *bunkerWebUser) {
+	m.ID = types.StringValue(user.Username)
+	m.Username = types.StringValue(user.Username)
+	m.Role = types.StringValue(user.Role)
+	m.TOTPRequired = types.BoolValue(user.TOTPRequired)
+	m.TOTPEnabled = types.BoolValue(user.TOTPEnabled)
+	if user.Email != nil && *user.Email != "" {
+		m.Email = types.StringValue(*user.Email)
+	} else {
+		m.Email = types.StringNull()
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccBunkerWebUserResource(t *testing.T) {
+	fakeAPI := newFakeBunkerWebAPI(t)
+
+	expectPassword := func(password string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			_, got, ok := fakeAPI.User("ops")
+			if !ok {
+				return fmt.Errorf("expected user ops to exist")
+			}
+			if got != password {
+				return fmt.Errorf("expected password %q, got %q", password, got)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if _, _, ok := fakeAPI.User("ops"); ok {
+				return fmt.Errorf("expected user ops to be deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBunkerWebUserResourceConfig(fakeAPI.URL(), "reader", "first-secret", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_user.ops", "id", "ops"),
+					resource.TestCheckResourceAttr("bunkerweb_user.ops", "role", "reader"),
+					resource.TestCheckResourceAttr("bunkerweb_user.ops", "totp_enabled", "false"),
+					expectPassword("first-secret"),
+				),
+			},
+			{
+				ResourceName:            "bunkerweb_user.ops",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				Config: testAccBunkerWebUserResourceConfig(fakeAPI.URL(), "writer", "second-secret", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunkerweb_user.ops", "role", "writer"),
+					resource.TestCheckResourceAttr("bunkerweb_user.ops", "totp_required", "true"),
+					expectPassword("second-secret"),
+				),
+			},
+			{
+				Config:      testAccBunkerWebUserResourceConfig(fakeAPI.URL(), "root", "second-secret", true),
+				ExpectError: regexp.MustCompile(`Invalid Role`),
+			},
+		},
+	})
+}
+
+func testAccBunkerWebUserResourceConfig(endpoint, role, password string, totp bool) string {
+	return fmt.Sprintf(`
+provider "bunkerweb" {
+  api_endpoint = "%s"
+  api_token    = "test-token"
+}
+
+resource "bunkerweb_user" "ops" {
+  username      = "ops"
+  password      = %q
+  role          = %q
+  email         = "ops@example.com"
+  totp_required = %t
+}
+`, endpoint, password, role, totp)
+}
@@ -0,0 +1,147 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// waiter polls a condition with exponential backoff. Zero fields fall back to
+// the values of defaultWaiter.
+type waiter struct {
+	// Timeout bounds the whole wait, on top of any context deadline.
+	Timeout time.Duration
+	// Delay is the pause before the second poll; it grows by Multiplier up to
+	// MaxDelay.
+	Delay      time.Duration
+	MaxDelay   time.Duration
+	Multiplier float64
+	// ContinueOnError reports whether a poll error is transient. Transient
+	// errors are retried and reported if the wait times out; any other error
+	// stops the wait immediately.
+	ContinueOnError func(error) bool
+}
+
+var defaultWaiter = waiter{
+	Timeout:    5 * time.Minute,
+	Delay:      500 * time.Millisecond,
+	MaxDelay:   15 * time.Second,
+	Multiplier: 2,
+}
+
+// waitTimeoutError is returned when the condition did not hold in time.
+type waitTimeoutError struct {
+	What    string
+	Timeout time.Duration
+	LastErr error
+}
+
+func (e *waitTimeoutError) Error() string {
+	msg := fmt.Sprintf("timed out after %s waiting for %s", e.Timeout, e.What)
+	if e.LastErr != nil {
+		msg += fmt.Sprintf(" (last error: %s)", e.LastErr)
+	}
+	return msg
+}
+
+func (e *waitTimeoutError) Unwrap() error {
+	return e.LastErr
+}
+
+// waitFor calls poll until it reports done, poll fails, the timeout elapses or
+// ctx is cancelled. what describes the awaited condition in errors and logs,
+// for example "instance edge-1 to become healthy".
+func (w waiter) waitFor(ctx context.Context, what string, poll func(context.Context) (bool, error)) error {
+	w = w.withDefaults()
+
+	waitCtx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	delay := w.Delay
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		done, err := poll(waitCtx)
+		switch {
+		case err == nil && done:
+			return nil
+		case err != nil && waitCtx.Err() == nil && (w.ContinueOnError == nil || !w.ContinueOnError(err)):
+			return err
+		case err != nil:
+			lastErr = err
+		}
+
+		tflog.Debug(ctx, "waiting for bunkerweb condition", map[string]any{"what": what, "attempt": attempt, "delay": delay.String()})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			if ctx.Err() != nil {
+				return fmt.Errorf("cancelled while waiting for %s: %w", what, ctx.Err())
+			}
+			return &waitTimeoutError{What: what, Timeout: w.Timeout, LastErr: lastErr}
+		case <-timer.C:
+		}
+
+		delay = w.nextDelay(delay)
+	}
+}
+
+func (w waiter) withDefaults() waiter {
+	if w.Timeout <= 0 {
+		w.Timeout = defaultWaiter.Timeout
+	}
+	if w.Delay <= 0 {
+		w.Delay = defaultWaiter.Delay
+	}
+	if w.MaxDelay <= 0 {
+		w.MaxDelay = defaultWaiter.MaxDelay
+	}
+	if w.Multiplier < 1 {
+		w.Multiplier = defaultWaiter.Multiplier
+	}
+	return w
+}
+
+func (w waiter) nextDelay(delay time.Duration) time.Duration {
+	next := time.Duration(float64(delay) * w.Multiplier)
+	if next > w.MaxDelay {
+		return w.MaxDelay
+	}
+	return next
+}
+
+// sleepContext pauses for d, returning early with ctx's error when it is
+// cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// addWaitError reports a failed wait with a consistent detail for timeouts and
+// cancellations.
+func addWaitError(diags *diag.Diagnostics, summary string, err error) {
+	var timeoutErr *waitTimeoutError
+	switch {
+	case errors.As(err, &timeoutErr):
+		diags.AddError(summary, fmt.Sprintf("%s. Increase the timeout, or inspect the scheduler with the bunkerweb_logs ephemeral resource.", err))
+	case errors.Is(err, context.Canceled):
+		diags.AddError(summary, fmt.Sprintf("%s. The operation was interrupted; its outcome on the BunkerWeb side is unknown.", err))
+	default:
+		diags.AddError(summary, err.Error())
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright Bunkerity 2025, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func testWaiter() waiter {
+	return waiter{Timeout: 200 * time.Millisecond, Delay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Multiplier: 2}
+}
+
+func TestWaiterSucceeds(t *testing.T) {
+	polls := 0
+	err := testWaiter().waitFor(context.Background(), "three polls", func(context.Context) (bool, error) {
+		polls++
+		return polls == 3, nil
+	})
+	if err != nil {
+		t.Fatalf("waitFor: %v", err)
+	}
+	if polls != 3 {
+		t.Fatalf("expected 3 polls, got %d", polls)
+	}
+}
+
+func TestWaiterTimeout(t *testing.T) {
+	transient := errors.New("connection refused")
+	w := testWaiter()
+	w.ContinueOnError = func(err error) bool { return errors.Is(err, transient) }
+
+	err := w.waitFor(context.Background(), "never", func(context.Context) (bool, error) {
+		return false, transient
+	})
+
+	var timeoutErr *waitTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if !errors.Is(err, transient) {
+		t.Fatalf("expected the last transient error to be wrapped, got %v", err)
+	}
+
+	var diags diag.Diagnostics
+	addWaitError(&diags, "Wait Failed", err)
+	if !strings.Contains(diags[0].Detail(), "timed out after 200ms waiting for never") {
+		t.Fatalf("unexpected detail: %s", diags[0].Detail())
+	}
+}
+
+func TestWaiterStopsOnPermanentError(t *testing.T) {
+	permanent := errors.New("job failed")
+	polls := 0
+	err := testWaiter().waitFor(context.Background(), "job", func(context.Context) (bool, error) {
+		polls++
+		return false, permanent
+	})
+	if !errors.Is(err, permanent) || polls != 1 {
+		t.Fatalf("expected a single poll returning the error, got %d polls and %v", polls, err)
+	}
+}
+
+func TestWaiterCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := testWaiter()
+	w.Timeout = time.Minute
+
+	err := w.waitFor(ctx, "cancel", func(context.Context) (bool, error) {
+		cancel()
+		return false, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
+
+func TestWaiterBackoff(t *testing.T) {
+	w := waiter{Delay: time.Second, MaxDelay: 5 * time.Second, Multiplier: 2}.withDefaults()
+
+	delay := w.Delay
+	var got []time.Duration
+	for range 4 {
+		delay = w.nextDelay(delay)
+		got = append(got, delay)
+	}
+
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected delays %v, got %v", want, got)
+		}
+	}
+}
@@ -5,13 +5,12 @@
 package main
 
 import (
-	"context"
 	"flag"
 	"log"
 
 	"terraform-provider-bunkerweb/internal/provider"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 var (
@@ -29,12 +28,13 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/bunkerity/bunkerweb",
-		Debug:   debug,
+	var opts []tf6server.ServeOpt
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	err := tf6server.Serve("registry.terraform.io/bunkerity/bunkerweb", provider.NewProtocol6Server(version), opts...)
+	provider.ReportRequestStats()
 
 	if err != nil {
 		log.Fatal(err.Error())